@@ -11,62 +11,58 @@ type ArgumentTypeRule struct{}
 func (r *ArgumentTypeRule) CheckIssues(nodes []ast.Node, filename string, ctx *AnalysisContext) []AnalysisIssue {
 	var issues []AnalysisIssue
 	fileCtx := analysisFileTypeContext(ctx, nodes)
-	var walk func(node ast.Node, class *ast.ClassNode, scope *functionScope)
+	var walk func(node ast.Node, class *ast.ClassNode)
 
-	walk = func(node ast.Node, class *ast.ClassNode, scope *functionScope) {
+	walk = func(node ast.Node, class *ast.ClassNode) {
 		switch n := node.(type) {
 		case *ast.ClassNode:
 			for _, methodNode := range n.Methods {
-				walk(methodNode, n, nil)
+				walk(methodNode, n)
 			}
 		case *ast.FunctionNode:
-			fnScope := analysisFunctionScope(ctx, class, n, fileCtx)
-			walkStatementsForArgTypes(n.Body, fnScope, ctx, filename, &issues)
+			flow := analysisTypeFlow(ctx, class, n, fileCtx)
+			walkStatementsForArgTypes(n.Body, flow, ctx, filename, &issues)
 		case *ast.NamespaceNode:
 			for _, child := range n.Body {
-				walk(child, class, scope)
+				walk(child, class)
 			}
 		}
 	}
 
 	for _, node := range nodes {
-		walk(node, nil, nil)
+		walk(node, nil)
 	}
 
 	return issues
 }
 
-func walkStatementsForArgTypes(nodes []ast.Node, scope *functionScope, ctx *AnalysisContext, filename string, issues *[]AnalysisIssue) {
+func walkStatementsForArgTypes(nodes []ast.Node, flow *typeFlow, ctx *AnalysisContext, filename string, issues *[]AnalysisIssue) {
 	for _, node := range nodes {
 		switch n := node.(type) {
 		case *ast.ExpressionStmt:
-			walkExprForArgTypes(n.Expr, scope, ctx, filename, issues)
-			applyExpressionScope(scope, n.Expr, ctx)
+			walkExprForArgTypes(n.Expr, flow.scopeAt(n), ctx, filename, issues)
 		case *ast.AssignmentNode:
-			walkExprForArgTypes(n.Right, scope, ctx, filename, issues)
-			applyAssignmentScope(scope, n, ctx)
+			walkExprForArgTypes(n.Right, flow.scopeAt(n), ctx, filename, issues)
 		case *ast.ReturnNode:
-			walkExprForArgTypes(n.Expr, scope, ctx, filename, issues)
+			walkExprForArgTypes(n.Expr, flow.scopeAt(n), ctx, filename, issues)
 		case *ast.IfNode:
-			walkExprForArgTypes(n.Condition, scope, ctx, filename, issues)
-			walkStatementsForArgTypes(n.Body, scopeForConditionTrue(scope, n.Condition), ctx, filename, issues)
+			walkExprForArgTypes(n.Condition, flow.scopeAt(n.Condition), ctx, filename, issues)
+			walkStatementsForArgTypes(n.Body, flow, ctx, filename, issues)
 			for _, elseif := range n.ElseIfs {
-				walkExprForArgTypes(elseif.Condition, scope, ctx, filename, issues)
-				walkStatementsForArgTypes(elseif.Body, scopeForConditionTrue(scope, elseif.Condition), ctx, filename, issues)
+				walkExprForArgTypes(elseif.Condition, flow.scopeAt(elseif.Condition), ctx, filename, issues)
+				walkStatementsForArgTypes(elseif.Body, flow, ctx, filename, issues)
 			}
 			if n.Else != nil {
-				walkStatementsForArgTypes(n.Else.Body, scope.clone(), ctx, filename, issues)
+				walkStatementsForArgTypes(n.Else.Body, flow, ctx, filename, issues)
 			}
-			applyTerminatingIfFalseScope(scope, n)
-			applyLazyInitPropertyScope(scope, n, ctx)
 		case *ast.BlockNode:
-			walkStatementsForArgTypes(n.Statements, scope.clone(), ctx, filename, issues)
+			walkStatementsForArgTypes(n.Statements, flow, ctx, filename, issues)
 		case *ast.WhileNode:
-			walkExprForArgTypes(n.Condition, scope, ctx, filename, issues)
-			walkStatementsForArgTypes(n.Body, scope.clone(), ctx, filename, issues)
+			walkExprForArgTypes(n.Condition, flow.scopeAt(n.Condition), ctx, filename, issues)
+			walkStatementsForArgTypes(n.Body, flow, ctx, filename, issues)
 		case *ast.ForeachNode:
-			walkExprForArgTypes(n.Expr, scope, ctx, filename, issues)
-			walkStatementsForArgTypes(n.Body, scope.clone(), ctx, filename, issues)
+			walkExprForArgTypes(n.Expr, flow.scopeAt(n.Expr), ctx, filename, issues)
+			walkStatementsForArgTypes(n.Body, flow, ctx, filename, issues)
 		}
 	}
 }
@@ -80,6 +76,15 @@ func scopeForConditionTrue(scope *functionScope, condition ast.Node) *functionSc
 	return refined
 }
 
+func scopeForConditionFalse(scope *functionScope, condition ast.Node) *functionScope {
+	refined := scope.clone()
+	if refined == nil {
+		return nil
+	}
+	applyConditionFalseScope(refined, condition)
+	return refined
+}
+
 func applyConditionTrueScope(scope *functionScope, condition ast.Node) {
 	if scope == nil {
 		return
@@ -89,6 +94,21 @@ func applyConditionTrueScope(scope *functionScope, condition ast.Node) {
 			scope.variables[variableName] = typ
 		}
 	}
+	applyPropertyNullGuardScope(scope, condition, true)
+}
+
+// applyConditionFalseScope narrows scope for the path on which condition
+// evaluated to false, e.g. after `if ($x === null) { return; }`.
+func applyConditionFalseScope(scope *functionScope, condition ast.Node) {
+	if scope == nil {
+		return
+	}
+	for variableName, typ := range variablesTypedWhenFalse(condition, scope) {
+		if !typ.IsEmpty() {
+			scope.variables[variableName] = typ
+		}
+	}
+	applyPropertyNullGuardScope(scope, condition, false)
 }
 
 func variablesTypedWhenTrue(node ast.Node, scope *functionScope) map[string]Type {
@@ -101,9 +121,19 @@ func variablesTypedWhenTrue(node ast.Node, scope *functionScope) map[string]Type
 				types[name] = typ
 			}
 			return types
+		case "||", "or":
+			// Only variables narrowed on both sides are known afterwards.
+			right := variablesTypedWhenTrue(n.Right, scope)
+			types := map[string]Type{}
+			for name, typ := range variablesTypedWhenTrue(n.Left, scope) {
+				if other, ok := right[name]; ok {
+					types[name] = typ.union(other)
+				}
+			}
+			return types
 		case "instanceof":
 			if variable, ok := n.Left.(*ast.VariableNode); ok {
-				if typ := typeFromInstanceofTarget(n.Right, scope); !typ.IsEmpty() {
+				if typ := instanceofNarrowedType(scope, variable.Name, typeFromInstanceofTarget(n.Right, scope)); !typ.IsEmpty() {
 					return map[string]Type{variable.Name: typ}
 				}
 			}
@@ -120,13 +150,7 @@ func variablesTypedWhenTrue(node ast.Node, scope *functionScope) map[string]Type
 		}
 	case *ast.UnaryExpr:
 		if n.Operator == "!" {
-			refined := map[string]Type{}
-			for _, variableName := range variablesNonNullWhenFalse(n.Operand) {
-				if typ, ok := nonNullVariableType(scope, variableName); ok {
-					refined[variableName] = typ
-				}
-			}
-			return refined
+			return variablesTypedWhenFalse(n.Operand, scope)
 		}
 	case *ast.VariableNode:
 		if typ, ok := nonNullVariableType(scope, n.Name); ok {
@@ -136,10 +160,114 @@ func variablesTypedWhenTrue(node ast.Node, scope *functionScope) map[string]Type
 		if variableName, typ, ok := builtinTypePredicate(n); ok {
 			return map[string]Type{variableName: typ}
 		}
+		if strings.EqualFold(functionCallName(n), "isset") {
+			types := map[string]Type{}
+			for _, arg := range n.Args {
+				if variable, ok := issetRootVariable(argumentValue(arg)); ok {
+					if typ, ok := nonNullVariableType(scope, variable.Name); ok {
+						types[variable.Name] = typ
+					}
+				}
+			}
+			return types
+		}
+	}
+	return map[string]Type{}
+}
+
+// variablesTypedWhenFalse is the counterpart of variablesTypedWhenTrue: it
+// strips null after failed null comparisons and removes the tested class or
+// builtin from a union after a failed instanceof or is_* check.
+func variablesTypedWhenFalse(node ast.Node, scope *functionScope) map[string]Type {
+	switch n := node.(type) {
+	case *ast.BinaryExpr:
+		switch n.Operator {
+		case "||", "or":
+			types := variablesTypedWhenFalse(n.Left, scope)
+			for name, typ := range variablesTypedWhenFalse(n.Right, scope) {
+				types[name] = typ
+			}
+			return types
+		case "instanceof":
+			if variable, ok := n.Left.(*ast.VariableNode); ok {
+				if typ, ok := variableTypeWithout(scope, variable.Name, typeFromInstanceofTarget(n.Right, scope)); ok {
+					return map[string]Type{variable.Name: typ}
+				}
+			}
+		case "==", "===":
+			if name, ok := nullComparisonVariable(n.Left, n.Right); ok {
+				if typ, ok := nonNullVariableType(scope, name); ok {
+					return map[string]Type{name: typ}
+				}
+			}
+		case "!=", "!==":
+			if name, ok := nullComparisonVariable(n.Left, n.Right); ok {
+				return map[string]Type{name: ParseType("null")}
+			}
+		}
+	case *ast.UnaryExpr:
+		if n.Operator == "!" {
+			return variablesTypedWhenTrue(n.Operand, scope)
+		}
+	case *ast.FunctionCallNode:
+		if variableName, typ, ok := builtinTypePredicate(n); ok {
+			if typ.hasBuiltin("null") {
+				if refined, ok := nonNullVariableType(scope, variableName); ok {
+					return map[string]Type{variableName: refined}
+				}
+			} else if refined, ok := variableTypeWithout(scope, variableName, typ); ok {
+				return map[string]Type{variableName: refined}
+			}
+		}
+		// A value that is not empty() is set, so like isset() its root is
+		// not null.
+		if strings.EqualFold(functionCallName(n), "empty") && len(n.Args) == 1 {
+			if variable, ok := issetRootVariable(argumentValue(n.Args[0])); ok {
+				if typ, ok := nonNullVariableType(scope, variable.Name); ok {
+					return map[string]Type{variable.Name: typ}
+				}
+			}
+		}
 	}
 	return map[string]Type{}
 }
 
+// instanceofNarrowedType is the type of a variable known to be an instance
+// of target: the classes of its current type that already are, or target.
+func instanceofNarrowedType(scope *functionScope, variableName string, target Type) Type {
+	className, ok := target.SingleClassName()
+	if !ok || scope == nil {
+		return target
+	}
+	var atoms []typeAtom
+	for _, atom := range scope.variables[variableName].atoms {
+		if atom.kind == typeKindClass && classHierarchyCompatible(className, atom.className(), scope, nil) {
+			atoms = append(atoms, atom)
+		}
+	}
+	if len(atoms) == 0 {
+		return target
+	}
+	return typeFromAtoms(atoms)
+}
+
+// variableTypeWithout removes excluded from the current type of a variable
+// when that leaves a narrower, non-empty type.
+func variableTypeWithout(scope *functionScope, variableName string, excluded Type) (Type, bool) {
+	if scope == nil || excluded.IsEmpty() {
+		return EmptyType(), false
+	}
+	current, ok := scope.variables[variableName]
+	if !ok {
+		return EmptyType(), false
+	}
+	refined := current.without(excluded)
+	if refined.IsEmpty() || refined.String() == current.String() {
+		return EmptyType(), false
+	}
+	return refined, true
+}
+
 func nonNullVariableType(scope *functionScope, variableName string) (Type, bool) {
 	if scope == nil {
 		return EmptyType(), false
@@ -210,107 +338,6 @@ func typeFromInstanceofTarget(node ast.Node, scope *functionScope) Type {
 	return ClassType(className)
 }
 
-func applyTerminatingIfFalseScope(scope *functionScope, node *ast.IfNode) {
-	if scope == nil || node == nil || node.Else != nil || len(node.ElseIfs) > 0 {
-		return
-	}
-	if !statementsExitCurrentBlock(node.Body) {
-		return
-	}
-	// Strip null from variables that are non-null when the condition is false
-	// (e.g. `if ($x === null) { return; }` → $x is non-null after).
-	for _, variableName := range variablesNonNullWhenFalse(node.Condition) {
-		current, ok := scope.variables[variableName]
-		if !ok {
-			continue
-		}
-		refined := current.withoutBuiltin("null")
-		if !refined.IsEmpty() {
-			scope.variables[variableName] = refined
-		}
-	}
-	// When the condition is `!<expr>` and the body terminates, execution
-	// continues only when <expr> is true. Apply the positive type narrowing
-	// of the inner expression (e.g. `if (!($x instanceof Foo)) { return; }`
-	// → after the if, $x is narrowed to Foo).
-	if unary, ok := node.Condition.(*ast.UnaryExpr); ok && unary.Operator == "!" {
-		for variableName, typ := range variablesTypedWhenTrue(unary.Operand, scope) {
-			if typ.IsEmpty() {
-				continue
-			}
-			scope.variables[variableName] = typ
-		}
-	}
-}
-
-func statementsExitCurrentBlock(stmts []ast.Node) bool {
-	if statementsTerminate(stmts) {
-		return true
-	}
-	if len(stmts) == 0 {
-		return false
-	}
-	for _, stmt := range stmts {
-		if exitsCurrentBlock(stmt) {
-			return true
-		}
-	}
-	return false
-}
-
-func exitsCurrentBlock(node ast.Node) bool {
-	if isTerminatingStatement(node) {
-		return true
-	}
-	switch n := node.(type) {
-	case *ast.ExpressionStmt:
-		return exitsCurrentBlock(n.Expr)
-	case *ast.IdentifierNode:
-		keyword := strings.ToLower(n.Value)
-		return keyword == "break" || keyword == "continue"
-	case *ast.IfNode:
-		if n.Else == nil {
-			return false
-		}
-		if !statementsExitCurrentBlock(n.Body) {
-			return false
-		}
-		for _, elseif := range n.ElseIfs {
-			if !statementsExitCurrentBlock(elseif.Body) {
-				return false
-			}
-		}
-		return statementsExitCurrentBlock(n.Else.Body)
-	}
-	return false
-}
-
-func variablesNonNullWhenFalse(node ast.Node) []string {
-	switch n := node.(type) {
-	case *ast.BinaryExpr:
-		switch n.Operator {
-		case "||", "or":
-			left := variablesNonNullWhenFalse(n.Left)
-			return append(left, variablesNonNullWhenFalse(n.Right)...)
-		case "==", "===":
-			if name, ok := nullComparisonVariable(n.Left, n.Right); ok {
-				return []string{name}
-			}
-		}
-	case *ast.UnaryExpr:
-		if n.Operator == "!" {
-			if variable, ok := n.Operand.(*ast.VariableNode); ok {
-				return []string{variable.Name}
-			}
-		}
-	case *ast.FunctionCallNode:
-		if variableName, typ, ok := builtinTypePredicate(n); ok && typ.hasBuiltin("null") {
-			return []string{variableName}
-		}
-	}
-	return nil
-}
-
 func nullComparisonVariable(left, right ast.Node) (string, bool) {
 	if isNullLiteral(left) {
 		if variable, ok := right.(*ast.VariableNode); ok {
@@ -334,97 +361,158 @@ func isNullLiteral(node ast.Node) bool {
 	}
 }
 
-// nullComparisonProperty returns the property name when the expression is
-// `null === $this->prop` or `$this->prop === null` (using == or ===).
-func nullComparisonProperty(node ast.Node) (string, bool) {
+// nullComparisonProperty returns the property fetch when the expression is
+// `null === $var->prop` or `$var->prop !== null` (with any of ==, ===, !=
+// and !==), and whether the comparison tests for equality.
+func nullComparisonProperty(node ast.Node) (*ast.PropertyFetchNode, bool, bool) {
 	binary, ok := node.(*ast.BinaryExpr)
 	if !ok {
-		return "", false
-	}
-	if binary.Operator != "==" && binary.Operator != "===" {
-		return "", false
+		return nil, false, false
+	}
+	var equal bool
+	switch binary.Operator {
+	case "==", "===":
+		equal = true
+	case "!=", "!==":
+		equal = false
+	default:
+		return nil, false, false
 	}
-	check := func(maybeNull, maybeExpr ast.Node) (string, bool) {
+	check := func(maybeNull, maybeExpr ast.Node) (*ast.PropertyFetchNode, bool) {
 		if !isNullLiteral(maybeNull) {
-			return "", false
+			return nil, false
 		}
 		prop, ok := maybeExpr.(*ast.PropertyFetchNode)
 		if !ok {
-			return "", false
+			return nil, false
 		}
-		obj, ok := prop.Object.(*ast.VariableNode)
-		if !ok || obj.Name != "this" {
-			return "", false
+		if _, ok := prop.Object.(*ast.VariableNode); !ok {
+			return nil, false
 		}
-		return prop.Property, true
-	}
-	if name, ok := check(binary.Left, binary.Right); ok {
-		return name, true
+		return prop, true
 	}
-	return check(binary.Right, binary.Left)
-}
-
-// bodyAssignsToThisProperty returns true when any top-level statement in body
-// is an assignment to $this-><propertyName>.
-func bodyAssignsToThisProperty(body []ast.Node, propertyName string) bool {
-	for _, stmt := range body {
-		var assignment *ast.AssignmentNode
-		switch n := stmt.(type) {
-		case *ast.AssignmentNode:
-			assignment = n
-		case *ast.ExpressionStmt:
-			a, ok := n.Expr.(*ast.AssignmentNode)
-			if !ok {
-				continue
-			}
-			assignment = a
-		default:
-			continue
-		}
-		prop, ok := assignment.Left.(*ast.PropertyFetchNode)
-		if !ok {
-			continue
-		}
-		obj, ok := prop.Object.(*ast.VariableNode)
-		if ok && obj.Name == "this" && strings.EqualFold(prop.Property, propertyName) {
-			return true
-		}
+	if prop, ok := check(binary.Left, binary.Right); ok {
+		return prop, equal, true
 	}
-	return false
+	prop, ok := check(binary.Right, binary.Left)
+	return prop, equal, ok
 }
 
-// applyLazyInitPropertyScope handles the lazy-initialisation pattern:
+// applyPropertyNullGuardScope narrows a property compared with null, on
+// $this or on a local variable. Together with merging at joins this covers
+// the lazy-initialisation pattern:
 //
 //	if (null === $this->prop) { $this->prop = ...; }
 //
-// After such a block the property is guaranteed non-null regardless of which
-// branch executed, so null is stripped from the property type in the outer scope.
-func applyLazyInitPropertyScope(scope *functionScope, node *ast.IfNode, ctx *AnalysisContext) {
-	if scope == nil || node == nil || node.Else != nil || len(node.ElseIfs) > 0 {
+// after which the property is non-null on both paths.
+func applyPropertyNullGuardScope(scope *functionScope, condition ast.Node, truth bool) {
+	switch n := condition.(type) {
+	case *ast.UnaryExpr:
+		if n.Operator == "!" {
+			applyPropertyNullGuardScope(scope, n.Operand, !truth)
+		}
 		return
-	}
-	if statementsTerminate(node.Body) {
+	case *ast.BinaryExpr:
+		switch n.Operator {
+		case "&&", "and":
+			if truth {
+				applyPropertyNullGuardScope(scope, n.Left, true)
+				applyPropertyNullGuardScope(scope, n.Right, true)
+			}
+			return
+		case "||", "or":
+			if !truth {
+				applyPropertyNullGuardScope(scope, n.Left, false)
+				applyPropertyNullGuardScope(scope, n.Right, false)
+			}
+			return
+		}
+	case *ast.FunctionCallNode:
+		name := functionCallName(n)
+		if (truth && strings.EqualFold(name, "isset")) || (!truth && strings.EqualFold(name, "empty")) {
+			for _, arg := range n.Args {
+				if prop, ok := argumentValue(arg).(*ast.PropertyFetchNode); ok {
+					narrowPropertyFetch(scope, prop, false)
+				}
+			}
+		}
 		return
 	}
-	propertyName, ok := nullComparisonProperty(node.Condition)
+	prop, equal, ok := nullComparisonProperty(condition)
 	if !ok {
 		return
 	}
-	if !bodyAssignsToThisProperty(node.Body, propertyName) {
+	narrowPropertyFetch(scope, prop, equal == truth)
+}
+
+// narrowPropertyFetch records that a property fetch is null or is not. $this
+// properties are narrowed in place; properties of other variables are noted
+// in fetchNull and applied to the declared type when the fetch is inferred.
+func narrowPropertyFetch(scope *functionScope, prop *ast.PropertyFetchNode, isNull bool) {
+	if isThisVariable(prop.Object) {
+		if isNull {
+			scope.properties[prop.Property] = ParseType("null")
+		} else {
+			narrowPropertyToNonNull(scope, prop.Property)
+		}
 		return
 	}
+	if key, ok := propertyFetchKey(prop); ok {
+		if scope.fetchNull == nil {
+			scope.fetchNull = map[string]bool{}
+		}
+		scope.fetchNull[key] = isNull
+	}
+}
+
+func narrowPropertyToNonNull(scope *functionScope, propertyName string) {
 	current, hasCurrent := scope.properties[propertyName]
 	if !hasCurrent {
-		if declType, ok := scope.propertyDecls[propertyName]; ok {
-			current = declType
-		}
+		current = scope.propertyDecls[propertyName]
 	}
-	refined := current.withoutBuiltin("null")
-	if !refined.IsEmpty() {
+	if refined := current.withoutBuiltin("null"); !refined.IsEmpty() {
 		scope.properties[propertyName] = refined
 	}
 }
 
+// propertyFetchKey names a fetch of a named property on a local variable, as
+// in "user->email", for fetchNull.
+func propertyFetchKey(prop *ast.PropertyFetchNode) (string, bool) {
+	variable, ok := prop.Object.(*ast.VariableNode)
+	if !ok || variable.Name == "this" || strings.HasPrefix(prop.Property, "$") {
+		return "", false
+	}
+	return variable.Name + "->" + prop.Property, true
+}
+
+// forgetPropertyFetches drops what is known about the properties of a
+// variable that is being assigned.
+func forgetPropertyFetches(scope *functionScope, variableName string) {
+	prefix := variableName + "->"
+	for key := range scope.fetchNull {
+		if strings.HasPrefix(key, prefix) {
+			delete(scope.fetchNull, key)
+		}
+	}
+}
+
+// issetRootVariable returns the variable at the root of an isset() operand:
+// isset($a->b['c']) implies $a is not null.
+func issetRootVariable(node ast.Node) (*ast.VariableNode, bool) {
+	for {
+		switch n := node.(type) {
+		case *ast.VariableNode:
+			return n, n.Name != "this"
+		case *ast.PropertyFetchNode:
+			node = n.Object
+		case *ast.ArrayAccessNode:
+			node = n.Var
+		default:
+			return nil, false
+		}
+	}
+}
+
 func walkExprForArgTypes(node ast.Node, scope *functionScope, ctx *AnalysisContext, filename string, issues *[]AnalysisIssue) {
 	if node == nil {
 		return
@@ -478,6 +566,7 @@ func checkMethodCallArgTypes(call *ast.MethodCallNode, scope *functionScope, ctx
 	if !ok || len(method.Params) == 0 {
 		return
 	}
+	method = instantiateTemplates(method, call.Args, scope, ctx)
 	checkResolvedCallArgTypes(fmt.Sprintf("Method %s", method.Name), method, call.Args, scope, ctx, filename, issues)
 }
 
@@ -558,7 +647,7 @@ func checkResolvedCallArgTypes(target string, method ResolvedMethod, args []ast.
 			continue
 		}
 
-		actual := inferType(argExpr, scope, ctx)
+		actual := inferTypeAgainst(expected, argExpr, scope, ctx)
 		if expected.AcceptsWithContext(actual, scope, ctx) {
 			usedParams[paramIndex] = struct{}{}
 			continue
@@ -687,3 +687,83 @@ class Example {
 		t.Fatalf("expected no A.ARG.TYPE issue after negated instanceof guard, got: %#v", issues)
 	}
 }
+
+func TestMethodArgumentTypeNarrowedInElseOfInstanceofOnUnion(t *testing.T) {
+	php := `<?php
+class Cat {}
+class Dog {}
+class Shelter {
+    public function adoptDog(Dog $dog): void {}
+
+    public function run(Cat|Dog $pet): void {
+        if ($pet instanceof Cat) {
+            return;
+        }
+        $this->adoptDog($pet);
+    }
+}
+`
+	issues := analysePHP(t, php)
+	if hasArgTypeIssue(issues) {
+		t.Fatalf("expected Cat to be removed from the union after a failed instanceof, got: %#v", issues)
+	}
+}
+
+func TestMethodArgumentTypeMergesBranchAssignments(t *testing.T) {
+	php := `<?php
+class Token {}
+class Example {
+    public function takesToken(Token $t): void {}
+
+    public function run(bool $flag): void {
+        if ($flag) {
+            $token = new Token();
+        } else {
+            $token = null;
+        }
+        $this->takesToken($token);
+    }
+}
+`
+	issues := analysePHP(t, php)
+	if !hasArgTypeIssue(issues) {
+		t.Fatalf("expected A.ARG.TYPE issue for Token|null after the branches join, got: %#v", issues)
+	}
+}
+
+func TestMethodArgumentTypeRefinedAfterEarlyReturnOnIsString(t *testing.T) {
+	php := `<?php
+class Example {
+    public function takesInt(int $n): void {}
+
+    public function run(int|string $value): void {
+        if (is_string($value)) {
+            return;
+        }
+        $this->takesInt($value);
+    }
+}
+`
+	issues := analysePHP(t, php)
+	if hasArgTypeIssue(issues) {
+		t.Fatalf("expected string to be removed from the union after an early return, got: %#v", issues)
+	}
+}
+
+func TestMethodArgumentTypeRefinedAfterNullCoalescingAssignment(t *testing.T) {
+	php := `<?php
+class Token {}
+class Example {
+    public function takesToken(Token $t): void {}
+
+    public function run(?Token $token): void {
+        $token ??= new Token();
+        $this->takesToken($token);
+    }
+}
+`
+	issues := analysePHP(t, php)
+	if hasArgTypeIssue(issues) {
+		t.Fatalf("expected ??= to make the variable non-null, got: %#v", issues)
+	}
+}
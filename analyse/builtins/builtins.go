@@ -0,0 +1,220 @@
+// Package builtins is the database of classes, functions and constants that
+// PHP core and some of its bundled extensions declare. symbols.json is
+// generated by cmd/php-stubgen from the hand-written phpstorm-stubs-format
+// files in stubs and embedded in the binary; see Default.
+package builtins
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:generate go run ../../cmd/php-stubgen -stubs stubs -out symbols.json
+
+//go:embed symbols.json
+var symbolsJSON []byte
+
+// Database lists the symbols of each extension.
+type Database struct {
+	Extensions []Extension `json:"extensions"`
+}
+
+// Extension groups the symbols declared by one PHP extension, named after its
+// directory in the stubs checkout (Core, standard, date, ...).
+type Extension struct {
+	Name      string     `json:"name"`
+	Classes   []Class    `json:"classes,omitempty"`
+	Functions []Function `json:"functions,omitempty"`
+	Constants []Constant `json:"constants,omitempty"`
+}
+
+// Availability records the PHP version that introduced a symbol and the first
+// version that no longer has it. Empty fields mean "always" and "still there".
+type Availability struct {
+	Since   string `json:"since,omitempty"`
+	Removed string `json:"removed,omitempty"`
+}
+
+// Class is a class, interface, trait or enum.
+type Class struct {
+	Name       string     `json:"name"`
+	Kind       string     `json:"kind"`
+	Extends    []string   `json:"extends,omitempty"`
+	Implements []string   `json:"implements,omitempty"`
+	Final      bool       `json:"final,omitempty"`
+	Abstract   bool       `json:"abstract,omitempty"`
+	Readonly   bool       `json:"readonly,omitempty"`
+	Methods    []Function `json:"methods,omitempty"`
+	Properties []Property `json:"properties,omitempty"`
+	Constants  []Constant `json:"constants,omitempty"`
+	Availability
+}
+
+// Function is a global function or a method. Visibility, Static, Abstract and
+// Final are only set for methods.
+type Function struct {
+	Name       string  `json:"name"`
+	Params     []Param `json:"params,omitempty"`
+	ReturnType string  `json:"return,omitempty"`
+	Visibility string  `json:"visibility,omitempty"`
+	Static     bool    `json:"static,omitempty"`
+	Abstract   bool    `json:"abstract,omitempty"`
+	Final      bool    `json:"final,omitempty"`
+	Availability
+}
+
+// Param is a function or method parameter. A parameter whose signature changed
+// between PHP versions appears once per variant, each with its availability.
+type Param struct {
+	Name     string `json:"name"`
+	Type     string `json:"type,omitempty"`
+	Optional bool   `json:"optional,omitempty"`
+	Variadic bool   `json:"variadic,omitempty"`
+	ByRef    bool   `json:"byref,omitempty"`
+	Availability
+}
+
+// Property is a declared class property.
+type Property struct {
+	Name       string `json:"name"`
+	Type       string `json:"type,omitempty"`
+	Visibility string `json:"visibility,omitempty"`
+	Static     bool   `json:"static,omitempty"`
+	Readonly   bool   `json:"readonly,omitempty"`
+}
+
+// Constant is a global or class constant. Type is inferred from the value in
+// the stubs.
+type Constant struct {
+	Name       string `json:"name"`
+	Type       string `json:"type,omitempty"`
+	Visibility string `json:"visibility,omitempty"`
+	Final      bool   `json:"final,omitempty"`
+	Availability
+}
+
+var (
+	defaultOnce sync.Once
+	defaultDB   *Database
+)
+
+// Default returns the embedded database. It panics if the embedded file is
+// not valid, which only a broken go generate run can cause.
+func Default() *Database {
+	defaultOnce.Do(func() {
+		db, err := Decode(symbolsJSON)
+		if err != nil {
+			panic(fmt.Sprintf("builtins: embedded symbols.json: %v", err))
+		}
+		defaultDB = db
+	})
+	return defaultDB
+}
+
+// Decode reads a database written by Encode.
+func Decode(data []byte) (*Database, error) {
+	var db Database
+	if err := json.Unmarshal(data, &db); err != nil {
+		return nil, err
+	}
+	return &db, nil
+}
+
+// Encode writes the database as indented JSON so regenerations diff cleanly.
+func (db *Database) Encode() ([]byte, error) {
+	data, err := json.MarshalIndent(db, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Extension returns the extension with the given name, ignoring case.
+func (db *Database) Extension(name string) (*Extension, bool) {
+	for i := range db.Extensions {
+		if strings.EqualFold(db.Extensions[i].Name, name) {
+			return &db.Extensions[i], true
+		}
+	}
+	return nil, false
+}
+
+// bundledExtensions are the extensions that ship with php-src, named like
+// their phpstorm-stubs directories. They are enabled unless configured off;
+// extensions in the database that are not listed here are only enabled on
+// request. The bundled stubs cover only some of these extensions and no PECL
+// ones, so symbols of extensions such as gd or redis need a stub file.
+var bundledExtensions = map[string]bool{
+	"bcmath": true, "bz2": true, "calendar": true, "core": true, "ctype": true,
+	"curl": true, "date": true, "dba": true, "dom": true, "enchant": true,
+	"exif": true, "ffi": true, "fileinfo": true, "filter": true, "ftp": true,
+	"gd": true, "gettext": true, "gmp": true, "hash": true, "iconv": true,
+	"imap": true, "intl": true, "json": true, "ldap": true, "libxml": true,
+	"mbstring": true, "mysqli": true, "mysqlnd": true, "odbc": true,
+	"openssl": true, "pcntl": true, "pcre": true, "pdo": true, "pdo_mysql": true,
+	"pdo_pgsql": true, "pdo_sqlite": true, "pgsql": true, "phar": true,
+	"posix": true, "pspell": true, "random": true, "readline": true,
+	"reflection": true, "session": true, "shmop": true, "simplexml": true,
+	"snmp": true, "soap": true, "sockets": true, "sodium": true, "spl": true,
+	"sqlite3": true, "standard": true, "sysvmsg": true, "sysvsem": true,
+	"sysvshm": true, "tidy": true, "tokenizer": true, "xml": true,
+	"xmlreader": true, "xmlwriter": true, "xsl": true, "zend opcache": true,
+	"zip": true, "zlib": true,
+}
+
+// IsBundled reports whether the named extension ships with PHP itself.
+func IsBundled(name string) bool {
+	return bundledExtensions[strings.ToLower(name)]
+}
+
+// AvailableIn reports whether the symbol exists in the given PHP version, such
+// as "8.1". An empty version stands for the newest release: everything that
+// has not been removed.
+func (a Availability) AvailableIn(version string) bool {
+	if version == "" {
+		return a.Removed == ""
+	}
+	if a.Since != "" && CompareVersions(version, a.Since) < 0 {
+		return false
+	}
+	return a.Removed == "" || CompareVersions(version, a.Removed) < 0
+}
+
+// ParamsIn returns the parameter list of the function in the given PHP version.
+func (f Function) ParamsIn(version string) []Param {
+	params := make([]Param, 0, len(f.Params))
+	for _, param := range f.Params {
+		if param.AvailableIn(version) {
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// CompareVersions compares dotted PHP versions numerically and returns -1, 0
+// or 1. Missing components count as zero, so "8" equals "8.0.0".
+func CompareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		x, y := versionPart(as, i), versionPart(bs, i)
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionPart(parts []string, i int) int {
+	if i >= len(parts) {
+		return 0
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(parts[i]))
+	return n
+}
@@ -0,0 +1,112 @@
+package builtins
+
+import "testing"
+
+func TestDefaultDatabaseHasCoreSymbols(t *testing.T) {
+	db := Default()
+	core, ok := db.Extension("core")
+	if !ok {
+		t.Fatal("expected the Core extension")
+	}
+	if findClass(core, "Exception") == nil {
+		t.Fatal("expected Exception in Core")
+	}
+	strlen := findFunction(core, "strlen")
+	if strlen == nil || strlen.ReturnType != "int" || len(strlen.Params) != 1 || strlen.Params[0].Type != "string" {
+		t.Fatalf("expected strlen(string $string): int, got %#v", strlen)
+	}
+	standard, ok := db.Extension("standard")
+	if !ok || findFunction(standard, "str_contains") == nil || findFunction(standard, "str_contains").Since != "8.0" {
+		t.Fatal("expected str_contains, available since 8.0, in standard")
+	}
+	date, _ := db.Extension("date")
+	if date == nil || findClass(date, "DateTimeImmutable") == nil {
+		t.Fatal("expected DateTimeImmutable in date")
+	}
+	period := findClass(date, "DatePeriod")
+	if period == nil || period.Methods[0].Name != "__construct" || period.Methods[0].Since != "" {
+		t.Fatalf("expected DatePeriod::__construct() to be available in every version, got %#v", period)
+	}
+	for _, name := range []string{"openssl", "xml", "xmlreader", "xmlwriter", "bcmath", "sodium", "mysqli", "sqlite3", "zip", "intl"} {
+		if _, ok := db.Extension(name); !ok || !IsBundled(name) {
+			t.Errorf("expected the bundled %s extension", name)
+		}
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	db := &Database{Extensions: []Extension{{
+		Name:      "demo",
+		Functions: []Function{{Name: "demo_fn", ReturnType: "int", Availability: Availability{Since: "8.1"}}},
+	}}}
+	data, err := db.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := Decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := decoded.Extensions[0].Functions[0]
+	if fn.Name != "demo_fn" || fn.ReturnType != "int" || fn.Since != "8.1" {
+		t.Fatalf("unexpected round trip result %#v", fn)
+	}
+}
+
+func TestAvailability(t *testing.T) {
+	a := Availability{Since: "7.3", Removed: "8.0"}
+	for version, want := range map[string]bool{"7.2": false, "7.3": true, "7.4.33": true, "8.0": false, "": false} {
+		if got := a.AvailableIn(version); got != want {
+			t.Errorf("AvailableIn(%q) = %v, want %v", version, got, want)
+		}
+	}
+	if !(Availability{}).AvailableIn("") {
+		t.Error("expected a symbol without bounds to be available in the newest version")
+	}
+
+	fn := Function{Params: []Param{
+		{Name: "old", Availability: Availability{Removed: "8.0"}},
+		{Name: "new", Availability: Availability{Since: "8.0"}},
+	}}
+	if params := fn.ParamsIn("7.4"); len(params) != 1 || params[0].Name != "old" {
+		t.Fatalf("expected the 7.4 variant, got %#v", params)
+	}
+	if params := fn.ParamsIn(""); len(params) != 1 || params[0].Name != "new" {
+		t.Fatalf("expected the newest variant, got %#v", params)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"8.0", "8", 0},
+		{"8.1", "8.0.30", 1},
+		{"7.4", "8.0", -1},
+		{"8.10", "8.9", 1},
+	}
+	for _, tc := range cases {
+		if got := CompareVersions(tc.a, tc.b); got != tc.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func findClass(ext *Extension, name string) *Class {
+	for i := range ext.Classes {
+		if ext.Classes[i].Name == name {
+			return &ext.Classes[i]
+		}
+	}
+	return nil
+}
+
+func findFunction(ext *Extension, name string) *Function {
+	for i := range ext.Functions {
+		if ext.Functions[i].Name == name {
+			return &ext.Functions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,514 @@
+package builtins
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+)
+
+// knownPHPVersions lets an inclusive "to" bound of PhpStormStubsElementAvailable
+// be turned into the first version without the symbol.
+var knownPHPVersions = []string{"5.3", "5.4", "5.5", "5.6", "7.0", "7.1", "7.2", "7.3", "7.4", "8.0", "8.1", "8.2", "8.3", "8.4", "8.5"}
+
+var (
+	docSincePattern   = regexp.MustCompile(`@since\s+(\d+(?:\.\d+)*)`)
+	docRemovedPattern = regexp.MustCompile(`@removed\s+(\d+(?:\.\d+)*)`)
+)
+
+var typeKeywords = map[string]struct{}{
+	"array": {}, "bool": {}, "boolean": {}, "callable": {}, "double": {}, "false": {}, "float": {},
+	"int": {}, "integer": {}, "iterable": {}, "mixed": {}, "never": {}, "null": {}, "object": {},
+	"parent": {}, "resource": {}, "self": {}, "static": {}, "string": {}, "true": {}, "void": {},
+}
+
+// Extract collects the symbols declared by the parsed stub files of one
+// extension. Files must be parsed with the project's parser; function bodies
+// may be skipped. The result is sorted by name.
+func Extract(name string, files ...[]ast.Node) Extension {
+	ext := Extension{Name: name}
+	for _, nodes := range files {
+		x := extractor{ext: &ext, uses: map[string]string{}}
+		x.walk(nodes)
+	}
+	ext.sort()
+	return ext
+}
+
+type extractor struct {
+	ext       *Extension
+	namespace string
+	uses      map[string]string
+}
+
+func (x *extractor) walk(nodes []ast.Node) {
+	// Attribute statements precede the declaration they annotate. Functions
+	// carry their own copy; classes only get them from here.
+	var attributes []*ast.AttributeNode
+	for _, node := range nodes {
+		if attr, ok := node.(*ast.AttributeNode); ok {
+			attributes = append(attributes, attr)
+			continue
+		}
+		switch n := node.(type) {
+		case *ast.NamespaceNode:
+			x.namespace = strings.Trim(n.Name, `\`)
+			x.uses = map[string]string{}
+			x.walk(n.Body)
+		case *ast.UseNode:
+			if n.Type == "" || n.Type == "class" {
+				alias := n.Alias
+				if alias == "" {
+					alias = lastSegment(n.Path)
+				}
+				x.uses[strings.ToLower(alias)] = strings.Trim(n.Path, `\`)
+			}
+		case *ast.FunctionNode:
+			x.ext.Functions = append(x.ext.Functions, x.function(n))
+		case *ast.ClassNode:
+			x.ext.Classes = append(x.ext.Classes, x.class(n, attributes))
+		case *ast.InterfaceNode:
+			x.ext.Classes = append(x.ext.Classes, x.iface(n, attributes))
+		case *ast.TraitNode:
+			if n.Name != nil {
+				class := Class{Name: x.qualify(n.Name.Name), Kind: "trait", Availability: availability(attributes, nil)}
+				x.members(&class, n.Body)
+				x.ext.Classes = append(x.ext.Classes, class)
+			}
+		case *ast.EnumNode:
+			x.ext.Classes = append(x.ext.Classes, x.enum(n, attributes))
+		case *ast.ConstantNode:
+			x.ext.Constants = append(x.ext.Constants, Constant{Name: x.qualify(n.Name), Type: x.constantType(n.Type, n.Value), Availability: availability(attributes, n.PHPDoc)})
+		case *ast.ExpressionStmt:
+			if constant, ok := x.define(n.Expr); ok {
+				x.ext.Constants = append(x.ext.Constants, constant)
+			}
+		}
+		attributes = nil
+	}
+}
+
+func (x *extractor) class(n *ast.ClassNode, attributes []*ast.AttributeNode) Class {
+	class := Class{
+		Name:         x.qualify(n.Name),
+		Kind:         "class",
+		Implements:   x.names(n.Implements),
+		Final:        strings.Contains(n.Modifier, "final"),
+		Abstract:     strings.Contains(n.Modifier, "abstract"),
+		Readonly:     strings.Contains(n.Modifier, "readonly"),
+		Availability: availability(attributes, n.PHPDoc),
+	}
+	if n.Extends != "" {
+		class.Extends = []string{x.resolve(n.Extends)}
+	}
+	x.members(&class, n.Properties)
+	x.members(&class, n.Methods)
+	x.members(&class, n.Constants)
+	return class
+}
+
+func (x *extractor) iface(n *ast.InterfaceNode, attributes []*ast.AttributeNode) Class {
+	class := Class{
+		Name:         x.qualify(n.Name),
+		Kind:         "interface",
+		Extends:      x.names(n.Extends),
+		Availability: availability(attributes, n.PHPDoc),
+	}
+	for _, member := range n.Members {
+		switch m := member.(type) {
+		case *ast.InterfaceMethodNode:
+			method := Function{
+				Name:         m.Name,
+				Params:       x.params(m.Params, m.PHPDoc),
+				ReturnType:   x.returnType(m.Attributes, interfaceReturnType(m.ReturnType), m.PHPDoc),
+				Visibility:   "public",
+				Abstract:     true,
+				Availability: availability(m.Attributes, m.PHPDoc),
+			}
+			class.Methods = append(class.Methods, method)
+		case *ast.ConstantNode:
+			class.Constants = append(class.Constants, x.classConstant(m))
+		}
+	}
+	return class
+}
+
+func (x *extractor) enum(n *ast.EnumNode, attributes []*ast.AttributeNode) Class {
+	name := x.qualify(n.Name)
+	class := Class{
+		Name:         name,
+		Kind:         "enum",
+		Implements:   x.names(n.Implements),
+		Final:        true,
+		Availability: availability(attributes, nil),
+	}
+	for _, enumCase := range n.Cases {
+		class.Constants = append(class.Constants, Constant{Name: enumCase.Name, Type: name, Visibility: "public"})
+	}
+	x.members(&class, n.Methods)
+	return class
+}
+
+func (x *extractor) members(class *Class, nodes []ast.Node) {
+	for _, node := range nodes {
+		switch m := node.(type) {
+		case *ast.FunctionNode:
+			method := x.function(m)
+			method.Name = m.Name
+			method.Visibility = methodVisibility(m)
+			method.Static = hasModifier(m.Modifiers, "static")
+			method.Abstract = hasModifier(m.Modifiers, "abstract")
+			method.Final = hasModifier(m.Modifiers, "final")
+			class.Methods = append(class.Methods, method)
+		case *ast.PropertyNode:
+			visibility := m.Visibility
+			if visibility == "" {
+				visibility = "public"
+			}
+			class.Properties = append(class.Properties, Property{
+				Name:       strings.TrimPrefix(m.Name, "$"),
+				Type:       x.resolveType(m.TypeHint),
+				Visibility: visibility,
+				Static:     m.IsStatic,
+				Readonly:   m.IsReadonly,
+			})
+		case *ast.ConstantNode:
+			class.Constants = append(class.Constants, x.classConstant(m))
+		}
+	}
+}
+
+func (x *extractor) function(n *ast.FunctionNode) Function {
+	return Function{
+		Name:         x.qualify(n.Name),
+		Params:       x.params(n.Params, n.PHPDoc),
+		ReturnType:   x.returnType(n.Attributes, n.ReturnType, n.PHPDoc),
+		Availability: availability(n.Attributes, n.PHPDoc),
+	}
+}
+
+func (x *extractor) params(nodes []ast.Node, doc *ast.PHPDocNode) []Param {
+	var params []Param
+	for _, node := range nodes {
+		p, ok := node.(*ast.ParamNode)
+		if !ok {
+			continue
+		}
+		typ := p.TypeHint
+		if typ == "" && p.UnionType != nil {
+			typ = p.UnionType.TokenLiteral()
+		}
+		if aware, ok := languageLevelType(p.Attributes); ok {
+			typ = aware
+		}
+		if typ == "" && doc != nil {
+			typ = doc.GetParamTypeFromPHPDoc(p.Name)
+		}
+		params = append(params, Param{
+			Name:         strings.TrimPrefix(p.Name, "$"),
+			Type:         x.resolveType(typ),
+			Optional:     p.DefaultValue != nil,
+			Variadic:     p.IsVariadic,
+			ByRef:        p.IsByRef,
+			Availability: availability(p.Attributes, nil),
+		})
+	}
+	return params
+}
+
+// returnType prefers the type for the newest PHP version given by
+// LanguageLevelTypeAware, then the native type, then the PHPDoc type.
+func (x *extractor) returnType(attributes []*ast.AttributeNode, native string, doc *ast.PHPDocNode) string {
+	if aware, ok := languageLevelType(attributes); ok {
+		return x.resolveType(aware)
+	}
+	if native == "" && doc != nil {
+		native = doc.ReturnType
+	}
+	return x.resolveType(native)
+}
+
+func (x *extractor) classConstant(c *ast.ConstantNode) Constant {
+	visibility := c.Visibility
+	if visibility == "" {
+		visibility = "public"
+	}
+	return Constant{
+		Name:         c.Name,
+		Type:         x.constantType(c.Type, c.Value),
+		Visibility:   visibility,
+		Final:        hasModifier(c.Modifiers, "final"),
+		Availability: availability(nil, c.PHPDoc),
+	}
+}
+
+// define reads a define('NAME', value) call.
+func (x *extractor) define(expr ast.Node) (Constant, bool) {
+	call, ok := expr.(*ast.FunctionCallNode)
+	if !ok || len(call.Args) < 2 {
+		return Constant{}, false
+	}
+	name, ok := call.Name.(*ast.IdentifierNode)
+	if !ok || !strings.EqualFold(strings.TrimPrefix(name.Value, `\`), "define") {
+		return Constant{}, false
+	}
+	constant, ok := stringValue(call.Args[0])
+	if !ok || constant == "" {
+		return Constant{}, false
+	}
+	return Constant{Name: strings.TrimPrefix(constant, `\`), Type: x.constantType("", call.Args[1])}, true
+}
+
+func (x *extractor) constantType(declared string, value ast.Node) string {
+	if declared != "" {
+		return x.resolveType(declared)
+	}
+	return literalType(value)
+}
+
+func literalType(value ast.Node) string {
+	switch v := value.(type) {
+	case *ast.IntegerLiteral, *ast.IntegerNode:
+		return "int"
+	case *ast.FloatLiteral, *ast.FloatNode:
+		return "float"
+	case *ast.StringLiteral, *ast.StringNode, *ast.InterpolatedStringLiteral, *ast.HeredocNode:
+		return "string"
+	case *ast.BooleanLiteral, *ast.BooleanNode:
+		return "bool"
+	case *ast.NullLiteral, *ast.NullNode:
+		return "null"
+	case *ast.ArrayNode:
+		return "array"
+	case *ast.UnaryExpr:
+		return literalType(v.Operand)
+	case *ast.IdentifierNode:
+		switch strings.ToLower(v.Value) {
+		case "true", "false":
+			return "bool"
+		case "null":
+			return "null"
+		}
+	}
+	return ""
+}
+
+// qualify prefixes a declared name with the current namespace.
+func (x *extractor) qualify(name string) string {
+	name = strings.TrimPrefix(name, `\`)
+	if x.namespace == "" || name == "" {
+		return name
+	}
+	return x.namespace + `\` + name
+}
+
+// resolve turns a class reference into a fully qualified name without the
+// leading backslash.
+func (x *extractor) resolve(name string) string {
+	name = strings.TrimSpace(name)
+	if strings.HasPrefix(name, `\`) {
+		return strings.TrimPrefix(name, `\`)
+	}
+	if _, ok := typeKeywords[strings.ToLower(name)]; ok {
+		return name
+	}
+	head, rest, qualified := strings.Cut(name, `\`)
+	if imported, ok := x.uses[strings.ToLower(head)]; ok {
+		if qualified {
+			return imported + `\` + rest
+		}
+		return imported
+	}
+	return x.qualify(name)
+}
+
+func (x *extractor) names(names []string) []string {
+	var out []string
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			out = append(out, x.resolve(name))
+		}
+	}
+	return out
+}
+
+// resolveType resolves the class names of a native type. PHPDoc types with
+// generics or array shapes are kept as written.
+func (x *extractor) resolveType(typ string) string {
+	typ = strings.TrimSpace(typ)
+	if typ == "" || strings.ContainsAny(typ, "<{[ $") {
+		return typ
+	}
+	var b strings.Builder
+	start := 0
+	flush := func(end int) {
+		if end > start {
+			b.WriteString(x.resolve(typ[start:end]))
+		}
+	}
+	for i, r := range typ {
+		switch r {
+		case '|', '&', '?', '(', ')':
+			flush(i)
+			b.WriteRune(r)
+			start = i + 1
+		}
+	}
+	flush(len(typ))
+	return b.String()
+}
+
+func interfaceReturnType(node ast.Node) string {
+	switch n := node.(type) {
+	case *ast.IdentifierNode:
+		return n.Value
+	case *ast.UnionTypeNode:
+		return strings.Join(n.Types, "|")
+	case *ast.IntersectionTypeNode:
+		return strings.Join(n.Types, "&")
+	}
+	return ""
+}
+
+// availability reads PhpStormStubsElementAvailable and falls back to the
+// PHPDoc @since and @removed tags.
+func availability(attributes []*ast.AttributeNode, doc *ast.PHPDocNode) Availability {
+	var a Availability
+	if doc != nil {
+		if m := docSincePattern.FindStringSubmatch(doc.RawContent); m != nil {
+			a.Since = m[1]
+		}
+		if m := docRemovedPattern.FindStringSubmatch(doc.RawContent); m != nil {
+			a.Removed = m[1]
+		}
+	}
+	attr := findAttribute(attributes, "PhpStormStubsElementAvailable")
+	if attr == nil {
+		return a
+	}
+	for i, arg := range attr.Arguments {
+		key := ""
+		if named, ok := arg.(*ast.NamedArgumentNode); ok {
+			key, arg = named.Name, named.Value
+		} else if i == 0 {
+			key = "from"
+		} else if i == 1 {
+			key = "to"
+		}
+		version, ok := stringValue(arg)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "from":
+			a.Since = version
+		case "to":
+			a.Removed = nextPHPVersion(version)
+		}
+	}
+	return a
+}
+
+// languageLevelType returns the type LanguageLevelTypeAware gives for the
+// newest PHP version it lists, or its default.
+func languageLevelType(attributes []*ast.AttributeNode) (string, bool) {
+	attr := findAttribute(attributes, "LanguageLevelTypeAware")
+	if attr == nil {
+		return "", false
+	}
+	typ, newest, found := "", "", false
+	for _, arg := range attr.Arguments {
+		if named, ok := arg.(*ast.NamedArgumentNode); ok {
+			if named.Name == "default" && !found {
+				typ, _ = stringValue(named.Value)
+			}
+			continue
+		}
+		versions, ok := arg.(*ast.ArrayNode)
+		if !ok {
+			continue
+		}
+		for _, element := range versions.Elements {
+			item, ok := element.(*ast.ArrayItemNode)
+			if !ok {
+				continue
+			}
+			version, vok := stringValue(item.Key)
+			value, tok := stringValue(item.Value)
+			if !vok || !tok {
+				continue
+			}
+			if !found || CompareVersions(version, newest) > 0 {
+				typ, newest, found = value, version, true
+			}
+		}
+	}
+	return typ, true
+}
+
+func stringValue(node ast.Node) (string, bool) {
+	switch n := node.(type) {
+	case *ast.StringLiteral:
+		return n.Value, true
+	case *ast.StringNode:
+		return n.Value, true
+	}
+	return "", false
+}
+
+func findAttribute(attributes []*ast.AttributeNode, name string) *ast.AttributeNode {
+	for _, attr := range attributes {
+		if strings.EqualFold(lastSegment(attr.Name), name) {
+			return attr
+		}
+	}
+	return nil
+}
+
+func nextPHPVersion(version string) string {
+	for _, known := range knownPHPVersions {
+		if CompareVersions(known, version) > 0 {
+			return known
+		}
+	}
+	return ""
+}
+
+func methodVisibility(fn *ast.FunctionNode) string {
+	for _, visibility := range []string{"private", "protected"} {
+		if fn.Visibility == visibility || hasModifier(fn.Modifiers, visibility) {
+			return visibility
+		}
+	}
+	return "public"
+}
+
+func hasModifier(modifiers []string, wanted string) bool {
+	for _, modifier := range modifiers {
+		if strings.EqualFold(modifier, wanted) {
+			return true
+		}
+	}
+	return false
+}
+
+func lastSegment(name string) string {
+	name = strings.TrimPrefix(name, `\`)
+	if i := strings.LastIndex(name, `\`); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+func (ext *Extension) sort() {
+	sort.SliceStable(ext.Classes, func(i, j int) bool {
+		return strings.ToLower(ext.Classes[i].Name) < strings.ToLower(ext.Classes[j].Name)
+	})
+	sort.SliceStable(ext.Functions, func(i, j int) bool {
+		return strings.ToLower(ext.Functions[i].Name) < strings.ToLower(ext.Functions[j].Name)
+	})
+	sort.SliceStable(ext.Constants, func(i, j int) bool {
+		return ext.Constants[i].Name < ext.Constants[j].Name
+	})
+}
@@ -0,0 +1,95 @@
+package builtins
+
+import (
+	"testing"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+	"github.com/ayanozturk/go-php-parser/lexer"
+	"github.com/ayanozturk/go-php-parser/parser"
+)
+
+func parseStub(t *testing.T, php string) []ast.Node {
+	t.Helper()
+	p := parser.New(lexer.New(php), false)
+	p.SkipFunctionBodies = true
+	nodes := p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("parse errors: %v", p.Errors())
+	}
+	return nodes
+}
+
+func TestExtractStubSymbols(t *testing.T) {
+	ext := Extract("demo", parseStub(t, `<?php
+namespace Demo;
+
+use JetBrains\PhpStorm\Internal\LanguageLevelTypeAware;
+use JetBrains\PhpStorm\Internal\PhpStormStubsElementAvailable;
+
+/**
+ * @since 8.1
+ */
+final class Widget extends \Exception implements Shape
+{
+    /**
+     * @since 8.2
+     */
+    const SIDES = 4;
+
+    public ?string $label;
+
+    /**
+     * @return static
+     */
+    public static function make(#[LanguageLevelTypeAware(['8.0' => 'int|string'], default: 'int')] $id, Widget ...$children) {}
+
+    #[PhpStormStubsElementAvailable(from: '7.0', to: '7.4')]
+    public function legacy(): void {}
+}
+
+interface Shape
+{
+    public function area(): float;
+}
+
+function widget_count(array &$widgets = []): int {}
+
+define('DEMO_LIMIT', 10);
+`))
+
+	if len(ext.Classes) != 2 || len(ext.Functions) != 1 || len(ext.Constants) != 1 {
+		t.Fatalf("unexpected symbols %#v", ext)
+	}
+	shape, widget := ext.Classes[0], ext.Classes[1]
+	if shape.Name != `Demo\Shape` || shape.Kind != "interface" || !shape.Methods[0].Abstract || shape.Methods[0].ReturnType != "float" {
+		t.Fatalf("unexpected interface %#v", shape)
+	}
+	if widget.Name != `Demo\Widget` || !widget.Final || widget.Since != "8.1" {
+		t.Fatalf("unexpected class %#v", widget)
+	}
+	if len(widget.Extends) != 1 || widget.Extends[0] != "Exception" || widget.Implements[0] != `Demo\Shape` {
+		t.Fatalf("expected resolved parents, got %#v %#v", widget.Extends, widget.Implements)
+	}
+	if widget.Constants[0].Type != "int" || widget.Constants[0].Since != "8.2" || widget.Properties[0].Type != "?string" {
+		t.Fatalf("unexpected members %#v %#v", widget.Constants, widget.Properties)
+	}
+
+	make, legacy := widget.Methods[0], widget.Methods[1]
+	if !make.Static || make.ReturnType != "static" {
+		t.Fatalf("unexpected make() %#v", make)
+	}
+	if make.Params[0].Type != "int|string" || make.Params[1].Type != `Demo\Widget` || !make.Params[1].Variadic {
+		t.Fatalf("unexpected make() params %#v", make.Params)
+	}
+	if legacy.Since != "7.0" || legacy.Removed != "8.0" {
+		t.Fatalf("expected legacy() available in 7.x only, got %#v", legacy.Availability)
+	}
+
+	count := ext.Functions[0]
+	if count.Name != `Demo\widget_count` || !count.Params[0].ByRef || !count.Params[0].Optional {
+		t.Fatalf("unexpected function %#v", count)
+	}
+	if ext.Constants[0].Name != "DEMO_LIMIT" || ext.Constants[0].Type != "int" {
+		t.Fatalf("unexpected constant %#v", ext.Constants[0])
+	}
+}
@@ -0,0 +1,425 @@
+<?php
+
+// Classes and interfaces of the Zend engine.
+
+use JetBrains\PhpStorm\Internal\TentativeType;
+
+class stdClass {}
+
+interface Traversable {}
+
+interface IteratorAggregate extends Traversable
+{
+    #[TentativeType]
+    public function getIterator(): Iterator;
+}
+
+interface Iterator extends Traversable
+{
+    #[TentativeType]
+    public function current(): mixed;
+
+    #[TentativeType]
+    public function next(): void;
+
+    #[TentativeType]
+    public function key(): mixed;
+
+    #[TentativeType]
+    public function valid(): bool;
+
+    #[TentativeType]
+    public function rewind(): void;
+}
+
+interface ArrayAccess
+{
+    #[TentativeType]
+    public function offsetExists(mixed $offset): bool;
+
+    #[TentativeType]
+    public function offsetGet(mixed $offset): mixed;
+
+    #[TentativeType]
+    public function offsetSet(mixed $offset, mixed $value): void;
+
+    #[TentativeType]
+    public function offsetUnset(mixed $offset): void;
+}
+
+interface Serializable
+{
+    public function serialize();
+
+    public function unserialize(string $data);
+}
+
+interface Countable
+{
+    #[TentativeType]
+    public function count(): int;
+}
+
+/**
+ * @since 8.0
+ */
+interface Stringable
+{
+    public function __toString(): string;
+}
+
+/**
+ * @since 7.0
+ */
+interface Throwable extends Stringable
+{
+    public function getMessage(): string;
+
+    public function getCode();
+
+    public function getFile(): string;
+
+    public function getLine(): int;
+
+    public function getTrace(): array;
+
+    public function getTraceAsString(): string;
+
+    public function getPrevious(): ?Throwable;
+}
+
+class Exception implements Throwable
+{
+    protected $message = '';
+    protected $code = 0;
+    protected string $file = '';
+    protected int $line = 0;
+
+    public function __construct(string $message = "", int $code = 0, ?Throwable $previous = null) {}
+
+    final public function getMessage(): string {}
+
+    final public function getCode() {}
+
+    final public function getFile(): string {}
+
+    final public function getLine(): int {}
+
+    final public function getTrace(): array {}
+
+    final public function getPrevious(): ?Throwable {}
+
+    final public function getTraceAsString(): string {}
+
+    public function __toString(): string {}
+
+    public function __wakeup(): void {}
+
+    private function __clone(): void {}
+}
+
+/**
+ * @since 7.0
+ */
+class Error implements Throwable
+{
+    protected $message = '';
+    protected $code = 0;
+    protected string $file = '';
+    protected int $line = 0;
+
+    public function __construct(string $message = "", int $code = 0, ?Throwable $previous = null) {}
+
+    final public function getMessage(): string {}
+
+    final public function getCode() {}
+
+    final public function getFile(): string {}
+
+    final public function getLine(): int {}
+
+    final public function getTrace(): array {}
+
+    final public function getPrevious(): ?Throwable {}
+
+    final public function getTraceAsString(): string {}
+
+    public function __toString(): string {}
+
+    public function __wakeup(): void {}
+
+    private function __clone(): void {}
+}
+
+class ErrorException extends Exception
+{
+    protected int $severity = E_ERROR;
+
+    public function __construct(string $message = "", int $code = 0, int $severity = E_ERROR, ?string $filename = null, ?int $line = null, ?Throwable $previous = null) {}
+
+    final public function getSeverity(): int {}
+}
+
+/**
+ * @since 7.3
+ */
+class CompileError extends Error {}
+
+/**
+ * @since 7.0
+ */
+class ParseError extends CompileError {}
+
+/**
+ * @since 7.0
+ */
+class TypeError extends Error {}
+
+/**
+ * @since 7.1
+ */
+class ArgumentCountError extends TypeError {}
+
+/**
+ * @since 8.0
+ */
+class ValueError extends Error {}
+
+/**
+ * @since 7.0
+ */
+class ArithmeticError extends Error {}
+
+/**
+ * @since 7.0
+ */
+class DivisionByZeroError extends ArithmeticError {}
+
+/**
+ * @since 8.0
+ */
+final class UnhandledMatchError extends Error {}
+
+/**
+ * @since 8.3
+ */
+class RequestParseBodyException extends Exception {}
+
+final class Closure
+{
+    private function __construct() {}
+
+    public function __invoke(...$args) {}
+
+    public function bindTo(?object $newThis, object|string|null $newScope = 'static'): ?Closure {}
+
+    public static function bind(Closure $closure, ?object $newThis, object|string|null $newScope = 'static'): ?Closure {}
+
+    public function call(object $newThis, mixed ...$args): mixed {}
+
+    public static function fromCallable(callable $callback): Closure {}
+
+    /**
+     * @since 8.4
+     */
+    public static function getCurrent(): Closure {}
+}
+
+final class Generator implements Iterator
+{
+    public function current(): mixed {}
+
+    public function next(): void {}
+
+    public function key(): mixed {}
+
+    public function valid(): bool {}
+
+    public function rewind(): void {}
+
+    public function send(mixed $value): mixed {}
+
+    public function throw(Throwable $exception): mixed {}
+
+    public function getReturn(): mixed {}
+}
+
+class ClosedGeneratorException extends Exception {}
+
+/**
+ * @since 7.4
+ */
+final class WeakReference
+{
+    public function __construct() {}
+
+    public static function create(object $object): WeakReference {}
+
+    public function get(): ?object {}
+}
+
+/**
+ * @since 8.0
+ */
+final class WeakMap implements ArrayAccess, Countable, IteratorAggregate
+{
+    public function offsetGet($object): mixed {}
+
+    public function offsetSet($object, mixed $value): void {}
+
+    public function offsetExists($object): bool {}
+
+    public function offsetUnset($object): void {}
+
+    public function getIterator(): Iterator {}
+
+    public function count(): int {}
+}
+
+/**
+ * @since 8.0
+ */
+final class Attribute
+{
+    public int $flags;
+
+    const TARGET_CLASS = 1;
+    const TARGET_FUNCTION = 2;
+    const TARGET_METHOD = 4;
+    const TARGET_PROPERTY = 8;
+    const TARGET_CLASS_CONSTANT = 16;
+    const TARGET_PARAMETER = 32;
+    const TARGET_ALL = 63;
+    const IS_REPEATABLE = 64;
+
+    public function __construct(int $flags = Attribute::TARGET_ALL) {}
+}
+
+/**
+ * @since 8.1
+ */
+final class ReturnTypeWillChange
+{
+    public function __construct() {}
+}
+
+/**
+ * @since 8.2
+ */
+final class AllowDynamicProperties
+{
+    public function __construct() {}
+}
+
+/**
+ * @since 8.2
+ */
+final class SensitiveParameter
+{
+    public function __construct() {}
+}
+
+/**
+ * @since 8.2
+ */
+final class SensitiveParameterValue
+{
+    public function __construct(mixed $value) {}
+
+    public function getValue(): mixed {}
+
+    public function __debugInfo(): array {}
+}
+
+/**
+ * @since 8.3
+ */
+final class Override
+{
+    public function __construct() {}
+}
+
+/**
+ * @since 8.4
+ */
+final class Deprecated
+{
+    public readonly ?string $message;
+    public readonly ?string $since;
+
+    public function __construct(?string $message = null, ?string $since = null) {}
+}
+
+/**
+ * @since 8.1
+ */
+interface UnitEnum
+{
+    public static function cases(): array;
+}
+
+/**
+ * @since 8.1
+ */
+interface BackedEnum extends UnitEnum
+{
+    public static function from(int|string $value): static;
+
+    public static function tryFrom(int|string $value): ?static;
+}
+
+/**
+ * @since 8.1
+ */
+final class Fiber
+{
+    public function __construct(callable $callback) {}
+
+    public function start(mixed ...$args): mixed {}
+
+    public function resume(mixed $value = null): mixed {}
+
+    public function throw(Throwable $exception): mixed {}
+
+    public function getReturn(): mixed {}
+
+    public function isStarted(): bool {}
+
+    public function isSuspended(): bool {}
+
+    public function isRunning(): bool {}
+
+    public function isTerminated(): bool {}
+
+    public static function suspend(mixed $value = null): mixed {}
+
+    public static function getCurrent(): ?Fiber {}
+}
+
+/**
+ * @since 8.1
+ */
+final class FiberError extends Error
+{
+    public function __construct() {}
+}
+
+/**
+ * @since 8.0
+ */
+final class InternalIterator implements Iterator
+{
+    private function __construct() {}
+
+    public function current(): mixed {}
+
+    public function next(): void {}
+
+    public function key(): mixed {}
+
+    public function valid(): bool {}
+
+    public function rewind(): void {}
+}
+
+class __PHP_Incomplete_Class {}
@@ -0,0 +1,75 @@
+<?php
+
+// Constants of the Zend engine.
+
+define('E_ERROR', 1);
+define('E_RECOVERABLE_ERROR', 4096);
+define('E_WARNING', 2);
+define('E_PARSE', 4);
+define('E_NOTICE', 8);
+define('E_STRICT', 2048);
+define('E_DEPRECATED', 8192);
+define('E_CORE_ERROR', 16);
+define('E_CORE_WARNING', 32);
+define('E_COMPILE_ERROR', 64);
+define('E_COMPILE_WARNING', 128);
+define('E_USER_ERROR', 256);
+define('E_USER_WARNING', 512);
+define('E_USER_NOTICE', 1024);
+define('E_USER_DEPRECATED', 16384);
+define('E_ALL', 32767);
+define('DEBUG_BACKTRACE_PROVIDE_OBJECT', 1);
+define('DEBUG_BACKTRACE_IGNORE_ARGS', 2);
+define('ZEND_THREAD_SAFE', false);
+define('ZEND_DEBUG_BUILD', false);
+define('PHP_VERSION', "8.4.0");
+define('PHP_MAJOR_VERSION', 8);
+define('PHP_MINOR_VERSION', 4);
+define('PHP_RELEASE_VERSION', 0);
+define('PHP_EXTRA_VERSION', "");
+define('PHP_VERSION_ID', 80400);
+define('PHP_ZTS', 0);
+define('PHP_DEBUG', 0);
+define('PHP_OS', "Linux");
+define('PHP_OS_FAMILY', "Linux");
+define('PHP_SAPI', "cli");
+define('DEFAULT_INCLUDE_PATH', ".:/usr/share/php");
+define('PEAR_INSTALL_DIR', "/usr/share/php");
+define('PEAR_EXTENSION_DIR', "/usr/lib/php");
+define('PHP_EXTENSION_DIR', "/usr/lib/php");
+define('PHP_PREFIX', "/usr");
+define('PHP_BINDIR', "/usr/bin");
+define('PHP_BINARY', "/usr/bin/php");
+define('PHP_MANDIR', "/usr/share/man");
+define('PHP_LIBDIR', "/usr/lib/php");
+define('PHP_DATADIR', "/usr/share/php");
+define('PHP_SYSCONFDIR', "/etc");
+define('PHP_LOCALSTATEDIR', "/var");
+define('PHP_CONFIG_FILE_PATH', "/etc/php");
+define('PHP_CONFIG_FILE_SCAN_DIR', "/etc/php/conf.d");
+define('PHP_SHLIB_SUFFIX', "so");
+define('PHP_EOL', "\n");
+define('PHP_MAXPATHLEN', 4096);
+define('PHP_INT_MAX', 9223372036854775807);
+define('PHP_INT_MIN', -9223372036854775807);
+define('PHP_INT_SIZE', 8);
+define('PHP_FLOAT_DIG', 15);
+define('PHP_FLOAT_EPSILON', 2.220446049250313E-16);
+define('PHP_FLOAT_MAX', 1.7976931348623157E+308);
+define('PHP_FLOAT_MIN', 2.2250738585072014E-308);
+define('PHP_FD_SETSIZE', 1024);
+define('PHP_OUTPUT_HANDLER_START', 1);
+define('PHP_OUTPUT_HANDLER_WRITE', 0);
+define('PHP_OUTPUT_HANDLER_FLUSH', 4);
+define('PHP_OUTPUT_HANDLER_CLEAN', 2);
+define('PHP_OUTPUT_HANDLER_FINAL', 8);
+define('PHP_OUTPUT_HANDLER_CONT', 0);
+define('PHP_OUTPUT_HANDLER_END', 8);
+define('PHP_OUTPUT_HANDLER_CLEANABLE', 16);
+define('PHP_OUTPUT_HANDLER_FLUSHABLE', 32);
+define('PHP_OUTPUT_HANDLER_REMOVABLE', 64);
+define('PHP_OUTPUT_HANDLER_STDFLAGS', 112);
+define('STDIN', fopen('php://stdin', 'r'));
+define('STDOUT', fopen('php://stdout', 'w'));
+define('STDERR', fopen('php://stderr', 'w'));
+define('__COMPILER_HALT_OFFSET__', 0);
@@ -0,0 +1,556 @@
+<?php
+
+// Runtime reflection of ext/reflection.
+
+class ReflectionException extends Exception {}
+
+class Reflection
+{
+    public static function getModifierNames(int $modifiers): array {}
+}
+
+interface Reflector extends Stringable {}
+
+abstract class ReflectionFunctionAbstract implements Reflector
+{
+    public string $name;
+
+    public function inNamespace(): bool {}
+
+    public function isClosure(): bool {}
+
+    public function isDeprecated(): bool {}
+
+    public function isInternal(): bool {}
+
+    public function isUserDefined(): bool {}
+
+    public function isGenerator(): bool {}
+
+    public function isVariadic(): bool {}
+
+    public function isStatic(): bool {}
+
+    public function getClosureThis(): ?object {}
+
+    public function getClosureScopeClass(): ?ReflectionClass {}
+
+    public function getClosureUsedVariables(): array {}
+
+    public function getDocComment(): string|false {}
+
+    public function getEndLine(): int|false {}
+
+    public function getExtension(): ?ReflectionExtension {}
+
+    public function getExtensionName(): string|false {}
+
+    public function getFileName(): string|false {}
+
+    public function getName(): string {}
+
+    public function getNamespaceName(): string {}
+
+    public function getNumberOfParameters(): int {}
+
+    public function getNumberOfRequiredParameters(): int {}
+
+    public function getParameters(): array {}
+
+    public function getShortName(): string {}
+
+    public function getStartLine(): int|false {}
+
+    public function getStaticVariables(): array {}
+
+    public function returnsReference(): bool {}
+
+    public function hasReturnType(): bool {}
+
+    public function getReturnType(): ?ReflectionType {}
+
+    public function hasTentativeReturnType(): bool {}
+
+    public function getTentativeReturnType(): ?ReflectionType {}
+
+    public function getAttributes(?string $name = null, int $flags = 0): array {}
+}
+
+class ReflectionFunction extends ReflectionFunctionAbstract
+{
+    const IS_DEPRECATED = 2048;
+
+    public function __construct(Closure|string $function) {}
+
+    public function __toString(): string {}
+
+    public function isAnonymous(): bool {}
+
+    public function isDisabled(): bool {}
+
+    public function invoke(mixed ...$args): mixed {}
+
+    public function invokeArgs(array $args = []): mixed {}
+
+    public function getClosure(): Closure {}
+}
+
+class ReflectionMethod extends ReflectionFunctionAbstract
+{
+    const IS_STATIC = 16;
+    const IS_PUBLIC = 1;
+    const IS_PROTECTED = 2;
+    const IS_PRIVATE = 4;
+    const IS_ABSTRACT = 64;
+    const IS_FINAL = 32;
+
+    public string $class;
+
+    public function __construct(object|string $objectOrMethod, ?string $method = null) {}
+
+    /**
+     * @since 8.3
+     */
+    public static function createFromMethodName(string $method): static {}
+
+    public function __toString(): string {}
+
+    public function isPublic(): bool {}
+
+    public function isPrivate(): bool {}
+
+    public function isProtected(): bool {}
+
+    public function isAbstract(): bool {}
+
+    public function isFinal(): bool {}
+
+    public function isConstructor(): bool {}
+
+    public function isDestructor(): bool {}
+
+    public function getClosure(?object $object = null): Closure {}
+
+    public function getModifiers(): int {}
+
+    public function invoke(?object $object, mixed ...$args): mixed {}
+
+    public function invokeArgs(?object $object, array $args = []): mixed {}
+
+    public function getDeclaringClass(): ReflectionClass {}
+
+    public function getPrototype(): ReflectionMethod {}
+
+    /**
+     * @since 8.2
+     */
+    public function hasPrototype(): bool {}
+
+    public function setAccessible(bool $accessible): void {}
+}
+
+class ReflectionClass implements Reflector
+{
+    const IS_IMPLICIT_ABSTRACT = 16;
+    const IS_EXPLICIT_ABSTRACT = 64;
+    const IS_FINAL = 32;
+    /**
+     * @since 8.2
+     */
+    const IS_READONLY = 65536;
+
+    public string $name;
+
+    public function __construct(object|string $objectOrClass) {}
+
+    public function __toString(): string {}
+
+    public function getName(): string {}
+
+    public function isInternal(): bool {}
+
+    public function isUserDefined(): bool {}
+
+    public function isAnonymous(): bool {}
+
+    public function isInstantiable(): bool {}
+
+    public function isCloneable(): bool {}
+
+    public function getFileName(): string|false {}
+
+    public function getStartLine(): int|false {}
+
+    public function getEndLine(): int|false {}
+
+    public function getDocComment(): string|false {}
+
+    public function getConstructor(): ?ReflectionMethod {}
+
+    public function hasMethod(string $name): bool {}
+
+    public function getMethod(string $name): ReflectionMethod {}
+
+    public function getMethods(?int $filter = null): array {}
+
+    public function hasProperty(string $name): bool {}
+
+    public function getProperty(string $name): ReflectionProperty {}
+
+    public function getProperties(?int $filter = null): array {}
+
+    public function hasConstant(string $name): bool {}
+
+    public function getConstants(?int $filter = null): array {}
+
+    public function getReflectionConstants(?int $filter = null): array {}
+
+    public function getConstant(string $name): mixed {}
+
+    public function getReflectionConstant(string $name): ReflectionClassConstant|false {}
+
+    public function getInterfaces(): array {}
+
+    public function getInterfaceNames(): array {}
+
+    public function isInterface(): bool {}
+
+    public function getTraits(): array {}
+
+    public function getTraitNames(): array {}
+
+    public function getTraitAliases(): array {}
+
+    public function isTrait(): bool {}
+
+    public function isEnum(): bool {}
+
+    public function isAbstract(): bool {}
+
+    public function isFinal(): bool {}
+
+    /**
+     * @since 8.2
+     */
+    public function isReadOnly(): bool {}
+
+    public function getModifiers(): int {}
+
+    public function isInstance(object $object): bool {}
+
+    public function newInstance(mixed ...$args): object {}
+
+    public function newInstanceWithoutConstructor(): object {}
+
+    public function newInstanceArgs(array $args = []): ?object {}
+
+    public function getParentClass(): ReflectionClass|false {}
+
+    public function isSubclassOf(ReflectionClass|string $class): bool {}
+
+    public function getStaticProperties(): ?array {}
+
+    public function getStaticPropertyValue(string $name, mixed $default = null): mixed {}
+
+    public function setStaticPropertyValue(string $name, mixed $value): void {}
+
+    public function getDefaultProperties(): array {}
+
+    public function isIterable(): bool {}
+
+    public function isIterateable(): bool {}
+
+    public function implementsInterface(ReflectionClass|string $interface): bool {}
+
+    public function getExtension(): ?ReflectionExtension {}
+
+    public function getExtensionName(): string|false {}
+
+    public function inNamespace(): bool {}
+
+    public function getNamespaceName(): string {}
+
+    public function getShortName(): string {}
+
+    public function getAttributes(?string $name = null, int $flags = 0): array {}
+}
+
+class ReflectionObject extends ReflectionClass
+{
+    public function __construct(object $object) {}
+}
+
+class ReflectionProperty implements Reflector
+{
+    const IS_STATIC = 16;
+    const IS_READONLY = 128;
+    const IS_PUBLIC = 1;
+    const IS_PROTECTED = 2;
+    const IS_PRIVATE = 4;
+
+    public string $name;
+    public string $class;
+
+    public function __construct(object|string $class, string $property) {}
+
+    public function __toString(): string {}
+
+    public function getName(): string {}
+
+    public function getValue(?object $object = null): mixed {}
+
+    public function setValue(mixed $objectOrValue, mixed $value = null): void {}
+
+    public function isInitialized(?object $object = null): bool {}
+
+    public function isPublic(): bool {}
+
+    public function isPrivate(): bool {}
+
+    public function isProtected(): bool {}
+
+    public function isStatic(): bool {}
+
+    public function isReadOnly(): bool {}
+
+    public function isDefault(): bool {}
+
+    public function isPromoted(): bool {}
+
+    public function getModifiers(): int {}
+
+    public function getDeclaringClass(): ReflectionClass {}
+
+    public function getDocComment(): string|false {}
+
+    public function setAccessible(bool $accessible): void {}
+
+    public function getType(): ?ReflectionType {}
+
+    public function hasType(): bool {}
+
+    public function hasDefaultValue(): bool {}
+
+    public function getDefaultValue(): mixed {}
+
+    public function getAttributes(?string $name = null, int $flags = 0): array {}
+}
+
+class ReflectionClassConstant implements Reflector
+{
+    const IS_PUBLIC = 1;
+    const IS_PROTECTED = 2;
+    const IS_PRIVATE = 4;
+    /**
+     * @since 8.1
+     */
+    const IS_FINAL = 32;
+
+    public string $name;
+    public string $class;
+
+    public function __construct(object|string $class, string $constant) {}
+
+    public function __toString(): string {}
+
+    public function getName(): string {}
+
+    public function getValue(): mixed {}
+
+    public function isPublic(): bool {}
+
+    public function isPrivate(): bool {}
+
+    public function isProtected(): bool {}
+
+    public function isFinal(): bool {}
+
+    public function getModifiers(): int {}
+
+    public function getDeclaringClass(): ReflectionClass {}
+
+    public function getDocComment(): string|false {}
+
+    public function getAttributes(?string $name = null, int $flags = 0): array {}
+
+    public function isEnumCase(): bool {}
+}
+
+class ReflectionParameter implements Reflector
+{
+    public string $name;
+
+    public function __construct($function, int|string $param) {}
+
+    public function __toString(): string {}
+
+    public function getName(): string {}
+
+    public function isPassedByReference(): bool {}
+
+    public function canBePassedByValue(): bool {}
+
+    public function getDeclaringFunction(): ReflectionFunctionAbstract {}
+
+    public function getDeclaringClass(): ?ReflectionClass {}
+
+    public function getClass(): ?ReflectionClass {}
+
+    public function hasType(): bool {}
+
+    public function getType(): ?ReflectionType {}
+
+    public function isArray(): bool {}
+
+    public function isCallable(): bool {}
+
+    public function allowsNull(): bool {}
+
+    public function getPosition(): int {}
+
+    public function isOptional(): bool {}
+
+    public function isDefaultValueAvailable(): bool {}
+
+    public function getDefaultValue(): mixed {}
+
+    public function isDefaultValueConstant(): bool {}
+
+    public function getDefaultValueConstantName(): ?string {}
+
+    public function isVariadic(): bool {}
+
+    public function isPromoted(): bool {}
+
+    public function getAttributes(?string $name = null, int $flags = 0): array {}
+}
+
+abstract class ReflectionType implements Stringable
+{
+    public function allowsNull(): bool {}
+
+    public function __toString(): string {}
+}
+
+class ReflectionNamedType extends ReflectionType
+{
+    public function getName(): string {}
+
+    public function isBuiltin(): bool {}
+}
+
+class ReflectionUnionType extends ReflectionType
+{
+    public function getTypes(): array {}
+}
+
+/**
+ * @since 8.1
+ */
+class ReflectionIntersectionType extends ReflectionType
+{
+    public function getTypes(): array {}
+}
+
+class ReflectionExtension implements Reflector
+{
+    public string $name;
+
+    public function __construct(string $name) {}
+
+    public function __toString(): string {}
+
+    public function getName(): string {}
+
+    public function getVersion(): ?string {}
+
+    public function getFunctions(): array {}
+
+    public function getConstants(): array {}
+
+    public function getINIEntries(): array {}
+
+    public function getClasses(): array {}
+
+    public function getClassNames(): array {}
+
+    public function getDependencies(): array {}
+
+    public function isPersistent(): bool {}
+
+    public function isTemporary(): bool {}
+}
+
+/**
+ * @since 8.0
+ */
+class ReflectionAttribute implements Reflector
+{
+    const IS_INSTANCEOF = 2;
+
+    public function getName(): string {}
+
+    public function getTarget(): int {}
+
+    public function isRepeated(): bool {}
+
+    public function getArguments(): array {}
+
+    public function newInstance(): object {}
+
+    public function __toString(): string {}
+}
+
+/**
+ * @since 8.1
+ */
+class ReflectionEnum extends ReflectionClass
+{
+    public function __construct(object|string $objectOrClass) {}
+
+    public function hasCase(string $name): bool {}
+
+    public function getCase(string $name): ReflectionEnumUnitCase {}
+
+    public function getCases(): array {}
+
+    public function isBacked(): bool {}
+
+    public function getBackingType(): ?ReflectionNamedType {}
+}
+
+/**
+ * @since 8.1
+ */
+class ReflectionEnumUnitCase extends ReflectionClassConstant
+{
+    public function getEnum(): ReflectionEnum {}
+
+    public function getValue(): UnitEnum {}
+}
+
+/**
+ * @since 8.1
+ */
+class ReflectionEnumBackedCase extends ReflectionEnumUnitCase
+{
+    public function getBackingValue(): int|string {}
+}
+
+/**
+ * @since 8.1
+ */
+final class ReflectionFiber
+{
+    public function __construct(Fiber $fiber) {}
+
+    public function getFiber(): Fiber {}
+
+    public function getExecutingFile(): string {}
+
+    public function getExecutingLine(): int {}
+
+    public function getCallable(): callable {}
+
+    public function getTrace(int $options = DEBUG_BACKTRACE_PROVIDE_OBJECT): array {}
+}
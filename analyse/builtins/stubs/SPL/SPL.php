@@ -0,0 +1,850 @@
+<?php
+
+// Exceptions, iterators and interfaces of ext/spl.
+
+class LogicException extends Exception {}
+
+class BadFunctionCallException extends LogicException {}
+
+class BadMethodCallException extends BadFunctionCallException {}
+
+class DomainException extends LogicException {}
+
+class InvalidArgumentException extends LogicException {}
+
+class LengthException extends LogicException {}
+
+class OutOfRangeException extends LogicException {}
+
+class RuntimeException extends Exception {}
+
+class OutOfBoundsException extends RuntimeException {}
+
+class OverflowException extends RuntimeException {}
+
+class RangeException extends RuntimeException {}
+
+class UnderflowException extends RuntimeException {}
+
+class UnexpectedValueException extends RuntimeException {}
+
+interface RecursiveIterator extends Iterator
+{
+    #[TentativeType]
+    public function hasChildren(): bool;
+
+    #[TentativeType]
+    public function getChildren(): ?RecursiveIterator;
+}
+
+interface OuterIterator extends Iterator
+{
+    #[TentativeType]
+    public function getInnerIterator(): ?Iterator;
+}
+
+interface SeekableIterator extends Iterator
+{
+    #[TentativeType]
+    public function seek(int $offset): void;
+}
+
+interface SplObserver
+{
+    #[TentativeType]
+    public function update(SplSubject $subject): void;
+}
+
+interface SplSubject
+{
+    #[TentativeType]
+    public function attach(SplObserver $observer): void;
+
+    #[TentativeType]
+    public function detach(SplObserver $observer): void;
+
+    #[TentativeType]
+    public function notify(): void;
+}
+
+class ArrayIterator implements SeekableIterator, ArrayAccess, Serializable, Countable
+{
+    const STD_PROP_LIST = 1;
+    const ARRAY_AS_PROPS = 2;
+
+    public function __construct(array|object $array = [], int $flags = 0) {}
+
+    public function offsetExists(mixed $key): bool {}
+
+    public function offsetGet(mixed $key): mixed {}
+
+    public function offsetSet(mixed $key, mixed $value): void {}
+
+    public function offsetUnset(mixed $key): void {}
+
+    public function append(mixed $value): void {}
+
+    public function getArrayCopy(): array {}
+
+    public function count(): int {}
+
+    public function getFlags(): int {}
+
+    public function setFlags(int $flags): void {}
+
+    public function asort(int $flags = SORT_REGULAR): bool {}
+
+    public function ksort(int $flags = SORT_REGULAR): bool {}
+
+    public function uasort(callable $callback): bool {}
+
+    public function uksort(callable $callback): bool {}
+
+    public function natsort(): bool {}
+
+    public function natcasesort(): bool {}
+
+    public function unserialize(string $data): void {}
+
+    public function serialize(): string {}
+
+    public function __serialize(): array {}
+
+    public function __unserialize(array $data): void {}
+
+    public function rewind(): void {}
+
+    public function current(): mixed {}
+
+    public function key(): string|int|null {}
+
+    public function next(): void {}
+
+    public function valid(): bool {}
+
+    public function seek(int $offset): void {}
+}
+
+class RecursiveArrayIterator extends ArrayIterator implements RecursiveIterator
+{
+    const CHILD_ARRAYS_ONLY = 4;
+
+    public function hasChildren(): bool {}
+
+    public function getChildren(): ?RecursiveArrayIterator {}
+}
+
+class ArrayObject implements IteratorAggregate, ArrayAccess, Serializable, Countable
+{
+    const STD_PROP_LIST = 1;
+    const ARRAY_AS_PROPS = 2;
+
+    public function __construct(array|object $array = [], int $flags = 0, string $iteratorClass = "ArrayIterator") {}
+
+    public function offsetExists(mixed $key): bool {}
+
+    public function offsetGet(mixed $key): mixed {}
+
+    public function offsetSet(mixed $key, mixed $value): void {}
+
+    public function offsetUnset(mixed $key): void {}
+
+    public function append(mixed $value): void {}
+
+    public function getArrayCopy(): array {}
+
+    public function count(): int {}
+
+    public function getFlags(): int {}
+
+    public function setFlags(int $flags): void {}
+
+    public function asort(int $flags = SORT_REGULAR): bool {}
+
+    public function ksort(int $flags = SORT_REGULAR): bool {}
+
+    public function uasort(callable $callback): bool {}
+
+    public function uksort(callable $callback): bool {}
+
+    public function natsort(): bool {}
+
+    public function natcasesort(): bool {}
+
+    public function unserialize(string $data): void {}
+
+    public function serialize(): string {}
+
+    public function __serialize(): array {}
+
+    public function __unserialize(array $data): void {}
+
+    public function getIterator(): Iterator {}
+
+    public function exchangeArray(array|object $array): array {}
+
+    public function setIteratorClass(string $iteratorClass): void {}
+
+    public function getIteratorClass(): string {}
+}
+
+class EmptyIterator implements Iterator
+{
+    public function current(): never {}
+
+    public function next(): void {}
+
+    public function key(): never {}
+
+    public function valid(): bool {}
+
+    public function rewind(): void {}
+}
+
+class IteratorIterator implements OuterIterator
+{
+    public function __construct(Traversable $iterator, ?string $class = null) {}
+
+    public function getInnerIterator(): ?Iterator {}
+
+    public function rewind(): void {}
+
+    public function valid(): bool {}
+
+    public function key(): mixed {}
+
+    public function current(): mixed {}
+
+    public function next(): void {}
+}
+
+abstract class FilterIterator extends IteratorIterator
+{
+    #[TentativeType]
+    abstract public function accept(): bool;
+
+    public function __construct(Iterator $iterator) {}
+}
+
+class CallbackFilterIterator extends FilterIterator
+{
+    public function __construct(Iterator $iterator, callable $callback) {}
+
+    public function accept(): bool {}
+}
+
+abstract class RecursiveFilterIterator extends FilterIterator implements RecursiveIterator
+{
+    public function __construct(RecursiveIterator $iterator) {}
+
+    public function hasChildren(): bool {}
+
+    public function getChildren(): ?RecursiveFilterIterator {}
+}
+
+class RecursiveCallbackFilterIterator extends CallbackFilterIterator implements RecursiveIterator
+{
+    public function __construct(RecursiveIterator $iterator, callable $callback) {}
+
+    public function hasChildren(): bool {}
+
+    public function getChildren(): RecursiveCallbackFilterIterator {}
+}
+
+class ParentIterator extends RecursiveFilterIterator
+{
+    public function accept(): bool {}
+}
+
+class LimitIterator extends IteratorIterator
+{
+    public function __construct(Iterator $iterator, int $offset = 0, int $limit = -1) {}
+
+    public function seek(int $offset): int {}
+
+    public function getPosition(): int {}
+}
+
+class CachingIterator extends IteratorIterator implements ArrayAccess, Countable, Stringable
+{
+    const CALL_TOSTRING = 1;
+    const CATCH_GET_CHILD = 16;
+    const TOSTRING_USE_KEY = 2;
+    const TOSTRING_USE_CURRENT = 4;
+    const TOSTRING_USE_INNER = 8;
+    const FULL_CACHE = 256;
+
+    public function __construct(Iterator $iterator, int $flags = CachingIterator::CALL_TOSTRING) {}
+
+    public function hasNext(): bool {}
+
+    public function __toString(): string {}
+
+    public function getFlags(): int {}
+
+    public function setFlags(int $flags): void {}
+
+    public function offsetGet($key): mixed {}
+
+    public function offsetSet($key, mixed $value): void {}
+
+    public function offsetUnset($key): void {}
+
+    public function offsetExists($key): bool {}
+
+    public function getCache(): array {}
+
+    public function count(): int {}
+}
+
+class NoRewindIterator extends IteratorIterator
+{
+    public function __construct(Iterator $iterator) {}
+}
+
+class AppendIterator extends IteratorIterator
+{
+    public function __construct() {}
+
+    public function append(Iterator $iterator): void {}
+
+    public function getIteratorIndex(): ?int {}
+
+    public function getArrayIterator(): ArrayIterator {}
+}
+
+class InfiniteIterator extends IteratorIterator
+{
+    public function __construct(Iterator $iterator) {}
+}
+
+class RegexIterator extends FilterIterator
+{
+    const USE_KEY = 1;
+    const INVERT_MATCH = 2;
+    const MATCH = 0;
+    const GET_MATCH = 1;
+    const ALL_MATCHES = 2;
+    const SPLIT = 3;
+    const REPLACE = 4;
+
+    public ?string $replacement = null;
+
+    public function __construct(Iterator $iterator, string $pattern, int $mode = RegexIterator::MATCH, int $flags = 0, int $pregFlags = 0) {}
+
+    public function accept(): bool {}
+
+    public function getMode(): int {}
+
+    public function setMode(int $mode): void {}
+
+    public function getFlags(): int {}
+
+    public function setFlags(int $flags): void {}
+
+    public function getRegex(): string {}
+}
+
+class RecursiveIteratorIterator implements OuterIterator
+{
+    const LEAVES_ONLY = 0;
+    const SELF_FIRST = 1;
+    const CHILD_FIRST = 2;
+    const CATCH_GET_CHILD = 16;
+
+    public function __construct(Traversable $iterator, int $mode = RecursiveIteratorIterator::LEAVES_ONLY, int $flags = 0) {}
+
+    public function rewind(): void {}
+
+    public function valid(): bool {}
+
+    public function key(): mixed {}
+
+    public function current(): mixed {}
+
+    public function next(): void {}
+
+    public function getDepth(): int {}
+
+    public function getSubIterator(?int $level = null): ?RecursiveIterator {}
+
+    public function getInnerIterator(): RecursiveIterator {}
+
+    public function setMaxDepth(int $maxDepth = -1): void {}
+
+    public function getMaxDepth(): int|false {}
+}
+
+class MultipleIterator implements Iterator
+{
+    const MIT_NEED_ANY = 0;
+    const MIT_NEED_ALL = 1;
+    const MIT_KEYS_NUMERIC = 0;
+    const MIT_KEYS_ASSOC = 2;
+
+    public function __construct(int $flags = MultipleIterator::MIT_NEED_ALL|MultipleIterator::MIT_KEYS_NUMERIC) {}
+
+    public function getFlags(): int {}
+
+    public function setFlags(int $flags): void {}
+
+    public function attachIterator(Iterator $iterator, string|int|null $info = null): void {}
+
+    public function detachIterator(Iterator $iterator): void {}
+
+    public function containsIterator(Iterator $iterator): bool {}
+
+    public function countIterators(): int {}
+
+    public function rewind(): void {}
+
+    public function valid(): bool {}
+
+    public function key(): array {}
+
+    public function current(): array {}
+
+    public function next(): void {}
+}
+
+class SplFileInfo implements Stringable
+{
+    public function __construct(string $filename) {}
+
+    public function getPath(): string {}
+
+    public function getFilename(): string {}
+
+    public function getExtension(): string {}
+
+    public function getBasename(string $suffix = ""): string {}
+
+    public function getPathname(): string {}
+
+    public function getPerms(): int|false {}
+
+    public function getInode(): int|false {}
+
+    public function getSize(): int|false {}
+
+    public function getOwner(): int|false {}
+
+    public function getGroup(): int|false {}
+
+    public function getATime(): int|false {}
+
+    public function getMTime(): int|false {}
+
+    public function getCTime(): int|false {}
+
+    public function getType(): string|false {}
+
+    public function isWritable(): bool {}
+
+    public function isReadable(): bool {}
+
+    public function isExecutable(): bool {}
+
+    public function isFile(): bool {}
+
+    public function isDir(): bool {}
+
+    public function isLink(): bool {}
+
+    public function getLinkTarget(): string|false {}
+
+    public function getRealPath(): string|false {}
+
+    public function getFileInfo(?string $class = null): SplFileInfo {}
+
+    public function getPathInfo(?string $class = null): ?SplFileInfo {}
+
+    public function openFile(string $mode = "r", bool $useIncludePath = false, $context = null): SplFileObject {}
+
+    public function __toString(): string {}
+}
+
+class DirectoryIterator extends SplFileInfo implements SeekableIterator
+{
+    public function __construct(string $directory) {}
+
+    public function isDot(): bool {}
+
+    public function rewind(): void {}
+
+    public function valid(): bool {}
+
+    public function key(): mixed {}
+
+    public function current(): mixed {}
+
+    public function next(): void {}
+
+    public function seek(int $offset): void {}
+}
+
+class FilesystemIterator extends DirectoryIterator
+{
+    const CURRENT_MODE_MASK = 240;
+    const CURRENT_AS_PATHNAME = 32;
+    const CURRENT_AS_FILEINFO = 0;
+    const CURRENT_AS_SELF = 16;
+    const KEY_MODE_MASK = 3840;
+    const KEY_AS_PATHNAME = 0;
+    const KEY_AS_FILENAME = 256;
+    const FOLLOW_SYMLINKS = 16384;
+    const NEW_CURRENT_AND_KEY = 256;
+    const SKIP_DOTS = 4096;
+    const UNIX_PATHS = 8192;
+    const OTHER_MODE_MASK = 28672;
+
+    public function __construct(string $directory, int $flags = FilesystemIterator::KEY_AS_PATHNAME|FilesystemIterator::CURRENT_AS_FILEINFO|FilesystemIterator::SKIP_DOTS) {}
+
+    public function getFlags(): int {}
+
+    public function setFlags(int $flags): void {}
+}
+
+class RecursiveDirectoryIterator extends FilesystemIterator implements RecursiveIterator
+{
+    public function __construct(string $directory, int $flags = FilesystemIterator::KEY_AS_PATHNAME|FilesystemIterator::CURRENT_AS_FILEINFO) {}
+
+    public function hasChildren(bool $allowLinks = false): bool {}
+
+    public function getChildren(): RecursiveDirectoryIterator {}
+
+    public function getSubPath(): string {}
+
+    public function getSubPathname(): string {}
+}
+
+class GlobIterator extends FilesystemIterator implements Countable
+{
+    public function __construct(string $pattern, int $flags = FilesystemIterator::KEY_AS_PATHNAME|FilesystemIterator::CURRENT_AS_FILEINFO) {}
+
+    public function count(): int {}
+}
+
+class SplFileObject extends SplFileInfo implements RecursiveIterator, SeekableIterator
+{
+    const DROP_NEW_LINE = 1;
+    const READ_AHEAD = 2;
+    const SKIP_EMPTY = 4;
+    const READ_CSV = 8;
+
+    public function __construct(string $filename, string $mode = "r", bool $useIncludePath = false, $context = null) {}
+
+    public function rewind(): void {}
+
+    public function eof(): bool {}
+
+    public function valid(): bool {}
+
+    public function fgets(): string {}
+
+    public function fread(int $length): string|false {}
+
+    public function fgetcsv(string $separator = ",", string $enclosure = "\"", string $escape = "\\"): array|false {}
+
+    public function fputcsv(array $fields, string $separator = ",", string $enclosure = "\"", string $escape = "\\", string $eol = "\n"): int|false {}
+
+    public function flock(int $operation, &$wouldBlock = null): bool {}
+
+    public function fflush(): bool {}
+
+    public function ftell(): int|false {}
+
+    public function fseek(int $offset, int $whence = SEEK_SET): int {}
+
+    public function fgetc(): string|false {}
+
+    public function fpassthru(): int {}
+
+    public function fwrite(string $data, int $length = 0): int|false {}
+
+    public function fstat(): array {}
+
+    public function ftruncate(int $size): bool {}
+
+    public function current(): string|array|false {}
+
+    public function key(): int {}
+
+    public function next(): void {}
+
+    public function setFlags(int $flags): void {}
+
+    public function getFlags(): int {}
+
+    public function setMaxLineLen(int $maxLength): void {}
+
+    public function getMaxLineLen(): int {}
+
+    public function hasChildren(): bool {}
+
+    public function getChildren(): ?RecursiveIterator {}
+
+    public function seek(int $line): void {}
+
+    public function getCurrentLine(): string {}
+}
+
+class SplTempFileObject extends SplFileObject
+{
+    public function __construct(int $maxMemory = 2097152) {}
+}
+
+class SplDoublyLinkedList implements Iterator, Countable, ArrayAccess, Serializable
+{
+    const IT_MODE_LIFO = 2;
+    const IT_MODE_FIFO = 0;
+    const IT_MODE_DELETE = 1;
+    const IT_MODE_KEEP = 0;
+
+    public function add(int $index, mixed $value): void {}
+
+    public function pop(): mixed {}
+
+    public function shift(): mixed {}
+
+    public function push(mixed $value): void {}
+
+    public function unshift(mixed $value): void {}
+
+    public function top(): mixed {}
+
+    public function bottom(): mixed {}
+
+    public function isEmpty(): bool {}
+
+    public function setIteratorMode(int $mode): int {}
+
+    public function getIteratorMode(): int {}
+
+    public function count(): int {}
+
+    public function offsetExists($index): bool {}
+
+    public function offsetGet($index): mixed {}
+
+    public function offsetSet($index, mixed $value): void {}
+
+    public function offsetUnset($index): void {}
+
+    public function rewind(): void {}
+
+    public function current(): mixed {}
+
+    public function key(): int {}
+
+    public function next(): void {}
+
+    public function prev(): void {}
+
+    public function valid(): bool {}
+
+    public function unserialize(string $data): void {}
+
+    public function serialize(): string {}
+
+    public function __serialize(): array {}
+
+    public function __unserialize(array $data): void {}
+}
+
+class SplQueue extends SplDoublyLinkedList
+{
+    public function enqueue(mixed $value): void {}
+
+    public function dequeue(): mixed {}
+}
+
+class SplStack extends SplDoublyLinkedList {}
+
+abstract class SplHeap implements Iterator, Countable
+{
+    public function extract(): mixed {}
+
+    public function insert(mixed $value): bool {}
+
+    public function top(): mixed {}
+
+    public function count(): int {}
+
+    public function isEmpty(): bool {}
+
+    public function rewind(): void {}
+
+    public function current(): mixed {}
+
+    public function key(): int {}
+
+    public function next(): void {}
+
+    public function valid(): bool {}
+
+    public function recoverFromCorruption(): bool {}
+
+    #[TentativeType]
+    abstract protected function compare(mixed $value1, mixed $value2): int;
+
+    public function isCorrupted(): bool {}
+}
+
+class SplMinHeap extends SplHeap
+{
+    protected function compare(mixed $value1, mixed $value2): int {}
+}
+
+class SplMaxHeap extends SplHeap
+{
+    protected function compare(mixed $value1, mixed $value2): int {}
+}
+
+class SplPriorityQueue implements Iterator, Countable
+{
+    const EXTR_BOTH = 3;
+    const EXTR_PRIORITY = 2;
+    const EXTR_DATA = 1;
+
+    public function compare(mixed $priority1, mixed $priority2): int {}
+
+    public function insert(mixed $value, mixed $priority) {}
+
+    public function setExtractFlags(int $flags): int {}
+
+    public function top(): mixed {}
+
+    public function extract(): mixed {}
+
+    public function count(): int {}
+
+    public function isEmpty(): bool {}
+
+    public function rewind(): void {}
+
+    public function current(): mixed {}
+
+    public function key(): int {}
+
+    public function next(): void {}
+
+    public function valid(): bool {}
+
+    public function recoverFromCorruption() {}
+
+    public function isCorrupted(): bool {}
+
+    public function getExtractFlags(): int {}
+}
+
+class SplFixedArray implements IteratorAggregate, ArrayAccess, Countable, JsonSerializable
+{
+    public function __construct(int $size = 0) {}
+
+    public function count(): int {}
+
+    public function toArray(): array {}
+
+    public static function fromArray(array $array, bool $preserveKeys = true): SplFixedArray {}
+
+    public function getSize(): int {}
+
+    public function setSize(int $size) {}
+
+    public function offsetExists($index): bool {}
+
+    public function offsetGet($index): mixed {}
+
+    public function offsetSet($index, mixed $value): void {}
+
+    public function offsetUnset($index): void {}
+
+    public function getIterator(): Iterator {}
+
+    public function jsonSerialize(): array {}
+
+    public function __wakeup(): void {}
+}
+
+class SplObjectStorage implements Countable, Iterator, Serializable, ArrayAccess
+{
+    public function attach(object $object, mixed $info = null): void {}
+
+    public function detach(object $object): void {}
+
+    public function contains(object $object): bool {}
+
+    public function addAll(SplObjectStorage $storage): int {}
+
+    public function removeAll(SplObjectStorage $storage): int {}
+
+    public function removeAllExcept(SplObjectStorage $storage): int {}
+
+    public function getInfo(): mixed {}
+
+    public function setInfo(mixed $info): void {}
+
+    public function count(int $mode = COUNT_NORMAL): int {}
+
+    public function rewind(): void {}
+
+    public function valid(): bool {}
+
+    public function key(): int {}
+
+    public function current(): object {}
+
+    public function next(): void {}
+
+    public function unserialize(string $data): void {}
+
+    public function serialize(): string {}
+
+    public function offsetExists($object): bool {}
+
+    public function offsetGet($object): mixed {}
+
+    public function offsetSet($object, mixed $info = null): void {}
+
+    public function offsetUnset($object): void {}
+
+    public function getHash(object $object): string {}
+
+    public function __serialize(): array {}
+
+    public function __unserialize(array $data): void {}
+}
+
+function class_implements($object_or_class, bool $autoload = true): array|false {}
+
+function class_parents($object_or_class, bool $autoload = true): array|false {}
+
+function class_uses($object_or_class, bool $autoload = true): array|false {}
+
+function spl_autoload(string $class, ?string $file_extensions = null): void {}
+
+function spl_autoload_call(string $class): void {}
+
+function spl_autoload_extensions(?string $file_extensions = null): string {}
+
+function spl_autoload_functions(): array {}
+
+function spl_autoload_register(?callable $callback = null, bool $throw = true, bool $prepend = false): bool {}
+
+function spl_autoload_unregister(callable $callback): bool {}
+
+function spl_classes(): array {}
+
+function spl_object_hash(object $object): string {}
+
+/**
+ * @since 7.2
+ */
+function spl_object_id(object $object): int {}
+
+function iterator_apply(Traversable $iterator, callable $callback, ?array $args = null): int {}
+
+function iterator_count(#[LanguageLevelTypeAware(['8.2' => 'Traversable|array'], default: 'Traversable')] $iterator): int {}
+
+function iterator_to_array(#[LanguageLevelTypeAware(['8.2' => 'Traversable|array'], default: 'Traversable')] $iterator, bool $preserve_keys = true): array {}
@@ -0,0 +1,56 @@
+<?php
+
+// SimpleXML of ext/simplexml.
+
+class SimpleXMLElement implements Stringable, Countable, RecursiveIterator
+{
+    public function __construct(string $data, int $options = 0, bool $dataIsURL = false, string $namespaceOrPrefix = "", bool $isPrefix = false) {}
+
+    public function asXML(?string $filename = null): string|bool {}
+
+    public function saveXML(?string $filename = null): string|bool {}
+
+    public function xpath(string $expression): array|null|false {}
+
+    public function registerXPathNamespace(string $prefix, string $namespace): bool {}
+
+    public function attributes(?string $namespaceOrPrefix = null, bool $isPrefix = false): ?static {}
+
+    public function children(?string $namespaceOrPrefix = null, bool $isPrefix = false): ?static {}
+
+    public function getNamespaces(bool $recursive = false): array {}
+
+    public function getDocNamespaces(bool $recursive = false, bool $fromRoot = true): array|false {}
+
+    public function getName(): string {}
+
+    public function addChild(string $qualifiedName, ?string $value = null, ?string $namespace = null): ?static {}
+
+    public function addAttribute(string $qualifiedName, string $value, ?string $namespace = null): void {}
+
+    public function __toString(): string {}
+
+    public function count(): int {}
+
+    public function rewind(): void {}
+
+    public function valid(): bool {}
+
+    public function current(): static {}
+
+    public function key(): string {}
+
+    public function next(): void {}
+
+    public function hasChildren(): bool {}
+
+    public function getChildren(): ?SimpleXMLElement {}
+}
+
+class SimpleXMLIterator extends SimpleXMLElement {}
+
+function simplexml_load_file(string $filename, ?string $class_name = SimpleXMLElement::class, int $options = 0, string $namespace_or_prefix = "", bool $is_prefix = false): SimpleXMLElement|false {}
+
+function simplexml_load_string(string $data, ?string $class_name = SimpleXMLElement::class, int $options = 0, string $namespace_or_prefix = "", bool $is_prefix = false): SimpleXMLElement|false {}
+
+function simplexml_import_dom(object $node, ?string $class_name = SimpleXMLElement::class): ?SimpleXMLElement {}
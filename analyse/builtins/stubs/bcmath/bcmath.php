@@ -0,0 +1,38 @@
+<?php
+
+// Arbitrary precision mathematics of ext/bcmath.
+
+function bcadd(string $num1, string $num2, ?int $scale = null): string {}
+
+function bcsub(string $num1, string $num2, ?int $scale = null): string {}
+
+function bcmul(string $num1, string $num2, ?int $scale = null): string {}
+
+function bcdiv(string $num1, string $num2, ?int $scale = null): string {}
+
+function bcmod(string $num1, string $num2, ?int $scale = null): string {}
+
+function bcpow(string $num, string $exponent, ?int $scale = null): string {}
+
+function bcsqrt(string $num, ?int $scale = null): string {}
+
+function bcscale(?int $scale = null): int {}
+
+function bccomp(string $num1, string $num2, ?int $scale = null): int {}
+
+function bcpowmod(string $num, string $exponent, string $modulus, ?int $scale = null): string {}
+
+/**
+ * @since 8.4
+ */
+function bcfloor(string $num): string {}
+
+/**
+ * @since 8.4
+ */
+function bcceil(string $num): string {}
+
+/**
+ * @since 8.4
+ */
+function bcdivmod(string $num1, string $num2, ?int $scale = null): array {}
@@ -0,0 +1,120 @@
+<?php
+
+// Client URL library of ext/curl.
+
+/**
+ * @since 8.0
+ */
+final class CurlHandle {}
+
+/**
+ * @since 8.0
+ */
+final class CurlMultiHandle {}
+
+/**
+ * @since 8.0
+ */
+final class CurlShareHandle {}
+
+class CURLFile
+{
+    public string $name = "";
+    public string $mime = "";
+    public string $postname = "";
+
+    public function __construct(string $filename, ?string $mime_type = null, ?string $posted_filename = null) {}
+
+    public function getFilename(): string {}
+
+    public function getMimeType(): string {}
+
+    public function getPostFilename(): string {}
+
+    public function setMimeType(string $mime_type): void {}
+
+    public function setPostFilename(string $posted_filename): void {}
+}
+
+function curl_init(?string $url = null): CurlHandle|false {}
+
+function curl_copy_handle(CurlHandle $handle): CurlHandle|false {}
+
+function curl_version(): array|false {}
+
+function curl_setopt(CurlHandle $handle, int $option, mixed $value): bool {}
+
+function curl_setopt_array(CurlHandle $handle, array $options): bool {}
+
+function curl_exec(CurlHandle $handle): string|bool {}
+
+function curl_getinfo(CurlHandle $handle, ?int $option = null): mixed {}
+
+function curl_error(CurlHandle $handle): string {}
+
+function curl_errno(CurlHandle $handle): int {}
+
+function curl_close(CurlHandle $handle): void {}
+
+function curl_reset(CurlHandle $handle): void {}
+
+function curl_escape(CurlHandle $handle, string $string): string|false {}
+
+function curl_unescape(CurlHandle $handle, string $string): string|false {}
+
+function curl_strerror(int $error_code): ?string {}
+
+function curl_multi_init(): CurlMultiHandle {}
+
+function curl_multi_add_handle(CurlMultiHandle $multi_handle, CurlHandle $handle): int {}
+
+function curl_multi_remove_handle(CurlMultiHandle $multi_handle, CurlHandle $handle): int {}
+
+function curl_multi_select(CurlMultiHandle $multi_handle, float $timeout = 1.0): int {}
+
+function curl_multi_exec(CurlMultiHandle $multi_handle, &$still_running): int {}
+
+function curl_multi_getcontent(CurlHandle $handle): ?string {}
+
+function curl_multi_info_read(CurlMultiHandle $multi_handle, &$queued_messages = null): array|false {}
+
+function curl_multi_close(CurlMultiHandle $multi_handle): void {}
+
+define('CURLOPT_URL', 10002);
+define('CURLOPT_PORT', 3);
+define('CURLOPT_TIMEOUT', 13);
+define('CURLOPT_CONNECTTIMEOUT', 78);
+define('CURLOPT_RETURNTRANSFER', 19913);
+define('CURLOPT_POST', 47);
+define('CURLOPT_POSTFIELDS', 10015);
+define('CURLOPT_HTTPHEADER', 10023);
+define('CURLOPT_HEADER', 42);
+define('CURLOPT_CUSTOMREQUEST', 10036);
+define('CURLOPT_FOLLOWLOCATION', 52);
+define('CURLOPT_MAXREDIRS', 68);
+define('CURLOPT_SSL_VERIFYPEER', 64);
+define('CURLOPT_SSL_VERIFYHOST', 81);
+define('CURLOPT_USERAGENT', 10018);
+define('CURLOPT_USERPWD', 10005);
+define('CURLOPT_HTTPAUTH', 107);
+define('CURLOPT_NOBODY', 44);
+define('CURLOPT_ENCODING', 10102);
+define('CURLOPT_COOKIE', 10022);
+define('CURLOPT_PROXY', 10004);
+define('CURLOPT_HTTPGET', 80);
+define('CURLOPT_PUT', 54);
+define('CURLOPT_FILE', 10001);
+define('CURLOPT_WRITEFUNCTION', 20011);
+define('CURLOPT_HEADERFUNCTION', 20079);
+define('CURLINFO_HTTP_CODE', 2097154);
+define('CURLINFO_RESPONSE_CODE', 2097154);
+define('CURLINFO_CONTENT_TYPE', 1048594);
+define('CURLINFO_HEADER_SIZE', 2097163);
+define('CURLINFO_TOTAL_TIME', 3145731);
+define('CURLINFO_EFFECTIVE_URL', 1048577);
+define('CURLAUTH_BASIC', 1);
+define('CURLAUTH_DIGEST', 2);
+define('CURLAUTH_ANY', -17);
+define('CURLE_OK', 0);
+define('CURLE_OPERATION_TIMEDOUT', 28);
+define('CURLM_OK', 0);
@@ -0,0 +1,409 @@
+<?php
+
+// Date and time functions and classes of ext/date.
+
+use JetBrains\PhpStorm\Internal\LanguageLevelTypeAware;
+use JetBrains\PhpStorm\Internal\TentativeType;
+
+function checkdate(int $month, int $day, int $year): bool {}
+
+function date(string $format, ?int $timestamp = null): string {}
+
+function gmdate(string $format, ?int $timestamp = null): string {}
+
+function idate(string $format, ?int $timestamp = null): int|false {}
+
+function getdate(?int $timestamp = null): array {}
+
+function localtime(?int $timestamp = null, bool $associative = false): array {}
+
+function mktime(int $hour, ?int $minute = null, ?int $second = null, ?int $month = null, ?int $day = null, ?int $year = null): int|false {}
+
+function gmmktime(int $hour, ?int $minute = null, ?int $second = null, ?int $month = null, ?int $day = null, ?int $year = null): int|false {}
+
+/**
+ * @deprecated 8.1
+ */
+function strftime(string $format, ?int $timestamp = null): string|false {}
+
+/**
+ * @deprecated 8.1
+ */
+function gmstrftime(string $format, ?int $timestamp = null): string|false {}
+
+function strtotime(string $datetime, ?int $baseTimestamp = null): int|false {}
+
+function time(): int {}
+
+function date_default_timezone_get(): string {}
+
+function date_default_timezone_set(string $timezoneId): bool {}
+
+function date_create(string $datetime = "now", ?DateTimeZone $timezone = null): DateTime|false {}
+
+function date_create_immutable(string $datetime = "now", ?DateTimeZone $timezone = null): DateTimeImmutable|false {}
+
+function date_create_from_format(string $format, string $datetime, ?DateTimeZone $timezone = null): DateTime|false {}
+
+function date_create_immutable_from_format(string $format, string $datetime, ?DateTimeZone $timezone = null): DateTimeImmutable|false {}
+
+function date_parse(string $datetime): array {}
+
+function date_parse_from_format(string $format, string $datetime): array {}
+
+function date_diff(DateTimeInterface $baseObject, DateTimeInterface $targetObject, bool $absolute = false): DateInterval {}
+
+function date_add(DateTime $object, DateInterval $interval): DateTime {}
+
+function date_sub(DateTime $object, DateInterval $interval): DateTime {}
+
+function date_format(DateTimeInterface $object, string $format): string {}
+
+function date_modify(DateTime $object, string $modifier): DateTime|false {}
+
+function date_timestamp_get(DateTimeInterface $object): int {}
+
+function date_timestamp_set(DateTime $object, int $timestamp): DateTime {}
+
+function date_timezone_get(DateTimeInterface $object): DateTimeZone|false {}
+
+function date_timezone_set(DateTime $object, DateTimeZone $timezone): DateTime {}
+
+function date_interval_format(DateInterval $object, string $format): string {}
+
+function date_sun_info(int $timestamp, float $latitude, float $longitude): array {}
+
+function timezone_open(string $timezone): DateTimeZone|false {}
+
+function timezone_identifiers_list(int $timezoneGroup = DateTimeZone::ALL, ?string $countryCode = null): array {}
+
+function timezone_abbreviations_list(): array {}
+
+function timezone_name_from_abbr(string $abbr, int $utcOffset = -1, int $isDST = -1): string|false {}
+
+interface DateTimeInterface
+{
+    const ATOM = 'Y-m-d\TH:i:sP';
+    const COOKIE = 'l, d-M-Y H:i:s T';
+    const ISO8601 = 'Y-m-d\TH:i:sO';
+    /**
+     * @since 8.2
+     */
+    const ISO8601_EXPANDED = 'X-m-d\TH:i:sP';
+    const RFC822 = 'D, d M y H:i:s O';
+    const RFC850 = 'l, d-M-y H:i:s T';
+    const RFC1036 = 'D, d M y H:i:s O';
+    const RFC1123 = 'D, d M Y H:i:s O';
+    const RFC7231 = 'D, d M Y H:i:s \G\M\T';
+    const RFC2822 = 'D, d M Y H:i:s O';
+    const RFC3339 = 'Y-m-d\TH:i:sP';
+    const RFC3339_EXTENDED = 'Y-m-d\TH:i:s.vP';
+    const RSS = 'D, d M Y H:i:s O';
+    const W3C = 'Y-m-d\TH:i:sP';
+
+    #[TentativeType]
+    public function format(string $format): string;
+
+    #[TentativeType]
+    public function getTimezone(): DateTimeZone|false;
+
+    #[TentativeType]
+    public function getOffset(): int;
+
+    #[TentativeType]
+    public function getTimestamp(): int;
+
+    /**
+     * @since 8.4
+     */
+    public function getMicrosecond(): int;
+
+    #[TentativeType]
+    public function diff(DateTimeInterface $targetObject, bool $absolute = false): DateInterval;
+
+    public function __wakeup(): void;
+
+    public function __serialize(): array;
+
+    public function __unserialize(array $data): void;
+}
+
+class DateTime implements DateTimeInterface
+{
+    public function __construct(string $datetime = "now", ?DateTimeZone $timezone = null) {}
+
+    public function __wakeup(): void {}
+
+    public function __serialize(): array {}
+
+    public function __unserialize(array $data): void {}
+
+    public static function __set_state(array $array): DateTime {}
+
+    public static function createFromImmutable(DateTimeImmutable $object): static {}
+
+    public static function createFromInterface(DateTimeInterface $object): static {}
+
+    public static function createFromFormat(string $format, string $datetime, ?DateTimeZone $timezone = null): DateTime|false {}
+
+    /**
+     * @since 8.4
+     */
+    public static function createFromTimestamp(int|float $timestamp): static {}
+
+    public static function getLastErrors(): array|false {}
+
+    public function format(string $format): string {}
+
+    public function modify(string $modifier): DateTime|false {}
+
+    public function add(DateInterval $interval): DateTime {}
+
+    public function sub(DateInterval $interval): DateTime {}
+
+    public function getTimezone(): DateTimeZone|false {}
+
+    public function setTimezone(DateTimeZone $timezone): DateTime {}
+
+    public function getOffset(): int {}
+
+    /**
+     * @since 8.4
+     */
+    public function getMicrosecond(): int {}
+
+    /**
+     * @since 8.4
+     */
+    public function setMicrosecond(int $microsecond): static {}
+
+    public function setTime(int $hour, int $minute, int $second = 0, int $microsecond = 0): DateTime {}
+
+    public function setDate(int $year, int $month, int $day): DateTime {}
+
+    public function setISODate(int $year, int $week, int $dayOfWeek = 1): DateTime {}
+
+    public function setTimestamp(int $timestamp): DateTime {}
+
+    public function getTimestamp(): int {}
+
+    public function diff(DateTimeInterface $targetObject, bool $absolute = false): DateInterval {}
+}
+
+class DateTimeImmutable implements DateTimeInterface
+{
+    public function __construct(string $datetime = "now", ?DateTimeZone $timezone = null) {}
+
+    public function __wakeup(): void {}
+
+    public function __serialize(): array {}
+
+    public function __unserialize(array $data): void {}
+
+    public static function __set_state(array $array): DateTimeImmutable {}
+
+    public static function createFromMutable(DateTime $object): static {}
+
+    public static function createFromInterface(DateTimeInterface $object): static {}
+
+    public static function createFromFormat(string $format, string $datetime, ?DateTimeZone $timezone = null): DateTimeImmutable|false {}
+
+    /**
+     * @since 8.4
+     */
+    public static function createFromTimestamp(int|float $timestamp): static {}
+
+    public static function getLastErrors(): array|false {}
+
+    public function format(string $format): string {}
+
+    public function modify(string $modifier): DateTimeImmutable|false {}
+
+    public function add(DateInterval $interval): DateTimeImmutable {}
+
+    public function sub(DateInterval $interval): DateTimeImmutable {}
+
+    public function getTimezone(): DateTimeZone|false {}
+
+    public function setTimezone(DateTimeZone $timezone): DateTimeImmutable {}
+
+    public function getOffset(): int {}
+
+    /**
+     * @since 8.4
+     */
+    public function getMicrosecond(): int {}
+
+    /**
+     * @since 8.4
+     */
+    public function setMicrosecond(int $microsecond): static {}
+
+    public function setTime(int $hour, int $minute, int $second = 0, int $microsecond = 0): DateTimeImmutable {}
+
+    public function setDate(int $year, int $month, int $day): DateTimeImmutable {}
+
+    public function setISODate(int $year, int $week, int $dayOfWeek = 1): DateTimeImmutable {}
+
+    public function setTimestamp(int $timestamp): DateTimeImmutable {}
+
+    public function getTimestamp(): int {}
+
+    public function diff(DateTimeInterface $targetObject, bool $absolute = false): DateInterval {}
+}
+
+class DateTimeZone
+{
+    const AFRICA = 1;
+    const AMERICA = 2;
+    const ANTARCTICA = 4;
+    const ARCTIC = 8;
+    const ASIA = 16;
+    const ATLANTIC = 32;
+    const AUSTRALIA = 64;
+    const EUROPE = 128;
+    const INDIAN = 256;
+    const PACIFIC = 512;
+    const UTC = 1024;
+    const ALL = 2047;
+    const ALL_WITH_BC = 4095;
+    const PER_COUNTRY = 4096;
+
+    public function __construct(string $timezone) {}
+
+    public function getName(): string {}
+
+    public function getOffset(DateTimeInterface $datetime): int {}
+
+    public function getTransitions(int $timestampBegin = PHP_INT_MIN, int $timestampEnd = PHP_INT_MAX): array|false {}
+
+    public function getLocation(): array|false {}
+
+    public static function listAbbreviations(): array {}
+
+    public static function listIdentifiers(int $timezoneGroup = DateTimeZone::ALL, ?string $countryCode = null): array {}
+
+    public function __wakeup(): void {}
+
+    public static function __set_state(array $an_array): DateTimeZone {}
+}
+
+class DateInterval
+{
+    public $y;
+    public $m;
+    public $d;
+    public $h;
+    public $i;
+    public $s;
+    public $f;
+    public $invert;
+    public $days;
+
+    public function __construct(string $duration) {}
+
+    public function format(string $format): string {}
+
+    public static function createFromDateString(string $datetime): DateInterval {}
+
+    public function __wakeup(): void {}
+
+    public static function __set_state(array $an_array): DateInterval {}
+}
+
+class DatePeriod implements IteratorAggregate
+{
+    const EXCLUDE_START_DATE = 1;
+    /**
+     * @since 8.2
+     */
+    const INCLUDE_END_DATE = 2;
+
+    public readonly ?DateTimeInterface $start;
+    public readonly ?DateTimeInterface $current;
+    public readonly ?DateTimeInterface $end;
+    public readonly ?DateInterval $interval;
+    public readonly int $recurrences;
+    public readonly bool $include_start_date;
+    public readonly bool $include_end_date;
+
+    public function __construct($start, $interval = null, $end = null, $options = null) {}
+
+    /**
+     * @since 8.3
+     */
+    public static function createFromISO8601String(string $specification, int $options = 0): static {}
+
+    public function getStartDate(): DateTimeInterface {}
+
+    public function getEndDate(): ?DateTimeInterface {}
+
+    public function getDateInterval(): DateInterval {}
+
+    public function getRecurrences(): ?int {}
+
+    public function getIterator(): Iterator {}
+
+    public function __wakeup(): void {}
+
+    public static function __set_state(array $array): DatePeriod {}
+}
+
+/**
+ * @since 8.3
+ */
+class DateError extends Error {}
+
+/**
+ * @since 8.3
+ */
+class DateObjectError extends DateError {}
+
+/**
+ * @since 8.3
+ */
+class DateRangeError extends DateError {}
+
+/**
+ * @since 8.3
+ */
+class DateException extends Exception {}
+
+/**
+ * @since 8.3
+ */
+class DateInvalidTimeZoneException extends DateException {}
+
+/**
+ * @since 8.3
+ */
+class DateInvalidOperationException extends DateException {}
+
+/**
+ * @since 8.3
+ */
+class DateMalformedStringException extends DateException {}
+
+/**
+ * @since 8.3
+ */
+class DateMalformedIntervalStringException extends DateException {}
+
+/**
+ * @since 8.3
+ */
+class DateMalformedPeriodStringException extends DateException {}
+
+define('DATE_ATOM', "Y-m-d\TH:i:sP");
+define('DATE_COOKIE', "l, d-M-Y H:i:s T");
+define('DATE_ISO8601', "Y-m-d\TH:i:sO");
+define('DATE_RFC822', "D, d M y H:i:s O");
+define('DATE_RFC850', "l, d-M-y H:i:s T");
+define('DATE_RFC1036', "D, d M y H:i:s O");
+define('DATE_RFC1123', "D, d M Y H:i:s O");
+define('DATE_RFC7231', "D, d M Y H:i:s \G\M\T");
+define('DATE_RFC2822', "D, d M Y H:i:s O");
+define('DATE_RFC3339', "Y-m-d\TH:i:sP");
+define('DATE_RFC3339_EXTENDED', "Y-m-d\TH:i:s.vP");
+define('DATE_RSS', "D, d M Y H:i:s O");
+define('DATE_W3C', "Y-m-d\TH:i:sP");
@@ -0,0 +1,326 @@
+<?php
+
+// Document object model of ext/dom.
+
+class DOMException extends Exception
+{
+    public $code;
+}
+
+class DOMNode
+{
+    public string $nodeName;
+    public ?string $nodeValue;
+    public int $nodeType;
+    public ?DOMNode $parentNode;
+    public DOMNodeList $childNodes;
+    public ?DOMNode $firstChild;
+    public ?DOMNode $lastChild;
+    public ?DOMNode $previousSibling;
+    public ?DOMNode $nextSibling;
+    public ?DOMNamedNodeMap $attributes;
+    public ?DOMDocument $ownerDocument;
+    public ?string $namespaceURI;
+    public string $prefix;
+    public ?string $localName;
+    public ?string $baseURI;
+    public string $textContent;
+
+    public function appendChild(DOMNode $node) {}
+
+    public function insertBefore(DOMNode $node, ?DOMNode $child = null) {}
+
+    public function removeChild(DOMNode $child) {}
+
+    public function replaceChild(DOMNode $node, DOMNode $child) {}
+
+    public function cloneNode(bool $deep = false) {}
+
+    public function hasChildNodes(): bool {}
+
+    public function hasAttributes(): bool {}
+
+    public function normalize(): void {}
+
+    public function isSameNode(DOMNode $otherNode): bool {}
+
+    public function lookupPrefix(?string $namespace): ?string {}
+
+    public function lookupNamespaceURI(?string $prefix): ?string {}
+
+    public function getNodePath(): ?string {}
+
+    public function getLineNo(): int {}
+
+    public function C14N(bool $exclusive = false, bool $withComments = false, ?array $xpath = null, ?array $nsPrefixes = null): string|false {}
+}
+
+class DOMNodeList implements IteratorAggregate, Countable
+{
+    public int $length;
+
+    public function count(): int {}
+
+    public function getIterator(): Iterator {}
+
+    public function item(int $index) {}
+}
+
+class DOMNamedNodeMap implements IteratorAggregate, Countable
+{
+    public int $length;
+
+    public function getNamedItem(string $qualifiedName): ?DOMNode {}
+
+    public function getNamedItemNS(?string $namespace, string $localName): ?DOMNode {}
+
+    public function item(int $index): ?DOMNode {}
+
+    public function count(): int {}
+
+    public function getIterator(): Iterator {}
+}
+
+class DOMCharacterData extends DOMNode
+{
+    public string $data;
+    public int $length;
+
+    public function substringData(int $offset, int $count) {}
+
+    public function appendData(string $data) {}
+
+    public function insertData(int $offset, string $data) {}
+
+    public function deleteData(int $offset, int $count) {}
+
+    public function replaceData(int $offset, int $count, string $data) {}
+}
+
+class DOMText extends DOMCharacterData
+{
+    public string $wholeText;
+
+    public function __construct(string $data = "") {}
+
+    public function isWhitespaceInElementContent(): bool {}
+
+    public function splitText(int $offset) {}
+}
+
+class DOMComment extends DOMCharacterData
+{
+    public function __construct(string $data = "") {}
+}
+
+class DOMCdataSection extends DOMText
+{
+    public function __construct(string $data) {}
+}
+
+class DOMAttr extends DOMNode
+{
+    public string $name;
+    public bool $specified;
+    public string $value;
+    public ?DOMElement $ownerElement;
+
+    public function __construct(string $name, string $value = "") {}
+
+    public function isId(): bool {}
+}
+
+class DOMElement extends DOMNode
+{
+    public string $tagName;
+    public ?DOMElement $firstElementChild;
+    public ?DOMElement $lastElementChild;
+    public int $childElementCount;
+    public ?DOMElement $previousElementSibling;
+    public ?DOMElement $nextElementSibling;
+
+    public function __construct(string $qualifiedName, ?string $value = null, string $namespace = "") {}
+
+    public function getAttribute(string $qualifiedName): string {}
+
+    public function getAttributeNS(?string $namespace, string $localName): string {}
+
+    public function getAttributeNode(string $qualifiedName) {}
+
+    public function getElementsByTagName(string $qualifiedName): DOMNodeList {}
+
+    public function getElementsByTagNameNS(?string $namespace, string $localName): DOMNodeList {}
+
+    public function hasAttribute(string $qualifiedName): bool {}
+
+    public function hasAttributeNS(?string $namespace, string $localName): bool {}
+
+    public function removeAttribute(string $qualifiedName): bool {}
+
+    public function removeAttributeNS(?string $namespace, string $localName): void {}
+
+    public function setAttribute(string $qualifiedName, string $value) {}
+
+    public function setAttributeNS(?string $namespace, string $qualifiedName, string $value): void {}
+
+    public function setAttributeNode(DOMAttr $attr) {}
+
+    public function setIdAttribute(string $qualifiedName, bool $isId): void {}
+
+    public function remove(): void {}
+
+    public function before(...$nodes): void {}
+
+    public function after(...$nodes): void {}
+
+    public function replaceWith(...$nodes): void {}
+
+    public function append(...$nodes): void {}
+
+    public function prepend(...$nodes): void {}
+}
+
+class DOMDocumentFragment extends DOMNode
+{
+    public function __construct() {}
+
+    public function appendXML(string $data): bool {}
+}
+
+class DOMDocumentType extends DOMNode
+{
+    public string $name;
+    public DOMNamedNodeMap $entities;
+    public DOMNamedNodeMap $notations;
+    public string $publicId;
+    public string $systemId;
+    public ?string $internalSubset;
+}
+
+class DOMProcessingInstruction extends DOMNode
+{
+    public string $target;
+    public string $data;
+
+    public function __construct(string $name, string $value = "") {}
+}
+
+class DOMImplementation
+{
+    public function hasFeature(string $feature, string $version): bool {}
+
+    public function createDocumentType(string $qualifiedName, string $publicId = "", string $systemId = "") {}
+
+    public function createDocument(?string $namespace = null, string $qualifiedName = "", ?DOMDocumentType $doctype = null) {}
+}
+
+class DOMDocument extends DOMNode
+{
+    public ?DOMDocumentType $doctype;
+    public DOMImplementation $implementation;
+    public ?DOMElement $documentElement;
+    public ?string $actualEncoding;
+    public ?string $encoding;
+    public ?string $xmlEncoding;
+    public bool $standalone;
+    public bool $xmlStandalone;
+    public ?string $version;
+    public ?string $xmlVersion;
+    public bool $strictErrorChecking;
+    public ?string $documentURI;
+    public bool $formatOutput;
+    public bool $validateOnParse;
+    public bool $resolveExternals;
+    public bool $preserveWhiteSpace;
+    public bool $recover;
+    public bool $substituteEntities;
+
+    public function __construct(string $version = "1.0", string $encoding = "") {}
+
+    public function createElement(string $localName, string $value = "") {}
+
+    public function createElementNS(?string $namespace, string $qualifiedName, string $value = "") {}
+
+    public function createDocumentFragment() {}
+
+    public function createTextNode(string $data) {}
+
+    public function createComment(string $data) {}
+
+    public function createCDATASection(string $data) {}
+
+    public function createProcessingInstruction(string $target, string $data = "") {}
+
+    public function createAttribute(string $localName) {}
+
+    public function createAttributeNS(?string $namespace, string $qualifiedName) {}
+
+    public function getElementsByTagName(string $qualifiedName): DOMNodeList {}
+
+    public function getElementsByTagNameNS(?string $namespace, string $localName): DOMNodeList {}
+
+    public function getElementById(string $elementId): ?DOMElement {}
+
+    public function importNode(DOMNode $node, bool $deep = false) {}
+
+    public function adoptNode(DOMNode $node) {}
+
+    public function load(string $filename, int $options = 0) {}
+
+    public function loadXML(string $source, int $options = 0) {}
+
+    public function loadHTML(string $source, int $options = 0) {}
+
+    public function loadHTMLFile(string $filename, int $options = 0) {}
+
+    public function save(string $filename, int $options = 0) {}
+
+    public function saveXML(?DOMNode $node = null, int $options = 0) {}
+
+    public function saveHTML(?DOMNode $node = null) {}
+
+    public function saveHTMLFile(string $filename) {}
+
+    public function normalizeDocument(): void {}
+
+    public function validate(): bool {}
+
+    public function xinclude(int $options = 0) {}
+
+    public function schemaValidate(string $filename, int $flags = 0): bool {}
+
+    public function schemaValidateSource(string $source, int $flags = 0): bool {}
+
+    public function registerNodeClass(string $baseClass, ?string $extendedClass): bool {}
+}
+
+class DOMXPath
+{
+    public DOMDocument $document;
+    public bool $registerNodeNamespaces;
+
+    public function __construct(DOMDocument $document, bool $registerNodeNS = true) {}
+
+    public function evaluate(string $expression, ?DOMNode $contextNode = null, bool $registerNodeNS = true): mixed {}
+
+    public function query(string $expression, ?DOMNode $contextNode = null, bool $registerNodeNS = true): mixed {}
+
+    public function registerNamespace(string $prefix, string $namespace): bool {}
+
+    public function registerPhpFunctions(string|array|null $restrict = null): void {}
+}
+
+function dom_import_simplexml(object $node): DOMElement {}
+
+define('XML_ELEMENT_NODE', 1);
+define('XML_ATTRIBUTE_NODE', 2);
+define('XML_TEXT_NODE', 3);
+define('XML_CDATA_SECTION_NODE', 4);
+define('XML_ENTITY_REF_NODE', 5);
+define('XML_ENTITY_NODE', 6);
+define('XML_PI_NODE', 7);
+define('XML_COMMENT_NODE', 8);
+define('XML_DOCUMENT_NODE', 9);
+define('XML_DOCUMENT_TYPE_NODE', 10);
+define('XML_DOCUMENT_FRAG_NODE', 11);
+define('XML_NOTATION_NODE', 12);
@@ -0,0 +1,41 @@
+<?php
+
+// File type detection of ext/fileinfo.
+
+/**
+ * @since 8.1
+ */
+final class finfo
+{
+    public function __construct(int $flags = FILEINFO_NONE, ?string $magic_database = null) {}
+
+    public function file(string $filename, int $flags = FILEINFO_NONE, $context = null): string|false {}
+
+    public function buffer(string $string, int $flags = FILEINFO_NONE, $context = null): string|false {}
+
+    public function set_flags(int $flags): bool {}
+}
+
+function finfo_open(int $flags = FILEINFO_NONE, ?string $magic_database = null): finfo|false {}
+
+function finfo_close(finfo $finfo): bool {}
+
+function finfo_set_flags(finfo $finfo, int $flags): bool {}
+
+function finfo_file(finfo $finfo, string $filename, int $flags = FILEINFO_NONE, $context = null): string|false {}
+
+function finfo_buffer(finfo $finfo, string $string, int $flags = FILEINFO_NONE, $context = null): string|false {}
+
+function mime_content_type($filename): string|false {}
+
+define('FILEINFO_NONE', 0);
+define('FILEINFO_SYMLINK', 2);
+define('FILEINFO_MIME', 1040);
+define('FILEINFO_MIME_TYPE', 16);
+define('FILEINFO_MIME_ENCODING', 1024);
+define('FILEINFO_DEVICES', 8);
+define('FILEINFO_CONTINUE', 32);
+define('FILEINFO_PRESERVE_ATIME', 128);
+define('FILEINFO_RAW', 256);
+define('FILEINFO_EXTENSION', 16777216);
+define('FILEINFO_APPLE', 2048);
@@ -0,0 +1,43 @@
+<?php
+
+// Message digests of ext/hash.
+
+final class HashContext
+{
+    private function __construct() {}
+
+    public function __serialize(): array {}
+
+    public function __unserialize(array $data): void {}
+}
+
+function hash(string $algo, string $data, bool $binary = false, array $options = []): string {}
+
+function hash_file(string $algo, string $filename, bool $binary = false, array $options = []): string|false {}
+
+function hash_hmac(string $algo, string $data, string $key, bool $binary = false): string {}
+
+function hash_hmac_file(string $algo, string $filename, string $key, bool $binary = false): string|false {}
+
+function hash_init(string $algo, int $flags = 0, string $key = "", array $options = []): HashContext {}
+
+function hash_update(HashContext $context, string $data): bool {}
+
+function hash_final(HashContext $context, bool $binary = false): string {}
+
+function hash_copy(HashContext $context): HashContext {}
+
+function hash_algos(): array {}
+
+/**
+ * @since 7.2
+ */
+function hash_hmac_algos(): array {}
+
+function hash_pbkdf2(string $algo, string $password, string $salt, int $iterations, int $length = 0, bool $binary = false, array $options = []): string {}
+
+function hash_equals(string $known_string, string $user_string): bool {}
+
+function hash_hkdf(string $algo, string $key, int $length = 0, string $info = "", string $salt = ""): string {}
+
+define('HASH_HMAC', 1);
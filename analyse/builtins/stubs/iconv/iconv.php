@@ -0,0 +1,22 @@
+<?php
+
+// Character set conversion of ext/iconv.
+
+function iconv(string $from_encoding, string $to_encoding, string $string): string|false {}
+
+function iconv_strlen(string $string, ?string $encoding = null): int|false {}
+
+function iconv_substr(string $string, int $offset, ?int $length = null, ?string $encoding = null): string|false {}
+
+function iconv_strpos(string $haystack, string $needle, int $offset = 0, ?string $encoding = null): int|false {}
+
+function iconv_strrpos(string $haystack, string $needle, ?string $encoding = null): int|false {}
+
+function iconv_get_encoding(string $type = "all"): array|string|false {}
+
+function iconv_set_encoding(string $type, string $encoding): bool {}
+
+define('ICONV_IMPL', "glibc");
+define('ICONV_VERSION', "2.36");
+define('ICONV_MIME_DECODE_STRICT', 1);
+define('ICONV_MIME_DECODE_CONTINUE_ON_ERROR', 2);
@@ -0,0 +1,947 @@
+<?php
+
+// Internationalization of ext/intl.
+
+class IntlException extends Exception
+{
+}
+
+class Collator
+{
+    const DEFAULT_VALUE = -1;
+    const PRIMARY = 0;
+    const SECONDARY = 1;
+    const TERTIARY = 2;
+    const DEFAULT_STRENGTH = 2;
+    const QUATERNARY = 3;
+    const IDENTICAL = 15;
+    const OFF = 16;
+    const ON = 17;
+    const SHIFTED = 20;
+    const NON_IGNORABLE = 21;
+    const LOWER_FIRST = 24;
+    const UPPER_FIRST = 25;
+    const FRENCH_COLLATION = 0;
+    const ALTERNATE_HANDLING = 1;
+    const CASE_FIRST = 2;
+    const CASE_LEVEL = 3;
+    const NORMALIZATION_MODE = 4;
+    const STRENGTH = 5;
+    const HIRAGANA_QUATERNARY_MODE = 6;
+    const NUMERIC_COLLATION = 7;
+    const SORT_REGULAR = 0;
+    const SORT_STRING = 1;
+    const SORT_NUMERIC = 2;
+
+    public function __construct(string $locale) {}
+
+    public static function create(string $locale): ?Collator {}
+
+    public function compare(string $string1, string $string2): int|false {}
+
+    public function sort(array &$array, int $flags = Collator::SORT_REGULAR): bool {}
+
+    public function sortWithSortKeys(array &$array): bool {}
+
+    public function asort(array &$array, int $flags = Collator::SORT_REGULAR): bool {}
+
+    public function getAttribute(int $attribute): int|false {}
+
+    public function setAttribute(int $attribute, int $value): bool {}
+
+    public function getStrength(): int {}
+
+    public function setStrength(int $strength): bool {}
+
+    public function getLocale(int $type): string|false {}
+
+    public function getErrorCode(): int|false {}
+
+    public function getErrorMessage(): string|false {}
+
+    public function getSortKey(string $string): string|false {}
+}
+
+class NumberFormatter
+{
+    const PATTERN_DECIMAL = 0;
+    const DECIMAL = 1;
+    const CURRENCY = 2;
+    const PERCENT = 3;
+    const SCIENTIFIC = 4;
+    const SPELLOUT = 5;
+    const ORDINAL = 6;
+    const DURATION = 7;
+    const PATTERN_RULEBASED = 9;
+    const IGNORE = 0;
+    /**
+     * @since 8.4
+     */
+    const DECIMAL_COMPACT_SHORT = 14;
+    /**
+     * @since 8.4
+     */
+    const DECIMAL_COMPACT_LONG = 15;
+    const CURRENCY_ACCOUNTING = 12;
+    const DEFAULT_STYLE = 1;
+    const ROUND_CEILING = 0;
+    const ROUND_FLOOR = 1;
+    const ROUND_DOWN = 2;
+    const ROUND_UP = 3;
+    const ROUND_HALFEVEN = 4;
+    const ROUND_HALFDOWN = 5;
+    const ROUND_HALFUP = 6;
+    const PAD_BEFORE_PREFIX = 0;
+    const PAD_AFTER_PREFIX = 1;
+    const PAD_BEFORE_SUFFIX = 2;
+    const PAD_AFTER_SUFFIX = 3;
+    const PARSE_INT_ONLY = 0;
+    const GROUPING_USED = 1;
+    const DECIMAL_ALWAYS_SHOWN = 2;
+    const MAX_INTEGER_DIGITS = 3;
+    const MIN_INTEGER_DIGITS = 4;
+    const INTEGER_DIGITS = 5;
+    const MAX_FRACTION_DIGITS = 6;
+    const MIN_FRACTION_DIGITS = 7;
+    const FRACTION_DIGITS = 8;
+    const MULTIPLIER = 9;
+    const GROUPING_SIZE = 10;
+    const ROUNDING_MODE = 11;
+    const ROUNDING_INCREMENT = 12;
+    const FORMAT_WIDTH = 13;
+    const PADDING_POSITION = 14;
+    const SECONDARY_GROUPING_SIZE = 15;
+    const SIGNIFICANT_DIGITS_USED = 16;
+    const MIN_SIGNIFICANT_DIGITS = 17;
+    const MAX_SIGNIFICANT_DIGITS = 18;
+    const LENIENT_PARSE = 19;
+    const POSITIVE_PREFIX = 0;
+    const POSITIVE_SUFFIX = 1;
+    const NEGATIVE_PREFIX = 2;
+    const NEGATIVE_SUFFIX = 3;
+    const PADDING_CHARACTER = 4;
+    const CURRENCY_CODE = 5;
+    const DEFAULT_RULESET = 6;
+    const PUBLIC_RULESETS = 7;
+    const DECIMAL_SEPARATOR_SYMBOL = 0;
+    const GROUPING_SEPARATOR_SYMBOL = 1;
+    const PATTERN_SEPARATOR_SYMBOL = 2;
+    const PERCENT_SYMBOL = 3;
+    const ZERO_DIGIT_SYMBOL = 4;
+    const DIGIT_SYMBOL = 5;
+    const MINUS_SIGN_SYMBOL = 6;
+    const PLUS_SIGN_SYMBOL = 7;
+    const CURRENCY_SYMBOL = 8;
+    const INTL_CURRENCY_SYMBOL = 9;
+    const MONETARY_SEPARATOR_SYMBOL = 10;
+    const EXPONENTIAL_SYMBOL = 11;
+    const PERMILL_SYMBOL = 12;
+    const PAD_ESCAPE_SYMBOL = 13;
+    const INFINITY_SYMBOL = 14;
+    const NAN_SYMBOL = 15;
+    const SIGNIFICANT_DIGIT_SYMBOL = 16;
+    const MONETARY_GROUPING_SEPARATOR_SYMBOL = 17;
+    const TYPE_DEFAULT = 0;
+    const TYPE_INT32 = 1;
+    const TYPE_INT64 = 2;
+    const TYPE_DOUBLE = 3;
+    const TYPE_CURRENCY = 4;
+
+    public function __construct(string $locale, int $style, ?string $pattern = null) {}
+
+    public static function create(string $locale, int $style, ?string $pattern = null): ?NumberFormatter {}
+
+    public function format(int|float $num, int $type = NumberFormatter::TYPE_DEFAULT): string|false {}
+
+    public function parse(string $string, int $type = NumberFormatter::TYPE_DOUBLE, &$offset = null): int|float|false {}
+
+    public function formatCurrency(float $amount, string $currency): string|false {}
+
+    public function parseCurrency(string $string, &$currency, &$offset = null): float|false {}
+
+    public function setAttribute(int $attribute, int|float $value): bool {}
+
+    public function getAttribute(int $attribute): int|float|false {}
+
+    public function setTextAttribute(int $attribute, string $value): bool {}
+
+    public function getTextAttribute(int $attribute): string|false {}
+
+    public function setSymbol(int $symbol, string $value): bool {}
+
+    public function getSymbol(int $symbol): string|false {}
+
+    public function setPattern(string $pattern): bool {}
+
+    public function getPattern(): string|false {}
+
+    public function getLocale(int $type = ULOC_ACTUAL_LOCALE): string|false {}
+
+    public function getErrorCode(): int {}
+
+    public function getErrorMessage(): string {}
+}
+
+class Normalizer
+{
+    const FORM_D = 4;
+    const NFD = 4;
+    const FORM_KD = 8;
+    const NFKD = 8;
+    const FORM_C = 16;
+    const NFC = 16;
+    const FORM_KC = 32;
+    const NFKC = 32;
+    const FORM_KC_CF = 48;
+    const NFKC_CF = 48;
+
+    public static function normalize(string $string, int $form = Normalizer::FORM_C): string|false {}
+
+    public static function isNormalized(string $string, int $form = Normalizer::FORM_C): bool {}
+
+    public static function getRawDecomposition(string $string, int $form = Normalizer::FORM_C): ?string {}
+}
+
+class Locale
+{
+    const ACTUAL_LOCALE = 0;
+    const VALID_LOCALE = 1;
+    const DEFAULT_LOCALE = null;
+    const LANG_TAG = "language";
+    const EXTLANG_TAG = "extlang";
+    const SCRIPT_TAG = "script";
+    const REGION_TAG = "region";
+    const VARIANT_TAG = "variant";
+    const GRANDFATHERED_LANG_TAG = "grandfathered";
+    const PRIVATE_TAG = "private";
+
+    public static function getDefault(): string {}
+
+    public static function setDefault(string $locale): bool {}
+
+    public static function getPrimaryLanguage(string $locale): ?string {}
+
+    public static function getScript(string $locale): ?string {}
+
+    public static function getRegion(string $locale): ?string {}
+
+    public static function getKeywords(string $locale): array|false|null {}
+
+    public static function getDisplayScript(string $locale, ?string $displayLocale = null): string|false {}
+
+    public static function getDisplayRegion(string $locale, ?string $displayLocale = null): string|false {}
+
+    public static function getDisplayName(string $locale, ?string $displayLocale = null): string|false {}
+
+    public static function getDisplayLanguage(string $locale, ?string $displayLocale = null): string|false {}
+
+    public static function getDisplayVariant(string $locale, ?string $displayLocale = null): string|false {}
+
+    public static function composeLocale(array $subtags): string|false {}
+
+    public static function parseLocale(string $locale): ?array {}
+
+    public static function getAllVariants(string $locale): ?array {}
+
+    public static function filterMatches(string $languageTag, string $locale, bool $canonicalize = false): ?bool {}
+
+    public static function lookup(array $languageTag, string $locale, bool $canonicalize = false, ?string $defaultLocale = null): ?string {}
+
+    public static function canonicalize(string $locale): ?string {}
+
+    public static function acceptFromHttp(string $header): string|false {}
+}
+
+class MessageFormatter
+{
+    public function __construct(string $locale, string $pattern) {}
+
+    public static function create(string $locale, string $pattern): ?MessageFormatter {}
+
+    public function format(array $values): string|false {}
+
+    public static function formatMessage(string $locale, string $pattern, array $values): string|false {}
+
+    public function parse(string $string): array|false {}
+
+    public static function parseMessage(string $locale, string $pattern, string $message): array|false {}
+
+    public function setPattern(string $pattern): bool {}
+
+    public function getPattern(): string|false {}
+
+    public function getLocale(): string {}
+
+    public function getErrorCode(): int {}
+
+    public function getErrorMessage(): string {}
+}
+
+class IntlDateFormatter
+{
+    const FULL = 0;
+    const LONG = 1;
+    const MEDIUM = 2;
+    const SHORT = 3;
+    const NONE = -1;
+    const RELATIVE_FULL = 128;
+    const RELATIVE_LONG = 129;
+    const RELATIVE_MEDIUM = 130;
+    const RELATIVE_SHORT = 131;
+    /**
+     * @since 8.3
+     */
+    const PATTERN = -2;
+    const GREGORIAN = 1;
+    const TRADITIONAL = 0;
+
+    public function __construct(?string $locale, int $dateType = IntlDateFormatter::FULL, int $timeType = IntlDateFormatter::FULL, $timezone = null, $calendar = null, ?string $pattern = null) {}
+
+    public static function create(?string $locale, int $dateType = IntlDateFormatter::FULL, int $timeType = IntlDateFormatter::FULL, $timezone = null, IntlCalendar|int|null $calendar = null, ?string $pattern = null): ?IntlDateFormatter {}
+
+    public function getDateType(): int|false {}
+
+    public function getTimeType(): int|false {}
+
+    public function getCalendar(): int|false {}
+
+    public function setCalendar(IntlCalendar|int|null $calendar): bool {}
+
+    public function getTimeZoneId(): string|false {}
+
+    public function getCalendarObject(): IntlCalendar|false|null {}
+
+    public function getTimeZone(): IntlTimeZone|false {}
+
+    public function setTimeZone($timezone): bool {}
+
+    public function setPattern(string $pattern): bool {}
+
+    public function getPattern(): string|false {}
+
+    public function getLocale(int $type = ULOC_ACTUAL_LOCALE): string|false {}
+
+    public function setLenient(bool $lenient): void {}
+
+    public function isLenient(): bool {}
+
+    public function format($datetime): string|false {}
+
+    public static function formatObject($datetime, $format = null, ?string $locale = null): string|false {}
+
+    public function parse(string $string, &$offset = null): int|float|false {}
+
+    public function localtime(string $string, &$offset = null): array|false {}
+
+    public function getErrorCode(): int {}
+
+    public function getErrorMessage(): string {}
+}
+
+class ResourceBundle implements IteratorAggregate, Countable
+{
+    public function __construct(?string $locale, ?string $bundle, bool $fallback = true) {}
+
+    public static function create(?string $locale, ?string $bundle, bool $fallback = true): ?ResourceBundle {}
+
+    public function get($index, bool $fallback = true): mixed {}
+
+    public function count(): int {}
+
+    public static function getLocales(string $bundle): array|false {}
+
+    public function getErrorCode(): int {}
+
+    public function getErrorMessage(): string {}
+
+    public function getIterator(): Iterator {}
+}
+
+class Transliterator
+{
+    const FORWARD = 0;
+    const REVERSE = 1;
+
+    public readonly string $id;
+
+    final private function __construct() {}
+
+    public static function create(string $id, int $direction = Transliterator::FORWARD): ?Transliterator {}
+
+    public static function createFromRules(string $rules, int $direction = Transliterator::FORWARD): ?Transliterator {}
+
+    public function createInverse(): ?Transliterator {}
+
+    public static function listIDs(): array|false {}
+
+    public function transliterate(string $string, int $start = 0, int $end = -1): string|false {}
+
+    public function getErrorCode(): int|false {}
+
+    public function getErrorMessage(): string|false {}
+}
+
+class Spoofchecker
+{
+    const SINGLE_SCRIPT_CONFUSABLE = 1;
+    const MIXED_SCRIPT_CONFUSABLE = 2;
+    const WHOLE_SCRIPT_CONFUSABLE = 4;
+    const ANY_CASE = 8;
+    const SINGLE_SCRIPT = 16;
+    const INVISIBLE = 32;
+    const CHAR_LIMIT = 64;
+
+    public function __construct() {}
+
+    public function isSuspicious(string $string, &$errorCode = null): bool {}
+
+    public function areConfusable(string $string1, string $string2, &$errorCode = null): bool {}
+
+    public function setAllowedLocales(string $locales): void {}
+
+    public function setChecks(int $checks): void {}
+}
+
+class IntlTimeZone
+{
+    const DISPLAY_SHORT = 1;
+    const DISPLAY_LONG = 2;
+    const TYPE_ANY = 0;
+    const TYPE_CANONICAL = 1;
+    const TYPE_CANONICAL_LOCATION = 2;
+
+    private function __construct() {}
+
+    public static function countEquivalentIDs(string $timezoneId): int|false {}
+
+    public static function createDefault(): IntlTimeZone {}
+
+    public static function createEnumeration($countryOrRawOffset = null): IntlIterator|false {}
+
+    public static function createTimeZone(string $timezoneId): ?IntlTimeZone {}
+
+    public static function fromDateTimeZone(DateTimeZone $timezone): ?IntlTimeZone {}
+
+    public static function getCanonicalID(string $timezoneId, &$isSystemId = null): string|false {}
+
+    public function getDisplayName(bool $dst = false, int $style = IntlTimeZone::DISPLAY_LONG, ?string $locale = null): string|false {}
+
+    public function getDSTSavings(): int {}
+
+    public static function getGMT(): IntlTimeZone {}
+
+    public function getID(): string|false {}
+
+    public function getOffset(float $timestamp, bool $local, &$rawOffset, &$dstOffset): bool {}
+
+    public function getRawOffset(): int {}
+
+    public static function getUnknown(): IntlTimeZone {}
+
+    public function hasSameRules(IntlTimeZone $other): bool {}
+
+    public function toDateTimeZone(): DateTimeZone|false {}
+
+    public function useDaylightTime(): bool {}
+
+    public function getErrorCode(): int|false {}
+
+    public function getErrorMessage(): string|false {}
+}
+
+class IntlCalendar
+{
+    const FIELD_ERA = 0;
+    const FIELD_YEAR = 1;
+    const FIELD_MONTH = 2;
+    const FIELD_WEEK_OF_YEAR = 3;
+    const FIELD_WEEK_OF_MONTH = 4;
+    const FIELD_DATE = 5;
+    const FIELD_DAY_OF_YEAR = 6;
+    const FIELD_DAY_OF_WEEK = 7;
+    const FIELD_DAY_OF_WEEK_IN_MONTH = 8;
+    const FIELD_AM_PM = 9;
+    const FIELD_HOUR = 10;
+    const FIELD_HOUR_OF_DAY = 11;
+    const FIELD_MINUTE = 12;
+    const FIELD_SECOND = 13;
+    const FIELD_MILLISECOND = 14;
+    const FIELD_ZONE_OFFSET = 15;
+    const FIELD_DST_OFFSET = 16;
+    const FIELD_YEAR_WOY = 17;
+    const FIELD_DOW_LOCAL = 18;
+    const FIELD_EXTENDED_YEAR = 19;
+    const FIELD_JULIAN_DAY = 20;
+    const FIELD_MILLISECONDS_IN_DAY = 21;
+    const FIELD_IS_LEAP_MONTH = 22;
+    const FIELD_FIELD_COUNT = 23;
+    const FIELD_DAY_OF_MONTH = 5;
+    const DOW_SUNDAY = 1;
+    const DOW_MONDAY = 2;
+    const DOW_TUESDAY = 3;
+    const DOW_WEDNESDAY = 4;
+    const DOW_THURSDAY = 5;
+    const DOW_FRIDAY = 6;
+    const DOW_SATURDAY = 7;
+    const DOW_TYPE_WEEKDAY = 0;
+    const DOW_TYPE_WEEKEND = 1;
+    const DOW_TYPE_WEEKEND_OFFSET = 2;
+    const DOW_TYPE_WEEKEND_CEASE = 3;
+    const WALLTIME_FIRST = 1;
+    const WALLTIME_LAST = 0;
+    const WALLTIME_NEXT_VALID = 2;
+
+    private function __construct() {}
+
+    public static function createInstance($timezone = null, ?string $locale = null): ?IntlCalendar {}
+
+    public static function fromDateTime(DateTime|string $datetime, ?string $locale = null): ?IntlCalendar {}
+
+    public static function getAvailableLocales(): array {}
+
+    public static function getNow(): float {}
+
+    public function add(int $field, int $value): bool {}
+
+    public function after(IntlCalendar $other): bool {}
+
+    public function before(IntlCalendar $other): bool {}
+
+    public function clear(?int $field = null): true {}
+
+    public function equals(IntlCalendar $other): bool {}
+
+    public function fieldDifference(float $timestamp, int $field): int|false {}
+
+    public function get(int $field): int|false {}
+
+    public function getActualMaximum(int $field): int|false {}
+
+    public function getActualMinimum(int $field): int|false {}
+
+    public function getFirstDayOfWeek(): int|false {}
+
+    public function getLocale(int $type): string|false {}
+
+    public function getTime(): float|false {}
+
+    public function getTimeZone(): IntlTimeZone|false {}
+
+    public function getType(): string {}
+
+    public function inDaylightTime(): bool {}
+
+    public function isLenient(): bool {}
+
+    public function isSet(int $field): bool {}
+
+    public function isWeekend(?float $timestamp = null): bool {}
+
+    public function roll(int $field, $value): bool {}
+
+    public function set(int $year, int $month, int $dayOfMonth = 0, int $hour = 0, int $minute = 0, int $second = 0): true {}
+
+    /**
+     * @since 8.3
+     */
+    public function setDate(int $year, int $month, int $dayOfMonth): void {}
+
+    /**
+     * @since 8.3
+     */
+    public function setDateTime(int $year, int $month, int $dayOfMonth, int $hour, int $minute, ?int $second = null): void {}
+
+    public function setFirstDayOfWeek(int $dayOfWeek): true {}
+
+    public function setLenient(bool $lenient): true {}
+
+    public function setTime(float $timestamp): bool {}
+
+    public function setTimeZone($timezone): bool {}
+
+    public function toDateTime(): DateTime|false {}
+
+    public function getErrorCode(): int|false {}
+
+    public function getErrorMessage(): string|false {}
+}
+
+class IntlGregorianCalendar extends IntlCalendar
+{
+    public function __construct($timezoneOrYear = null, $localeOrMonth = null, $day = null, $hour = null, $minute = null, $second = null) {}
+
+    public function setGregorianChange(float $timestamp): bool {}
+
+    public function getGregorianChange(): float {}
+
+    public function isLeapYear(int $year): bool {}
+}
+
+class IntlIterator implements Iterator
+{
+    public function current(): mixed {}
+
+    public function key(): mixed {}
+
+    public function next(): void {}
+
+    public function rewind(): void {}
+
+    public function valid(): bool {}
+}
+
+class IntlBreakIterator implements IteratorAggregate
+{
+    const DONE = -1;
+    const WORD_NONE = 0;
+    const WORD_NONE_LIMIT = 100;
+    const WORD_NUMBER = 100;
+    const WORD_NUMBER_LIMIT = 200;
+    const WORD_LETTER = 200;
+    const WORD_LETTER_LIMIT = 300;
+    const WORD_KANA = 300;
+    const WORD_KANA_LIMIT = 400;
+    const WORD_IDEO = 400;
+    const WORD_IDEO_LIMIT = 500;
+    const LINE_SOFT = 0;
+    const LINE_SOFT_LIMIT = 100;
+    const LINE_HARD = 100;
+    const LINE_HARD_LIMIT = 200;
+    const SENTENCE_TERM = 0;
+    const SENTENCE_TERM_LIMIT = 100;
+    const SENTENCE_SEP = 100;
+    const SENTENCE_SEP_LIMIT = 200;
+
+    private function __construct() {}
+
+    public static function createCharacterInstance(?string $locale = null): ?IntlBreakIterator {}
+
+    public static function createCodePointInstance(): IntlCodePointBreakIterator {}
+
+    public static function createLineInstance(?string $locale = null): ?IntlBreakIterator {}
+
+    public static function createSentenceInstance(?string $locale = null): ?IntlBreakIterator {}
+
+    public static function createTitleInstance(?string $locale = null): ?IntlBreakIterator {}
+
+    public static function createWordInstance(?string $locale = null): ?IntlBreakIterator {}
+
+    public function current(): int {}
+
+    public function first(): int {}
+
+    public function following(int $offset): int {}
+
+    public function getLocale(int $type): string|false {}
+
+    public function getPartsIterator(string $type = IntlPartsIterator::KEY_SEQUENTIAL): IntlPartsIterator {}
+
+    public function getText(): ?string {}
+
+    public function isBoundary(int $offset): bool {}
+
+    public function last(): int {}
+
+    public function next(?int $offset = null): int {}
+
+    public function preceding(int $offset): int {}
+
+    public function previous(): int {}
+
+    public function setText(string $text): bool {}
+
+    public function getIterator(): Iterator {}
+
+    public function getErrorCode(): int {}
+
+    public function getErrorMessage(): string {}
+}
+
+class IntlRuleBasedBreakIterator extends IntlBreakIterator
+{
+    public function __construct(string $rules, bool $compiled = false) {}
+
+    public function getBinaryRules(): string|false {}
+
+    public function getRules(): string|false {}
+
+    public function getRuleStatus(): int {}
+
+    public function getRuleStatusVec(): array|false {}
+}
+
+class IntlCodePointBreakIterator extends IntlBreakIterator
+{
+    public function getLastCodePoint(): int {}
+}
+
+class IntlPartsIterator extends IntlIterator
+{
+    const KEY_SEQUENTIAL = 0;
+    const KEY_LEFT = 1;
+    const KEY_RIGHT = 2;
+
+    public function getBreakIterator(): IntlBreakIterator {}
+
+    /**
+     * @since 8.1
+     */
+    public function getRuleStatus(): int {}
+}
+
+class UConverter
+{
+    const REASON_UNASSIGNED = 0;
+    const REASON_ILLEGAL = 1;
+    const REASON_IRREGULAR = 2;
+    const REASON_RESET = 3;
+    const REASON_CLOSE = 4;
+    const REASON_CLONE = 5;
+    const UNSUPPORTED_CONVERTER = -1;
+    const SBCS = 0;
+    const DBCS = 1;
+    const MBCS = 2;
+    const LATIN_1 = 3;
+    const UTF8 = 4;
+    const UTF16_BigEndian = 5;
+    const UTF16_LittleEndian = 6;
+    const UTF32_BigEndian = 7;
+    const UTF32_LittleEndian = 8;
+    const EBCDIC_STATEFUL = 9;
+    const ISO_2022 = 10;
+    const LMBCS_1 = 11;
+    const UTF16 = 29;
+    const UTF32 = 30;
+    const UTF7 = 27;
+    const UTF8_CESU = 31;
+    const IMAP_MAILBOX = 32;
+
+    public function __construct(?string $destination_encoding = null, ?string $source_encoding = null) {}
+
+    public function convert(string $str, bool $reverse = false): string|false {}
+
+    public static function transcode(string $str, string $toEncoding, string $fromEncoding, ?array $options = null): string|false {}
+
+    public static function getAliases(string $name): array|false|null {}
+
+    public static function getAvailable(): array {}
+
+    public function getDestinationEncoding(): string|false|null {}
+
+    public function getSourceEncoding(): string|false|null {}
+
+    public function setDestinationEncoding(string $encoding): bool {}
+
+    public function setSourceEncoding(string $encoding): bool {}
+
+    public function getSubstChars(): string|false|null {}
+
+    public function setSubstChars(string $chars): bool {}
+
+    public static function getStandards(): ?array {}
+
+    public static function reasonText(int $reason): string {}
+
+    public function getErrorCode(): int {}
+
+    public function getErrorMessage(): ?string {}
+}
+
+class IntlChar
+{
+    const UNICODE_VERSION = "15.1";
+    const CODEPOINT_MIN = 0;
+    const CODEPOINT_MAX = 1114111;
+    const NO_NUMERIC_VALUE = -123456789;
+
+    public static function chr($codepoint): ?string {}
+
+    public static function ord($character): ?int {}
+
+    public static function charName($codepoint, int $type = IntlChar::UNICODE_CHAR_NAME): ?string {}
+
+    public static function charType($codepoint): ?int {}
+
+    public static function digit(int|string $codepoint, int $base = 10): int|false|null {}
+
+    public static function foldCase($codepoint, int $options = IntlChar::FOLD_CASE_DEFAULT): string|int|null {}
+
+    public static function getNumericValue($codepoint): ?float {}
+
+    public static function hasBinaryProperty($codepoint, int $property): ?bool {}
+
+    public static function isalnum($codepoint): ?bool {}
+
+    public static function isalpha($codepoint): ?bool {}
+
+    public static function isdigit($codepoint): ?bool {}
+
+    public static function islower($codepoint): ?bool {}
+
+    public static function isupper($codepoint): ?bool {}
+
+    public static function isspace($codepoint): ?bool {}
+
+    public static function ispunct($codepoint): ?bool {}
+
+    public static function isprint($codepoint): ?bool {}
+
+    public static function tolower($codepoint): string|int|null {}
+
+    public static function toupper($codepoint): string|int|null {}
+
+    public static function totitle($codepoint): string|int|null {}
+
+    const UNICODE_CHAR_NAME = 0;
+    const FOLD_CASE_DEFAULT = 0;
+}
+
+function collator_create(string $locale): ?Collator {}
+
+function collator_compare(Collator $object, string $string1, string $string2): int|false {}
+
+function collator_sort(Collator $object, array &$array, int $flags = Collator::SORT_REGULAR): bool {}
+
+function collator_asort(Collator $object, array &$array, int $flags = Collator::SORT_REGULAR): bool {}
+
+function numfmt_create(string $locale, int $style, ?string $pattern = null): ?NumberFormatter {}
+
+function numfmt_format(NumberFormatter $formatter, int|float $num, int $type = NumberFormatter::TYPE_DEFAULT): string|false {}
+
+function numfmt_parse(NumberFormatter $formatter, string $string, int $type = NumberFormatter::TYPE_DOUBLE, &$offset = null): int|float|false {}
+
+function numfmt_format_currency(NumberFormatter $formatter, float $amount, string $currency): string|false {}
+
+function normalizer_normalize(string $string, int $form = Normalizer::FORM_C): string|false {}
+
+function normalizer_is_normalized(string $string, int $form = Normalizer::FORM_C): bool {}
+
+function locale_get_default(): string {}
+
+function locale_set_default(string $locale): bool {}
+
+function locale_get_primary_language(string $locale): ?string {}
+
+function locale_get_region(string $locale): ?string {}
+
+function locale_accept_from_http(string $header): string|false {}
+
+function locale_canonicalize(string $locale): ?string {}
+
+function locale_get_display_name(string $locale, ?string $displayLocale = null): string|false {}
+
+function msgfmt_create(string $locale, string $pattern): ?MessageFormatter {}
+
+function msgfmt_format(MessageFormatter $formatter, array $values): string|false {}
+
+function msgfmt_format_message(string $locale, string $pattern, array $values): string|false {}
+
+function datefmt_create(?string $locale, int $dateType = IntlDateFormatter::FULL, int $timeType = IntlDateFormatter::FULL, $timezone = null, IntlCalendar|int|null $calendar = null, ?string $pattern = null): ?IntlDateFormatter {}
+
+function datefmt_format(IntlDateFormatter $formatter, $datetime): string|false {}
+
+function datefmt_format_object($datetime, $format = null, ?string $locale = null): string|false {}
+
+function datefmt_parse(IntlDateFormatter $formatter, string $string, &$offset = null): int|float|false {}
+
+function grapheme_strlen(string $string): int|false|null {}
+
+function grapheme_strpos(string $haystack, string $needle, int $offset = 0): int|false {}
+
+function grapheme_stripos(string $haystack, string $needle, int $offset = 0): int|false {}
+
+function grapheme_strrpos(string $haystack, string $needle, int $offset = 0): int|false {}
+
+function grapheme_strripos(string $haystack, string $needle, int $offset = 0): int|false {}
+
+function grapheme_substr(string $string, int $offset, ?int $length = null): string|false {}
+
+function grapheme_strstr(string $haystack, string $needle, bool $beforeNeedle = false): string|false {}
+
+function grapheme_stristr(string $haystack, string $needle, bool $beforeNeedle = false): string|false {}
+
+function grapheme_extract(string $haystack, int $size, int $type = GRAPHEME_EXTR_COUNT, int $offset = 0, &$next = null): string|false {}
+
+/**
+ * @since 8.4
+ */
+function grapheme_str_split(string $string, int $length = 1): array|false {}
+
+function idn_to_ascii(string $domain, int $flags = IDNA_DEFAULT, int $variant = INTL_IDNA_VARIANT_UTS46, &$idna_info = null): string|false {}
+
+function idn_to_utf8(string $domain, int $flags = IDNA_DEFAULT, int $variant = INTL_IDNA_VARIANT_UTS46, &$idna_info = null): string|false {}
+
+function resourcebundle_create(?string $locale, ?string $bundle, bool $fallback = true): ?ResourceBundle {}
+
+function transliterator_create(string $id, int $direction = Transliterator::FORWARD): ?Transliterator {}
+
+function transliterator_transliterate(Transliterator|string $transliterator, string $string, int $start = 0, int $end = -1): string|false {}
+
+function intlcal_create_instance($timezone = null, ?string $locale = null): ?IntlCalendar {}
+
+function intltz_create_time_zone(string $timezoneId): ?IntlTimeZone {}
+
+function intl_get_error_code(): int {}
+
+function intl_get_error_message(): string {}
+
+function intl_is_failure(int $errorCode): bool {}
+
+function intl_error_name(int $errorCode): string {}
+
+define('INTL_MAX_LOCALE_LEN', 156);
+define('INTL_ICU_VERSION', "74.1");
+define('INTL_ICU_DATA_VERSION', "74.1");
+define('ULOC_ACTUAL_LOCALE', 0);
+define('ULOC_VALID_LOCALE', 1);
+define('GRAPHEME_EXTR_COUNT', 0);
+define('GRAPHEME_EXTR_MAXBYTES', 1);
+define('GRAPHEME_EXTR_MAXCHARS', 2);
+define('U_USING_FALLBACK_WARNING', -128);
+define('U_ERROR_WARNING_START', -128);
+define('U_USING_DEFAULT_WARNING', -127);
+define('U_SAFECLONE_ALLOCATED_WARNING', -126);
+define('U_STATE_OLD_WARNING', -125);
+define('U_STRING_NOT_TERMINATED_WARNING', -124);
+define('U_SORT_KEY_TOO_SHORT_WARNING', -123);
+define('U_AMBIGUOUS_ALIAS_WARNING', -122);
+define('U_DIFFERENT_UCA_VERSION', -121);
+define('U_ERROR_WARNING_LIMIT', -119);
+define('U_ZERO_ERROR', 0);
+define('U_ILLEGAL_ARGUMENT_ERROR', 1);
+define('U_MISSING_RESOURCE_ERROR', 2);
+define('U_INVALID_FORMAT_ERROR', 3);
+define('U_FILE_ACCESS_ERROR', 4);
+define('U_INTERNAL_PROGRAM_ERROR', 5);
+define('U_MESSAGE_PARSE_ERROR', 6);
+define('U_MEMORY_ALLOCATION_ERROR', 7);
+define('U_INDEX_OUTOFBOUNDS_ERROR', 8);
+define('U_PARSE_ERROR', 9);
+define('U_INVALID_CHAR_FOUND', 10);
+define('U_TRUNCATED_CHAR_FOUND', 11);
+define('U_ILLEGAL_CHAR_FOUND', 12);
+define('U_INVALID_TABLE_FORMAT', 13);
+define('U_INVALID_TABLE_FILE', 14);
+define('U_BUFFER_OVERFLOW_ERROR', 15);
+define('U_UNSUPPORTED_ERROR', 16);
+define('IDNA_DEFAULT', 0);
+define('IDNA_ALLOW_UNASSIGNED', 1);
+define('IDNA_USE_STD3_RULES', 2);
+define('IDNA_CHECK_BIDI', 4);
+define('IDNA_CHECK_CONTEXTJ', 8);
+define('IDNA_NONTRANSITIONAL_TO_ASCII', 16);
+define('IDNA_NONTRANSITIONAL_TO_UNICODE', 32);
+define('INTL_IDNA_VARIANT_UTS46', 1);
+define('IDNA_ERROR_EMPTY_LABEL', 1);
+define('IDNA_ERROR_LABEL_TOO_LONG', 2);
+define('IDNA_ERROR_DOMAIN_NAME_TOO_LONG', 4);
+define('IDNA_ERROR_LEADING_HYPHEN', 8);
+define('IDNA_ERROR_TRAILING_HYPHEN', 16);
+define('IDNA_ERROR_HYPHEN_3_4', 32);
+define('IDNA_ERROR_LEADING_COMBINING_MARK', 64);
+define('IDNA_ERROR_DISALLOWED', 128);
+define('IDNA_ERROR_PUNYCODE', 256);
+define('IDNA_ERROR_LABEL_HAS_DOT', 512);
+define('IDNA_ERROR_INVALID_ACE_LABEL', 1024);
+define('IDNA_ERROR_BIDI', 2048);
+define('IDNA_ERROR_CONTEXTJ', 4096);
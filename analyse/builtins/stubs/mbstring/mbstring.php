@@ -0,0 +1,100 @@
+<?php
+
+// Multibyte string functions of ext/mbstring.
+
+function mb_strlen(string $string, ?string $encoding = null): int {}
+
+function mb_substr(string $string, int $start, ?int $length = null, ?string $encoding = null): string {}
+
+function mb_strpos(string $haystack, string $needle, int $offset = 0, ?string $encoding = null): int|false {}
+
+function mb_strrpos(string $haystack, string $needle, int $offset = 0, ?string $encoding = null): int|false {}
+
+function mb_stripos(string $haystack, string $needle, int $offset = 0, ?string $encoding = null): int|false {}
+
+function mb_strripos(string $haystack, string $needle, int $offset = 0, ?string $encoding = null): int|false {}
+
+function mb_strstr(string $haystack, string $needle, bool $before_needle = false, ?string $encoding = null): string|false {}
+
+function mb_substr_count(string $haystack, string $needle, ?string $encoding = null): int {}
+
+function mb_strtoupper(string $string, ?string $encoding = null): string {}
+
+function mb_strtolower(string $string, ?string $encoding = null): string {}
+
+function mb_convert_case(string $string, int $mode, ?string $encoding = null): string {}
+
+function mb_convert_encoding(array|string $string, string $to_encoding, array|string|null $from_encoding = null): array|string|false {}
+
+function mb_detect_encoding(string $string, array|string|null $encodings = null, bool $strict = false): string|false {}
+
+function mb_check_encoding(array|string|null $value = null, ?string $encoding = null): bool {}
+
+function mb_internal_encoding(?string $encoding = null): string|bool {}
+
+function mb_list_encodings(): array {}
+
+function mb_strwidth(string $string, ?string $encoding = null): int {}
+
+function mb_strimwidth(string $string, int $start, int $width, string $trim_marker = "", ?string $encoding = null): string {}
+
+/**
+ * @since 7.4
+ */
+function mb_str_split(string $string, int $length = 1, ?string $encoding = null): array {}
+
+function mb_substitute_character(string|int|null $substitute_character = null): string|int|bool {}
+
+/**
+ * @since 7.2
+ */
+function mb_ord(string $string, ?string $encoding = null): int|false {}
+
+/**
+ * @since 7.2
+ */
+function mb_chr(int $codepoint, ?string $encoding = null): string|false {}
+
+/**
+ * @since 7.2
+ */
+function mb_scrub(string $string, ?string $encoding = null): string {}
+
+/**
+ * @since 8.3
+ */
+function mb_str_pad(string $string, int $length, string $pad_string = " ", int $pad_type = STR_PAD_RIGHT, ?string $encoding = null): string {}
+
+/**
+ * @since 8.4
+ */
+function mb_trim(string $string, ?string $characters = null, ?string $encoding = null): string {}
+
+/**
+ * @since 8.4
+ */
+function mb_ltrim(string $string, ?string $characters = null, ?string $encoding = null): string {}
+
+/**
+ * @since 8.4
+ */
+function mb_rtrim(string $string, ?string $characters = null, ?string $encoding = null): string {}
+
+/**
+ * @since 8.4
+ */
+function mb_ucfirst(string $string, ?string $encoding = null): string {}
+
+/**
+ * @since 8.4
+ */
+function mb_lcfirst(string $string, ?string $encoding = null): string {}
+
+define('MB_CASE_UPPER', 0);
+define('MB_CASE_LOWER', 1);
+define('MB_CASE_TITLE', 2);
+define('MB_CASE_FOLD', 3);
+define('MB_CASE_UPPER_SIMPLE', 4);
+define('MB_CASE_LOWER_SIMPLE', 5);
+define('MB_CASE_TITLE_SIMPLE', 6);
+define('MB_CASE_FOLD_SIMPLE', 7);
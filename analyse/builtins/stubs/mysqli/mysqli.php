@@ -0,0 +1,481 @@
+<?php
+
+// MySQL improved client of ext/mysqli.
+
+final class mysqli_sql_exception extends RuntimeException
+{
+    protected $sqlstate = "00000";
+
+    public function getSqlState(): string {}
+}
+
+final class mysqli_driver
+{
+    public string $client_info;
+    public int $client_version;
+    public int $driver_version;
+    public bool $reconnect;
+    public int $report_mode;
+}
+
+class mysqli
+{
+    public int|string $affected_rows;
+    public string $client_info;
+    public int $client_version;
+    public int $connect_errno;
+    public ?string $connect_error;
+    public int $errno;
+    public string $error;
+    public array $error_list;
+    public int $field_count;
+    public string $host_info;
+    public ?string $info;
+    public int|string $insert_id;
+    public string $server_info;
+    public int $server_version;
+    public string $sqlstate;
+    public int $protocol_version;
+    public int $thread_id;
+    public int $warning_count;
+
+    public function __construct(?string $hostname = null, ?string $username = null, ?string $password = null, ?string $database = null, ?int $port = null, ?string $socket = null) {}
+
+    public function autocommit(bool $enable): bool {}
+
+    public function begin_transaction(int $flags = 0, ?string $name = null): bool {}
+
+    public function change_user(string $username, string $password, ?string $database): bool {}
+
+    public function character_set_name(): string {}
+
+    public function close(): true {}
+
+    public function commit(int $flags = 0, ?string $name = null): bool {}
+
+    public function connect(?string $hostname = null, ?string $username = null, ?string $password = null, ?string $database = null, ?int $port = null, ?string $socket = null): bool {}
+
+    public function dump_debug_info(): bool {}
+
+    public function debug(string $options): true {}
+
+    public function get_charset(): ?object {}
+
+    /**
+     * @since 8.2
+     */
+    public function execute_query(string $query, ?array $params = null): mysqli_result|bool {}
+
+    public function get_client_info(): string {}
+
+    public function get_connection_stats(): array {}
+
+    public function get_server_info(): string {}
+
+    public function get_warnings(): mysqli_warning|false {}
+
+    public function init() {}
+
+    public function kill(int $process_id): bool {}
+
+    public function multi_query(string $query): bool {}
+
+    public function more_results(): bool {}
+
+    public function next_result(): bool {}
+
+    public function ping(): bool {}
+
+    public static function poll(?array &$read, ?array &$error, array &$reject, int $seconds, int $microseconds = 0): int|false {}
+
+    public function prepare(string $query): mysqli_stmt|false {}
+
+    public function query(string $query, int $result_mode = MYSQLI_STORE_RESULT): mysqli_result|bool {}
+
+    public function real_connect(?string $hostname = null, ?string $username = null, ?string $password = null, ?string $database = null, ?int $port = null, ?string $socket = null, int $flags = 0): bool {}
+
+    public function real_escape_string(string $string): string {}
+
+    public function reap_async_query(): mysqli_result|bool {}
+
+    public function escape_string(string $string): string {}
+
+    public function real_query(string $query): bool {}
+
+    public function release_savepoint(string $name): bool {}
+
+    public function rollback(int $flags = 0, ?string $name = null): bool {}
+
+    public function savepoint(string $name): bool {}
+
+    public function select_db(string $database): bool {}
+
+    public function set_charset(string $charset): bool {}
+
+    public function options(int $option, $value): bool {}
+
+    public function set_opt(int $option, $value): bool {}
+
+    public function ssl_set(?string $key, ?string $certificate, ?string $ca_certificate, ?string $ca_path, ?string $cipher_algos): true {}
+
+    public function stat(): string|false {}
+
+    public function stmt_init(): mysqli_stmt|false {}
+
+    public function store_result(int $mode = 0): mysqli_result|false {}
+
+    public function thread_safe(): bool {}
+
+    public function use_result(): mysqli_result|false {}
+
+    public function refresh(int $flags): bool {}
+}
+
+final class mysqli_warning
+{
+    public string $message;
+    public string $sqlstate;
+    public int $errno;
+
+    private function __construct() {}
+
+    public function next(): bool {}
+}
+
+class mysqli_result implements IteratorAggregate
+{
+    public int $current_field;
+    public int $field_count;
+    public ?array $lengths;
+    public int|string $num_rows;
+    public int $type;
+
+    public function __construct(mysqli $mysql, int $result_mode = MYSQLI_STORE_RESULT) {}
+
+    public function close(): void {}
+
+    public function free(): void {}
+
+    public function data_seek(int $offset): bool {}
+
+    public function fetch_field(): object|false {}
+
+    public function fetch_fields(): array {}
+
+    public function fetch_field_direct(int $index): object|false {}
+
+    public function fetch_all(int $mode = MYSQLI_NUM): array {}
+
+    public function fetch_array(int $mode = MYSQLI_BOTH): array|null|false {}
+
+    public function fetch_assoc(): array|null|false {}
+
+    public function fetch_object(string $class = "stdClass", array $constructor_args = []): object|null|false {}
+
+    public function fetch_row(): array|null|false {}
+
+    /**
+     * @since 8.1
+     */
+    public function fetch_column(int $column = 0): null|int|float|string|false {}
+
+    public function field_seek(int $index): true {}
+
+    public function free_result(): void {}
+
+    public function getIterator(): Iterator {}
+}
+
+class mysqli_stmt
+{
+    public int|string $affected_rows;
+    public int|string $insert_id;
+    public int|string $num_rows;
+    public int $param_count;
+    public int $field_count;
+    public int $errno;
+    public string $error;
+    public array $error_list;
+    public string $sqlstate;
+    public int $id;
+
+    public function __construct(mysqli $mysql, ?string $query = null) {}
+
+    public function attr_get(int $attribute): int {}
+
+    public function attr_set(int $attribute, int $value): bool {}
+
+    public function bind_param(string $types, mixed &$var, mixed &...$vars): bool {}
+
+    public function bind_result(mixed &...$vars): bool {}
+
+    public function close(): true {}
+
+    public function data_seek(int $offset): void {}
+
+    public function execute(?array $params = null): bool {}
+
+    public function fetch(): ?bool {}
+
+    public function get_warnings(): mysqli_warning|false {}
+
+    public function result_metadata(): mysqli_result|false {}
+
+    public function more_results(): bool {}
+
+    public function next_result(): bool {}
+
+    public function num_rows(): int|string {}
+
+    public function send_long_data(int $param_num, string $data): bool {}
+
+    public function free_result(): void {}
+
+    public function reset(): bool {}
+
+    public function prepare(string $query): bool {}
+
+    public function store_result(): bool {}
+
+    public function get_result(): mysqli_result|false {}
+}
+
+function mysqli_affected_rows(mysqli $mysql): int|string {}
+
+function mysqli_autocommit(mysqli $mysql, bool $enable): bool {}
+
+function mysqli_begin_transaction(mysqli $mysql, int $flags = 0, ?string $name = null): bool {}
+
+function mysqli_character_set_name(mysqli $mysql): string {}
+
+function mysqli_close(mysqli $mysql): true {}
+
+function mysqli_commit(mysqli $mysql, int $flags = 0, ?string $name = null): bool {}
+
+function mysqli_connect(?string $hostname = null, ?string $username = null, ?string $password = null, ?string $database = null, ?int $port = null, ?string $socket = null): mysqli|false {}
+
+function mysqli_connect_errno(): int {}
+
+function mysqli_connect_error(): ?string {}
+
+function mysqli_data_seek(mysqli_result $result, int $offset): bool {}
+
+function mysqli_errno(mysqli $mysql): int {}
+
+function mysqli_error(mysqli $mysql): string {}
+
+function mysqli_error_list(mysqli $mysql): array {}
+
+/**
+ * @since 8.2
+ */
+function mysqli_execute_query(mysqli $mysql, string $query, ?array $params = null): mysqli_result|bool {}
+
+function mysqli_fetch_all(mysqli_result $result, int $mode = MYSQLI_NUM): array {}
+
+function mysqli_fetch_array(mysqli_result $result, int $mode = MYSQLI_BOTH): array|null|false {}
+
+function mysqli_fetch_assoc(mysqli_result $result): array|null|false {}
+
+/**
+ * @since 8.1
+ */
+function mysqli_fetch_column(mysqli_result $result, int $column = 0): null|int|float|string|false {}
+
+function mysqli_fetch_field(mysqli_result $result): object|false {}
+
+function mysqli_fetch_fields(mysqli_result $result): array {}
+
+function mysqli_fetch_object(mysqli_result $result, string $class = "stdClass", array $constructor_args = []): object|null|false {}
+
+function mysqli_fetch_row(mysqli_result $result): array|null|false {}
+
+function mysqli_field_count(mysqli $mysql): int {}
+
+function mysqli_free_result(mysqli_result $result): void {}
+
+function mysqli_get_charset(mysqli $mysql): ?object {}
+
+function mysqli_get_client_info(?mysqli $mysql = null): string {}
+
+function mysqli_get_client_version(): int {}
+
+function mysqli_get_host_info(mysqli $mysql): string {}
+
+function mysqli_get_server_info(mysqli $mysql): string {}
+
+function mysqli_get_server_version(mysqli $mysql): int {}
+
+function mysqli_info(mysqli $mysql): ?string {}
+
+function mysqli_init(): mysqli|false {}
+
+function mysqli_insert_id(mysqli $mysql): int|string {}
+
+function mysqli_more_results(mysqli $mysql): bool {}
+
+function mysqli_multi_query(mysqli $mysql, string $query): bool {}
+
+function mysqli_next_result(mysqli $mysql): bool {}
+
+function mysqli_num_fields(mysqli_result $result): int {}
+
+function mysqli_num_rows(mysqli_result $result): int|string {}
+
+function mysqli_options(mysqli $mysql, int $option, $value): bool {}
+
+function mysqli_ping(mysqli $mysql): bool {}
+
+function mysqli_prepare(mysqli $mysql, string $query): mysqli_stmt|false {}
+
+function mysqli_query(mysqli $mysql, string $query, int $result_mode = MYSQLI_STORE_RESULT): mysqli_result|bool {}
+
+function mysqli_real_connect(mysqli $mysql, ?string $hostname = null, ?string $username = null, ?string $password = null, ?string $database = null, ?int $port = null, ?string $socket = null, int $flags = 0): bool {}
+
+function mysqli_real_escape_string(mysqli $mysql, string $string): string {}
+
+function mysqli_escape_string(mysqli $mysql, string $string): string {}
+
+function mysqli_real_query(mysqli $mysql, string $query): bool {}
+
+function mysqli_report(int $flags): bool {}
+
+function mysqli_rollback(mysqli $mysql, int $flags = 0, ?string $name = null): bool {}
+
+function mysqli_select_db(mysqli $mysql, string $database): bool {}
+
+function mysqli_set_charset(mysqli $mysql, string $charset): bool {}
+
+function mysqli_sqlstate(mysqli $mysql): string {}
+
+function mysqli_stmt_affected_rows(mysqli_stmt $statement): int|string {}
+
+function mysqli_stmt_bind_param(mysqli_stmt $statement, string $types, mixed &$var, mixed &...$vars): bool {}
+
+function mysqli_stmt_bind_result(mysqli_stmt $statement, mixed &...$vars): bool {}
+
+function mysqli_stmt_close(mysqli_stmt $statement): true {}
+
+function mysqli_stmt_errno(mysqli_stmt $statement): int {}
+
+function mysqli_stmt_error(mysqli_stmt $statement): string {}
+
+function mysqli_stmt_execute(mysqli_stmt $statement, ?array $params = null): bool {}
+
+function mysqli_stmt_fetch(mysqli_stmt $statement): ?bool {}
+
+function mysqli_stmt_get_result(mysqli_stmt $statement): mysqli_result|false {}
+
+function mysqli_stmt_init(mysqli $mysql): mysqli_stmt|false {}
+
+function mysqli_stmt_insert_id(mysqli_stmt $statement): int|string {}
+
+function mysqli_stmt_num_rows(mysqli_stmt $statement): int|string {}
+
+function mysqli_stmt_prepare(mysqli_stmt $statement, string $query): bool {}
+
+function mysqli_stmt_store_result(mysqli_stmt $statement): bool {}
+
+function mysqli_store_result(mysqli $mysql, int $mode = 0): mysqli_result|false {}
+
+function mysqli_thread_id(mysqli $mysql): int {}
+
+function mysqli_use_result(mysqli $mysql): mysqli_result|false {}
+
+function mysqli_warning_count(mysqli $mysql): int {}
+
+define('MYSQLI_READ_DEFAULT_GROUP', 5);
+define('MYSQLI_READ_DEFAULT_FILE', 4);
+define('MYSQLI_OPT_CONNECT_TIMEOUT', 0);
+define('MYSQLI_OPT_LOCAL_INFILE', 8);
+define('MYSQLI_OPT_INT_AND_FLOAT_NATIVE', 201);
+define('MYSQLI_OPT_READ_TIMEOUT', 11);
+define('MYSQLI_OPT_SSL_VERIFY_SERVER_CERT', 21);
+define('MYSQLI_INIT_COMMAND', 3);
+define('MYSQLI_CLIENT_SSL', 2048);
+define('MYSQLI_CLIENT_COMPRESS', 32);
+define('MYSQLI_CLIENT_INTERACTIVE', 1024);
+define('MYSQLI_CLIENT_IGNORE_SPACE', 256);
+define('MYSQLI_CLIENT_NO_SCHEMA', 16);
+define('MYSQLI_CLIENT_FOUND_ROWS', 2);
+define('MYSQLI_STORE_RESULT', 0);
+define('MYSQLI_USE_RESULT', 1);
+define('MYSQLI_ASYNC', 8);
+define('MYSQLI_STORE_RESULT_COPY_DATA', 16);
+define('MYSQLI_ASSOC', 1);
+define('MYSQLI_NUM', 2);
+define('MYSQLI_BOTH', 3);
+define('MYSQLI_STMT_ATTR_UPDATE_MAX_LENGTH', 0);
+define('MYSQLI_STMT_ATTR_CURSOR_TYPE', 1);
+define('MYSQLI_CURSOR_TYPE_NO_CURSOR', 0);
+define('MYSQLI_CURSOR_TYPE_READ_ONLY', 1);
+define('MYSQLI_NOT_NULL_FLAG', 1);
+define('MYSQLI_PRI_KEY_FLAG', 2);
+define('MYSQLI_UNIQUE_KEY_FLAG', 4);
+define('MYSQLI_MULTIPLE_KEY_FLAG', 8);
+define('MYSQLI_BLOB_FLAG', 16);
+define('MYSQLI_UNSIGNED_FLAG', 32);
+define('MYSQLI_ZEROFILL_FLAG', 64);
+define('MYSQLI_AUTO_INCREMENT_FLAG', 512);
+define('MYSQLI_TIMESTAMP_FLAG', 1024);
+define('MYSQLI_SET_FLAG', 2048);
+define('MYSQLI_NUM_FLAG', 32768);
+define('MYSQLI_PART_KEY_FLAG', 16384);
+define('MYSQLI_GROUP_FLAG', 32768);
+define('MYSQLI_ENUM_FLAG', 256);
+define('MYSQLI_BINARY_FLAG', 128);
+define('MYSQLI_TYPE_DECIMAL', 0);
+define('MYSQLI_TYPE_TINY', 1);
+define('MYSQLI_TYPE_SHORT', 2);
+define('MYSQLI_TYPE_LONG', 3);
+define('MYSQLI_TYPE_FLOAT', 4);
+define('MYSQLI_TYPE_DOUBLE', 5);
+define('MYSQLI_TYPE_NULL', 6);
+define('MYSQLI_TYPE_TIMESTAMP', 7);
+define('MYSQLI_TYPE_LONGLONG', 8);
+define('MYSQLI_TYPE_INT24', 9);
+define('MYSQLI_TYPE_DATE', 10);
+define('MYSQLI_TYPE_TIME', 11);
+define('MYSQLI_TYPE_DATETIME', 12);
+define('MYSQLI_TYPE_YEAR', 13);
+define('MYSQLI_TYPE_NEWDATE', 14);
+define('MYSQLI_TYPE_BIT', 16);
+define('MYSQLI_TYPE_JSON', 245);
+define('MYSQLI_TYPE_NEWDECIMAL', 246);
+define('MYSQLI_TYPE_ENUM', 247);
+define('MYSQLI_TYPE_SET', 248);
+define('MYSQLI_TYPE_TINY_BLOB', 249);
+define('MYSQLI_TYPE_MEDIUM_BLOB', 250);
+define('MYSQLI_TYPE_LONG_BLOB', 251);
+define('MYSQLI_TYPE_BLOB', 252);
+define('MYSQLI_TYPE_VAR_STRING', 253);
+define('MYSQLI_TYPE_STRING', 254);
+define('MYSQLI_TYPE_CHAR', 1);
+define('MYSQLI_TYPE_INTERVAL', 247);
+define('MYSQLI_TYPE_GEOMETRY', 255);
+define('MYSQLI_NO_DATA', 100);
+define('MYSQLI_DATA_TRUNCATED', 101);
+define('MYSQLI_REPORT_INDEX', 4);
+define('MYSQLI_REPORT_ERROR', 1);
+define('MYSQLI_REPORT_STRICT', 2);
+define('MYSQLI_REPORT_ALL', 255);
+define('MYSQLI_REPORT_OFF', 0);
+define('MYSQLI_DEBUG_TRACE_ENABLED', 0);
+define('MYSQLI_REFRESH_GRANT', 1);
+define('MYSQLI_REFRESH_LOG', 2);
+define('MYSQLI_REFRESH_TABLES', 4);
+define('MYSQLI_REFRESH_HOSTS', 8);
+define('MYSQLI_REFRESH_STATUS', 16);
+define('MYSQLI_REFRESH_THREADS', 32);
+define('MYSQLI_REFRESH_REPLICA', 64);
+define('MYSQLI_REFRESH_SLAVE', 64);
+define('MYSQLI_REFRESH_MASTER', 128);
+define('MYSQLI_REFRESH_BACKUP_LOG', 2097152);
+define('MYSQLI_TRANS_START_WITH_CONSISTENT_SNAPSHOT', 1);
+define('MYSQLI_TRANS_START_READ_WRITE', 2);
+define('MYSQLI_TRANS_START_READ_ONLY', 4);
+define('MYSQLI_TRANS_COR_AND_CHAIN', 1);
+define('MYSQLI_TRANS_COR_AND_NO_CHAIN', 2);
+define('MYSQLI_TRANS_COR_RELEASE', 4);
+define('MYSQLI_TRANS_COR_NO_RELEASE', 8);
+define('MYSQLI_IS_MARIADB', false);
@@ -0,0 +1,51 @@
+<?php
+
+// Perl-compatible regular expressions of ext/pcre.
+
+function preg_match(string $pattern, string $subject, &$matches = null, int $flags = 0, int $offset = 0): int|false {}
+
+function preg_match_all(string $pattern, string $subject, &$matches = null, int $flags = 0, int $offset = 0): int|false {}
+
+function preg_replace(string|array $pattern, string|array $replacement, string|array $subject, int $limit = -1, &$count = null): string|array|null {}
+
+function preg_replace_callback(string|array $pattern, callable $callback, string|array $subject, int $limit = -1, &$count = null, int $flags = 0): string|array|null {}
+
+/**
+ * @since 7.0
+ */
+function preg_replace_callback_array(array $pattern, string|array $subject, int $limit = -1, &$count = null, int $flags = 0): string|array|null {}
+
+function preg_filter(string|array $pattern, string|array $replacement, string|array $subject, int $limit = -1, &$count = null): string|array|null {}
+
+function preg_split(string $pattern, string $subject, int $limit = -1, int $flags = 0): array|false {}
+
+function preg_quote(string $str, ?string $delimiter = null): string {}
+
+function preg_grep(string $pattern, array $array, int $flags = 0): array|false {}
+
+function preg_last_error(): int {}
+
+/**
+ * @since 8.0
+ */
+function preg_last_error_msg(): string {}
+
+define('PREG_PATTERN_ORDER', 1);
+define('PREG_SET_ORDER', 2);
+define('PREG_OFFSET_CAPTURE', 256);
+define('PREG_UNMATCHED_AS_NULL', 512);
+define('PREG_SPLIT_NO_EMPTY', 1);
+define('PREG_SPLIT_DELIM_CAPTURE', 2);
+define('PREG_SPLIT_OFFSET_CAPTURE', 4);
+define('PREG_GREP_INVERT', 1);
+define('PREG_NO_ERROR', 0);
+define('PREG_INTERNAL_ERROR', 1);
+define('PREG_BACKTRACK_LIMIT_ERROR', 2);
+define('PREG_RECURSION_LIMIT_ERROR', 3);
+define('PREG_BAD_UTF8_ERROR', 4);
+define('PREG_BAD_UTF8_OFFSET_ERROR', 5);
+define('PREG_JIT_STACKLIMIT_ERROR', 6);
+define('PCRE_VERSION', "10.42 2022-12-11");
+define('PCRE_VERSION_MAJOR', 10);
+define('PCRE_VERSION_MINOR', 42);
+define('PCRE_JIT_SUPPORT', true);
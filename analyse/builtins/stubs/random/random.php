@@ -0,0 +1,156 @@
+<?php
+
+// Random number generation of ext/random.
+
+namespace {
+    function rand(int $min = null, int $max = null): int {}
+
+    function mt_rand(int $min = null, int $max = null): int {}
+
+    function mt_srand(int $seed = 0, int $mode = MT_RAND_MT19937): void {}
+
+    function srand(int $seed = 0, int $mode = MT_RAND_MT19937): void {}
+
+    function getrandmax(): int {}
+
+    function mt_getrandmax(): int {}
+
+    function lcg_value(): float {}
+
+    /**
+     * @since 7.0
+     */
+    function random_int(int $min, int $max): int {}
+
+    /**
+     * @since 7.0
+     */
+    function random_bytes(int $length): string {}
+
+    define('MT_RAND_MT19937', 0);
+    define('MT_RAND_PHP', 1);
+}
+
+namespace Random {
+    /**
+     * @since 8.2
+     */
+    interface Engine
+    {
+        public function generate(): string;
+    }
+
+    /**
+     * @since 8.2
+     */
+    interface CryptoSafeEngine extends Engine {}
+
+    /**
+     * @since 8.2
+     */
+    class RandomError extends \Error {}
+
+    /**
+     * @since 8.2
+     */
+    class BrokenRandomEngineError extends RandomError {}
+
+    /**
+     * @since 8.2
+     */
+    class RandomException extends \Exception {}
+
+    /**
+     * @since 8.3
+     */
+    enum IntervalBoundary
+    {
+        case ClosedOpen;
+        case ClosedClosed;
+        case OpenClosed;
+        case OpenOpen;
+    }
+
+    /**
+     * @since 8.2
+     */
+    final class Randomizer
+    {
+        public readonly Engine $engine;
+
+        public function __construct(?Engine $engine = null) {}
+
+        public function nextInt(): int {}
+
+        /**
+         * @since 8.3
+         */
+        public function nextFloat(): float {}
+
+        /**
+         * @since 8.3
+         */
+        public function getFloat(float $min, float $max, IntervalBoundary $boundary = IntervalBoundary::ClosedOpen): float {}
+
+        public function getInt(int $min, int $max): int {}
+
+        public function getBytes(int $length): string {}
+
+        /**
+         * @since 8.3
+         */
+        public function getBytesFromString(string $string, int $length): string {}
+
+        public function shuffleArray(array $array): array {}
+
+        public function shuffleBytes(string $bytes): string {}
+
+        public function pickArrayKeys(array $array, int $num): array {}
+    }
+}
+
+namespace Random\Engine {
+    /**
+     * @since 8.2
+     */
+    final class Mt19937 implements \Random\Engine
+    {
+        public function __construct(?int $seed = null, int $mode = MT_RAND_MT19937) {}
+
+        public function generate(): string {}
+    }
+
+    /**
+     * @since 8.2
+     */
+    final class PcgOneseq128XslRr64 implements \Random\Engine
+    {
+        public function __construct(string|int|null $seed = null) {}
+
+        public function generate(): string {}
+
+        public function jump(int $advance): void {}
+    }
+
+    /**
+     * @since 8.2
+     */
+    final class Xoshiro256StarStar implements \Random\Engine
+    {
+        public function __construct(string|int|null $seed = null) {}
+
+        public function generate(): string {}
+
+        public function jump(): void {}
+
+        public function jumpLong(): void {}
+    }
+
+    /**
+     * @since 8.2
+     */
+    final class Secure implements \Random\CryptoSafeEngine
+    {
+        public function generate(): string {}
+    }
+}
@@ -0,0 +1,190 @@
+<?php
+
+// Array functions of ext/standard.
+
+function array_change_key_case(array $array, int $case = CASE_LOWER): array {}
+
+function array_chunk(array $array, int $length, bool $preserve_keys = false): array {}
+
+function array_column(array $array, int|string|null $column_key, int|string|null $index_key = null): array {}
+
+function array_combine(array $keys, array $values): array {}
+
+function array_count_values(array $array): array {}
+
+function array_diff(array $array, array ...$arrays): array {}
+
+function array_diff_assoc(array $array, array ...$arrays): array {}
+
+function array_diff_key(array $array, array ...$arrays): array {}
+
+function array_diff_uassoc(array $array, ...$rest): array {}
+
+function array_diff_ukey(array $array, ...$rest): array {}
+
+function array_udiff(array $array, ...$rest): array {}
+
+function array_udiff_assoc(array $array, ...$rest): array {}
+
+function array_udiff_uassoc(array $array, ...$rest): array {}
+
+function array_fill(int $start_index, int $count, mixed $value): array {}
+
+function array_fill_keys(array $keys, mixed $value): array {}
+
+function array_filter(array $array, ?callable $callback = null, int $mode = 0): array {}
+
+/**
+ * @since 8.4
+ */
+function array_find(array $array, callable $callback): mixed {}
+
+/**
+ * @since 8.4
+ */
+function array_find_key(array $array, callable $callback): mixed {}
+
+/**
+ * @since 8.4
+ */
+function array_any(array $array, callable $callback): bool {}
+
+/**
+ * @since 8.4
+ */
+function array_all(array $array, callable $callback): bool {}
+
+function array_flip(array $array): array {}
+
+function array_intersect(array $array, array ...$arrays): array {}
+
+function array_intersect_assoc(array $array, array ...$arrays): array {}
+
+function array_intersect_key(array $array, array ...$arrays): array {}
+
+function array_intersect_uassoc(array $array, ...$rest): array {}
+
+function array_intersect_ukey(array $array, ...$rest): array {}
+
+function array_uintersect(array $array, ...$rest): array {}
+
+function array_uintersect_assoc(array $array, ...$rest): array {}
+
+function array_uintersect_uassoc(array $array, ...$rest): array {}
+
+/**
+ * @since 8.1
+ */
+function array_is_list(array $array): bool {}
+
+function array_key_exists($key, array $array): bool {}
+
+function key_exists($key, array $array): bool {}
+
+/**
+ * @since 7.3
+ */
+function array_key_first(array $array): int|string|null {}
+
+/**
+ * @since 7.3
+ */
+function array_key_last(array $array): int|string|null {}
+
+function array_keys(array $array, mixed $filter_value = null, bool $strict = false): array {}
+
+function array_map(?callable $callback, array $array, array ...$arrays): array {}
+
+function array_merge(array ...$arrays): array {}
+
+function array_merge_recursive(array ...$arrays): array {}
+
+function array_multisort(&$array, &...$rest): bool {}
+
+function array_pad(array $array, int $length, mixed $value): array {}
+
+function array_pop(array &$array): mixed {}
+
+function array_product(array $array): int|float {}
+
+function array_push(array &$array, mixed ...$values): int {}
+
+function array_rand(array $array, int $num = 1): array|string|int {}
+
+function array_reduce(array $array, callable $callback, mixed $initial = null): mixed {}
+
+function array_replace(array $array, array ...$replacements): array {}
+
+function array_replace_recursive(array $array, array ...$replacements): array {}
+
+function array_reverse(array $array, bool $preserve_keys = false): array {}
+
+function array_search(mixed $needle, array $haystack, bool $strict = false): int|string|false {}
+
+function array_shift(array &$array): mixed {}
+
+function array_slice(array $array, int $offset, ?int $length = null, bool $preserve_keys = false): array {}
+
+function array_splice(array &$array, int $offset, ?int $length = null, mixed $replacement = []): array {}
+
+function array_sum(array $array): int|float {}
+
+function array_unique(array $array, int $flags = SORT_STRING): array {}
+
+function array_unshift(array &$array, mixed ...$values): int {}
+
+function array_values(array $array): array {}
+
+function array_walk(array|object &$array, callable $callback, mixed $arg = null): bool {}
+
+function array_walk_recursive(array|object &$array, callable $callback, mixed $arg = null): bool {}
+
+function arsort(array &$array, int $flags = SORT_REGULAR): bool {}
+
+function asort(array &$array, int $flags = SORT_REGULAR): bool {}
+
+function compact($var_name, ...$var_names): array {}
+
+function count(Countable|array $value, int $mode = COUNT_NORMAL): int {}
+
+function sizeof(Countable|array $value, int $mode = COUNT_NORMAL): int {}
+
+function current(array|object $array): mixed {}
+
+function pos(array|object $array): mixed {}
+
+function end(array|object &$array): mixed {}
+
+function extract(array &$array, int $flags = EXTR_OVERWRITE, string $prefix = ""): int {}
+
+function in_array(mixed $needle, array $haystack, bool $strict = false): bool {}
+
+function key(array|object $array): int|string|null {}
+
+function krsort(array &$array, int $flags = SORT_REGULAR): bool {}
+
+function ksort(array &$array, int $flags = SORT_REGULAR): bool {}
+
+function natcasesort(array &$array): bool {}
+
+function natsort(array &$array): bool {}
+
+function next(array|object &$array): mixed {}
+
+function prev(array|object &$array): mixed {}
+
+function range($start, $end, int|float $step = 1): array {}
+
+function reset(array|object &$array): mixed {}
+
+function rsort(array &$array, int $flags = SORT_REGULAR): bool {}
+
+function shuffle(array &$array): bool {}
+
+function sort(array &$array, int $flags = SORT_REGULAR): bool {}
+
+function uasort(array &$array, callable $callback): bool {}
+
+function uksort(array &$array, callable $callback): bool {}
+
+function usort(array &$array, callable $callback): bool {}
@@ -0,0 +1,247 @@
+<?php
+
+// Miscellaneous functions of ext/standard.
+
+function call_user_func(callable $callback, mixed ...$args): mixed {}
+
+function call_user_func_array(callable $callback, array $args): mixed {}
+
+function forward_static_call(callable $callback, mixed ...$args): mixed {}
+
+function forward_static_call_array(callable $callback, array $args): mixed {}
+
+function register_shutdown_function(callable $callback, mixed ...$args): void {}
+
+function register_tick_function(callable $callback, mixed ...$args): bool {}
+
+function unregister_tick_function(callable $callback): void {}
+
+function connection_aborted(): int {}
+
+function connection_status(): int {}
+
+function ignore_user_abort(?bool $enable = null): int {}
+
+function error_log(string $message, int $message_type = 0, ?string $destination = null, ?string $additional_headers = null): bool {}
+
+function error_get_last(): ?array {}
+
+/**
+ * @since 7.0
+ */
+function error_clear_last(): void {}
+
+function highlight_file(string $filename, bool $return = false): string|bool {}
+
+function show_source(string $filename, bool $return = false): string|bool {}
+
+function highlight_string(string $string, bool $return = false): string|true {}
+
+function php_strip_whitespace(string $filename): string {}
+
+function ini_get(string $option): string|false {}
+
+function ini_get_all(?string $extension = null, bool $details = true): array|false {}
+
+function ini_set(string $option, string|int|float|bool|null $value): string|false {}
+
+function ini_alter(string $option, string|int|float|bool|null $value): string|false {}
+
+function ini_restore(string $option): void {}
+
+/**
+ * @since 8.2
+ */
+function ini_parse_quantity(string $shorthand): int {}
+
+function get_include_path(): string|false {}
+
+function set_include_path(string $include_path): string|false {}
+
+function getenv(?string $name = null, bool $local_only = false): array|string|false {}
+
+function putenv(string $assignment): bool {}
+
+function getopt(string $short_options, array $long_options = [], &$rest_index = null): array|false {}
+
+function gethostname(): string|false {}
+
+function gethostbyname(string $hostname): string {}
+
+function gethostbynamel(string $hostname): array|false {}
+
+function gethostbyaddr(string $ip): string|false {}
+
+function getmypid(): int|false {}
+
+function getmyuid(): int|false {}
+
+function getmygid(): int|false {}
+
+function getlastmod(): int|false {}
+
+function getrusage(int $mode = 0): array|false {}
+
+function php_sapi_name(): string|false {}
+
+function php_uname(string $mode = "a"): string {}
+
+function phpversion(?string $extension = null): string|false {}
+
+function phpinfo(int $flags = INFO_ALL): true {}
+
+function php_ini_loaded_file(): string|false {}
+
+function set_time_limit(int $seconds): bool {}
+
+function sleep(int $seconds): int {}
+
+function usleep(int $microseconds): void {}
+
+function time_nanosleep(int $seconds, int $nanoseconds): array|bool {}
+
+function time_sleep_until(float $timestamp): bool {}
+
+function microtime(bool $as_float = false): string|float {}
+
+function gettimeofday(bool $as_float = false): array|float {}
+
+/**
+ * @since 7.3
+ */
+function hrtime(bool $as_number = false): array|int|float|false {}
+
+function version_compare(string $version1, string $version2, ?string $operator = null): int|bool {}
+
+function headers_sent(&$filename = null, &$line = null): bool {}
+
+function headers_list(): array {}
+
+function header(string $header, bool $replace = true, int $response_code = 0): void {}
+
+function header_remove(?string $name = null): void {}
+
+function http_response_code(int $response_code = 0): int|bool {}
+
+function setcookie(string $name, string $value = "", array|int $expires_or_options = 0, string $path = "", string $domain = "", bool $secure = false, bool $httponly = false): bool {}
+
+function setrawcookie(string $name, string $value = "", array|int $expires_or_options = 0, string $path = "", string $domain = "", bool $secure = false, bool $httponly = false): bool {}
+
+function ob_start($callback = null, int $chunk_size = 0, int $flags = PHP_OUTPUT_HANDLER_STDFLAGS): bool {}
+
+function ob_flush(): bool {}
+
+function ob_clean(): bool {}
+
+function ob_end_flush(): bool {}
+
+function ob_end_clean(): bool {}
+
+function ob_get_flush(): string|false {}
+
+function ob_get_clean(): string|false {}
+
+function ob_get_contents(): string|false {}
+
+function ob_get_length(): int|false {}
+
+function ob_get_level(): int {}
+
+function ob_get_status(bool $full_status = false): array {}
+
+function ob_implicit_flush(bool $enable = true): void {}
+
+function ob_list_handlers(): array {}
+
+function flush(): void {}
+
+function output_add_rewrite_var(string $name, string $value): bool {}
+
+function output_reset_rewrite_vars(): bool {}
+
+function escapeshellarg(string $arg): string {}
+
+function escapeshellcmd(string $command): string {}
+
+function exec(string $command, &$output = null, &$result_code = null): string|false {}
+
+function passthru(string $command, &$result_code = null): false|null {}
+
+function shell_exec(string $command): string|false|null {}
+
+function system(string $command, &$result_code = null): string|false {}
+
+function proc_open(array|string $command, array $descriptor_spec, &$pipes, ?string $cwd = null, ?array $env_vars = null, ?array $options = null) {}
+
+function proc_close($process): int {}
+
+function proc_terminate($process, int $signal = 15): bool {}
+
+function proc_get_status($process): array {}
+
+function proc_nice(int $priority): bool {}
+
+function mail(string $to, string $subject, string $message, array|string $additional_headers = [], string $additional_params = ""): bool {}
+
+function password_hash(string $password, string|int|null $algo, array $options = []): string {}
+
+function password_verify(string $password, string $hash): bool {}
+
+function password_needs_rehash(string $hash, string|int|null $algo, array $options = []): bool {}
+
+function password_get_info(string $hash): array {}
+
+/**
+ * @since 7.4
+ */
+function password_algos(): array {}
+
+function long2ip(int $ip): string {}
+
+function ip2long(string $ip): int|false {}
+
+function inet_pton(string $ip): string|false {}
+
+function inet_ntop(string $ip): string|false {}
+
+function checkdnsrr(string $hostname, string $type = "MX"): bool {}
+
+function dns_get_record(string $hostname, int $type = DNS_ANY, &$authoritative_name_servers = null, &$additional_records = null, bool $raw = false): array|false {}
+
+function fsockopen(string $hostname, int $port = -1, &$error_code = null, &$error_message = null, ?float $timeout = null) {}
+
+function iptcparse(string $iptc_block): array|false {}
+
+function getimagesize(string $filename, &$image_info = null): array|false {}
+
+function getimagesizefromstring(string $string, &$image_info = null): array|false {}
+
+function image_type_to_mime_type(int $image_type): string {}
+
+function image_type_to_extension(int $image_type, bool $include_dot = true): string|false {}
+
+function get_browser(?string $user_agent = null, bool $return_array = false): object|array|false {}
+
+function get_cfg_var(string $option): string|array|false {}
+
+function get_current_user(): string {}
+
+function get_meta_tags(string $filename, bool $use_include_path = false): array|false {}
+
+function sapi_windows_vt100_support($stream, ?bool $enable = null): bool {}
+
+function cli_set_process_title(string $title): bool {}
+
+function cli_get_process_title(): ?string {}
+
+function assert(mixed $assertion, Throwable|string|null $description = null): bool {}
+
+function assert_options(int $option, mixed $value): mixed {}
+
+/**
+ * @since 7.3
+ */
+function net_get_interfaces(): array|false {}
+
+function ftok(string $filename, string $project_id): int {}
+
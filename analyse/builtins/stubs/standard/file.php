@@ -0,0 +1,220 @@
+<?php
+
+// Filesystem, stream and directory functions of ext/standard.
+
+function basename(string $path, string $suffix = ""): string {}
+
+function chgrp(string $filename, string|int $group): bool {}
+
+function chmod(string $filename, int $permissions): bool {}
+
+function chown(string $filename, string|int $user): bool {}
+
+function clearstatcache(bool $clear_realpath_cache = false, string $filename = ""): void {}
+
+function copy(string $from, string $to, $context = null): bool {}
+
+function dirname(string $path, int $levels = 1): string {}
+
+function disk_free_space(string $directory): float|false {}
+
+function disk_total_space(string $directory): float|false {}
+
+function fclose($stream): bool {}
+
+function feof($stream): bool {}
+
+function fflush($stream): bool {}
+
+function fgetc($stream): string|false {}
+
+function fgetcsv($stream, ?int $length = null, string $separator = ",", string $enclosure = "\"", string $escape = "\\"): array|false {}
+
+function fgets($stream, ?int $length = null): string|false {}
+
+function file(string $filename, int $flags = 0, $context = null): array|false {}
+
+function file_exists(string $filename): bool {}
+
+function file_get_contents(string $filename, bool $use_include_path = false, $context = null, int $offset = 0, ?int $length = null): string|false {}
+
+function file_put_contents(string $filename, mixed $data, int $flags = 0, $context = null): int|false {}
+
+function fileatime(string $filename): int|false {}
+
+function filectime(string $filename): int|false {}
+
+function filegroup(string $filename): int|false {}
+
+function fileinode(string $filename): int|false {}
+
+function filemtime(string $filename): int|false {}
+
+function fileowner(string $filename): int|false {}
+
+function fileperms(string $filename): int|false {}
+
+function filesize(string $filename): int|false {}
+
+function filetype(string $filename): string|false {}
+
+function flock($stream, int $operation, &$would_block = null): bool {}
+
+function fnmatch(string $pattern, string $filename, int $flags = 0): bool {}
+
+function fopen(string $filename, string $mode, bool $use_include_path = false, $context = null) {}
+
+function fpassthru($stream): int {}
+
+function fputcsv($stream, array $fields, string $separator = ",", string $enclosure = "\"", string $escape = "\\", string $eol = "\n"): int|false {}
+
+function fputs($stream, string $data, ?int $length = null): int|false {}
+
+function fread($stream, int $length): string|false {}
+
+function fscanf($stream, string $format, mixed &...$vars): array|int|false|null {}
+
+function fseek($stream, int $offset, int $whence = SEEK_SET): int {}
+
+function fstat($stream): array|false {}
+
+/**
+ * @since 8.1
+ */
+function fsync($stream): bool {}
+
+/**
+ * @since 8.1
+ */
+function fdatasync($stream): bool {}
+
+function ftell($stream): int|false {}
+
+function ftruncate($stream, int $size): bool {}
+
+function fwrite($stream, string $data, ?int $length = null): int|false {}
+
+function glob(string $pattern, int $flags = 0): array|false {}
+
+function is_dir(string $filename): bool {}
+
+function is_executable(string $filename): bool {}
+
+function is_file(string $filename): bool {}
+
+function is_link(string $filename): bool {}
+
+function is_readable(string $filename): bool {}
+
+function is_uploaded_file(string $filename): bool {}
+
+function is_writable(string $filename): bool {}
+
+function is_writeable(string $filename): bool {}
+
+function link(string $target, string $link): bool {}
+
+function lstat(string $filename): array|false {}
+
+function mkdir(string $directory, int $permissions = 0777, bool $recursive = false, $context = null): bool {}
+
+function move_uploaded_file(string $from, string $to): bool {}
+
+function parse_ini_file(string $filename, bool $process_sections = false, int $scanner_mode = INI_SCANNER_NORMAL): array|false {}
+
+function parse_ini_string(string $ini_string, bool $process_sections = false, int $scanner_mode = INI_SCANNER_NORMAL): array|false {}
+
+function pathinfo(string $path, int $flags = PATHINFO_ALL): array|string {}
+
+function pclose($handle): int {}
+
+function popen(string $command, string $mode) {}
+
+function readfile(string $filename, bool $use_include_path = false, $context = null): int|false {}
+
+function readlink(string $path): string|false {}
+
+function realpath(string $path): string|false {}
+
+function rename(string $from, string $to, $context = null): bool {}
+
+function rewind($stream): bool {}
+
+function rmdir(string $directory, $context = null): bool {}
+
+function stat(string $filename): array|false {}
+
+function symlink(string $target, string $link): bool {}
+
+function tempnam(string $directory, string $prefix): string|false {}
+
+function tmpfile() {}
+
+function touch(string $filename, ?int $mtime = null, ?int $atime = null): bool {}
+
+function umask(?int $mask = null): int {}
+
+function unlink(string $filename, $context = null): bool {}
+
+function opendir(string $directory, $context = null) {}
+
+function closedir($dir_handle = null): void {}
+
+function readdir($dir_handle = null): string|false {}
+
+function rewinddir($dir_handle = null): void {}
+
+function scandir(string $directory, int $sorting_order = SCANDIR_SORT_ASCENDING, $context = null): array|false {}
+
+function dir(string $directory, $context = null): Directory|false {}
+
+function getcwd(): string|false {}
+
+function chdir(string $directory): bool {}
+
+function stream_context_create(?array $options = null, ?array $params = null) {}
+
+function stream_context_get_options($stream_or_context): array {}
+
+function stream_context_set_option($context, array|string $wrapper_or_options, ?string $option_name = null, mixed $value = null): bool {}
+
+function stream_get_contents($stream, ?int $length = null, int $offset = -1): string|false {}
+
+function stream_get_meta_data($stream): array {}
+
+function stream_set_blocking($stream, bool $enable): bool {}
+
+function stream_set_timeout($stream, int $seconds, int $microseconds = 0): bool {}
+
+function stream_copy_to_stream($from, $to, ?int $length = null, int $offset = 0): int|false {}
+
+function stream_is_local($stream): bool {}
+
+function stream_isatty($stream): bool {}
+
+function stream_select(?array &$read, ?array &$write, ?array &$except, ?int $seconds, ?int $microseconds = null): int|false {}
+
+function stream_socket_client(string $address, &$error_code = null, &$error_message = null, ?float $timeout = null, int $flags = STREAM_CLIENT_CONNECT, $context = null) {}
+
+function stream_socket_server(string $address, &$error_code = null, &$error_message = null, int $flags = STREAM_SERVER_BIND | STREAM_SERVER_LISTEN, $context = null) {}
+
+function stream_wrapper_register(string $protocol, string $class, int $flags = 0): bool {}
+
+function stream_get_wrappers(): array {}
+
+function stream_resolve_include_path(string $filename): string|false {}
+
+function sys_get_temp_dir(): string {}
+
+class Directory
+{
+    public readonly string $path;
+
+    public readonly mixed $handle;
+
+    public function close(): void {}
+
+    public function rewind(): void {}
+
+    public function read(): string|false {}
+}
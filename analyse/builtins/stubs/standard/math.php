@@ -0,0 +1,100 @@
+<?php
+
+// Math functions of ext/standard.
+
+function abs(int|float $num): int|float {}
+
+function acos(float $num): float {}
+
+function acosh(float $num): float {}
+
+function asin(float $num): float {}
+
+function asinh(float $num): float {}
+
+function atan(float $num): float {}
+
+function atan2(float $y, float $x): float {}
+
+function atanh(float $num): float {}
+
+function base_convert(string $num, int $from_base, int $to_base): string {}
+
+function bindec(string $binary_string): int|float {}
+
+function ceil(int|float $num): float {}
+
+function cos(float $num): float {}
+
+function cosh(float $num): float {}
+
+function decbin(int $num): string {}
+
+function dechex(int $num): string {}
+
+function decoct(int $num): string {}
+
+function deg2rad(float $num): float {}
+
+function exp(float $num): float {}
+
+function expm1(float $num): float {}
+
+/**
+ * @since 8.4
+ */
+function fpow(float $num, float $exponent): float {}
+
+function floor(int|float $num): float {}
+
+/**
+ * @since 8.0
+ */
+function fdiv(float $num1, float $num2): float {}
+
+function fmod(float $num1, float $num2): float {}
+
+function hexdec(string $hex_string): int|float {}
+
+function hypot(float $x, float $y): float {}
+
+/**
+ * @since 7.0
+ */
+function intdiv(int $num1, int $num2): int {}
+
+function is_finite(float $num): bool {}
+
+function is_infinite(float $num): bool {}
+
+function is_nan(float $num): bool {}
+
+function log(float $num, float $base = M_E): float {}
+
+function log10(float $num): float {}
+
+function log1p(float $num): float {}
+
+function max(mixed $value, mixed ...$values): mixed {}
+
+function min(mixed $value, mixed ...$values): mixed {}
+
+function octdec(string $octal_string): int|float {}
+
+function pi(): float {}
+
+function pow(mixed $num, mixed $exponent): int|float|object {}
+
+function rad2deg(float $num): float {}
+
+function round(int|float $num, int $precision = 0, int $mode = PHP_ROUND_HALF_UP): float {}
+
+function sin(float $num): float {}
+
+function sinh(float $num): float {}
+
+function sqrt(float $num): float {}
+
+function tan(float $num): float {}
+
+function tanh(float $num): float {}
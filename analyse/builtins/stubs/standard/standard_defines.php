@@ -0,0 +1,176 @@
+<?php
+
+// Constants of ext/standard.
+
+define('SORT_ASC', 4);
+define('SORT_DESC', 3);
+define('SORT_REGULAR', 0);
+define('SORT_NUMERIC', 1);
+define('SORT_STRING', 2);
+define('SORT_LOCALE_STRING', 5);
+define('SORT_NATURAL', 6);
+define('SORT_FLAG_CASE', 8);
+define('COUNT_NORMAL', 0);
+define('COUNT_RECURSIVE', 1);
+define('CASE_LOWER', 0);
+define('CASE_UPPER', 1);
+define('EXTR_OVERWRITE', 0);
+define('EXTR_SKIP', 1);
+define('EXTR_PREFIX_SAME', 2);
+define('EXTR_PREFIX_ALL', 3);
+define('EXTR_PREFIX_INVALID', 4);
+define('EXTR_PREFIX_IF_EXISTS', 5);
+define('EXTR_IF_EXISTS', 6);
+define('EXTR_REFS', 256);
+define('ARRAY_FILTER_USE_BOTH', 1);
+define('ARRAY_FILTER_USE_KEY', 2);
+define('HTML_SPECIALCHARS', 0);
+define('HTML_ENTITIES', 1);
+define('ENT_COMPAT', 2);
+define('ENT_QUOTES', 3);
+define('ENT_NOQUOTES', 0);
+define('ENT_IGNORE', 4);
+define('ENT_SUBSTITUTE', 8);
+define('ENT_DISALLOWED', 128);
+define('ENT_HTML401', 0);
+define('ENT_XML1', 16);
+define('ENT_XHTML', 32);
+define('ENT_HTML5', 48);
+define('STR_PAD_LEFT', 0);
+define('STR_PAD_RIGHT', 1);
+define('STR_PAD_BOTH', 2);
+define('PATHINFO_DIRNAME', 1);
+define('PATHINFO_BASENAME', 2);
+define('PATHINFO_EXTENSION', 4);
+define('PATHINFO_FILENAME', 8);
+define('PATHINFO_ALL', 15);
+define('FILE_USE_INCLUDE_PATH', 1);
+define('FILE_IGNORE_NEW_LINES', 2);
+define('FILE_SKIP_EMPTY_LINES', 4);
+define('FILE_APPEND', 8);
+define('FILE_NO_DEFAULT_CONTEXT', 16);
+define('LOCK_SH', 1);
+define('LOCK_EX', 2);
+define('LOCK_UN', 3);
+define('LOCK_NB', 4);
+define('SEEK_SET', 0);
+define('SEEK_CUR', 1);
+define('SEEK_END', 2);
+define('GLOB_BRACE', 1024);
+define('GLOB_MARK', 2);
+define('GLOB_NOSORT', 4);
+define('GLOB_NOCHECK', 16);
+define('GLOB_NOESCAPE', 64);
+define('GLOB_ERR', 1);
+define('GLOB_ONLYDIR', 8192);
+define('GLOB_AVAILABLE_FLAGS', 9303);
+define('SCANDIR_SORT_ASCENDING', 0);
+define('SCANDIR_SORT_DESCENDING', 1);
+define('SCANDIR_SORT_NONE', 2);
+define('FNM_NOESCAPE', 2);
+define('FNM_PATHNAME', 1);
+define('FNM_PERIOD', 4);
+define('FNM_CASEFOLD', 16);
+define('INI_SCANNER_NORMAL', 0);
+define('INI_SCANNER_RAW', 1);
+define('INI_SCANNER_TYPED', 2);
+define('PHP_QUERY_RFC1738', 1);
+define('PHP_QUERY_RFC3986', 2);
+define('PHP_URL_SCHEME', 0);
+define('PHP_URL_HOST', 1);
+define('PHP_URL_PORT', 2);
+define('PHP_URL_USER', 3);
+define('PHP_URL_PASS', 4);
+define('PHP_URL_PATH', 5);
+define('PHP_URL_QUERY', 6);
+define('PHP_URL_FRAGMENT', 7);
+define('M_E', 2.718281828459045);
+define('M_LOG2E', 1.4426950408889634);
+define('M_LOG10E', 0.4342944819032518);
+define('M_LN2', 0.6931471805599453);
+define('M_LN10', 2.302585092994046);
+define('M_PI', 3.141592653589793);
+define('M_PI_2', 1.5707963267948966);
+define('M_PI_4', 0.7853981633974483);
+define('M_1_PI', 0.3183098861837907);
+define('M_2_PI', 0.6366197723675814);
+define('M_SQRTPI', 1.772453850905516);
+define('M_2_SQRTPI', 1.1283791670955126);
+define('M_SQRT2', 1.4142135623730951);
+define('M_SQRT3', 1.7320508075688772);
+define('M_SQRT1_2', 0.7071067811865476);
+define('M_LNPI', 1.1447298858494002);
+define('M_EULER', 0.5772156649015329);
+define('INF', 1.0E+1000);
+define('NAN', 0.0 / 0.0);
+define('PHP_ROUND_HALF_UP', 1);
+define('PHP_ROUND_HALF_DOWN', 2);
+define('PHP_ROUND_HALF_EVEN', 3);
+define('PHP_ROUND_HALF_ODD', 4);
+define('INFO_GENERAL', 1);
+define('INFO_CREDITS', 2);
+define('INFO_CONFIGURATION', 4);
+define('INFO_MODULES', 8);
+define('INFO_ENVIRONMENT', 16);
+define('INFO_VARIABLES', 32);
+define('INFO_LICENSE', 64);
+define('INFO_ALL', 4294967295);
+define('PASSWORD_DEFAULT', "2y");
+define('PASSWORD_BCRYPT', "2y");
+define('PASSWORD_BCRYPT_DEFAULT_COST', 10);
+define('PASSWORD_ARGON2I', "argon2i");
+define('PASSWORD_ARGON2ID', "argon2id");
+define('CRYPT_SALT_LENGTH', 123);
+define('CRYPT_STD_DES', 1);
+define('CRYPT_EXT_DES', 1);
+define('CRYPT_MD5', 1);
+define('CRYPT_BLOWFISH', 1);
+define('CRYPT_SHA256', 1);
+define('CRYPT_SHA512', 1);
+define('DNS_A', 1);
+define('DNS_NS', 2);
+define('DNS_CNAME', 16);
+define('DNS_SOA', 32);
+define('DNS_PTR', 2048);
+define('DNS_HINFO', 4096);
+define('DNS_MX', 16384);
+define('DNS_TXT', 32768);
+define('DNS_SRV', 33554432);
+define('DNS_AAAA', 134217728);
+define('DNS_ANY', 268435456);
+define('DNS_ALL', 251721779);
+define('STREAM_CLIENT_CONNECT', 4);
+define('STREAM_CLIENT_ASYNC_CONNECT', 2);
+define('STREAM_CLIENT_PERSISTENT', 1);
+define('STREAM_SERVER_BIND', 4);
+define('STREAM_SERVER_LISTEN', 8);
+define('LC_CTYPE', 0);
+define('LC_NUMERIC', 1);
+define('LC_TIME', 2);
+define('LC_COLLATE', 3);
+define('LC_MONETARY', 4);
+define('LC_MESSAGES', 5);
+define('LC_ALL', 6);
+define('DIRECTORY_SEPARATOR', "/");
+define('PATH_SEPARATOR', ":");
+define('ASSERT_ACTIVE', 1);
+define('ASSERT_CALLBACK', 2);
+define('ASSERT_BAIL', 3);
+define('ASSERT_WARNING', 4);
+define('ASSERT_EXCEPTION', 5);
+define('CONNECTION_ABORTED', 1);
+define('CONNECTION_NORMAL', 0);
+define('CONNECTION_TIMEOUT', 2);
+define('IMAGETYPE_GIF', 1);
+define('IMAGETYPE_JPEG', 2);
+define('IMAGETYPE_PNG', 3);
+define('IMAGETYPE_WEBP', 18);
+define('IMAGETYPE_AVIF', 19);
+define('UPLOAD_ERR_OK', 0);
+define('UPLOAD_ERR_INI_SIZE', 1);
+define('UPLOAD_ERR_FORM_SIZE', 2);
+define('UPLOAD_ERR_PARTIAL', 3);
+define('UPLOAD_ERR_NO_FILE', 4);
+define('UPLOAD_ERR_NO_TMP_DIR', 6);
+define('UPLOAD_ERR_CANT_WRITE', 7);
+define('UPLOAD_ERR_EXTENSION', 8);
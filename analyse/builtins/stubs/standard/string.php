@@ -0,0 +1,246 @@
+<?php
+
+// String functions of ext/standard.
+
+use JetBrains\PhpStorm\Internal\PhpStormStubsElementAvailable;
+
+function addcslashes(string $string, string $characters): string {}
+
+function addslashes(string $string): string {}
+
+function bin2hex(string $string): string {}
+
+function hex2bin(string $string): string|false {}
+
+function chop(string $string, string $characters = " \n\r\t\v\0"): string {}
+
+function chr(int $codepoint): string {}
+
+function ord(string $character): int {}
+
+function chunk_split(string $string, int $length = 76, string $separator = "\r\n"): string {}
+
+function convert_uuencode(string $string): string {}
+
+function convert_uudecode(string $string): string|false {}
+
+function count_chars(string $string, int $mode = 0): array|string {}
+
+function crc32(string $string): int {}
+
+function crypt(string $string, string $salt): string {}
+
+function explode(string $separator, string $string, int $limit = PHP_INT_MAX): array {}
+
+function implode(array|string $separator, ?array $array = null): string {}
+
+function join(array|string $separator, ?array $array = null): string {}
+
+function get_html_translation_table(int $table = HTML_SPECIALCHARS, int $flags = ENT_QUOTES | ENT_SUBSTITUTE | ENT_HTML401, string $encoding = "UTF-8"): array {}
+
+function html_entity_decode(string $string, int $flags = ENT_QUOTES | ENT_SUBSTITUTE | ENT_HTML401, ?string $encoding = null): string {}
+
+function htmlentities(string $string, int $flags = ENT_QUOTES | ENT_SUBSTITUTE | ENT_HTML401, ?string $encoding = null, bool $double_encode = true): string {}
+
+function htmlspecialchars(string $string, int $flags = ENT_QUOTES | ENT_SUBSTITUTE | ENT_HTML401, ?string $encoding = null, bool $double_encode = true): string {}
+
+function htmlspecialchars_decode(string $string, int $flags = ENT_QUOTES | ENT_SUBSTITUTE | ENT_HTML401): string {}
+
+function lcfirst(string $string): string {}
+
+function levenshtein(string $string1, string $string2, int $insertion_cost = 1, int $replacement_cost = 1, int $deletion_cost = 1): int {}
+
+function ltrim(string $string, string $characters = " \n\r\t\v\0"): string {}
+
+function rtrim(string $string, string $characters = " \n\r\t\v\0"): string {}
+
+function trim(string $string, string $characters = " \n\r\t\v\0"): string {}
+
+function md5(string $string, bool $binary = false): string {}
+
+function md5_file(string $filename, bool $binary = false): string|false {}
+
+function metaphone(string $string, int $max_phonemes = 0): string {}
+
+function nl2br(string $string, bool $use_xhtml = true): string {}
+
+function number_format(float $num, int $decimals = 0, ?string $decimal_separator = ".", ?string $thousands_separator = ","): string {}
+
+function parse_str(string $string, &$result): void {}
+
+function printf(string $format, mixed ...$values): int {}
+
+function sprintf(string $format, mixed ...$values): string {}
+
+function vprintf(string $format, array $values): int {}
+
+function vsprintf(string $format, array $values): string {}
+
+function fprintf($stream, string $format, mixed ...$values): int {}
+
+function vfprintf($stream, string $format, array $values): int {}
+
+function sscanf(string $string, string $format, mixed &...$vars): array|int|null {}
+
+function quoted_printable_decode(string $string): string {}
+
+function quoted_printable_encode(string $string): string {}
+
+function quotemeta(string $string): string {}
+
+function sha1(string $string, bool $binary = false): string {}
+
+function sha1_file(string $filename, bool $binary = false): string|false {}
+
+function similar_text(string $string1, string $string2, &$percent = null): int {}
+
+function soundex(string $string): string {}
+
+/**
+ * @since 8.0
+ */
+function str_contains(string $haystack, string $needle): bool {}
+
+/**
+ * @since 8.0
+ */
+function str_starts_with(string $haystack, string $needle): bool {}
+
+/**
+ * @since 8.0
+ */
+function str_ends_with(string $haystack, string $needle): bool {}
+
+function str_getcsv(string $string, string $separator = ",", string $enclosure = "\"", string $escape = "\\"): array {}
+
+function str_ireplace(array|string $search, array|string $replace, string|array $subject, &$count = null): string|array {}
+
+function str_replace(array|string $search, array|string $replace, string|array $subject, &$count = null): string|array {}
+
+function str_pad(string $string, int $length, string $pad_string = " ", int $pad_type = STR_PAD_RIGHT): string {}
+
+function str_repeat(string $string, int $times): string {}
+
+function str_rot13(string $string): string {}
+
+function str_shuffle(string $string): string {}
+
+function str_split(string $string, int $length = 1): array {}
+
+function str_word_count(string $string, int $format = 0, ?string $characters = null): array|int {}
+
+/**
+ * @since 8.3
+ */
+function str_increment(string $string): string {}
+
+/**
+ * @since 8.3
+ */
+function str_decrement(string $string): string {}
+
+function strcoll(string $string1, string $string2): int {}
+
+function strcspn(string $string, string $characters, int $offset = 0, ?int $length = null): int {}
+
+function strip_tags(string $string, array|string|null $allowed_tags = null): string {}
+
+function stripcslashes(string $string): string {}
+
+function stripslashes(string $string): string {}
+
+function stripos(string $haystack, string $needle, int $offset = 0): int|false {}
+
+function stristr(string $haystack, string $needle, bool $before_needle = false): string|false {}
+
+function strnatcasecmp(string $string1, string $string2): int {}
+
+function strnatcmp(string $string1, string $string2): int {}
+
+function strpbrk(string $string, string $characters): string|false {}
+
+function strpos(string $haystack, string $needle, int $offset = 0): int|false {}
+
+function strrchr(string $haystack, string $needle, bool $before_needle = false): string|false {}
+
+function strrev(string $string): string {}
+
+function strripos(string $haystack, string $needle, int $offset = 0): int|false {}
+
+function strrpos(string $haystack, string $needle, int $offset = 0): int|false {}
+
+function strspn(string $string, string $characters, int $offset = 0, ?int $length = null): int {}
+
+function strstr(string $haystack, string $needle, bool $before_needle = false): string|false {}
+
+function strchr(string $haystack, string $needle, bool $before_needle = false): string|false {}
+
+function strtok(string $string, ?string $token = null): string|false {}
+
+function strtolower(string $string): string {}
+
+function strtoupper(string $string): string {}
+
+function strtr(string $string, array|string $from, ?string $to = null): string {}
+
+function substr(string $string, int $offset, ?int $length = null): string {}
+
+function substr_compare(string $haystack, string $needle, int $offset, ?int $length = null, bool $case_insensitive = false): int {}
+
+function substr_count(string $haystack, string $needle, int $offset = 0, ?int $length = null): int {}
+
+function substr_replace(array|string $string, array|string $replace, array|int $offset, array|int|null $length = null): string|array {}
+
+function ucfirst(string $string): string {}
+
+function ucwords(string $string, string $separators = " \t\r\n\f\v"): string {}
+
+function wordwrap(string $string, int $width = 75, string $break = "\n", bool $cut_long_words = false): string {}
+
+function nl_langinfo(int $item): string|false {}
+
+function localeconv(): array {}
+
+function setlocale(int $category, $locales, ...$rest): string|false {}
+
+#[PhpStormStubsElementAvailable(to: '7.4')]
+function money_format(string $format, float $number): string {}
+
+#[PhpStormStubsElementAvailable(to: '7.4')]
+function convert_cyr_string(string $str, string $from, string $to): string {}
+
+#[PhpStormStubsElementAvailable(to: '7.4')]
+function hebrevc(string $hebrew_text, int $max_chars_per_line = null): string {}
+
+function hebrev(string $string, int $max_chars_per_line = 0): string {}
+
+/**
+ * @deprecated 8.2
+ */
+function utf8_encode(string $string): string {}
+
+/**
+ * @deprecated 8.2
+ */
+function utf8_decode(string $string): string {}
+
+function base64_encode(string $string): string {}
+
+function base64_decode(string $string, bool $strict = false): string|false {}
+
+function urlencode(string $string): string {}
+
+function urldecode(string $string): string {}
+
+function rawurlencode(string $string): string {}
+
+function rawurldecode(string $string): string {}
+
+function http_build_query(array|object $data, string $numeric_prefix = "", ?string $arg_separator = null, int $encoding_type = PHP_QUERY_RFC1738): string {}
+
+function parse_url(string $url, int $component = -1): int|string|array|false|null {}
+
+function get_headers(string $url, bool $associative = false, $context = null): array|false {}
+
+function uniqid(string $prefix = "", bool $more_entropy = false): string {}
+
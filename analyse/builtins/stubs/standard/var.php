@@ -0,0 +1,81 @@
+<?php
+
+// Variable handling functions of ext/standard.
+
+function boolval(mixed $value): bool {}
+
+function debug_zval_dump(mixed $value, mixed ...$values): void {}
+
+function doubleval(mixed $value): float {}
+
+function floatval(mixed $value): float {}
+
+function intval(mixed $value, int $base = 10): int {}
+
+function strval(mixed $value): string {}
+
+function settype(mixed &$var, string $type): bool {}
+
+function gettype(mixed $value): string {}
+
+/**
+ * @since 8.0
+ */
+function get_debug_type(mixed $value): string {}
+
+function is_array(mixed $value): bool {}
+
+function is_bool(mixed $value): bool {}
+
+function is_callable(mixed $value, bool $syntax_only = false, &$callable_name = null): bool {}
+
+/**
+ * @since 7.3
+ */
+function is_countable(mixed $value): bool {}
+
+function is_double(mixed $value): bool {}
+
+function is_float(mixed $value): bool {}
+
+function is_int(mixed $value): bool {}
+
+function is_integer(mixed $value): bool {}
+
+function is_long(mixed $value): bool {}
+
+/**
+ * @since 7.1
+ */
+function is_iterable(mixed $value): bool {}
+
+function is_null(mixed $value): bool {}
+
+function is_numeric(mixed $value): bool {}
+
+function is_object(mixed $value): bool {}
+
+function is_resource(mixed $value): bool {}
+
+function is_scalar(mixed $value): bool {}
+
+function is_string(mixed $value): bool {}
+
+function print_r(mixed $value, bool $return = false): string|bool {}
+
+function serialize(mixed $value): string {}
+
+function unserialize(string $data, array $options = []): mixed {}
+
+function var_dump(mixed $value, mixed ...$values): void {}
+
+function var_export(mixed $value, bool $return = false): ?string {}
+
+function memory_get_usage(bool $real_usage = false): int {}
+
+function memory_get_peak_usage(bool $real_usage = false): int {}
+
+/**
+ * @since 8.2
+ */
+function memory_reset_peak_usage(): void {}
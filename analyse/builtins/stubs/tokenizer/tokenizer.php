@@ -0,0 +1,32 @@
+<?php
+
+// PHP tokenizer of ext/tokenizer.
+
+/**
+ * @since 8.0
+ */
+class PhpToken implements Stringable
+{
+    public int $id;
+    public string $text;
+    public int $line;
+    public int $pos;
+
+    final public function __construct(int $id, string $text, int $line = -1, int $pos = -1) {}
+
+    public static function tokenize(string $code, int $flags = 0): array {}
+
+    public function is($kind): bool {}
+
+    public function isIgnorable(): bool {}
+
+    public function getTokenName(): ?string {}
+
+    public function __toString(): string {}
+}
+
+function token_get_all(string $code, int $flags = 0): array {}
+
+function token_name(int $id): string {}
+
+define('TOKEN_PARSE', 1);
@@ -0,0 +1,94 @@
+<?php
+
+// Pull parsing of XML documents of ext/xmlreader.
+
+class XMLReader
+{
+    const NONE = 0;
+    const ELEMENT = 1;
+    const ATTRIBUTE = 2;
+    const TEXT = 3;
+    const CDATA = 4;
+    const ENTITY_REF = 5;
+    const ENTITY = 6;
+    const PI = 7;
+    const COMMENT = 8;
+    const DOC = 9;
+    const DOC_TYPE = 10;
+    const DOC_FRAGMENT = 11;
+    const NOTATION = 12;
+    const WHITESPACE = 13;
+    const SIGNIFICANT_WHITESPACE = 14;
+    const END_ELEMENT = 15;
+    const END_ENTITY = 16;
+    const XML_DECLARATION = 17;
+    const LOADDTD = 1;
+    const DEFAULTATTRS = 2;
+    const VALIDATE = 3;
+    const SUBST_ENTITIES = 4;
+
+    public int $attributeCount;
+    public string $baseURI;
+    public int $depth;
+    public bool $hasAttributes;
+    public bool $hasValue;
+    public bool $isDefault;
+    public bool $isEmptyElement;
+    public string $localName;
+    public string $name;
+    public string $namespaceURI;
+    public int $nodeType;
+    public string $prefix;
+    public string $value;
+    public string $xmlLang;
+
+    public function close(): true {}
+
+    public function getAttribute(string $name): ?string {}
+
+    public function getAttributeNo(int $index): ?string {}
+
+    public function getAttributeNs(string $name, string $namespace): ?string {}
+
+    public function getParserProperty(int $property): bool {}
+
+    public function isValid(): bool {}
+
+    public function lookupNamespace(string $prefix): ?string {}
+
+    public function moveToAttribute(string $name): bool {}
+
+    public function moveToAttributeNo(int $index): bool {}
+
+    public function moveToAttributeNs(string $name, string $namespace): bool {}
+
+    public function moveToElement(): bool {}
+
+    public function moveToFirstAttribute(): bool {}
+
+    public function moveToNextAttribute(): bool {}
+
+    public function read(): bool {}
+
+    public function next(?string $name = null): bool {}
+
+    public static function open(string $uri, ?string $encoding = null, int $flags = 0) {}
+
+    public function readInnerXml(): string {}
+
+    public function readOuterXml(): string {}
+
+    public function readString(): string {}
+
+    public function setSchema(?string $filename): bool {}
+
+    public function setParserProperty(int $property, bool $value): bool {}
+
+    public function setRelaxNGSchema(?string $filename): bool {}
+
+    public function setRelaxNGSchemaSource(?string $source): bool {}
+
+    public static function XML(string $source, ?string $encoding = null, int $flags = 0) {}
+
+    public function expand(?DOMNode $baseNode = null): DOMNode|false {}
+}
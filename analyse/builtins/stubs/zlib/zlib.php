@@ -0,0 +1,37 @@
+<?php
+
+// Compression of ext/zlib.
+
+function gzcompress(string $data, int $level = -1, int $encoding = ZLIB_ENCODING_DEFLATE): string|false {}
+
+function gzuncompress(string $data, int $max_length = 0): string|false {}
+
+function gzdeflate(string $data, int $level = -1, int $encoding = ZLIB_ENCODING_RAW): string|false {}
+
+function gzinflate(string $data, int $max_length = 0): string|false {}
+
+function gzencode(string $data, int $level = -1, int $encoding = ZLIB_ENCODING_GZIP): string|false {}
+
+function gzdecode(string $data, int $max_length = 0): string|false {}
+
+function zlib_encode(string $data, int $encoding, int $level = -1): string|false {}
+
+function zlib_decode(string $data, int $max_length = 0): string|false {}
+
+function gzopen(string $filename, string $mode, int $use_include_path = 0) {}
+
+function gzread($stream, int $length): string|false {}
+
+function gzwrite($stream, string $data, ?int $length = null): int|false {}
+
+function gzclose($stream): bool {}
+
+function gzeof($stream): bool {}
+
+function gzfile(string $filename, int $use_include_path = 0): array|false {}
+
+define('FORCE_GZIP', 31);
+define('FORCE_DEFLATE', 15);
+define('ZLIB_ENCODING_RAW', -15);
+define('ZLIB_ENCODING_GZIP', 31);
+define('ZLIB_ENCODING_DEFLATE', 15);
@@ -0,0 +1,667 @@
+package cfg
+
+import (
+	"github.com/ayanozturk/go-php-parser/ast"
+	"strings"
+)
+
+type builder struct {
+	g       *Graph
+	opts    Options
+	cur     *Block
+	targets []*jumpTarget
+	tries   []*tryContext
+	labels  map[string]*Block
+}
+
+// jumpTarget is an enclosing loop or switch for break/continue.
+type jumpTarget struct {
+	breakTo    *Block
+	continueTo *Block
+	tryDepth   int
+}
+
+// tryContext tracks an enclosing try statement while its body or catch
+// clauses are being built.
+type tryContext struct {
+	catches    []*Block
+	catchesAll bool
+	inCatch    bool
+	finally    *finallyContext
+}
+
+// finallyContext collects the jumps that must run a finally block before
+// continuing to their real destination.
+type finallyContext struct {
+	entry   *Block
+	pending []pendingJump
+}
+
+type pendingJump struct {
+	target   *Block // nil for a propagating exception
+	tryDepth int
+}
+
+func newBuilder(opts *Options) *builder {
+	b := &builder{
+		g:      &Graph{blockOf: map[ast.Node][]*Block{}},
+		labels: map[string]*Block{},
+	}
+	if opts != nil {
+		b.opts = *opts
+	}
+	b.g.Entry = b.newBlock()
+	b.g.Exit = b.newBlock()
+	b.g.Throw = b.newBlock()
+	b.cur = b.g.Entry
+	return b
+}
+
+func (b *builder) newBlock() *Block {
+	block := &Block{ID: len(b.g.Blocks)}
+	b.g.Blocks = append(b.g.Blocks, block)
+	return block
+}
+
+func (b *builder) edge(from, to *Block, kind EdgeKind) {
+	for _, existing := range from.Succs {
+		if existing.To == to && existing.Kind == kind {
+			return
+		}
+	}
+	e := &Edge{From: from, To: to, Kind: kind}
+	from.Succs = append(from.Succs, e)
+	to.Preds = append(to.Preds, e)
+}
+
+func (b *builder) record(node ast.Node) {
+	if node == nil {
+		return
+	}
+	for _, block := range b.g.blockOf[node] {
+		if block == b.cur {
+			return
+		}
+	}
+	b.g.blockOf[node] = append(b.g.blockOf[node], b.cur)
+}
+
+func (b *builder) add(node ast.Node) {
+	if node == nil {
+		return
+	}
+	b.record(node)
+	b.cur.Nodes = append(b.cur.Nodes, node)
+}
+
+// terminate starts a fresh block with no predecessors for the code that
+// follows a jump; anything placed in it is unreachable.
+func (b *builder) terminate() {
+	b.cur = b.newBlock()
+}
+
+func (b *builder) finish() {
+	b.g.End = b.newBlock()
+	b.edge(b.cur, b.g.End, Normal)
+	b.edge(b.g.End, b.g.Exit, Normal)
+	b.markReachable()
+}
+
+func (b *builder) markReachable() {
+	stack := []*Block{b.g.Entry}
+	b.g.Entry.Reachable = true
+	for len(stack) > 0 {
+		block := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, e := range block.Succs {
+			if !e.To.Reachable {
+				e.To.Reachable = true
+				stack = append(stack, e.To)
+			}
+		}
+	}
+}
+
+func (b *builder) stmts(nodes []ast.Node) {
+	for _, node := range nodes {
+		b.stmt(node)
+	}
+}
+
+func (b *builder) stmt(node ast.Node) {
+	switch n := node.(type) {
+	case nil:
+		return
+	case *ast.BlockNode:
+		b.record(n)
+		if n.Loop {
+			b.forLoop(n)
+			return
+		}
+		b.stmts(n.Statements)
+	case *ast.NamespaceNode:
+		b.record(n)
+		b.stmts(n.Body)
+	case *ast.IfNode:
+		b.ifStmt(n)
+	case *ast.WhileNode:
+		b.whileLoop(n)
+	case *ast.DoWhileNode:
+		b.doWhileLoop(n)
+	case *ast.ForeachNode:
+		b.foreachLoop(n)
+	case *ast.SwitchNode:
+		b.switchStmt(n)
+	case *ast.TryNode:
+		b.tryStmt(n)
+	case *ast.ReturnNode:
+		b.matchExpr(n.Expr)
+		b.add(n)
+		b.jump(b.cur, b.g.Exit, 0)
+		b.terminate()
+	case *ast.ThrowNode:
+		b.matchExpr(n.Expr)
+		b.add(n)
+		b.throw(b.cur)
+		b.terminate()
+	case *ast.GotoNode:
+		b.add(n)
+		b.edge(b.cur, b.label(n.Label), Normal)
+		b.terminate()
+	case *ast.LabelNode:
+		target := b.label(n.Name)
+		b.edge(b.cur, target, Normal)
+		b.cur = target
+		b.add(n)
+	case *ast.ExpressionStmt:
+		b.expressionStmt(n)
+	case *ast.AssignmentNode:
+		b.matchExpr(n.Right)
+		b.add(n)
+	default:
+		b.add(n)
+	}
+}
+
+func (b *builder) expressionStmt(n *ast.ExpressionStmt) {
+	switch expr := n.Expr.(type) {
+	case *ast.IdentifierNode:
+		switch strings.ToLower(expr.Value) {
+		case "break":
+			b.add(n)
+			b.breakOrContinue(true, 1)
+			return
+		case "continue":
+			b.add(n)
+			b.breakOrContinue(false, 1)
+			return
+		}
+	case *ast.UnaryExpr:
+		if expr.Operator == "break" || expr.Operator == "continue" {
+			b.add(n)
+			b.breakOrContinue(expr.Operator == "break", jumpLevel(expr.Operand))
+			return
+		}
+	case *ast.ThrowNode:
+		b.matchExpr(expr.Expr)
+		b.add(n)
+		b.throw(b.cur)
+		b.terminate()
+		return
+	case *ast.AssignmentNode:
+		b.matchExpr(expr.Right)
+	default:
+		b.matchExpr(expr)
+	}
+	b.add(n)
+	if b.neverReturns(n.Expr) {
+		b.terminate()
+	}
+}
+
+func jumpLevel(node ast.Node) int {
+	switch n := node.(type) {
+	case *ast.IntegerNode:
+		return int(n.Value)
+	case *ast.IntegerLiteral:
+		return int(n.Value)
+	}
+	return 1
+}
+
+func (b *builder) breakOrContinue(isBreak bool, level int) {
+	if level < 1 {
+		level = 1
+	}
+	if level > len(b.targets) {
+		// break/continue outside a loop is a compile error in PHP; treat it
+		// as ending the path.
+		b.terminate()
+		return
+	}
+	target := b.targets[len(b.targets)-level]
+	to := target.continueTo
+	if isBreak {
+		to = target.breakTo
+	}
+	b.jump(b.cur, to, target.tryDepth)
+	b.terminate()
+}
+
+func (b *builder) label(name string) *Block {
+	key := strings.ToLower(name)
+	if block, ok := b.labels[key]; ok {
+		return block
+	}
+	block := b.newBlock()
+	b.labels[key] = block
+	return block
+}
+
+func (b *builder) ifStmt(n *ast.IfNode) {
+	b.record(n)
+	b.matchExpr(n.Condition)
+	cond := b.cur
+	cond.Nodes = append(cond.Nodes, n.Condition)
+	cond.Cond = n.Condition
+	after := b.newBlock()
+
+	then := b.newBlock()
+	b.edge(cond, then, True)
+	b.cur = then
+	b.stmts(n.Body)
+	b.edge(b.cur, after, Normal)
+
+	prev := cond
+	for _, elseif := range n.ElseIfs {
+		test := b.newBlock()
+		b.edge(prev, test, False)
+		b.cur = test
+		b.record(elseif)
+		test.Nodes = append(test.Nodes, elseif.Condition)
+		test.Cond = elseif.Condition
+		body := b.newBlock()
+		b.edge(test, body, True)
+		b.cur = body
+		b.stmts(elseif.Body)
+		b.edge(b.cur, after, Normal)
+		prev = test
+	}
+	if n.Else != nil {
+		elseBlock := b.newBlock()
+		b.edge(prev, elseBlock, False)
+		b.cur = elseBlock
+		b.record(n.Else)
+		b.stmts(n.Else.Body)
+		b.edge(b.cur, after, Normal)
+	} else {
+		b.edge(prev, after, False)
+	}
+	b.cur = after
+}
+
+func (b *builder) pushTarget(breakTo, continueTo *Block) {
+	b.targets = append(b.targets, &jumpTarget{breakTo: breakTo, continueTo: continueTo, tryDepth: len(b.tries)})
+}
+
+func (b *builder) popTarget() {
+	b.targets = b.targets[:len(b.targets)-1]
+}
+
+func (b *builder) whileLoop(n *ast.WhileNode) {
+	b.record(n)
+	header := b.newBlock()
+	b.edge(b.cur, header, Normal)
+	header.Nodes = append(header.Nodes, n.Condition)
+	header.Cond = n.Condition
+	body := b.newBlock()
+	after := b.newBlock()
+	b.edge(header, body, True)
+	if !isLiteralTrue(n.Condition) {
+		b.edge(header, after, False)
+	}
+
+	b.pushTarget(after, header)
+	b.cur = body
+	b.stmts(n.Body)
+	b.edge(b.cur, header, Normal)
+	b.popTarget()
+	b.cur = after
+}
+
+func (b *builder) doWhileLoop(n *ast.DoWhileNode) {
+	b.record(n)
+	body := b.newBlock()
+	b.edge(b.cur, body, Normal)
+	cond := b.newBlock()
+	after := b.newBlock()
+
+	b.pushTarget(after, cond)
+	b.cur = body
+	b.stmts(n.Body)
+	b.edge(b.cur, cond, Normal)
+	b.popTarget()
+
+	cond.Nodes = append(cond.Nodes, n.Condition)
+	cond.Cond = n.Condition
+	b.edge(cond, body, True)
+	if !isLiteralTrue(n.Condition) {
+		b.edge(cond, after, False)
+	}
+	b.cur = after
+}
+
+// forLoop models a for statement. Its control expressions are not part of
+// the AST, so the loop may run zero or more times. The loop node itself is
+// added before the header as a marker for its control variables.
+func (b *builder) forLoop(n *ast.BlockNode) {
+	b.cur.Nodes = append(b.cur.Nodes, n)
+	header := b.newBlock()
+	b.edge(b.cur, header, Normal)
+	body := b.newBlock()
+	after := b.newBlock()
+	b.edge(header, body, Normal)
+	if !n.NoCondition {
+		b.edge(header, after, Normal)
+	}
+
+	b.pushTarget(after, header)
+	b.cur = body
+	b.stmts(n.Statements)
+	b.edge(b.cur, header, Normal)
+	b.popTarget()
+	b.cur = after
+}
+
+func (b *builder) foreachLoop(n *ast.ForeachNode) {
+	b.record(n)
+	b.matchExpr(n.Expr)
+	b.cur.Nodes = append(b.cur.Nodes, n.Expr)
+	header := b.newBlock()
+	b.edge(b.cur, header, Normal)
+	bind := b.newBlock()
+	after := b.newBlock()
+	b.edge(header, bind, True)
+	b.edge(header, after, False)
+	bind.Nodes = append(bind.Nodes, n)
+
+	b.pushTarget(after, header)
+	b.cur = bind
+	b.stmts(n.Body)
+	b.edge(b.cur, header, Normal)
+	b.popTarget()
+	b.cur = after
+}
+
+func (b *builder) switchStmt(n *ast.SwitchNode) {
+	b.record(n)
+	b.matchExpr(n.Expr)
+	b.cur.Nodes = append(b.cur.Nodes, n.Expr)
+	after := b.newBlock()
+
+	bodies := make([]*Block, len(n.Cases))
+	for i := range n.Cases {
+		bodies[i] = b.newBlock()
+	}
+
+	prev, prevKind := b.cur, Normal
+	var defaultBody *Block
+	for i, c := range n.Cases {
+		if c.IsDefault {
+			defaultBody = bodies[i]
+			continue
+		}
+		test := b.newBlock()
+		b.edge(prev, test, prevKind)
+		test.Nodes = append(test.Nodes, c.Expr)
+		test.Cond = c.Expr
+		b.edge(test, bodies[i], True)
+		prev, prevKind = test, False
+	}
+	if defaultBody != nil {
+		b.edge(prev, defaultBody, prevKind)
+	} else {
+		b.edge(prev, after, prevKind)
+	}
+
+	// continue inside a switch behaves like break.
+	b.pushTarget(after, after)
+	for i, c := range n.Cases {
+		b.cur = bodies[i]
+		b.record(c)
+		b.stmts(c.Body)
+		next := after
+		if i+1 < len(bodies) {
+			next = bodies[i+1]
+		}
+		b.edge(b.cur, next, Normal)
+	}
+	b.popTarget()
+	b.cur = after
+}
+
+func (b *builder) tryStmt(n *ast.TryNode) {
+	b.record(n)
+	// entry holds the state before the try body runs; an exception can be
+	// raised before any statement of the body completes.
+	entry := b.newBlock()
+	b.edge(b.cur, entry, Normal)
+	after := b.newBlock()
+
+	ctx := &tryContext{}
+	for _, c := range n.Catches {
+		ctx.catches = append(ctx.catches, b.newBlock())
+		if catchesEverything(c.Types) {
+			ctx.catchesAll = true
+		}
+	}
+	normalExit := after
+	if n.Finally != nil {
+		// The finally body is built twice: once for normal completion, which
+		// continues after the try, and once for jumps and exceptions passing
+		// through it, which continue to their original destination.
+		ctx.finally = &finallyContext{entry: b.newBlock()}
+		normalExit = b.newBlock()
+	}
+
+	b.tries = append(b.tries, ctx)
+	firstBodyBlock := len(b.g.Blocks)
+	b.cur = b.newBlock()
+	b.edge(entry, b.cur, Normal)
+	b.stmts(n.Body)
+	b.edge(b.cur, normalExit, Normal)
+	b.raiseImplicit(entry)
+	for _, block := range b.g.Blocks[firstBodyBlock:] {
+		b.raiseImplicit(block)
+	}
+
+	ctx.inCatch = true
+	firstCatchBlock := len(b.g.Blocks)
+	for i, c := range n.Catches {
+		b.cur = ctx.catches[i]
+		b.record(c)
+		b.cur.Nodes = append(b.cur.Nodes, c)
+		b.stmts(c.Body)
+		b.edge(b.cur, normalExit, Normal)
+	}
+	if ctx.finally != nil {
+		for _, block := range append(append([]*Block(nil), ctx.catches...), b.g.Blocks[firstCatchBlock:]...) {
+			b.raiseImplicit(block)
+		}
+	}
+	b.tries = b.tries[:len(b.tries)-1]
+
+	if ctx.finally == nil {
+		b.cur = after
+		return
+	}
+
+	b.cur = normalExit
+	b.stmts(n.Finally)
+	b.edge(b.cur, after, Normal)
+
+	finally := ctx.finally
+	if len(finally.pending) > 0 {
+		b.cur = finally.entry
+		b.stmts(n.Finally)
+		finallyEnd := b.cur
+		seen := map[*Block]bool{}
+		rethrow := false
+		for _, pending := range finally.pending {
+			if pending.target == nil {
+				rethrow = true
+				continue
+			}
+			if seen[pending.target] {
+				continue
+			}
+			seen[pending.target] = true
+			b.jump(finallyEnd, pending.target, pending.tryDepth)
+		}
+		if rethrow {
+			b.throw(finallyEnd)
+		}
+	}
+	b.cur = after
+}
+
+// jump moves control from a block to target, routing through the finally
+// blocks of every try statement between the jump and tryDepth.
+func (b *builder) jump(from, target *Block, tryDepth int) {
+	for i := len(b.tries) - 1; i >= tryDepth; i-- {
+		ctx := b.tries[i]
+		if ctx.finally == nil {
+			continue
+		}
+		b.edge(from, ctx.finally.entry, Normal)
+		ctx.finally.pending = append(ctx.finally.pending, pendingJump{target: target, tryDepth: tryDepth})
+		return
+	}
+	b.edge(from, target, Normal)
+}
+
+// throw routes an explicit throw from a block to the innermost handlers.
+func (b *builder) throw(from *Block) {
+	for i := len(b.tries) - 1; i >= 0; i-- {
+		ctx := b.tries[i]
+		if !ctx.inCatch && len(ctx.catches) > 0 {
+			for _, c := range ctx.catches {
+				b.edge(from, c, Exception)
+			}
+			if ctx.catchesAll {
+				return
+			}
+		}
+		if ctx.finally != nil {
+			b.edge(from, ctx.finally.entry, Exception)
+			ctx.finally.pending = append(ctx.finally.pending, pendingJump{})
+			return
+		}
+	}
+	b.edge(from, b.g.Throw, Exception)
+}
+
+// raiseImplicit adds the exception edges for a block inside the innermost
+// try that may throw from any call it makes.
+func (b *builder) raiseImplicit(from *Block) {
+	ctx := b.tries[len(b.tries)-1]
+	if !ctx.inCatch {
+		for _, c := range ctx.catches {
+			b.edge(from, c, Exception)
+		}
+		if ctx.catchesAll {
+			return
+		}
+	}
+	if ctx.finally != nil {
+		b.edge(from, ctx.finally.entry, Exception)
+		ctx.finally.pending = append(ctx.finally.pending, pendingJump{})
+	}
+}
+
+// matchExpr models a match expression appearing directly in a statement:
+// each arm is its own block, arms that throw do not rejoin, and a match
+// without a default arm can throw UnhandledMatchError.
+func (b *builder) matchExpr(expr ast.Node) {
+	m, ok := expr.(*ast.MatchNode)
+	if !ok {
+		return
+	}
+	b.cur.Nodes = append(b.cur.Nodes, m.Condition)
+	after := b.newBlock()
+	prev, prevKind := b.cur, Normal
+	var defaultArm *Block
+	for i := range m.Arms {
+		arm := &m.Arms[i]
+		armBlock := b.newBlock()
+		armBlock.Nodes = append(armBlock.Nodes, arm.Body)
+		if isDefaultArm(arm) {
+			defaultArm = armBlock
+		} else {
+			test := b.newBlock()
+			b.edge(prev, test, prevKind)
+			test.Nodes = append(test.Nodes, arm.Conditions...)
+			b.edge(test, armBlock, True)
+			prev, prevKind = test, False
+		}
+		switch body := arm.Body.(type) {
+		case *ast.ThrowNode:
+			b.throw(armBlock)
+		default:
+			if !b.neverReturns(body) {
+				b.edge(armBlock, after, Normal)
+			}
+		}
+	}
+	if defaultArm != nil {
+		b.edge(prev, defaultArm, prevKind)
+	} else {
+		b.throw(prev)
+	}
+	b.cur = after
+}
+
+func isDefaultArm(arm *ast.MatchArmNode) bool {
+	if len(arm.Conditions) != 1 {
+		return false
+	}
+	ident, ok := arm.Conditions[0].(*ast.IdentifierNode)
+	return ok && strings.EqualFold(ident.Value, "default")
+}
+
+func (b *builder) neverReturns(expr ast.Node) bool {
+	switch n := expr.(type) {
+	case *ast.FunctionCallNode:
+		if ident, ok := n.Name.(*ast.IdentifierNode); ok {
+			name := strings.TrimLeft(strings.ToLower(ident.Value), `\`)
+			if name == "exit" || name == "die" {
+				return true
+			}
+		}
+	case *ast.MethodCallNode:
+		// Resolved through Options below.
+	default:
+		return false
+	}
+	return b.opts.NeverReturns != nil && b.opts.NeverReturns(expr)
+}
+
+func isLiteralTrue(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.BooleanNode:
+		return n.Value
+	case *ast.BooleanLiteral:
+		return n.Value
+	}
+	return false
+}
+
+func catchesEverything(types []string) bool {
+	for _, t := range types {
+		switch strings.ToLower(strings.TrimLeft(t, `\`)) {
+		case "throwable":
+			return true
+		}
+	}
+	return false
+}
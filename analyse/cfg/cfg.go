@@ -0,0 +1,156 @@
+// Package cfg builds control-flow graphs for PHP function bodies.
+//
+// A Graph splits a body into basic blocks connected by edges. Blocks hold the
+// simple statements and the expressions evaluated in them (conditions, switch
+// subjects, match arms, ...) in execution order; compound statements such as
+// if or while are represented by the blocks and edges they produce and are
+// mapped to the block in which they start, so rules can ask whether any
+// statement is reachable.
+//
+// The graph is intentionally statement-grained: short-circuit operators and
+// ternaries are not split into blocks, and only literal true loop conditions
+// are folded.
+package cfg
+
+import "github.com/ayanozturk/go-php-parser/ast"
+
+// EdgeKind describes why control moves along an edge.
+type EdgeKind int
+
+const (
+	// Normal is unconditional sequential flow, including jumps.
+	Normal EdgeKind = iota
+	// True is taken when the source block's Cond holds.
+	True
+	// False is taken when the source block's Cond does not hold.
+	False
+	// Exception is taken when the source block throws.
+	Exception
+)
+
+func (k EdgeKind) String() string {
+	switch k {
+	case True:
+		return "true"
+	case False:
+		return "false"
+	case Exception:
+		return "exception"
+	default:
+		return "normal"
+	}
+}
+
+// Edge connects two blocks.
+type Edge struct {
+	From *Block
+	To   *Block
+	Kind EdgeKind
+}
+
+// Block is a straight-line sequence of nodes with a single entry.
+//
+// Nodes are simple statements or expressions. Three compound nodes appear as
+// markers: a *ast.ForeachNode at the start of a loop body binds the key and
+// value variables, a *ast.CatchNode at the start of a catch body binds the
+// exception variable, and a for loop's *ast.BlockNode before its header
+// stands for the control expressions.
+type Block struct {
+	ID    int
+	Nodes []ast.Node
+	// Cond is the branch condition for blocks with True/False successors.
+	Cond      ast.Node
+	Succs     []*Edge
+	Preds     []*Edge
+	Reachable bool
+}
+
+// Options customises graph construction.
+type Options struct {
+	// NeverReturns reports whether a call never returns, e.g. because its
+	// declared return type is never. exit and die are always terminating.
+	NeverReturns func(call ast.Node) bool
+}
+
+// Graph is the control-flow graph of one body.
+type Graph struct {
+	Entry *Block
+	// End is reached by falling off the end of the body.
+	End *Block
+	// Exit joins End and every return statement.
+	Exit *Block
+	// Throw collects exceptions that escape the body.
+	Throw  *Block
+	Blocks []*Block
+
+	// blockOf maps a node to the blocks it starts in. finally bodies are
+	// built once per way of entering them, so their nodes have two blocks.
+	blockOf map[ast.Node][]*Block
+}
+
+// ForFunction builds the graph for a function, method, closure or arrow
+// function. It returns nil for other nodes and for bodiless (abstract)
+// functions.
+func ForFunction(fn ast.Node, opts *Options) *Graph {
+	switch n := fn.(type) {
+	case *ast.FunctionNode:
+		if n.Body == nil {
+			return nil
+		}
+		return New(n.Body, opts)
+	case *ast.ArrowFunctionNode:
+		return New([]ast.Node{&ast.ReturnNode{Expr: n.Expr, Pos: n.Pos}}, opts)
+	}
+	return nil
+}
+
+// New builds the graph for a list of statements. Nested function and class
+// declarations are treated as simple statements; build their bodies
+// separately.
+func New(body []ast.Node, opts *Options) *Graph {
+	b := newBuilder(opts)
+	b.stmts(body)
+	b.finish()
+	return b.g
+}
+
+// BlockOf returns the block in which node starts, preferring a reachable one
+// when the node was built more than once. Statements, if/elseif/else parts,
+// switch cases and catch clauses are recorded.
+func (g *Graph) BlockOf(node ast.Node) (*Block, bool) {
+	if g == nil {
+		return nil, false
+	}
+	blocks := g.blockOf[node]
+	if len(blocks) == 0 {
+		return nil, false
+	}
+	for _, block := range blocks {
+		if block.Reachable {
+			return block, true
+		}
+	}
+	return blocks[0], true
+}
+
+// Reachable reports whether node can execute. Nodes that are not part of
+// the graph are reported as reachable so callers stay conservative.
+func (g *Graph) Reachable(node ast.Node) bool {
+	block, ok := g.BlockOf(node)
+	if !ok {
+		return true
+	}
+	return block.Reachable
+}
+
+// CanFallOffEnd reports whether execution can reach the end of the body
+// without a return, throw or other terminating statement.
+func (g *Graph) CanFallOffEnd() bool {
+	return g != nil && g.End.Reachable
+}
+
+// CanReturn reports whether the body can complete at all, either through a
+// return statement or by falling off its end.
+func (g *Graph) CanReturn() bool {
+	return g != nil && g.Exit.Reachable
+}
@@ -0,0 +1,343 @@
+package cfg
+
+import (
+	"github.com/ayanozturk/go-php-parser/ast"
+	"github.com/ayanozturk/go-php-parser/lexer"
+	"github.com/ayanozturk/go-php-parser/parser"
+	"strings"
+	"testing"
+)
+
+func parseFunction(t *testing.T, src string) *ast.FunctionNode {
+	t.Helper()
+	p := parser.New(lexer.New(src), false)
+	nodes := p.Parse()
+	if errs := p.Errors(); len(errs) > 0 {
+		t.Fatalf("parse errors: %v", errs)
+	}
+	for _, node := range nodes {
+		if fn, ok := node.(*ast.FunctionNode); ok {
+			return fn
+		}
+	}
+	t.Fatal("no function in source")
+	return nil
+}
+
+// findCall returns the first expression statement calling name.
+func findCall(t *testing.T, nodes []ast.Node, name string) ast.Node {
+	t.Helper()
+	var found ast.Node
+	var walk func([]ast.Node)
+	walk = func(stmts []ast.Node) {
+		for _, stmt := range stmts {
+			if found != nil {
+				return
+			}
+			switch n := stmt.(type) {
+			case *ast.ExpressionStmt:
+				if call, ok := n.Expr.(*ast.FunctionCallNode); ok {
+					if ident, ok := call.Name.(*ast.IdentifierNode); ok && strings.EqualFold(ident.Value, name) {
+						found = n
+					}
+				}
+			case *ast.IfNode:
+				walk(n.Body)
+				for _, elseif := range n.ElseIfs {
+					walk(elseif.Body)
+				}
+				if n.Else != nil {
+					walk(n.Else.Body)
+				}
+			case *ast.WhileNode:
+				walk(n.Body)
+			case *ast.DoWhileNode:
+				walk(n.Body)
+			case *ast.ForeachNode:
+				walk(n.Body)
+			case *ast.BlockNode:
+				walk(n.Statements)
+			case *ast.SwitchNode:
+				for _, c := range n.Cases {
+					walk(c.Body)
+				}
+			case *ast.TryNode:
+				walk(n.Body)
+				for _, c := range n.Catches {
+					walk(c.Body)
+				}
+				walk(n.Finally)
+			}
+		}
+	}
+	walk(nodes)
+	if found == nil {
+		t.Fatalf("call to %s not found", name)
+	}
+	return found
+}
+
+func TestIfElseBothReturningCannotFallOffEnd(t *testing.T) {
+	fn := parseFunction(t, `<?php
+function f($x) {
+    if ($x) {
+        return 1;
+    } elseif ($x > 1) {
+        return 2;
+    } else {
+        return 3;
+    }
+    after();
+}`)
+	g := ForFunction(fn, nil)
+	if g.CanFallOffEnd() {
+		t.Fatal("expected function to always return")
+	}
+	if g.Reachable(findCall(t, fn.Body, "after")) {
+		t.Fatal("expected statement after exhaustive if/else to be unreachable")
+	}
+}
+
+func TestIfWithoutElseCanFallOffEnd(t *testing.T) {
+	fn := parseFunction(t, `<?php
+function f($x) {
+    if ($x) {
+        return 1;
+    }
+}`)
+	if !ForFunction(fn, nil).CanFallOffEnd() {
+		t.Fatal("expected missing else branch to fall off the end")
+	}
+}
+
+func TestBreakLevelsLeaveOuterLoop(t *testing.T) {
+	fn := parseFunction(t, `<?php
+function f($rows) {
+    while (true) {
+        foreach ($rows as $row) {
+            break 2;
+            skipped();
+        }
+        inner();
+    }
+    return 1;
+}`)
+	g := ForFunction(fn, nil)
+	if g.Reachable(findCall(t, fn.Body, "skipped")) {
+		t.Fatal("expected statement after break to be unreachable")
+	}
+	if !g.Reachable(findCall(t, fn.Body, "inner")) {
+		t.Fatal("expected statement after inner loop to be reachable when the loop runs zero times")
+	}
+	if g.CanFallOffEnd() {
+		t.Fatal("expected return after loop to be the only exit")
+	}
+	if !g.CanReturn() {
+		t.Fatal("expected break 2 to reach the return after the outer loop")
+	}
+}
+
+func TestInfiniteLoopWithoutBreakNeverCompletes(t *testing.T) {
+	fn := parseFunction(t, `<?php
+function f() {
+    while (true) {
+        work();
+        continue;
+    }
+    after();
+}`)
+	g := ForFunction(fn, nil)
+	if g.Reachable(findCall(t, fn.Body, "after")) {
+		t.Fatal("expected code after while(true) without break to be unreachable")
+	}
+	if g.CanReturn() {
+		t.Fatal("expected infinite loop to never return")
+	}
+}
+
+func TestForWithoutConditionIsInfinite(t *testing.T) {
+	fn := parseFunction(t, `<?php
+function f() {
+    for (;;) {
+        work();
+    }
+    after();
+}`)
+	g := ForFunction(fn, nil)
+	if g.Reachable(findCall(t, fn.Body, "after")) {
+		t.Fatal("expected code after for(;;) without break to be unreachable")
+	}
+
+	fn = parseFunction(t, `<?php
+function f($items) {
+    for ($i = 0; ; $i++) {
+        if ($i > count($items)) {
+            break;
+        }
+    }
+    after();
+}`)
+	g = ForFunction(fn, nil)
+	if !g.Reachable(findCall(t, fn.Body, "after")) {
+		t.Fatal("expected break to leave a for loop without a condition")
+	}
+}
+
+func TestSwitchFallthroughAndDefault(t *testing.T) {
+	fn := parseFunction(t, `<?php
+function f($x) {
+    switch ($x) {
+        case 1:
+            one();
+        case 2:
+            return 2;
+        default:
+            throw new Exception();
+    }
+    after();
+}`)
+	g := ForFunction(fn, nil)
+	if !g.Reachable(findCall(t, fn.Body, "one")) {
+		t.Fatal("expected case body to be reachable")
+	}
+	if g.Reachable(findCall(t, fn.Body, "after")) {
+		t.Fatal("expected fallthrough into return and a throwing default to make code after the switch unreachable")
+	}
+}
+
+func TestSwitchWithoutDefaultCanSkipAllCases(t *testing.T) {
+	fn := parseFunction(t, `<?php
+function f($x) {
+    switch ($x) {
+        case 1:
+            return 1;
+    }
+    after();
+}`)
+	if !ForFunction(fn, nil).Reachable(findCall(t, fn.Body, "after")) {
+		t.Fatal("expected code after a switch without default to be reachable")
+	}
+}
+
+func TestTryFinallyRoutesReturnThroughFinally(t *testing.T) {
+	fn := parseFunction(t, `<?php
+function f() {
+    try {
+        return compute();
+    } finally {
+        cleanup();
+    }
+    after();
+}`)
+	g := ForFunction(fn, nil)
+	if !g.Reachable(findCall(t, fn.Body, "cleanup")) {
+		t.Fatal("expected finally to run on return")
+	}
+	if g.Reachable(findCall(t, fn.Body, "after")) {
+		t.Fatal("expected code after try/finally whose body returns to be unreachable")
+	}
+	if g.CanFallOffEnd() {
+		t.Fatal("expected function to return through finally")
+	}
+}
+
+func TestCatchMakesCodeAfterTryReachable(t *testing.T) {
+	fn := parseFunction(t, `<?php
+function f() {
+    try {
+        return compute();
+    } catch (Exception $e) {
+        report();
+    }
+    after();
+}`)
+	g := ForFunction(fn, nil)
+	if !g.Reachable(findCall(t, fn.Body, "report")) {
+		t.Fatal("expected catch body to be reachable")
+	}
+	if !g.Reachable(findCall(t, fn.Body, "after")) {
+		t.Fatal("expected code after a completing catch to be reachable")
+	}
+}
+
+func TestGotoSkipsStatements(t *testing.T) {
+	fn := parseFunction(t, `<?php
+function f() {
+    goto done;
+    skipped();
+    done:
+    reached();
+}`)
+	g := ForFunction(fn, nil)
+	if g.Reachable(findCall(t, fn.Body, "skipped")) {
+		t.Fatal("expected statement after goto to be unreachable")
+	}
+	if !g.Reachable(findCall(t, fn.Body, "reached")) {
+		t.Fatal("expected statement after label to be reachable")
+	}
+}
+
+func TestExitAndNeverReturningCallsTerminate(t *testing.T) {
+	fn := parseFunction(t, `<?php
+function f($x) {
+    if ($x) {
+        abort();
+        afterAbort();
+    }
+    exit(1);
+    afterExit();
+}`)
+	g := ForFunction(fn, &Options{NeverReturns: func(call ast.Node) bool {
+		c, ok := call.(*ast.FunctionCallNode)
+		if !ok {
+			return false
+		}
+		ident, ok := c.Name.(*ast.IdentifierNode)
+		return ok && ident.Value == "abort"
+	}})
+	if g.Reachable(findCall(t, fn.Body, "afterAbort")) {
+		t.Fatal("expected code after never-returning call to be unreachable")
+	}
+	if g.Reachable(findCall(t, fn.Body, "afterExit")) {
+		t.Fatal("expected code after exit to be unreachable")
+	}
+	if g.CanReturn() {
+		t.Fatal("expected function to never return")
+	}
+}
+
+func TestMatchWithOnlyThrowingArmsTerminates(t *testing.T) {
+	fn := parseFunction(t, `<?php
+function f($x) {
+    $y = match ($x) {
+        1 => throw new Exception(),
+        default => throw new LogicException(),
+    };
+    after();
+}`)
+	g := ForFunction(fn, nil)
+	if g.Reachable(findCall(t, fn.Body, "after")) {
+		t.Fatal("expected code after a match whose arms all throw to be unreachable")
+	}
+}
+
+func TestMatchWithValueArmCompletes(t *testing.T) {
+	fn := parseFunction(t, `<?php
+function f($x) {
+    return match ($x) {
+        1 => 'one',
+        default => throw new LogicException(),
+    };
+}`)
+	g := ForFunction(fn, nil)
+	if !g.CanReturn() || g.CanFallOffEnd() {
+		t.Fatal("expected match with a value arm to return")
+	}
+}
+
+func TestArrowFunctionAlwaysReturns(t *testing.T) {
+	g := ForFunction(&ast.ArrowFunctionNode{Expr: &ast.IntegerNode{Value: 1}}, nil)
+	if g == nil || g.CanFallOffEnd() || !g.CanReturn() {
+		t.Fatal("expected arrow function to return its expression")
+	}
+}
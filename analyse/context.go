@@ -1,6 +1,7 @@
 package analyse
 
 import (
+	"github.com/ayanozturk/go-php-parser/analyse/cfg"
 	"github.com/ayanozturk/go-php-parser/ast"
 	"strings"
 )
@@ -11,6 +12,7 @@ type SymbolResolver interface {
 	ConstantExists(name string) bool
 	ResolveClass(name string) (ResolvedClass, bool)
 	ResolveMethod(className, methodName string) (ResolvedMethod, bool)
+	ResolveStaticMethod(className, methodName string) (ResolvedMethod, bool)
 	ResolveProperty(className, propertyName string) (ResolvedProperty, bool)
 	ResolveFunction(name string) (ResolvedFunction, bool)
 }
@@ -22,11 +24,19 @@ type ResolvedClass struct {
 	TemplateParams        []string
 	GenericParents        []ResolvedGenericParent
 	Traits                []string
+	TraitRules            []ResolvedTraitRule
 	Kind                  string
 	Final                 bool
 	Abstract              bool
 	Readonly              bool
 	ConsistentConstructor bool
+	// Mixins, MagicMethods and MagicProperties come from the @mixin, @method
+	// and @property tags of the class PHPDoc.
+	Mixins          []string
+	MagicMethods    []ResolvedMethod
+	MagicProperties []ResolvedProperty
+	// Cases lists the cases of an enum in declaration order.
+	Cases []string
 }
 
 // ResolvedGenericParent binds a class-like inheritance target to the type
@@ -36,6 +46,16 @@ type ResolvedGenericParent struct {
 	TypeArguments []string
 }
 
+// ResolvedTraitRule is an insteadof or as rule of a trait use block, with
+// trait names resolved.
+type ResolvedTraitRule struct {
+	Trait      string
+	Method     string
+	InsteadOf  []string
+	Alias      string
+	Visibility string
+}
+
 type ResolvedMethod struct {
 	Name           string
 	DeclaringClass string
@@ -45,6 +65,12 @@ type ResolvedMethod struct {
 	IsStatic       bool
 	Abstract       bool
 	Final          bool
+	// Templates are the method's own @template parameters, bound per call
+	// by instantiateTemplates.
+	Templates []ResolvedTemplate
+	// Magic methods are not declared by the class itself but by an @method
+	// tag, a @mixin class or a __call/__callStatic handler.
+	Magic bool
 }
 
 type ResolvedProperty struct {
@@ -53,6 +79,12 @@ type ResolvedProperty struct {
 	Visibility string
 	IsStatic   bool
 	Readonly   bool
+	// Magic properties come from an @property tag, a @mixin class or a
+	// __get/__set handler.
+	Magic bool
+	// Initialized is set for natively typed properties that always hold a
+	// value: those with a default value and promoted constructor parameters.
+	Initialized bool
 }
 
 type ResolvedConstant struct {
@@ -67,6 +99,7 @@ type ResolvedFunction struct {
 	Name       string
 	ReturnType string
 	Params     []ResolvedParam
+	Templates  []ResolvedTemplate
 }
 
 type ResolvedParam struct {
@@ -74,6 +107,7 @@ type ResolvedParam struct {
 	Type       string
 	HasDefault bool
 	IsVariadic bool
+	ByRef      bool
 }
 
 type AnalysisContext struct {
@@ -87,6 +121,10 @@ type AnalysisContext struct {
 	hasFileTypeContext  bool
 	functionScopeByNode map[*ast.FunctionNode]*functionScope
 	classScopeByNode    map[*ast.ClassNode]classScopeData
+	controlFlowByNode   map[*ast.FunctionNode]*cfg.Graph
+	typeFlowByNode      map[*ast.FunctionNode]*typeFlow
+	variableIssues      []AnalysisIssue
+	hasVariableIssues   bool
 }
 
 func analysisFileTypeContext(ctx *AnalysisContext, nodes []ast.Node) fileTypeContext {
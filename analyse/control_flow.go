@@ -0,0 +1,62 @@
+package analyse
+
+import (
+	"github.com/ayanozturk/go-php-parser/analyse/cfg"
+	"github.com/ayanozturk/go-php-parser/ast"
+	"strings"
+)
+
+// analysisControlFlow returns the cached control-flow graph of a function,
+// method or closure body. Calls whose resolved return type is never are
+// treated as terminating. It returns nil for bodiless functions.
+func analysisControlFlow(ctx *AnalysisContext, class *ast.ClassNode, fn *ast.FunctionNode, typeCtx fileTypeContext) *cfg.Graph {
+	if fn == nil || fn.Body == nil {
+		return nil
+	}
+	if ctx != nil {
+		if graph, ok := ctx.controlFlowByNode[fn]; ok {
+			return graph
+		}
+	}
+	graph := cfg.ForFunction(fn, &cfg.Options{NeverReturns: neverReturningCall(ctx, class, fn, typeCtx)})
+	if ctx != nil {
+		if ctx.controlFlowByNode == nil {
+			ctx.controlFlowByNode = make(map[*ast.FunctionNode]*cfg.Graph)
+		}
+		ctx.controlFlowByNode[fn] = graph
+	}
+	return graph
+}
+
+func neverReturningCall(ctx *AnalysisContext, class *ast.ClassNode, fn *ast.FunctionNode, typeCtx fileTypeContext) func(ast.Node) bool {
+	if ctx == nil || ctx.Resolver == nil {
+		return nil
+	}
+	var scope *functionScope
+	return func(call ast.Node) bool {
+		switch n := call.(type) {
+		case *ast.FunctionCallNode:
+			name := functionCallName(n)
+			if name == "" {
+				return false
+			}
+			if className, methodName, ok := strings.Cut(name, "::"); ok {
+				resolved := resolveClassLikeForCall(className, class, typeCtx, ctx)
+				method, found := resolveStaticCallMethod(ctx, className, resolved, methodName)
+				return found && isNeverType(method.ReturnType)
+			}
+			function, found := ctx.Resolver.ResolveFunction(resolveFunctionNameForCall(name, typeCtx, ctx))
+			return found && isNeverType(function.ReturnType)
+		case *ast.MethodCallNode:
+			if scope == nil {
+				scope = analysisFunctionScope(ctx, class, fn, typeCtx)
+			}
+			return inferMethodCallType(n, scope, ctx).String() == "never"
+		}
+		return false
+	}
+}
+
+func isNeverType(raw string) bool {
+	return strings.EqualFold(strings.TrimPrefix(strings.TrimSpace(raw), `\`), "never")
+}
@@ -0,0 +1,586 @@
+package analyse
+
+import (
+	"github.com/ayanozturk/go-php-parser/analyse/cfg"
+	"github.com/ayanozturk/go-php-parser/ast"
+	"sort"
+	"strings"
+)
+
+// definedness is what is known about a variable at one program point.
+// Variables missing from an assignmentState are undefined.
+type definedness uint8
+
+const (
+	maybeDefined definedness = iota + 1
+	definitelyDefined
+)
+
+// assignmentState is the set of variables assigned on the paths reaching a
+// program point. dynamic is set once extract(), include or a similar
+// construct may have defined arbitrary variables.
+type assignmentState struct {
+	vars    map[string]definedness
+	dynamic bool
+}
+
+func newAssignmentState() *assignmentState {
+	return &assignmentState{vars: map[string]definedness{}}
+}
+
+func (s *assignmentState) clone() *assignmentState {
+	out := &assignmentState{vars: make(map[string]definedness, len(s.vars)), dynamic: s.dynamic}
+	for name, d := range s.vars {
+		out.vars[name] = d
+	}
+	return out
+}
+
+// merge joins two states at a control-flow merge point. A nil state stands
+// for a path that has not been reached.
+func mergeAssignmentStates(a, b *assignmentState) *assignmentState {
+	if a == nil {
+		if b == nil {
+			return nil
+		}
+		return b.clone()
+	}
+	if b == nil {
+		return a.clone()
+	}
+	out := &assignmentState{vars: make(map[string]definedness, len(a.vars)), dynamic: a.dynamic || b.dynamic}
+	for name, d := range a.vars {
+		if d == definitelyDefined && b.vars[name] == definitelyDefined {
+			out.vars[name] = definitelyDefined
+		} else {
+			out.vars[name] = maybeDefined
+		}
+	}
+	for name := range b.vars {
+		if _, ok := out.vars[name]; !ok {
+			out.vars[name] = maybeDefined
+		}
+	}
+	return out
+}
+
+func (s *assignmentState) equal(other *assignmentState) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+	if s.dynamic != other.dynamic || len(s.vars) != len(other.vars) {
+		return false
+	}
+	for name, d := range s.vars {
+		if other.vars[name] != d {
+			return false
+		}
+	}
+	return true
+}
+
+// variableUse records how a variable read was seen across every copy of the
+// block it appears in (finally bodies are built more than once).
+type variableUse struct {
+	name         string
+	pos          ast.Position
+	mayDefined   bool
+	mayUndefined bool
+}
+
+// definiteAssignment runs a forward "defined / maybe defined / undefined"
+// analysis over one control-flow graph and collects every variable read.
+type definiteAssignment struct {
+	ctx     *AnalysisContext
+	typeCtx fileTypeContext
+	class   *ast.ClassNode
+	fn      *ast.FunctionNode
+	scope   *functionScope
+	// split holds the nodes the graph already placed in blocks, so match
+	// expressions it modelled arm by arm are not evaluated twice.
+	split map[ast.Node]bool
+	uses  map[ast.Node]*variableUse
+	order []ast.Node
+	// recording is off while the fixpoint is computed.
+	recording bool
+}
+
+var superglobalVariables = map[string]bool{
+	"GLOBALS": true, "_SERVER": true, "_GET": true, "_POST": true, "_FILES": true,
+	"_COOKIE": true, "_SESSION": true, "_REQUEST": true, "_ENV": true,
+}
+
+// analyseDefiniteAssignment reports the variable reads in graph with how
+// defined each one is. entry is the state on entry to the body.
+func analyseDefiniteAssignment(graph *cfg.Graph, entry *assignmentState, class *ast.ClassNode, fn *ast.FunctionNode, typeCtx fileTypeContext, ctx *AnalysisContext) []*variableUse {
+	if graph == nil {
+		return nil
+	}
+	da := &definiteAssignment{
+		ctx:     ctx,
+		typeCtx: typeCtx,
+		class:   class,
+		fn:      fn,
+		split:   map[ast.Node]bool{},
+		uses:    map[ast.Node]*variableUse{},
+	}
+	for _, block := range graph.Blocks {
+		for _, node := range block.Nodes {
+			da.split[node] = true
+		}
+	}
+
+	in := make(map[*cfg.Block]*assignmentState, len(graph.Blocks))
+	in[graph.Entry] = entry
+	worklist := []*cfg.Block{graph.Entry}
+	queued := map[*cfg.Block]bool{graph.Entry: true}
+	for len(worklist) > 0 {
+		block := worklist[0]
+		worklist = worklist[1:]
+		queued[block] = false
+		out := da.transferBlock(block, in[block].clone())
+		for _, edge := range block.Succs {
+			next := da.refine(out, block, edge)
+			merged := mergeAssignmentStates(in[edge.To], next)
+			if in[edge.To] != nil && merged.equal(in[edge.To]) {
+				continue
+			}
+			in[edge.To] = merged
+			if !queued[edge.To] {
+				queued[edge.To] = true
+				worklist = append(worklist, edge.To)
+			}
+		}
+	}
+
+	da.recording = true
+	for _, block := range graph.Blocks {
+		if state := in[block]; state != nil && block.Reachable {
+			da.transferBlock(block, state.clone())
+		}
+	}
+
+	uses := make([]*variableUse, 0, len(da.order))
+	for _, node := range da.order {
+		uses = append(uses, da.uses[node])
+	}
+	sort.SliceStable(uses, func(i, j int) bool {
+		if uses[i].pos.Line != uses[j].pos.Line {
+			return uses[i].pos.Line < uses[j].pos.Line
+		}
+		return uses[i].pos.Column < uses[j].pos.Column
+	})
+	return uses
+}
+
+// refine applies what a branch edge implies, such as isset() holding on the
+// true edge of its condition.
+func (da *definiteAssignment) refine(state *assignmentState, block *cfg.Block, edge *cfg.Edge) *assignmentState {
+	if block.Cond == nil || (edge.Kind != cfg.True && edge.Kind != cfg.False) {
+		return state
+	}
+	names := guardedVariables(block.Cond, edge.Kind == cfg.True)
+	if len(names) == 0 {
+		return state
+	}
+	refined := state.clone()
+	for _, name := range names {
+		refined.vars[name] = definitelyDefined
+	}
+	return refined
+}
+
+// guardedVariables returns the variables a condition proves defined when it
+// evaluates to truth: isset($x) when true, empty($x) when false, and their
+// combinations with !, && and ||.
+func guardedVariables(cond ast.Node, truth bool) []string {
+	switch n := cond.(type) {
+	case *ast.UnaryExpr:
+		if n.Operator == "!" {
+			return guardedVariables(n.Operand, !truth)
+		}
+	case *ast.BinaryExpr:
+		switch strings.ToLower(n.Operator) {
+		case "&&", "and":
+			if truth {
+				return append(guardedVariables(n.Left, true), guardedVariables(n.Right, true)...)
+			}
+		case "||", "or":
+			if !truth {
+				return append(guardedVariables(n.Left, false), guardedVariables(n.Right, false)...)
+			}
+		}
+	case *ast.FunctionCallNode:
+		name := strings.ToLower(functionCallName(n))
+		if (name == "isset" && truth) || (name == "empty" && !truth) {
+			var names []string
+			for _, arg := range n.Args {
+				if base := baseVariableName(arg); base != "" {
+					names = append(names, base)
+				}
+			}
+			return names
+		}
+	}
+	return nil
+}
+
+// baseVariableName returns the variable at the root of $x, $x[...] or
+// $x->y chains.
+func baseVariableName(node ast.Node) string {
+	switch n := node.(type) {
+	case *ast.VariableNode:
+		return n.Name
+	case *ast.ArrayAccessNode:
+		return baseVariableName(n.Var)
+	case *ast.PropertyFetchNode:
+		return baseVariableName(n.Object)
+	}
+	return ""
+}
+
+func (da *definiteAssignment) transferBlock(block *cfg.Block, state *assignmentState) *assignmentState {
+	for _, node := range block.Nodes {
+		da.statement(node, state)
+	}
+	return state
+}
+
+func (da *definiteAssignment) statement(node ast.Node, state *assignmentState) {
+	switch n := node.(type) {
+	case *ast.ExpressionStmt:
+		da.expr(n.Expr, state)
+	case *ast.ReturnNode:
+		da.expr(n.Expr, state)
+	case *ast.ThrowNode:
+		da.expr(n.Expr, state)
+	case *ast.StaticVarDeclNode:
+		for _, entry := range n.Vars {
+			da.expr(entry.Init, state)
+			state.vars[entry.Name] = definitelyDefined
+		}
+	case *ast.GlobalNode:
+		for _, v := range n.Vars {
+			state.vars[v.Name] = definitelyDefined
+		}
+	case *ast.ForeachNode:
+		// Marker at the start of the loop body.
+		da.assign(n.KeyVar, state)
+		da.assign(n.ValueVar, state)
+	case *ast.CatchNode:
+		if n.Variable != "" {
+			state.vars[strings.TrimPrefix(n.Variable, "$")] = definitelyDefined
+		}
+	case *ast.BlockNode:
+		// Marker for a for loop, whose control expressions are not parsed.
+		for _, v := range n.ControlVars {
+			state.vars[v.Name] = definitelyDefined
+		}
+	case *ast.FunctionNode, *ast.ClassNode, *ast.InterfaceNode, *ast.TraitNode, *ast.EnumNode:
+		// Declarations have their own scope.
+	default:
+		da.expr(n, state)
+	}
+}
+
+func (da *definiteAssignment) use(node ast.Node, name string, state *assignmentState) {
+	if !da.recording || superglobalVariables[name] || state.dynamic {
+		return
+	}
+	use := da.uses[node]
+	if use == nil {
+		use = &variableUse{name: name, pos: node.GetPos()}
+		da.uses[node] = use
+		da.order = append(da.order, node)
+	}
+	switch state.vars[name] {
+	case definitelyDefined:
+		use.mayDefined = true
+	case maybeDefined:
+		use.mayDefined = true
+		use.mayUndefined = true
+	default:
+		use.mayUndefined = true
+	}
+}
+
+func (da *definiteAssignment) expr(node ast.Node, state *assignmentState) {
+	switch n := node.(type) {
+	case nil:
+	case *ast.VariableNode:
+		da.use(n, n.Name, state)
+	case *ast.AssignmentNode:
+		da.expr(n.Right, state)
+		da.assign(n.Left, state)
+	case *ast.FunctionCallNode:
+		da.call(n, state)
+	case *ast.MethodCallNode:
+		da.expr(n.Object, state)
+		da.args(n.Args, da.methodParams(n), state)
+	case *ast.NewNode:
+		da.expr(n.ClassExpr, state)
+		da.args(n.Args, nil, state)
+	case *ast.PropertyFetchNode:
+		da.expr(n.Object, state)
+	case *ast.ArrayAccessNode:
+		da.expr(n.Var, state)
+		da.expr(n.Index, state)
+	case *ast.BinaryExpr:
+		da.binary(n, state)
+	case *ast.UnaryExpr:
+		switch strings.ToLower(n.Operator) {
+		case "include", "include_once", "require", "require_once":
+			da.expr(n.Operand, state)
+			state.dynamic = true
+		case "break", "continue":
+		default:
+			da.expr(n.Operand, state)
+		}
+	case *ast.TernaryExpr:
+		da.expr(n.Condition, state)
+		whenTrue := state.clone()
+		for _, name := range guardedVariables(n.Condition, true) {
+			whenTrue.vars[name] = definitelyDefined
+		}
+		if n.IfTrue != nil {
+			da.expr(n.IfTrue, whenTrue)
+		}
+		whenFalse := state.clone()
+		for _, name := range guardedVariables(n.Condition, false) {
+			whenFalse.vars[name] = definitelyDefined
+		}
+		da.expr(n.IfFalse, whenFalse)
+		*state = *mergeAssignmentStates(whenTrue, whenFalse)
+	case *ast.MatchNode:
+		if da.split[n.Condition] {
+			// The graph already models this match arm by arm.
+			return
+		}
+		da.expr(n.Condition, state)
+		var merged *assignmentState
+		for _, arm := range n.Arms {
+			armState := state.clone()
+			for _, condition := range arm.Conditions {
+				da.expr(condition, armState)
+			}
+			da.expr(arm.Body, armState)
+			merged = mergeAssignmentStates(merged, armState)
+		}
+		if merged != nil {
+			*state = *merged
+		}
+	case *ast.ArrayNode:
+		for _, element := range n.Elements {
+			da.expr(element, state)
+		}
+	case *ast.ArrayItemNode:
+		da.expr(n.Key, state)
+		if n.ByRef {
+			da.assign(n.Value, state)
+		} else {
+			da.expr(n.Value, state)
+		}
+	case *ast.ConcatNode:
+		for _, part := range n.Parts {
+			da.expr(part, state)
+		}
+	case *ast.NamedArgumentNode:
+		da.expr(n.Value, state)
+	case *ast.UnpackedArgumentNode:
+		da.expr(n.Expr, state)
+	case *ast.TypeCastNode:
+		da.expr(n.Expr, state)
+	case *ast.YieldNode:
+		da.expr(n.Key, state)
+		da.expr(n.Value, state)
+	case *ast.ThrowNode:
+		da.expr(n.Expr, state)
+	case *ast.ExpressionStmt:
+		da.expr(n.Expr, state)
+	}
+}
+
+func (da *definiteAssignment) binary(n *ast.BinaryExpr, state *assignmentState) {
+	switch strings.ToLower(n.Operator) {
+	case "??":
+		// The left operand is checked like isset().
+		da.quiet(n.Left, state)
+		rhs := state.clone()
+		da.expr(n.Right, rhs)
+		*state = *mergeAssignmentStates(state, rhs)
+	case "&&", "and", "||", "or":
+		da.expr(n.Left, state)
+		evaluatesRight := strings.ToLower(n.Operator) == "&&" || strings.ToLower(n.Operator) == "and"
+		rhs := state.clone()
+		for _, name := range guardedVariables(n.Left, evaluatesRight) {
+			rhs.vars[name] = definitelyDefined
+		}
+		da.expr(n.Right, rhs)
+		*state = *mergeAssignmentStates(state, rhs)
+	default:
+		da.expr(n.Left, state)
+		da.expr(n.Right, state)
+	}
+}
+
+// quiet evaluates the sub-expressions of an isset()-like operand without
+// reporting the variable it checks.
+func (da *definiteAssignment) quiet(node ast.Node, state *assignmentState) {
+	switch n := node.(type) {
+	case *ast.ArrayAccessNode:
+		da.quiet(n.Var, state)
+		da.expr(n.Index, state)
+	case *ast.PropertyFetchNode:
+		da.quiet(n.Object, state)
+	case *ast.VariableNode:
+	default:
+		da.expr(node, state)
+	}
+}
+
+func (da *definiteAssignment) call(n *ast.FunctionCallNode, state *assignmentState) {
+	name := functionCallName(n)
+	switch strings.ToLower(name) {
+	case "isset", "empty":
+		for _, arg := range n.Args {
+			da.quiet(argumentValue(arg), state)
+		}
+		return
+	case "unset":
+		for _, arg := range n.Args {
+			if v, ok := argumentValue(arg).(*ast.VariableNode); ok {
+				delete(state.vars, v.Name)
+				continue
+			}
+			da.quiet(argumentValue(arg), state)
+		}
+		return
+	case "compact":
+		for _, arg := range n.Args {
+			if variableName, ok := stringLiteralValue(argumentValue(arg)); ok {
+				da.use(arg, variableName, state)
+				continue
+			}
+			da.expr(argumentValue(arg), state)
+		}
+		return
+	case "extract", "get_defined_vars":
+		da.args(n.Args, nil, state)
+		if strings.EqualFold(name, "extract") {
+			state.dynamic = true
+		}
+		return
+	case "parse_str":
+		da.args(n.Args, da.functionParams(name), state)
+		if len(n.Args) < 2 {
+			state.dynamic = true
+		}
+		return
+	}
+	if name == "" {
+		da.expr(n.Name, state)
+	}
+	da.args(n.Args, da.functionParams(name), state)
+}
+
+// args evaluates call arguments; arguments bound to by-reference parameters
+// are assignments rather than reads.
+func (da *definiteAssignment) args(args []ast.Node, params []ResolvedParam, state *assignmentState) {
+	for i, arg := range args {
+		if param, ok := parameterForArgument(params, i, arg); ok && param.ByRef {
+			da.assign(argumentValue(arg), state)
+			continue
+		}
+		da.expr(arg, state)
+	}
+}
+
+func parameterForArgument(params []ResolvedParam, index int, arg ast.Node) (ResolvedParam, bool) {
+	if named, ok := arg.(*ast.NamedArgumentNode); ok {
+		for _, param := range params {
+			if param.Name == named.Name {
+				return param, true
+			}
+		}
+		return ResolvedParam{}, false
+	}
+	if index < len(params) {
+		return params[index], true
+	}
+	if len(params) > 0 && params[len(params)-1].IsVariadic {
+		return params[len(params)-1], true
+	}
+	return ResolvedParam{}, false
+}
+
+func (da *definiteAssignment) functionParams(name string) []ResolvedParam {
+	if name == "" || da.ctx == nil || da.ctx.Resolver == nil {
+		return nil
+	}
+	if className, methodName, ok := strings.Cut(name, "::"); ok {
+		if strings.HasPrefix(className, "$") {
+			return nil
+		}
+		method, found := resolveStaticCallMethod(da.ctx, className, resolveClassLikeForCall(className, da.class, da.typeCtx, da.ctx), methodName)
+		if !found {
+			return nil
+		}
+		return method.Params
+	}
+	fn, found := da.ctx.Resolver.ResolveFunction(resolveFunctionNameForCall(name, da.typeCtx, da.ctx))
+	if !found {
+		return nil
+	}
+	return fn.Params
+}
+
+func (da *definiteAssignment) methodParams(n *ast.MethodCallNode) []ResolvedParam {
+	if da.ctx == nil || da.ctx.Resolver == nil {
+		return nil
+	}
+	className := methodCallClassName(n.Object, da.typeCtx)
+	if receiver, ok := n.Object.(*ast.VariableNode); ok {
+		if receiver.Name == "this" {
+			className = currentClassName(da.class, da.typeCtx)
+		} else if da.fn != nil {
+			if da.scope == nil {
+				da.scope = analysisFunctionScope(da.ctx, da.class, da.fn, da.typeCtx)
+			}
+			className, _ = inferType(receiver, da.scope, da.ctx).SingleClassName()
+		}
+	}
+	if className == "" {
+		return nil
+	}
+	method, ok := da.ctx.Resolver.ResolveMethod(className, n.Method)
+	if !ok {
+		return nil
+	}
+	return method.Params
+}
+
+// assign marks the variables written by an assignment target as defined.
+// Array writes create the array; property writes read their object.
+func (da *definiteAssignment) assign(target ast.Node, state *assignmentState) {
+	switch n := target.(type) {
+	case *ast.VariableNode:
+		state.vars[n.Name] = definitelyDefined
+	case *ast.ArrayAccessNode:
+		da.expr(n.Index, state)
+		if v, ok := n.Var.(*ast.VariableNode); ok {
+			state.vars[v.Name] = definitelyDefined
+			return
+		}
+		da.assign(n.Var, state)
+	case *ast.PropertyFetchNode:
+		da.expr(n.Object, state)
+	case *ast.ArrayNode:
+		// list() and [...] destructuring.
+		for _, element := range n.Elements {
+			da.assign(element, state)
+		}
+	case *ast.ArrayItemNode:
+		da.expr(n.Key, state)
+		da.assign(n.Value, state)
+	}
+}
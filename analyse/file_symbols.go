@@ -0,0 +1,90 @@
+package analyse
+
+import (
+	"sort"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+)
+
+// FileSymbols holds the declarations of a single file. A ProjectIndex is
+// merged from them, and they contain no AST, so they can be cached between
+// runs. Maps are keyed like the ProjectIndex maps.
+type FileSymbols struct {
+	Classes     []FileClass
+	Methods     map[string]map[string]ResolvedMethod
+	Properties  map[string]map[string]ResolvedProperty
+	ClassConsts map[string]map[string]ResolvedConstant
+	Functions   map[string]ResolvedFunction
+	Constants   []string
+	// Duplicates are classes declared twice within the file.
+	Duplicates []DuplicateSymbol
+}
+
+// FileClass is a class-like declared by a file and where it was declared.
+type FileClass struct {
+	Class ResolvedClass
+	Pos   ast.Position
+}
+
+// CollectFileSymbols indexes the declarations of one parsed file.
+func CollectFileSymbols(filename string, nodes []ast.Node) *FileSymbols {
+	file := newEmptyProjectIndex()
+	file.indexNodes(filename, nodes, collectFileTypeContext(nodes), "")
+	symbols := &FileSymbols{
+		Methods:     file.Methods,
+		Properties:  file.Properties,
+		ClassConsts: file.ClassConsts,
+		Functions:   file.Functions,
+		Constants:   sortedKeys(file.Constants),
+		Duplicates:  file.Duplicates,
+	}
+	for _, key := range sortedKeys(file.Classes) {
+		symbols.Classes = append(symbols.Classes, FileClass{Class: file.Classes[key], Pos: file.classPos[key]})
+	}
+	return symbols
+}
+
+// AddFileSymbols merges the declarations of a file into the index. Classes
+// already declared by another file are recorded as duplicates; their members
+// are merged like members declared in a single pass over both files.
+func (idx *ProjectIndex) AddFileSymbols(filename string, symbols *FileSymbols) {
+	if symbols == nil {
+		return
+	}
+	for _, declared := range symbols.Classes {
+		idx.addClass(filename, declared.Class, declared.Pos)
+	}
+	for _, duplicate := range symbols.Duplicates {
+		duplicate.File = filename
+		idx.Duplicates = append(idx.Duplicates, duplicate)
+	}
+	mergeMembers(idx.Methods, symbols.Methods)
+	mergeMembers(idx.Properties, symbols.Properties)
+	mergeMembers(idx.ClassConsts, symbols.ClassConsts)
+	for key, fn := range symbols.Functions {
+		idx.Functions[key] = fn
+	}
+	for _, key := range symbols.Constants {
+		idx.Constants[key] = struct{}{}
+	}
+}
+
+func mergeMembers[V any](dst, src map[string]map[string]V) {
+	for class, members := range src {
+		if dst[class] == nil {
+			dst[class] = make(map[string]V, len(members))
+		}
+		for name, member := range members {
+			dst[class][name] = member
+		}
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -71,6 +71,12 @@ func normalizeTemplateAwareType(raw string, ctx fileTypeContext, templates map[s
 				intersections[j] = atom
 				continue
 			}
+			// Generic types such as class-string<T> keep their arguments, so
+			// templates can be inferred from them.
+			if mentionsTemplate(atom, templates) {
+				intersections[j] = strings.TrimPrefix(atom, `\`)
+				continue
+			}
 			intersections[j] = normalizeTypeWithContext(atom, ctx)
 		}
 		parts[i] = strings.Join(intersections, "&")
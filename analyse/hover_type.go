@@ -76,8 +76,8 @@ func findHoverTypeMatch(nodes []ast.Node, query hoverTypeQuery, ctx *AnalysisCon
 				walk(methodNode, n)
 			}
 		case *ast.FunctionNode:
-			scope := analysisFunctionScope(ctx, class, n, fileCtx)
-			walkStatementsForHoverTypes(n.Body, scope, ctx, query, &best)
+			flow := analysisTypeFlow(ctx, class, n, fileCtx)
+			walkStatementsForHoverTypes(n.Body, flow, ctx, query, &best)
 		case *ast.NamespaceNode:
 			for _, child := range n.Body {
 				walk(child, class)
@@ -92,38 +92,37 @@ func findHoverTypeMatch(nodes []ast.Node, query hoverTypeQuery, ctx *AnalysisCon
 	return best
 }
 
-func walkStatementsForHoverTypes(nodes []ast.Node, scope *functionScope, ctx *AnalysisContext, query hoverTypeQuery, best *hoverTypeMatch) {
+func walkStatementsForHoverTypes(nodes []ast.Node, flow *typeFlow, ctx *AnalysisContext, query hoverTypeQuery, best *hoverTypeMatch) {
 	for _, node := range nodes {
 		switch n := node.(type) {
 		case *ast.ExpressionStmt:
-			walkExprForHoverTypes(n.Expr, scope, ctx, query, best)
-			applyExpressionScope(scope, n.Expr, ctx)
+			walkExprForHoverTypes(n.Expr, flow.scopeAt(n), ctx, query, best)
 		case *ast.AssignmentNode:
+			scope := flow.scopeAt(n)
 			walkExprForHoverTypes(n.Right, scope, ctx, query, best)
-			applyAssignmentScope(scope, n, ctx)
-			walkExprForHoverTypes(n.Left, scope, ctx, query, best)
+			assignedScope := scope.clone()
+			applyAssignmentScope(assignedScope, n, ctx)
+			walkExprForHoverTypes(n.Left, assignedScope, ctx, query, best)
 		case *ast.ReturnNode:
-			walkExprForHoverTypes(n.Expr, scope, ctx, query, best)
+			walkExprForHoverTypes(n.Expr, flow.scopeAt(n), ctx, query, best)
 		case *ast.IfNode:
-			walkExprForHoverTypes(n.Condition, scope, ctx, query, best)
-			walkStatementsForHoverTypes(n.Body, scopeForConditionTrue(scope, n.Condition), ctx, query, best)
+			walkExprForHoverTypes(n.Condition, flow.scopeAt(n.Condition), ctx, query, best)
+			walkStatementsForHoverTypes(n.Body, flow, ctx, query, best)
 			for _, elseif := range n.ElseIfs {
-				walkExprForHoverTypes(elseif.Condition, scope, ctx, query, best)
-				walkStatementsForHoverTypes(elseif.Body, scopeForConditionTrue(scope, elseif.Condition), ctx, query, best)
+				walkExprForHoverTypes(elseif.Condition, flow.scopeAt(elseif.Condition), ctx, query, best)
+				walkStatementsForHoverTypes(elseif.Body, flow, ctx, query, best)
 			}
 			if n.Else != nil {
-				walkStatementsForHoverTypes(n.Else.Body, scope.clone(), ctx, query, best)
+				walkStatementsForHoverTypes(n.Else.Body, flow, ctx, query, best)
 			}
-			applyTerminatingIfFalseScope(scope, n)
-			applyLazyInitPropertyScope(scope, n, ctx)
 		case *ast.BlockNode:
-			walkStatementsForHoverTypes(n.Statements, scope.clone(), ctx, query, best)
+			walkStatementsForHoverTypes(n.Statements, flow, ctx, query, best)
 		case *ast.WhileNode:
-			walkExprForHoverTypes(n.Condition, scope, ctx, query, best)
-			walkStatementsForHoverTypes(n.Body, scope.clone(), ctx, query, best)
+			walkExprForHoverTypes(n.Condition, flow.scopeAt(n.Condition), ctx, query, best)
+			walkStatementsForHoverTypes(n.Body, flow, ctx, query, best)
 		case *ast.ForeachNode:
-			walkExprForHoverTypes(n.Expr, scope, ctx, query, best)
-			walkStatementsForHoverTypes(n.Body, scope.clone(), ctx, query, best)
+			walkExprForHoverTypes(n.Expr, flow.scopeAt(n.Expr), ctx, query, best)
+			walkStatementsForHoverTypes(n.Body, flow, ctx, query, best)
 		}
 	}
 }
@@ -98,6 +98,31 @@ class TeamEndpoint extends BaseEndpoint {
 	}
 }
 
+func TestInferHoverTypeUsesMagicMemberTypes(t *testing.T) {
+	php := `<?php
+namespace App;
+/**
+ * @property-read Money $total
+ * @method Customer customer()
+ */
+class Order {
+    public function run(): void {
+        $total = $this->total;
+        $customer = $this->customer();
+    }
+}`
+	nodes := parseHoverFixture(t, php)
+	project := BuildProjectIndex(map[string][]ast.Node{"test.php": nodes})
+	ctx := &AnalysisContext{Resolver: project, Project: project}
+
+	if target, ok := InferHoverTargetAtPosition(nodes, 9, 10, "total", ctx); !ok || target.Type != `App\Money` {
+		t.Fatalf("expected @property type, got %#v, %t", target, ok)
+	}
+	if target, ok := InferHoverTargetAtPosition(nodes, 10, 10, "customer", ctx); !ok || target.Type != `App\Customer` {
+		t.Fatalf("expected @method return type, got %#v, %t", target, ok)
+	}
+}
+
 func parseHoverFixture(t *testing.T, php string) []ast.Node {
 	t.Helper()
 	l := lexer.New(php)
@@ -108,3 +133,35 @@ func parseHoverFixture(t *testing.T, php string) []ast.Node {
 	}
 	return nodes
 }
+
+func TestInferHoverTypeFollowsBranchNarrowing(t *testing.T) {
+	php := `<?php
+class Cat {}
+class Dog {}
+class Shelter {
+    public function run(Cat|Dog|null $pet): void {
+        if ($pet instanceof Cat) {
+            echo $pet;
+        } elseif ($pet !== null) {
+            echo $pet;
+        }
+        echo $pet;
+    }
+}`
+	nodes := parseHoverFixture(t, php)
+
+	cases := []struct {
+		line int
+		want string
+	}{
+		{7, "Cat"},
+		{9, "Dog"},
+		{11, "Cat|Dog|null"},
+	}
+	for _, c := range cases {
+		target, ok := InferHoverTargetAtPosition(nodes, c.line, 18, "pet", nil)
+		if !ok || target.Type != c.want {
+			t.Fatalf("line %d: expected hover type %s, got %#v, %t", c.line, c.want, target, ok)
+		}
+	}
+}
@@ -0,0 +1,630 @@
+package analyse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+)
+
+const (
+	impossibleCheckCode   = "PHPStan.DeadCode.ImpossibleCheck"
+	unreachableBranchCode = "PHPStan.DeadCode.UnreachableBranch"
+)
+
+// ImpossibleCheckRule reports conditions whose outcome the declared and
+// flow-narrowed types already decide: instanceof, is_*() and === null checks
+// that always or never hold, isset() on properties that are initialized and
+// not nullable, and elseif, else and match arms that can never be reached.
+//
+// The last condition of an elseif chain, or the last arm of a match (true)
+// without a default, is not reported as always true, since spelling it out
+// is common for readability. Variables that may change behind the type
+// flow's back, because they are passed or captured by reference, are not
+// checked.
+type ImpossibleCheckRule struct{}
+
+func (r *ImpossibleCheckRule) CheckIssues(filename string, nodes []ast.Node, ctx *AnalysisContext) []AnalysisIssue {
+	ctx = ensureLevel0Context(filename, nodes, ctx)
+	checker := &impossibleChecker{
+		filename:   filename,
+		ctx:        ctx,
+		referenced: map[*ast.FunctionNode]map[string]bool{},
+		lastChecks: map[ast.Node]bool{},
+	}
+	walkTypedExpressions(nodes, ctx, func(node ast.Node, at typedPosition) {
+		switch n := node.(type) {
+		case *ast.IfNode:
+			checker.checkIfChain(n, at)
+		case *ast.MatchNode:
+			checker.checkMatch(n, at)
+		case *ast.FunctionCallNode:
+			if strings.EqualFold(functionCallName(n), "isset") {
+				for _, arg := range n.Args {
+					if message, ok := checker.redundantIsset(argumentValue(arg), at); ok {
+						checker.report(impossibleCheckCode, arg, message)
+					}
+				}
+				return
+			}
+		}
+		if value, message, ok := checker.check(node, at); ok && !(value && checker.lastChecks[node]) {
+			checker.report(impossibleCheckCode, node, message)
+		}
+	})
+	return checker.issues
+}
+
+type impossibleChecker struct {
+	filename string
+	ctx      *AnalysisContext
+	// referenced caches the variables of each body that are shared by
+	// reference.
+	referenced map[*ast.FunctionNode]map[string]bool
+	// lastChecks holds the final conditions of elseif chains and match arms,
+	// which are not reported when always true.
+	lastChecks map[ast.Node]bool
+	issues     []AnalysisIssue
+}
+
+func (c *impossibleChecker) report(code string, node ast.Node, message string) {
+	c.issues = append(c.issues, issue(c.filename, node.GetPos(), code, message))
+}
+
+// checkIfChain reports the elseif and else branches that follow a condition
+// that is always true.
+func (c *impossibleChecker) checkIfChain(n *ast.IfNode, at typedPosition) {
+	conditions := []ast.Node{n.Condition}
+	for _, elseif := range n.ElseIfs {
+		conditions = append(conditions, elseif.Condition)
+	}
+	if n.Else == nil && len(n.ElseIfs) > 0 {
+		c.lastChecks[conditions[len(conditions)-1]] = true
+	}
+	for i, condition := range conditions {
+		position := at
+		if scope, ok := at.flow.before[condition]; ok {
+			position.scope = scope
+		}
+		if value, ok := c.outcome(condition, position); !ok || !value {
+			continue
+		}
+		for _, elseif := range n.ElseIfs[i:] {
+			c.report(unreachableBranchCode, elseif, "Elseif branch is unreachable because previous condition is always true.")
+		}
+		if n.Else != nil {
+			c.report(unreachableBranchCode, n.Else, "Else branch is unreachable because previous condition is always true.")
+		}
+		return
+	}
+}
+
+// checkMatch reports match arms that can never be taken: arms whose value
+// can never be identical to the subject, and arms after the subject's
+// possible values, or a condition of match (true), are exhausted.
+func (c *impossibleChecker) checkMatch(n *ast.MatchNode, at typedPosition) {
+	if isBooleanLiteral(n.Condition, true) {
+		c.checkMatchTrue(n, at)
+		return
+	}
+	subject := c.operandType(n.Condition, at)
+	if !c.knownType(subject, at) {
+		return
+	}
+	remaining := expandFiniteType(subject, at.scope, c.ctx)
+	for i := range n.Arms {
+		arm := &n.Arms[i]
+		if isDefaultMatchArm(arm) {
+			continue
+		}
+		if remaining.IsEmpty() {
+			c.report(unreachableBranchCode, arm, "Match arm is unreachable because previous comparison is always true.")
+			continue
+		}
+		for _, condition := range arm.Conditions {
+			value := c.matchArmType(condition, at)
+			if !c.knownType(value, at) {
+				continue
+			}
+			if typesDisjoint(remaining, value, at.scope, c.ctx) {
+				c.report(unreachableBranchCode, condition, fmt.Sprintf("Match arm comparison between %s and %s is always false.", remaining.String(), value.String()))
+				continue
+			}
+			if isSingleValueType(value) {
+				remaining = remaining.without(value)
+			}
+			if remaining.IsEmpty() {
+				break
+			}
+		}
+	}
+}
+
+func (c *impossibleChecker) checkMatchTrue(n *ast.MatchNode, at typedPosition) {
+	hasDefault := false
+	for i := range n.Arms {
+		hasDefault = hasDefault || isDefaultMatchArm(&n.Arms[i])
+	}
+	if !hasDefault && len(n.Arms) > 0 {
+		for _, condition := range n.Arms[len(n.Arms)-1].Conditions {
+			c.lastChecks[condition] = true
+		}
+	}
+	position := at
+	exhausted := false
+	for i := range n.Arms {
+		arm := &n.Arms[i]
+		if isDefaultMatchArm(arm) {
+			continue
+		}
+		if exhausted {
+			c.report(unreachableBranchCode, arm, "Match arm is unreachable because previous comparison is always true.")
+			continue
+		}
+		for _, condition := range arm.Conditions {
+			if value, ok := c.outcome(condition, position); ok && value {
+				exhausted = true
+				break
+			}
+			position = position.narrowed(condition, false)
+		}
+	}
+}
+
+// outcome returns the value a condition always evaluates to, combining the
+// checks of && and || operands.
+func (c *impossibleChecker) outcome(node ast.Node, at typedPosition) (bool, bool) {
+	switch n := node.(type) {
+	case *ast.BinaryExpr:
+		switch n.Operator {
+		case "&&", "and":
+			left, leftKnown := c.outcome(n.Left, at)
+			if leftKnown && !left {
+				return false, true
+			}
+			right, rightKnown := c.outcome(n.Right, at.narrowed(n.Left, true))
+			if rightKnown && !right {
+				return false, true
+			}
+			return true, leftKnown && rightKnown
+		case "||", "or":
+			left, leftKnown := c.outcome(n.Left, at)
+			if leftKnown && left {
+				return true, true
+			}
+			right, rightKnown := c.outcome(n.Right, at.narrowed(n.Left, false))
+			if rightKnown && right {
+				return true, true
+			}
+			return false, leftKnown && rightKnown
+		}
+	case *ast.UnaryExpr:
+		if n.Operator == "!" {
+			value, ok := c.outcome(n.Operand, at)
+			return !value, ok
+		}
+	case *ast.FunctionCallNode:
+		if strings.EqualFold(functionCallName(n), "isset") && len(n.Args) > 0 {
+			for _, arg := range n.Args {
+				if _, ok := c.redundantIsset(argumentValue(arg), at); !ok {
+					return false, false
+				}
+			}
+			return true, true
+		}
+	}
+	value, _, ok := c.check(node, at)
+	return value, ok
+}
+
+// check decides a single instanceof, is_*() or null comparison.
+func (c *impossibleChecker) check(node ast.Node, at typedPosition) (bool, string, bool) {
+	if at.scope == nil {
+		return false, "", false
+	}
+	switch n := node.(type) {
+	case *ast.BinaryExpr:
+		switch n.Operator {
+		case "instanceof":
+			return c.checkInstanceof(n, at)
+		case "===", "!==":
+			return c.checkNullComparison(n, at)
+		}
+	case *ast.FunctionCallNode:
+		return c.checkTypePredicate(n, at)
+	}
+	return false, "", false
+}
+
+func (c *impossibleChecker) checkInstanceof(n *ast.BinaryExpr, at typedPosition) (bool, string, bool) {
+	identifier, ok := n.Right.(*ast.IdentifierNode)
+	if !ok || strings.EqualFold(identifier.Value, "static") {
+		return false, "", false
+	}
+	target, ok := resolveKnownClass(canonicalClassName(at.scope.typeCtx.resolveClassLike(identifier.Value), at.scope, c.ctx), c.ctx)
+	if !ok || target.Kind == "trait" {
+		return false, "", false
+	}
+	typ := c.operandType(n.Left, at)
+	if !c.knownType(typ, at) {
+		return false, "", false
+	}
+	always, never := true, true
+	for _, atom := range typ.atoms {
+		switch c.instanceofAtomOutcome(atom, target, at) {
+		case outcomeTrue:
+			never = false
+		case outcomeFalse:
+			always = false
+		default:
+			return false, "", false
+		}
+	}
+	value := always
+	return value, fmt.Sprintf("Instanceof between %s and %s will always evaluate to %t.", typ.String(), strings.TrimPrefix(target.Name, `\`), value), always || never
+}
+
+type atomOutcome int
+
+const (
+	outcomeUnknown atomOutcome = iota
+	outcomeTrue
+	outcomeFalse
+)
+
+// instanceofAtomOutcome decides whether a value of one atom is an instance
+// of target. A class is only known not to be an instance when no subclass
+// of it can be: it is final, or both it and target are classes outside each
+// other's hierarchy.
+func (c *impossibleChecker) instanceofAtomOutcome(atom typeAtom, target ResolvedClass, at typedPosition) atomOutcome {
+	if atom.kind == typeKindBuiltin {
+		switch atom.plainKey() {
+		case "object", "callable", "iterable":
+			return outcomeUnknown
+		}
+		return outcomeFalse
+	}
+	if classHierarchyCompatible(target.Name, atom.className(), at.scope, c.ctx) {
+		return outcomeTrue
+	}
+	actual, ok := resolveKnownClass(canonicalClassName(atom.className(), at.scope, c.ctx), c.ctx)
+	if !ok {
+		return outcomeUnknown
+	}
+	if actual.Final || target.Final && !classHierarchyCompatible(actual.Name, target.Name, at.scope, c.ctx) {
+		return outcomeFalse
+	}
+	if isConcreteClassKind(actual.Kind) && isConcreteClassKind(target.Kind) && !classHierarchyCompatible(actual.Name, target.Name, at.scope, c.ctx) {
+		return outcomeFalse
+	}
+	return outcomeUnknown
+}
+
+func isConcreteClassKind(kind string) bool {
+	return kind == "" || kind == "class"
+}
+
+func (c *impossibleChecker) checkNullComparison(n *ast.BinaryExpr, at typedPosition) (bool, string, bool) {
+	operand := n.Right
+	if !isNullLiteral(n.Left) {
+		if !isNullLiteral(n.Right) {
+			return false, "", false
+		}
+		operand = n.Left
+	}
+	typ := c.operandType(operand, at)
+	if !c.knownType(typ, at) {
+		return false, "", false
+	}
+	var isNull bool
+	switch {
+	case !typ.hasBuiltin("null"):
+		isNull = false
+	case len(typ.atoms) == 1:
+		isNull = true
+	default:
+		return false, "", false
+	}
+	value := isNull == (n.Operator == "===")
+	left, right := typ.String(), "null"
+	if operand == n.Right {
+		left, right = right, left
+	}
+	return value, fmt.Sprintf("Strict comparison using %s between %s and %s will always evaluate to %t.", n.Operator, left, right, value), true
+}
+
+// checkTypePredicate decides is_string(), is_int() and the other is_*()
+// checks builtinTypePredicate knows.
+func (c *impossibleChecker) checkTypePredicate(call *ast.FunctionCallNode, at typedPosition) (bool, string, bool) {
+	_, predicate, ok := builtinTypePredicate(call)
+	if !ok {
+		return false, "", false
+	}
+	typ := c.operandType(argumentValue(call.Args[0]), at)
+	if !c.knownType(typ, at) {
+		return false, "", false
+	}
+	want := predicate.sortedAtoms()[0].plainKey()
+	always, never := true, true
+	for _, atom := range typ.atoms {
+		category := valueCategory(atom)
+		if category == "" {
+			return false, "", false
+		}
+		if category == want {
+			never = false
+		} else {
+			always = false
+		}
+	}
+	if !always && !never {
+		return false, "", false
+	}
+	return always, fmt.Sprintf("Call to function %s() with %s will always evaluate to %t.", strings.ToLower(strings.TrimPrefix(functionCallName(call), `\`)), typ.String(), always), true
+}
+
+// valueCategory returns the builtin type every value of an atom has, in the
+// terms of the is_*() functions, or "" when that depends on the value.
+func valueCategory(atom typeAtom) string {
+	if atom.kind == typeKindClass {
+		return "object"
+	}
+	switch key := atom.plainKey(); key {
+	case "true", "false", "bool":
+		return "bool"
+	case "int", "float", "string", "array", "null", "object", "resource":
+		return key
+	}
+	return ""
+}
+
+// redundantIsset reports a property fetch in isset() whose property always
+// holds a non-null value.
+func (c *impossibleChecker) redundantIsset(node ast.Node, at typedPosition) (string, bool) {
+	fetch, ok := node.(*ast.PropertyFetchNode)
+	if !ok || fetch.NullSafe || !isStaticMemberName(fetch.Property) {
+		return "", false
+	}
+	var className string
+	if isThisVariable(fetch.Object) {
+		className = currentClassName(at.class, at.ft)
+	} else {
+		object := c.operandType(fetch.Object, at)
+		if className, ok = object.SingleClassName(); !ok || !c.knownType(object, at) {
+			return "", false
+		}
+	}
+	class, ok := resolveKnownClass(canonicalClassName(className, at.scope, c.ctx), c.ctx)
+	if !ok {
+		return "", false
+	}
+	for _, magic := range []string{"__isset", "__get"} {
+		if _, ok := c.ctx.Resolver.ResolveMethod(class.Name, magic); ok {
+			return "", false
+		}
+	}
+	property, ok := c.ctx.Resolver.ResolveProperty(class.Name, fetch.Property)
+	if !ok || property.Magic || !property.Initialized {
+		return "", false
+	}
+	typ := ParseType(property.Type)
+	if !c.knownType(typ, at) || typ.hasBuiltin("null") {
+		return "", false
+	}
+	return fmt.Sprintf("Property %s::$%s (%s) in isset() is not nullable nor uninitialized.", strings.TrimPrefix(class.Name, `\`), property.Name, typ.String()), true
+}
+
+// operandType is the type of a checked expression, with self and static
+// bound to the current class. $this properties are typed by their
+// declaration, since calls may change them behind the type flow's back, and
+// variables shared by reference are not typed at all.
+func (c *impossibleChecker) operandType(node ast.Node, at typedPosition) Type {
+	switch n := node.(type) {
+	case *ast.VariableNode:
+		if c.referencedVariables(at)[n.Name] {
+			return EmptyType()
+		}
+	case *ast.PropertyFetchNode:
+		if isThisVariable(n.Object) && !n.NullSafe && at.scope != nil {
+			if declared, ok := at.scope.propertyDecls[n.Property]; ok {
+				return declared
+			}
+		}
+	}
+	return inferType(node, at.scope, c.ctx).boundTo(at.scope.className, "")
+}
+
+// knownType reports whether a type is precise enough to decide a check: it
+// is not mixed and every class in it is known along with its ancestors.
+func (c *impossibleChecker) knownType(typ Type, at typedPosition) bool {
+	if typ.IsEmpty() || typ.hasBuiltin("mixed") || typ.hasBuiltin("void") || typ.hasBuiltin("never") {
+		return false
+	}
+	for _, atom := range typ.atoms {
+		if atom.kind != typeKindClass {
+			continue
+		}
+		if _, ok := resolveKnownClass(canonicalClassName(atom.className(), at.scope, c.ctx), c.ctx); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *impossibleChecker) matchArmType(condition ast.Node, at typedPosition) Type {
+	switch n := condition.(type) {
+	case *ast.BooleanNode:
+		return ParseType(fmt.Sprint(n.Value))
+	case *ast.BooleanLiteral:
+		return ParseType(fmt.Sprint(n.Value))
+	case *ast.VariableNode, *ast.PropertyFetchNode:
+		return c.operandType(condition, at)
+	}
+	return inferPreciseType(condition, at.scope, c.ctx)
+}
+
+// referencedVariables returns the variables of the current body that other
+// code may write: those passed to by-reference parameters or to calls whose
+// parameters are unknown, bound by reference, or captured by reference. In
+// bodies that access variables by computed name, every variable counts.
+func (c *impossibleChecker) referencedVariables(at typedPosition) map[string]bool {
+	if names, ok := c.referenced[at.fn]; ok {
+		return names
+	}
+	names := map[string]bool{}
+	c.referenced[at.fn] = names
+	if at.fn == nil {
+		return names
+	}
+	if hasDynamicVariables(at.fn.Body) {
+		for name := range at.scope.variables {
+			names[name] = true
+		}
+		return names
+	}
+	calls := &definiteAssignment{ctx: c.ctx, typeCtx: at.ft, class: at.class, fn: at.fn}
+	markArgs := func(args []ast.Node, params []ResolvedParam, known bool) {
+		for i, arg := range args {
+			name := baseVariableName(argumentValue(arg))
+			if name == "" {
+				continue
+			}
+			if param, ok := parameterForArgument(params, i, arg); !known || ok && param.ByRef {
+				names[name] = true
+			}
+		}
+	}
+	var visit func(ast.Node)
+	visit = func(node ast.Node) {
+		switch n := node.(type) {
+		case nil, *ast.ClassNode:
+			return
+		case *ast.FunctionNode:
+			for _, use := range n.Uses {
+				if use.ByRef {
+					names[strings.TrimPrefix(use.Name, "$")] = true
+				}
+			}
+			return
+		case *ast.FunctionCallNode:
+			switch strings.ToLower(functionCallName(n)) {
+			case "isset", "empty", "unset":
+			default:
+				params := calls.functionParams(functionCallName(n))
+				markArgs(n.Args, params, params != nil)
+			}
+		case *ast.MethodCallNode:
+			params := calls.methodParams(n)
+			markArgs(n.Args, params, params != nil)
+		case *ast.AssignmentNode:
+			if ref, ok := n.Right.(*ast.UnaryExpr); ok && ref.Operator == "&" {
+				names[baseVariableName(n.Left)] = true
+				names[baseVariableName(ref.Operand)] = true
+			}
+		case *ast.ForeachNode:
+			if n.ByRef {
+				names[baseVariableName(n.ValueVar)] = true
+			}
+		case *ast.ArrayItemNode:
+			if n.ByRef {
+				names[baseVariableName(n.Value)] = true
+			}
+		}
+		for _, child := range childNodes(node) {
+			visit(child)
+		}
+	}
+	for _, stmt := range at.fn.Body {
+		visit(stmt)
+	}
+	return names
+}
+
+// expandFiniteType replaces bool with true|false and each known enum with
+// its cases, so that match arms can remove them one by one.
+func expandFiniteType(typ Type, scope *functionScope, ctx *AnalysisContext) Type {
+	return typeFromAtoms(finiteAtoms(typ, scope, ctx))
+}
+
+// finiteAtoms returns the atoms of expandFiniteType with enum cases in
+// declaration order.
+func finiteAtoms(typ Type, scope *functionScope, ctx *AnalysisContext) []typeAtom {
+	var atoms []typeAtom
+	for _, atom := range typ.sortedAtoms() {
+		if atom.kind == typeKindBuiltin && atom.key == "bool" {
+			atoms = append(atoms, builtinAtom("true"), builtinAtom("false"))
+			continue
+		}
+		if atom.kind == typeKindClass && atom.literal == "" && len(atom.args) == 0 {
+			if resolved, ok := ctx.Resolver.ResolveClass(canonicalClassName(atom.className(), scope, ctx)); ok && resolved.Kind == "enum" && len(resolved.Cases) > 0 {
+				for _, name := range resolved.Cases {
+					atoms = append(atoms, classConstantAtom(strings.TrimPrefix(resolved.Name, `\`), name))
+				}
+				continue
+			}
+		}
+		atoms = append(atoms, atom)
+	}
+	return atoms
+}
+
+// typesDisjoint reports whether no value of one type can be identical to a
+// value of the other.
+func typesDisjoint(a, b Type, scope *functionScope, ctx *AnalysisContext) bool {
+	for _, left := range a.atoms {
+		for _, right := range b.atoms {
+			if atomsCompatibleWithContext(left, right, scope, ctx) || atomsCompatibleWithContext(right, left, scope, ctx) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isSingleValueType reports whether a type has exactly one value, such as a
+// literal, an enum case, true, false or null.
+func isSingleValueType(typ Type) bool {
+	if len(typ.atoms) != 1 {
+		return false
+	}
+	for _, atom := range typ.atoms {
+		if atom.literal != "" {
+			return true
+		}
+		switch atom.key {
+		case "true", "false", "null":
+			return true
+		}
+	}
+	return false
+}
+
+func isDefaultMatchArm(arm *ast.MatchArmNode) bool {
+	if len(arm.Conditions) != 1 {
+		return false
+	}
+	identifier, ok := arm.Conditions[0].(*ast.IdentifierNode)
+	return ok && strings.EqualFold(identifier.Value, "default")
+}
+
+func isBooleanLiteral(node ast.Node, value bool) bool {
+	switch n := node.(type) {
+	case *ast.BooleanNode:
+		return n.Value == value
+	case *ast.BooleanLiteral:
+		return n.Value == value
+	}
+	return false
+}
+
+func init() {
+	RegisterAnalysisRuleWithMeta(AnalysisRuleMeta{
+		Code:           impossibleCheckCode,
+		Reports:        []string{unreachableBranchCode},
+		Level:          4,
+		Category:       "phpstan.deadCode",
+		DefaultEnabled: true,
+	}, func(filename string, nodes []ast.Node, ctx *AnalysisContext) []AnalysisIssue {
+		return (&ImpossibleCheckRule{}).CheckIssues(filename, nodes, ctx)
+	})
+}
@@ -0,0 +1,176 @@
+package analyse
+
+import "testing"
+
+const impossibleCheckFixture = `<?php
+interface Shape {}
+class Circle implements Shape {}
+final class Square implements Shape {}
+class Account {
+    public string $name = '';
+    public ?string $email = null;
+    public int $id;
+    public function __construct(public readonly int $owner) {}
+}
+enum Suit {
+    case Hearts;
+    case Spades;
+}
+`
+
+func TestImpossibleCheckReportsDecidedChecks(t *testing.T) {
+	issues := runLevelOnFiles(t, 4, map[string]string{
+		"test.php": impossibleCheckFixture + `
+function check(Circle $circle, string $text, Account $account, Account $other, Account $third, ?Account $maybe, Square $square): void {
+    if ($circle instanceof Shape) {}
+    if ($square instanceof Circle) {}
+    if (is_string($text)) {}
+    if (is_array($text)) {}
+    if ($account === null) {}
+    if (null !== $other) {}
+    if (isset($third->name)) {}
+    if (isset($third->owner)) {}
+    if ($maybe !== null && $maybe === null) {}
+}
+`,
+	})
+
+	for _, needle := range []string{
+		"Instanceof between Circle and Shape will always evaluate to true.",
+		"Instanceof between Square and Circle will always evaluate to false.",
+		"Call to function is_string() with string will always evaluate to true.",
+		"Call to function is_array() with string will always evaluate to false.",
+		"Strict comparison using === between Account and null will always evaluate to false.",
+		"Strict comparison using !== between null and Account will always evaluate to true.",
+		"Property Account::$name (string) in isset() is not nullable nor uninitialized.",
+		"Property Account::$owner (int) in isset() is not nullable nor uninitialized.",
+		"Strict comparison using === between Account and null will always evaluate to false.",
+	} {
+		if !hasIssueContaining(issues, impossibleCheckCode, needle) {
+			t.Errorf("expected %q, got %#v", needle, issues)
+		}
+	}
+	if got := countIssueContaining(issues, impossibleCheckCode, ""); got != 9 {
+		t.Errorf("expected nine decided checks, got %d: %#v", got, issues)
+	}
+}
+
+func TestImpossibleCheckSkipsUndecidedChecks(t *testing.T) {
+	issues := runLevelOnFiles(t, 4, map[string]string{
+		"test.php": impossibleCheckFixture + `
+function check(Shape $shape, ?Account $maybe, Account $account, mixed $value, $untyped, Circle $circle): void {
+    if ($shape instanceof Circle) {}
+    if ($circle instanceof Vendor\Widget) {}
+    if ($maybe === null) {}
+    if (is_string($value) || is_string($untyped)) {}
+    if (isset($account->email, $account->id, $account->missing)) {}
+    $matches = null;
+    preg_match('/x/', 'x', $matches);
+    if ($matches === null) {}
+    $shared = null;
+    $callback = function () use (&$shared) { $shared = 1; };
+    $callback();
+    if ($shared === null) {}
+    if ($maybe === null) {
+        return;
+    } elseif ($maybe instanceof Account) {
+        return;
+    }
+}
+`,
+	})
+
+	if got := countIssueContaining(issues, impossibleCheckCode, ""); got != 0 {
+		t.Fatalf("expected no decided checks, got %#v", issues)
+	}
+	if got := countIssueContaining(issues, unreachableBranchCode, ""); got != 0 {
+		t.Fatalf("expected no unreachable branches, got %#v", issues)
+	}
+}
+
+func TestImpossibleCheckTreatsNullDefaultsAsNullable(t *testing.T) {
+	issues := runLevelOnFiles(t, 4, map[string]string{
+		"test.php": impossibleCheckFixture + `
+class Holder {
+    public function __construct(public Circle $circle = null) {}
+    public function check(): void {
+        if ($this->circle === null) {}
+    }
+}
+function check(Circle $circle = null, Account $account = null): void {
+    if ($circle === null) {}
+    if (null !== $account) {}
+}
+`,
+	})
+
+	if got := countIssueContaining(issues, impossibleCheckCode, ""); got != 0 {
+		t.Fatalf("expected parameters defaulting to null to be nullable, got %#v", issues)
+	}
+}
+
+func TestImpossibleCheckReportsUnreachableBranches(t *testing.T) {
+	issues := runLevelOnFiles(t, 4, map[string]string{
+		"test.php": impossibleCheckFixture + `
+function branches(Circle $circle, ?Account $maybe, Suit $suit, int $code, bool $flag): string {
+    if ($circle instanceof Shape) {
+        $label = 'shape';
+    } elseif ($maybe !== null) {
+        $label = 'account';
+    } else {
+        $label = 'other';
+    }
+    if ($maybe === null || $maybe instanceof Account) {
+        $label .= '!';
+    } else {
+        $label .= '?';
+    }
+    $label .= match ($suit) {
+        Suit::Hearts => 'h',
+        Suit::Spades => 's',
+        default => 'x',
+    };
+    $label .= match ($suit) {
+        Suit::Hearts, Suit::Spades => 'any',
+        Suit::Hearts => 'again',
+    };
+    $label .= match ($code) {
+        1 => 'one',
+        'one' => 'text',
+        default => 'many',
+    };
+    $label .= match ($flag) {
+        true => 'yes',
+        false => 'no',
+        null => 'never',
+    };
+    return $label . match (true) {
+        $circle instanceof Circle => 'circle',
+        $code > 1 => 'big',
+    };
+}
+`,
+	})
+
+	for _, needle := range []string{
+		"Elseif branch is unreachable because previous condition is always true.",
+		"Else branch is unreachable because previous condition is always true.",
+		"Match arm is unreachable because previous comparison is always true.",
+		"Match arm comparison between int and 'one' is always false.",
+	} {
+		if !hasIssueContaining(issues, unreachableBranchCode, needle) {
+			t.Errorf("expected %q, got %#v", needle, issues)
+		}
+	}
+	if got := countIssueContaining(issues, unreachableBranchCode, "Else branch"); got != 2 {
+		t.Errorf("expected both else branches, got %#v", issues)
+	}
+	// Suit::Hearts again, null after true|false, and $code > 1 after an
+	// always-true arm.
+	if got := countIssueContaining(issues, unreachableBranchCode, "Match arm is unreachable"); got != 3 {
+		t.Errorf("expected three exhausted match arms, got %#v", issues)
+	}
+	if got := countIssueContaining(issues, unreachableBranchCode, ""); got != 7 {
+		t.Errorf("expected seven unreachable branches, got %#v", issues)
+	}
+}
@@ -0,0 +1,179 @@
+package analyse
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+)
+
+const (
+	unhandledMatchCode  = "PHPStan.Match.Unhandled"
+	unhandledSwitchCode = "PHPStan.Switch.Unhandled"
+)
+
+// MatchExhaustivenessRule reports match expressions without a default arm
+// whose subject can take values no arm handles, which throw
+// UnhandledMatchError at runtime. It only applies to subjects whose values
+// can be listed: enum cases known to the project index, bool, null and
+// literal unions such as 'draft'|'published' from PHPDoc. Switch statements
+// on enums that miss cases without a default are reported as warnings,
+// since falling through a switch is not an error.
+type MatchExhaustivenessRule struct{}
+
+func (r *MatchExhaustivenessRule) CheckIssues(filename string, nodes []ast.Node, ctx *AnalysisContext) []AnalysisIssue {
+	ctx = ensureLevel0Context(filename, nodes, ctx)
+	checker := &impossibleChecker{
+		filename:   filename,
+		ctx:        ctx,
+		referenced: map[*ast.FunctionNode]map[string]bool{},
+		lastChecks: map[ast.Node]bool{},
+	}
+	var issues []AnalysisIssue
+	walkTypedExpressions(nodes, ctx, func(node ast.Node, at typedPosition) {
+		switch n := node.(type) {
+		case *ast.MatchNode:
+			if isBooleanLiteral(n.Condition, true) {
+				return
+			}
+			conditions := make([]ast.Node, 0, len(n.Arms))
+			for i := range n.Arms {
+				if isDefaultMatchArm(&n.Arms[i]) {
+					return
+				}
+				conditions = append(conditions, n.Arms[i].Conditions...)
+			}
+			missing, ok := unhandledValues(checker, n.Condition, conditions, false, at)
+			if !ok || len(missing) == 0 {
+				return
+			}
+			issues = append(issues, issue(filename, n.Pos, unhandledMatchCode, fmt.Sprintf("Match expression does not handle remaining %s: %s.", pluralValues(missing), strings.Join(missing, ", "))))
+		case *ast.SwitchNode:
+			conditions := make([]ast.Node, 0, len(n.Cases))
+			for _, c := range n.Cases {
+				if c.IsDefault {
+					return
+				}
+				conditions = append(conditions, c.Expr)
+			}
+			missing, ok := unhandledValues(checker, n.Expr, conditions, true, at)
+			if !ok || len(missing) == 0 {
+				return
+			}
+			found := issue(filename, n.Pos, unhandledSwitchCode, fmt.Sprintf("Switch statement does not handle remaining enum %s: %s.", pluralCases(missing), strings.Join(missing, ", ")))
+			found.Warning = true
+			issues = append(issues, found)
+		}
+	})
+	return issues
+}
+
+// unhandledValues returns the values of subject that none of conditions
+// matches, in declaration order for enum cases. It gives up when the
+// subject has values that cannot be listed, or when a condition may match
+// some but not all of them. With enumsOnly set, subjects that are not an
+// enum, optionally nullable, are skipped.
+func unhandledValues(checker *impossibleChecker, subject ast.Node, conditions []ast.Node, enumsOnly bool, at typedPosition) ([]string, bool) {
+	typ := checker.operandType(subject, at)
+	if !checker.knownType(typ, at) {
+		return nil, false
+	}
+	atoms := finiteAtoms(typ, at.scope, checker.ctx)
+	sortEnumCases(atoms, checker.ctx)
+	hasEnum := false
+	for _, atom := range atoms {
+		if !isSingleValueType(typeFromAtoms([]typeAtom{atom})) {
+			return nil, false
+		}
+		if atom.kind == typeKindClass {
+			hasEnum = true
+		} else if enumsOnly && atom.key != "null" {
+			return nil, false
+		}
+	}
+	if enumsOnly && !hasEnum {
+		return nil, false
+	}
+	remaining := typeFromAtoms(atoms)
+	for _, condition := range conditions {
+		value := checker.matchArmType(condition, at)
+		if !checker.knownType(value, at) {
+			return nil, false
+		}
+		if typesDisjoint(remaining, value, at.scope, checker.ctx) {
+			continue
+		}
+		if !isSingleValueType(value) {
+			return nil, false
+		}
+		remaining = remaining.without(value)
+		if remaining.IsEmpty() {
+			return nil, true
+		}
+	}
+	var missing []string
+	for _, atom := range atoms {
+		if _, ok := remaining.atoms[atom.key]; ok {
+			missing = append(missing, atom.display)
+		}
+	}
+	return missing, true
+}
+
+// sortEnumCases puts enum case atoms first, in declaration order, since a
+// narrowed subject no longer carries the order of its enum's cases.
+func sortEnumCases(atoms []typeAtom, ctx *AnalysisContext) {
+	rank := func(atom typeAtom) (string, int, bool) {
+		if atom.kind != typeKindClass || atom.literal == "" {
+			return "", 0, false
+		}
+		resolved, ok := ctx.Resolver.ResolveClass(atom.base)
+		if !ok || resolved.Kind != "enum" {
+			return "", 0, false
+		}
+		for i, name := range resolved.Cases {
+			if name == atom.literal {
+				return strings.ToLower(atom.base), i, true
+			}
+		}
+		return "", 0, false
+	}
+	sort.SliceStable(atoms, func(i, j int) bool {
+		leftEnum, leftIndex, leftOK := rank(atoms[i])
+		rightEnum, rightIndex, rightOK := rank(atoms[j])
+		if !leftOK || !rightOK {
+			return leftOK && !rightOK
+		}
+		if leftEnum != rightEnum {
+			return leftEnum < rightEnum
+		}
+		return leftIndex < rightIndex
+	})
+}
+
+func pluralValues(values []string) string {
+	if len(values) == 1 {
+		return "value"
+	}
+	return "values"
+}
+
+func pluralCases(values []string) string {
+	if len(values) == 1 {
+		return "case"
+	}
+	return "cases"
+}
+
+func init() {
+	RegisterAnalysisRuleWithMeta(AnalysisRuleMeta{
+		Code:           unhandledMatchCode,
+		Reports:        []string{unhandledSwitchCode},
+		Level:          4,
+		Category:       "phpstan.match",
+		DefaultEnabled: true,
+	}, func(filename string, nodes []ast.Node, ctx *AnalysisContext) []AnalysisIssue {
+		return (&MatchExhaustivenessRule{}).CheckIssues(filename, nodes, ctx)
+	})
+}
@@ -0,0 +1,153 @@
+package analyse
+
+import "testing"
+
+const matchExhaustivenessFixture = `<?php
+enum Suit {
+    case Hearts;
+    case Diamonds;
+    case Clubs;
+    case Spades;
+}
+`
+
+func TestMatchExhaustivenessReportsUnhandledValues(t *testing.T) {
+	issues := runLevelOnFiles(t, 4, map[string]string{
+		"test.php": matchExhaustivenessFixture + `
+/** @param 'draft'|'published'|'archived' $status */
+function describe(Suit $suit, ?Suit $maybe, string $status, bool $flag): string {
+    $color = match ($suit) {
+        Suit::Hearts, Suit::Diamonds => 'red',
+        Suit::Spades => 'black',
+    };
+    $label = match ($status) {
+        'draft' => 'Draft',
+    };
+    $optional = match ($maybe) {
+        Suit::Hearts, Suit::Diamonds, Suit::Clubs, Suit::Spades => 'set',
+    };
+    $answer = match ($flag) {
+        true => 'yes',
+    };
+    return $color . $label . $optional . $answer;
+}
+`,
+	})
+
+	for _, needle := range []string{
+		"Match expression does not handle remaining value: Suit::Clubs.",
+		"Match expression does not handle remaining values: 'archived', 'published'.",
+		"Match expression does not handle remaining value: null.",
+		"Match expression does not handle remaining value: false.",
+	} {
+		if !hasIssueContaining(issues, unhandledMatchCode, needle) {
+			t.Errorf("expected %q, got %#v", needle, issues)
+		}
+	}
+}
+
+func TestMatchExhaustivenessAcceptsHandledValues(t *testing.T) {
+	issues := runLevelOnFiles(t, 4, map[string]string{
+		"test.php": matchExhaustivenessFixture + `
+function describe(Suit $suit, Suit $other, string $text, int $number, Suit $third): string {
+    $color = match ($suit) {
+        Suit::Hearts, Suit::Diamonds => 'red',
+        Suit::Clubs, Suit::Spades => 'black',
+    };
+    $fallback = match ($other) {
+        Suit::Hearts => 'hearts',
+        default => 'other',
+    };
+    $open = match ($text) {
+        'a' => 1,
+    };
+    $dynamic = match ($third) {
+        $suit => 'same',
+        Suit::Hearts => 'hearts',
+    };
+    return $color . $fallback . $open . $dynamic;
+}
+`,
+	})
+
+	if countIssueContaining(issues, unhandledMatchCode, "") != 0 {
+		t.Fatalf("expected no unhandled match issues, got %#v", issues)
+	}
+}
+
+func TestMatchExhaustivenessUsesNarrowedSubject(t *testing.T) {
+	issues := runLevelOnFiles(t, 4, map[string]string{
+		"test.php": matchExhaustivenessFixture + `
+/** @param 'draft'|'published' $status */
+function describe(Suit $suit, Suit $other, string $status, Suit $partial): int {
+    if ($suit === Suit::Hearts) {
+        return 1;
+    }
+    $first = match ($suit) {
+        Suit::Spades, Suit::Clubs, Suit::Diamonds => 2,
+    };
+    if (Suit::Hearts !== $other && $other !== Suit::Spades) {
+        $second = match ($other) {
+            Suit::Clubs, Suit::Diamonds => 3,
+        };
+    }
+    if ($status === 'draft') {
+        return 4;
+    }
+    $third = match ($status) {
+        'published' => 5,
+    };
+    if ($partial === Suit::Hearts) {
+        return 6;
+    }
+    return match ($partial) {
+        Suit::Spades => 7,
+    };
+}
+`,
+	})
+
+	if countIssueContaining(issues, unhandledMatchCode, "") != 1 {
+		t.Fatalf("expected only the partial match to be reported, got %#v", issues)
+	}
+	if !hasIssueContaining(issues, unhandledMatchCode, "Match expression does not handle remaining values: Suit::Diamonds, Suit::Clubs.") {
+		t.Fatalf("expected narrowed remaining cases, got %#v", issues)
+	}
+}
+
+func TestMatchExhaustivenessReportsSwitchAsWarning(t *testing.T) {
+	issues := runLevelOnFiles(t, 4, map[string]string{
+		"test.php": matchExhaustivenessFixture + `
+function describe(Suit $suit, Suit $other, string $text): string {
+    switch ($suit) {
+        case Suit::Hearts:
+        case Suit::Diamonds:
+            return 'red';
+    }
+    switch ($other) {
+        case Suit::Hearts:
+            return 'hearts';
+        default:
+            return 'other';
+    }
+    switch ($text) {
+        case 'a':
+            return 'a';
+    }
+    return '';
+}
+`,
+	})
+
+	if countIssueContaining(issues, unhandledSwitchCode, "") != 1 {
+		t.Fatalf("expected one unhandled switch issue, got %#v", issues)
+	}
+	for _, found := range issues {
+		if found.Code != unhandledSwitchCode {
+			continue
+		}
+		if found.Message != "Switch statement does not handle remaining enum cases: Suit::Clubs, Suit::Spades." || !found.Warning {
+			t.Fatalf("expected a warning listing the missing cases, got %#v", found)
+		}
+	}
+}
@@ -148,6 +148,10 @@ func normalizeTypeWithContext(raw string, ctx fileTypeContext) string {
 			if intersectionPart == "" {
 				continue
 			}
+			if atoms, ok := parseTypeAtoms(intersectionPart, ctx.resolveClassLike); ok {
+				intersectionParts[intersectionIdx] = renderTypeAtoms(atoms)
+				continue
+			}
 			intersectionPart = canonicalizeDocType(strings.TrimPrefix(intersectionPart, `\`))
 			if len(splitTopLevelTypes(intersectionPart, '|')) > 1 {
 				intersectionParts[intersectionIdx] = intersectionPart
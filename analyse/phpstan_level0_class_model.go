@@ -8,10 +8,8 @@ import (
 
 func (r *PHPStanLevel0Rule) checkClassModel(filename string, nodes []ast.Node, ctx *AnalysisContext, fileCtx fileTypeContext) []AnalysisIssue {
 	var issues []AnalysisIssue
-	for _, duplicate := range ctx.Project.Duplicates {
-		if duplicate.File == filename {
-			issues = append(issues, issue(filename, duplicate.Pos, level0ClassModelCode, fmt.Sprintf("Duplicate declaration of class %s.", duplicate.Name)))
-		}
+	for _, duplicate := range ctx.Project.duplicatesIn(filename) {
+		issues = append(issues, issue(filename, duplicate.Pos, level0ClassModelCode, fmt.Sprintf("Duplicate declaration of class %s.", duplicate.Name)))
 	}
 
 	var walk func([]ast.Node, fileTypeContext, string)
@@ -60,6 +58,7 @@ func (r *PHPStanLevel0Rule) checkClassModel(filename string, nodes []ast.Node, c
 				checkConsistentConstructorLegality(filename, className, n, ctx, &issues)
 				checkClassConstantLegality(filename, className, n, ctx, &issues)
 				checkReadonlyClassProperties(filename, className, n, ctx, &issues)
+				checkTraitAdaptations(filename, className, n.Properties, ft, ctx, &issues)
 				walk(n.Properties, ft, className)
 				walk(n.Methods, ft, className)
 			case *ast.InterfaceNode:
@@ -93,6 +92,54 @@ func (r *PHPStanLevel0Rule) checkClassModel(filename string, nodes []ast.Node, c
 	return issues
 }
 
+// checkTraitAdaptations reports insteadof and as rules naming a trait the
+// class does not use, and method collisions between traits left unresolved.
+func checkTraitAdaptations(filename, className string, members []ast.Node, ft fileTypeContext, ctx *AnalysisContext, issues *[]AnalysisIssue) {
+	used := map[string]struct{}{}
+	var firstUse ast.Node
+	for _, member := range members {
+		if use, ok := member.(*ast.TraitUseNode); ok {
+			if firstUse == nil {
+				firstUse = use
+			}
+			for _, trait := range use.Traits {
+				used[indexKey(ft.resolveClassLike(trait))] = struct{}{}
+			}
+		}
+	}
+	if firstUse == nil {
+		return
+	}
+	for _, member := range members {
+		use, ok := member.(*ast.TraitUseNode)
+		if !ok {
+			continue
+		}
+		for _, adaptation := range use.Adaptations {
+			named := adaptation.InsteadOf
+			if adaptation.Trait != "" {
+				named = append([]string{adaptation.Trait}, named...)
+			}
+			for _, trait := range named {
+				traitName := ft.resolveClassLike(trait)
+				if _, ok := used[indexKey(traitName)]; !ok {
+					*issues = append(*issues, issue(filename, adaptation.Pos, level0ClassModelCode, fmt.Sprintf("Trait %s in the adaptation of %s() is not used by class %s.", traitName, adaptation.Method, className)))
+				}
+			}
+		}
+	}
+	if ctx.Project == nil {
+		return
+	}
+	class, ok := ctx.Project.ResolveClass(className)
+	if !ok {
+		return
+	}
+	for _, collision := range ctx.Project.traitCollisions(class) {
+		*issues = append(*issues, issue(filename, firstUse.GetPos(), level0ClassModelCode, fmt.Sprintf("Trait method %s::%s() has not been applied as %s::%s(), because of collision with %s::%s().", collision.Trait, collision.Method, className, collision.Method, collision.Applied, collision.Method)))
+	}
+}
+
 func checkClassMethodLegality(filename, className string, class *ast.ClassNode, ctx *AnalysisContext, issues *[]AnalysisIssue) {
 	isAbstractClass := hasClassModifier(class, "abstract")
 	for _, methodNode := range class.Methods {
@@ -188,7 +235,7 @@ func checkReadonlyClassProperties(filename, className string, class *ast.ClassNo
 			if parentName == "" {
 				continue
 			}
-			if parentProperty, ok := ctx.Resolver.ResolveProperty(parentName, property.Name); ok && parentProperty.Readonly && !property.IsReadonly && !classReadonly {
+			if parentProperty, ok := ctx.Resolver.ResolveProperty(parentName, property.Name); ok && !parentProperty.Magic && parentProperty.Readonly && !property.IsReadonly && !classReadonly {
 				*issues = append(*issues, issue(filename, property.GetPos(), level0ClassModelCode, fmt.Sprintf("Property %s::$%s overriding readonly property must be readonly.", className, property.Name)))
 			}
 		}
@@ -204,7 +251,7 @@ func finalMethodInAncestors(project *ProjectIndex, className, methodName string)
 		return ResolvedMethod{}, false
 	}
 	for _, parent := range class.Extends {
-		if method, ok := project.Methods[indexKey(parent)][strings.ToLower(methodName)]; ok && method.Final {
+		if method, ok := project.classMethods(parent)[strings.ToLower(methodName)]; ok && method.Final {
 			return method, true
 		}
 		if method, ok := finalMethodInAncestors(project, parent, methodName); ok {
@@ -223,7 +270,7 @@ func finalConstantInAncestors(project *ProjectIndex, className, constName string
 		return ResolvedConstant{}, false
 	}
 	for _, parent := range class.Extends {
-		if constant, ok := project.ClassConsts[indexKey(parent)][strings.ToLower(constName)]; ok && constant.Final && constant.Visibility != "private" {
+		if constant, ok := project.classConstants(parent)[strings.ToLower(constName)]; ok && constant.Final && constant.Visibility != "private" {
 			constant.DeclaringClass = parent
 			return constant, true
 		}
@@ -244,7 +291,7 @@ func consistentConstructorInAncestors(project *ProjectIndex, className string) (
 	}
 	for _, parent := range class.Extends {
 		if parentClass, ok := project.ResolveClass(parent); ok && parentClass.ConsistentConstructor {
-			constructor, ok := project.Methods[indexKey(parent)]["__construct"]
+			constructor, ok := project.classMethods(parent)["__construct"]
 			if !ok {
 				constructor = ResolvedMethod{Name: "__construct", DeclaringClass: parent, Visibility: "public"}
 			}
@@ -275,7 +322,7 @@ func ownConstructor(project *ProjectIndex, className string) (ResolvedMethod, bo
 	if project == nil {
 		return ResolvedMethod{}, false
 	}
-	methods := project.Methods[indexKey(className)]
+	methods := project.classMethods(className)
 	if methods == nil {
 		return ResolvedMethod{}, false
 	}
@@ -385,7 +432,7 @@ func collectAbstractMethods(project *ProjectIndex, className string, out map[str
 	for _, iface := range class.Implements {
 		collectAbstractMethods(project, iface, out)
 	}
-	for _, method := range project.Methods[indexKey(className)] {
+	for _, method := range project.classMethods(className) {
 		if method.Abstract {
 			out[strings.ToLower(method.Name)] = method
 		}
@@ -400,7 +447,7 @@ func collectUnimplementedParentAbstractMethods(project *ProjectIndex, className
 	for _, parent := range class.Extends {
 		collectUnimplementedParentAbstractMethods(project, parent, out)
 	}
-	for _, method := range project.Methods[indexKey(className)] {
+	for _, method := range project.classMethods(className) {
 		key := strings.ToLower(method.Name)
 		if method.Abstract {
 			out[key] = method
@@ -418,7 +465,7 @@ func findConcreteClassMethod(project *ProjectIndex, className, methodName string
 			return ResolvedMethod{}, false
 		}
 		seen[key] = struct{}{}
-		if method, ok := project.Methods[key][strings.ToLower(methodName)]; ok && !method.Abstract {
+		if method, ok := project.classMethods(className)[strings.ToLower(methodName)]; ok && !method.Abstract {
 			return method, true
 		}
 		class, ok := project.ResolveClass(className)
@@ -47,6 +47,16 @@ func resolveClassLikeForCall(name string, current *ast.ClassNode, ft fileTypeCon
 	return ft.resolveClassLike(name)
 }
 
+// resolveStaticCallMethod resolves the method of a Foo::bar() call on the
+// class resolved from written. Calls through self, static and parent keep
+// $this, so their magic fallback is __call rather than __callStatic.
+func resolveStaticCallMethod(ctx *AnalysisContext, written, className, methodName string) (ResolvedMethod, bool) {
+	if isSpecialClassName(written) {
+		return ctx.Resolver.ResolveMethod(className, methodName)
+	}
+	return ctx.Resolver.ResolveStaticMethod(className, methodName)
+}
+
 func resolveFunctionNameForCall(name string, ft fileTypeContext, ctx *AnalysisContext) string {
 	name = strings.TrimPrefix(strings.TrimSpace(name), `\`)
 	if name == "" || strings.Contains(name, "::") {
@@ -104,14 +114,6 @@ func stringLiteralValue(node ast.Node) (string, bool) {
 	return "", false
 }
 
-func cloneBoolMap(in map[string]bool) map[string]bool {
-	out := make(map[string]bool, len(in))
-	for k, v := range in {
-		out[k] = v
-	}
-	return out
-}
-
 func issue(filename string, pos ast.Position, code, message string) AnalysisIssue {
 	return AnalysisIssue{Filename: filename, Line: pos.Line, Column: pos.Column, Code: code, Message: message}
 }
@@ -19,13 +19,17 @@ func parsePHPForLevel0(t *testing.T, php string) []ast.Node {
 }
 
 func runLevel0OnFiles(t *testing.T, files map[string]string) []AnalysisIssue {
+	t.Helper()
+	return runLevelOnFiles(t, 0, files)
+}
+
+func runLevelOnFiles(t *testing.T, level int, files map[string]string) []AnalysisIssue {
 	t.Helper()
 	parsed := make(map[string][]ast.Node, len(files))
 	for filename, php := range files {
 		parsed[filename] = parsePHPForLevel0(t, php)
 	}
 	project := BuildProjectIndex(parsed)
-	level := 0
 	var issues []AnalysisIssue
 	for filename, nodes := range parsed {
 		ctx := &AnalysisContext{Resolver: project, Project: project, AnalysisLevel: &level}
@@ -100,6 +104,127 @@ function run(array $rows): array
 	}
 }
 
+func TestLevel0RecognizesExtensionSymbolsFromBuiltinDatabase(t *testing.T) {
+	issues := runLevel0OnFiles(t, map[string]string{
+		"test.php": `<?php
+function run(string $s): void
+{
+    $storage = new SplObjectStorage();
+    $storage->attach(new stdClass());
+    ctype_digit($s);
+    preg_replace_callback('/a/', fn ($m) => 'b', $s);
+    $flags = JSON_PRETTY_PRINT | PHP_INT_MAX;
+    $format = DateTimeInterface::ATOM;
+    $cipher = openssl_encrypt($s, 'aes-256-cbc', $s, OPENSSL_RAW_DATA);
+    $sum = bcadd($s, '1', 2);
+    $zip = new ZipArchive();
+    $zip->open($s, ZipArchive::CREATE);
+    $formatter = new NumberFormatter('en', NumberFormatter::DECIMAL);
+    $db = new mysqli();
+    $rows = $db->query($s);
+    $key = sodium_crypto_secretbox_keygen();
+    $writer = new XMLWriter();
+    $writer->openMemory();
+    throw new InvalidArgumentException($s);
+}
+`,
+	})
+
+	for _, unexpected := range []string{"SplObjectStorage", "ctype_digit", "preg_replace_callback", "JSON_PRETTY_PRINT", "PHP_INT_MAX", "ATOM", "InvalidArgumentException", "openssl_encrypt", "OPENSSL_RAW_DATA", "bcadd", "ZipArchive", "NumberFormatter", "mysqli", "sodium", "XMLWriter", "openMemory"} {
+		if hasIssueContaining(issues, level0SymbolsCode, unexpected) {
+			t.Fatalf("expected %s to be recognized as builtin, got %#v", unexpected, issues)
+		}
+	}
+}
+
+func TestProjectIndexSeedsBuiltinSignatures(t *testing.T) {
+	idx := NewProjectIndex()
+
+	fn, ok := idx.ResolveFunction("strlen")
+	if !ok || fn.ReturnType != "int" {
+		t.Fatalf("expected strlen(): int, got %#v (found %v)", fn, ok)
+	}
+	fn, ok = idx.ResolveFunction(`\preg_match`)
+	if !ok || len(fn.Params) != 5 || !fn.Params[2].ByRef || !fn.Params[2].HasDefault {
+		t.Fatalf("expected preg_match with optional by-ref $matches, got %#v", fn)
+	}
+	if _, ok := idx.ResolveFunction("each"); ok {
+		t.Fatal("expected each(), removed in PHP 8.0, not to be seeded")
+	}
+	if _, ok := idx.ResolveFunction("isset"); !ok {
+		t.Fatal("expected language constructs to stay known")
+	}
+	method, ok := idx.ResolveMethod("DateTimeImmutable", "modify")
+	if !ok || method.ReturnType != "DateTimeImmutable|false" {
+		t.Fatalf("expected DateTimeImmutable::modify return type, got %#v", method)
+	}
+	method, ok = idx.ResolveMethod("RuntimeException", "getMessage")
+	if !ok || !method.Final || method.DeclaringClass != "Exception" {
+		t.Fatalf("expected inherited final Exception::getMessage, got %#v", method)
+	}
+
+	idx.addMethod("Exception", ResolvedMethod{Name: "projectOnly"})
+	if _, ok := NewProjectIndex().ResolveMethod("Exception", "projectOnly"); ok {
+		t.Fatal("expected project symbols not to leak into other indexes")
+	}
+}
+
+func TestLevel0UsesConfiguredStubsAndExtensions(t *testing.T) {
+	stub := parsePHPForLevel0(t, `<?php
+class Redis
+{
+    public function get(string $key): mixed {}
+}
+
+function generated_helper(): string {}
+
+define('GENERATED_FLAG', 1);
+`)
+	if unknown := ConfigureBuiltins(BuiltinOptions{
+		Extensions: map[string]bool{"ctype": false},
+		Stubs:      map[string][]ast.Node{"stubs/redis.php": stub},
+	}); len(unknown) != 0 {
+		t.Fatalf("expected every configured extension to be known, got %v", unknown)
+	}
+	t.Cleanup(func() { ConfigureBuiltins(BuiltinOptions{}) })
+
+	issues := runLevel0OnFiles(t, map[string]string{
+		"test.php": `<?php
+function run(Redis $redis): void
+{
+    $redis->get('key');
+    generated_helper();
+    $flag = GENERATED_FLAG;
+    ctype_digit('1');
+}
+`,
+		"Redis.php": `<?php
+class Redis {}
+`,
+	})
+
+	for _, unexpected := range []string{"Redis", "generated_helper", "GENERATED_FLAG"} {
+		if hasIssueContaining(issues, level0SymbolsCode, unexpected) {
+			t.Fatalf("expected stub symbol %s to be known, got %#v", unexpected, issues)
+		}
+	}
+	if hasIssueContaining(issues, level0ClassModelCode, "Duplicate declaration of class Redis") {
+		t.Fatalf("expected a project class to replace the stub class, got %#v", issues)
+	}
+	if !hasIssueContaining(issues, level0SymbolsCode, "Function ctype_digit not found") {
+		t.Fatalf("expected disabled extension function to be unknown, got %#v", issues)
+	}
+
+	unknown := ConfigureBuiltins(BuiltinOptions{Extensions: map[string]bool{"no_such_ext": true, "other_ext": false, "ctype": false}})
+	if len(unknown) != 2 || unknown[0] != "no_such_ext" || unknown[1] != "other_ext" {
+		t.Fatalf("expected unknown extensions to be returned, got %v", unknown)
+	}
+	issues = runLevel0OnFiles(t, map[string]string{"test.php": "<?php\nctype_digit('1');\n"})
+	if !hasIssueContaining(issues, level0SymbolsCode, "Function ctype_digit not found") {
+		t.Fatalf("expected known extensions to be configured next to unknown ones, got %#v", issues)
+	}
+}
+
 func TestLevel0RecognizesEnumCasesAndNativeMethods(t *testing.T) {
 	issues := runLevel0OnFiles(t, map[string]string{
 		"test.php": `<?php
@@ -204,6 +329,62 @@ class UsesHelpers {
 	}
 }
 
+func TestLevel0TraitAdaptations(t *testing.T) {
+	issues := runLevel0OnFiles(t, map[string]string{
+		"test.php": `<?php
+trait A {
+    public function smallTalk() {}
+    public function bigTalk() {}
+    public function shout() {}
+}
+
+trait B {
+    public function smallTalk() {}
+    public function bigTalk() {}
+    public function shout() {}
+}
+
+trait C {}
+
+class Talker {
+    use A, B {
+        B::smallTalk insteadof A;
+        A::bigTalk insteadof B, C;
+        B::bigTalk as protected talk;
+        smallTalk as protected;
+    }
+
+    public function run() {
+        $this->talk();
+        $this->bigTalk();
+    }
+}
+
+function caller(Talker $talker) {
+    (new Talker())->talk();
+}
+`,
+	})
+
+	for _, expected := range []string{
+		"Trait C in the adaptation of bigTalk() is not used by class Talker.",
+		"Trait method B::shout() has not been applied as Talker::shout(), because of collision with A::shout().",
+	} {
+		if !hasIssueContaining(issues, level0ClassModelCode, expected) {
+			t.Fatalf("expected %q issue, got %#v", expected, issues)
+		}
+	}
+	if countIssueContaining(issues, level0ClassModelCode, "because of collision") != 1 {
+		t.Fatalf("did not expect collisions resolved by insteadof, got %#v", issues)
+	}
+	if hasIssueContaining(issues, level0SymbolsCode, "undefined method") {
+		t.Fatalf("expected trait methods and aliases to resolve, got %#v", issues)
+	}
+	if !hasIssueContaining(issues, level0InvocationCode, "Call to protected method Talker::talk()") {
+		t.Fatalf("expected the alias visibility to apply, got %#v", issues)
+	}
+}
+
 func TestLevel0DoesNotValidateAttributeConstructorArity(t *testing.T) {
 	issues := runLevel0OnFiles(t, map[string]string{
 		"test.php": `<?php
@@ -625,6 +806,137 @@ class Props {
 	}
 }
 
+func TestLevel0ResolvesMagicMembers(t *testing.T) {
+	issues := runLevel0OnFiles(t, map[string]string{
+		"test.php": `<?php
+/**
+ * @property int $id
+ * @property-read string $label
+ * @method string format(int $width)
+ * @method static static create()
+ * @mixin Builder
+ */
+class Model {
+    public function run() {
+        $this->id;
+        $this->label;
+        $this->where();
+        $this->format(10);
+        $this->format();
+        self::create();
+        $this->missing();
+        $this->missingProperty;
+        self::$id;
+    }
+}
+
+class Builder {
+    public function where() {}
+}
+
+class Proxy {
+    public function __get($name) {}
+    public function __call($name, $arguments) {}
+    public static function __callStatic($name, $arguments) {}
+
+    public function run() {
+        $this->anything;
+        $this->anything(1, 2, 3);
+        self::anythingStatic();
+    }
+}
+`,
+	})
+
+	for _, unexpected := range []string{"an undefined property Model::$id", "Model::$label", "Model::where()", "Model::create()", "Proxy::"} {
+		if hasIssueContaining(issues, level0SymbolsCode, unexpected) {
+			t.Fatalf("did not expect an issue for %s, got %#v", unexpected, issues)
+		}
+	}
+	for _, expected := range []string{
+		"Call to an undefined method Model::missing()",
+		"Access to an undefined property Model::$missingProperty",
+		"Access to undefined static property Model::$id",
+	} {
+		if !hasIssueContaining(issues, level0SymbolsCode, expected) {
+			t.Fatalf("expected %q issue, got %#v", expected, issues)
+		}
+	}
+	if !hasIssueContaining(issues, level0InvocationCode, "Method Model::format() invoked with 0 parameters, at least 1 required") {
+		t.Fatalf("expected @method arguments to be checked, got %#v", issues)
+	}
+}
+
+func TestLevel0ResolvesMagicCallsByCallKind(t *testing.T) {
+	files := map[string]string{"test.php": `<?php
+class Both {
+    public function __call($name, $arguments): string {}
+    public static function __callStatic($name, $arguments): int {}
+}
+
+class OnlyInstance {
+    public function __call($name, $arguments) {}
+}
+
+function run(Both $both) {
+    Both::b();
+    $both->c();
+    OnlyInstance::d();
+}
+`}
+	issues := runLevelOnFiles(t, 0, files)
+
+	if hasIssueContaining(issues, level0InvocationCode, "Both::b()") {
+		t.Fatalf("expected Both::b() to resolve to __callStatic, got %#v", issues)
+	}
+	if !hasIssueContaining(issues, level0InvocationCode, "Static call to instance method OnlyInstance::d().") {
+		t.Fatalf("expected a static call handled only by __call to be reported, got %#v", issues)
+	}
+
+	parsed := map[string][]ast.Node{}
+	for filename, php := range files {
+		parsed[filename] = parsePHPForLevel0(t, php)
+	}
+	project := BuildProjectIndex(parsed)
+	if method, ok := project.ResolveStaticMethod("Both", "b"); !ok || !method.IsStatic || method.ReturnType != "int" {
+		t.Fatalf("expected a static call to use __callStatic, got %#v", method)
+	}
+	if method, ok := project.ResolveMethod("Both", "c"); !ok || method.IsStatic || method.ReturnType != "string" {
+		t.Fatalf("expected an instance call to use __call, got %#v", method)
+	}
+}
+
+func TestProjectIndexMarksMagicMembers(t *testing.T) {
+	project := BuildProjectIndex(map[string][]ast.Node{"test.php": parsePHPForLevel0(t, `<?php
+namespace App;
+
+/** @property-read Money $total */
+class Order {
+    public int $id;
+}
+
+/** @mixin Order */
+class Decorator {
+    public function __get(string $name): string {}
+}`)})
+
+	if property, ok := project.ResolveProperty(`App\Order`, "id"); !ok || property.Magic {
+		t.Fatalf("expected a declared property, got %#v", property)
+	}
+	if property, ok := project.ResolveProperty(`App\Order`, "total"); !ok || !property.Magic || !property.Readonly || property.Type != `App\Money` {
+		t.Fatalf("expected a read-only magic property, got %#v", property)
+	}
+	if property, ok := project.ResolveProperty(`App\Decorator`, "id"); !ok || !property.Magic || property.Type != "int" {
+		t.Fatalf("expected the mixin property, got %#v", property)
+	}
+	if property, ok := project.ResolveProperty(`App\Decorator`, "other"); !ok || !property.Magic || property.Type != "string" {
+		t.Fatalf("expected the __get return type, got %#v", property)
+	}
+	if _, ok := project.ResolveMethod(`App\Decorator`, "__construct"); ok {
+		t.Fatal("constructors must not be resolved magically")
+	}
+}
+
 func TestLevel0IssetAndEmptyAllowUndefinedVariables(t *testing.T) {
 	issues := runLevel0OnFiles(t, map[string]string{
 		"test.php": `<?php
@@ -1153,3 +1465,280 @@ function bad(): int {
 		}
 	}
 }
+
+func TestPHPStanLevelRulesAreNotRunBelowTheirLevel(t *testing.T) {
+	tests := []struct {
+		name  string
+		level int
+		code  string
+		php   string
+	}{
+		{
+			name:  "unknown methods on inferred receivers",
+			level: 2,
+			code:  level2MethodsCode,
+			php: `<?php
+class Repository {}
+function show(): void {
+    (new Repository())->missing();
+}
+`,
+		},
+		{
+			name:  "decided type checks",
+			level: 4,
+			code:  impossibleCheckCode,
+			php: `<?php
+function check(string $text): void {
+    if (is_string($text)) {}
+}
+`,
+		},
+		{
+			name:  "unhandled match values",
+			level: 4,
+			code:  unhandledMatchCode,
+			php: `<?php
+enum Suit {
+    case Hearts;
+    case Spades;
+}
+function describe(Suit $suit): string {
+    return match ($suit) {
+        Suit::Hearts => 'red',
+    };
+}
+`,
+		},
+		{
+			name:  "missing types",
+			level: 6,
+			code:  missingParameterTypeCode,
+			php: `<?php
+function helper($value): void {}
+`,
+		},
+		{
+			name:  "nullable uses",
+			level: 8,
+			code:  level8NullSafetyCode,
+			php: `<?php
+class User {
+    public function getName(): string { return ''; }
+}
+function show(?User $user): string {
+    return $user->getName();
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := map[string]string{"test.php": tt.php}
+			if got := countIssueContaining(runLevelOnFiles(t, tt.level, files), tt.code, ""); got != 1 {
+				t.Fatalf("expected one %s issue at level %d, got %d", tt.code, tt.level, got)
+			}
+			if issues := runLevelOnFiles(t, tt.level-1, files); countIssueContaining(issues, tt.code, "") != 0 {
+				t.Fatalf("expected %s to be skipped at level %d, got %#v", tt.code, tt.level-1, issues)
+			}
+		})
+	}
+}
+
+func TestLevel0BranchAssignedVariableIsOnlyPossiblyUndefined(t *testing.T) {
+	files := map[string]string{
+		"test.php": `<?php
+function f($x) {
+    if ($x) {
+        $y = 1;
+    }
+    echo $y;
+    if ($x) {
+        $z = 1;
+    } else {
+        $z = 2;
+    }
+    return $z;
+}
+`,
+	}
+
+	level0 := runLevel0OnFiles(t, files)
+	if hasIssueContaining(level0, level0VariablesCode, "$y") || hasIssueContaining(level0, level1VariablesCode, "$y") {
+		t.Fatalf("possibly undefined variable should not be reported at level 0, got %#v", level0)
+	}
+	level1 := runLevelOnFiles(t, 1, files)
+	if !hasIssueContaining(level1, level1VariablesCode, "Variable $y might not be defined.") {
+		t.Fatalf("expected possibly undefined variable issue at level 1, got %#v", level1)
+	}
+	if hasIssueContaining(level1, level1VariablesCode, "$z") {
+		t.Fatalf("variable assigned on both branches should not be reported, got %#v", level1)
+	}
+}
+
+func TestLevel1DefiniteAssignmentSources(t *testing.T) {
+	issues := runLevelOnFiles(t, 1, map[string]string{
+		"test.php": `<?php
+class Filler {
+    public function fill(&$target) { $target = 1; }
+}
+function f(&$ref, Filler $filler) {
+    global $config;
+    static $calls = 0;
+    list($a, $b) = [1, 2];
+    [$c, [$d]] = [3, [4]];
+    preg_match('/x/', 's', $matches);
+    $filler->fill($filled);
+    for ($i = 0; $i < 3; $i++) {
+        echo $i;
+    }
+    if (isset($cached)) {
+        return $cached;
+    }
+    if (empty($fallback)) {
+        $fallback = 1;
+    }
+    $value = $maybe ?? 1;
+    do {
+        $tries = 1;
+    } while (false);
+    return [$ref, $config, $calls, $a, $b, $c, $d, $matches, $filled, $fallback, $value, $tries];
+}
+`,
+	})
+
+	for _, issue := range issues {
+		if issue.Code == level0VariablesCode || issue.Code == level1VariablesCode {
+			t.Fatalf("expected no variable issues, got %#v", issues)
+		}
+	}
+}
+
+func TestLevel1UnsetAndExtractAffectDefinedness(t *testing.T) {
+	issues := runLevelOnFiles(t, 1, map[string]string{
+		"test.php": `<?php
+function removed() {
+    $gone = 1;
+    unset($gone);
+    return $gone;
+}
+function extracted(array $data) {
+    extract($data);
+    return $anything;
+}
+function caught() {
+    try {
+        $result = compute();
+    } catch (Exception $e) {
+        return [$e, $result];
+    }
+    return $result;
+}
+`,
+	})
+
+	if !hasIssueContaining(issues, level0VariablesCode, "Undefined variable: $gone") {
+		t.Fatalf("expected unset variable to be undefined, got %#v", issues)
+	}
+	if hasIssueContaining(issues, level0VariablesCode, "$anything") || hasIssueContaining(issues, level1VariablesCode, "$anything") {
+		t.Fatalf("extract() should suppress undefined variable issues, got %#v", issues)
+	}
+	if countIssueContaining(issues, level1VariablesCode, "Variable $result might not be defined.") != 1 {
+		t.Fatalf("expected $result to be possibly undefined only inside the catch, got %#v", issues)
+	}
+}
+
+type fakeClassLoader struct {
+	t     *testing.T
+	files map[string]string
+	loads map[string]int
+}
+
+func (l *fakeClassLoader) LoadClass(name string) (string, []ast.Node, bool) {
+	path := "vendor/" + strings.ReplaceAll(name, `\`, "/") + ".php"
+	source, ok := l.files[path]
+	if !ok {
+		return "", nil, false
+	}
+	l.loads[path]++
+	return path, parsePHPForLevel0(l.t, source), true
+}
+
+func TestLevel0LoadsMissingClassesThroughClassLoader(t *testing.T) {
+	loader := &fakeClassLoader{t: t, loads: map[string]int{}, files: map[string]string{
+		"vendor/Acme/Console/Command.php": `<?php
+namespace Acme\Console;
+
+abstract class Command implements CommandInterface
+{
+    public const SUCCESS = 0;
+
+    final public function run(): int { return missing_vendor_function(); }
+
+    abstract protected function execute(): int;
+}
+`,
+		"vendor/Acme/Console/CommandInterface.php": `<?php
+namespace Acme\Console;
+
+interface CommandInterface
+{
+    public function getName(): string;
+}
+`,
+	}}
+	ConfigureClassLoader(loader)
+	t.Cleanup(func() { ConfigureClassLoader(nil) })
+
+	issues := runLevel0OnFiles(t, map[string]string{
+		"src/GreetCommand.php": `<?php
+namespace App;
+
+use Acme\Console\Command;
+use Acme\Console\Missing;
+
+class GreetCommand extends Command
+{
+    public function run(): int { return self::SUCCESS; }
+
+    protected function execute(): int { return 0; }
+
+    public function getName(): string { return 'greet'; }
+}
+
+class BrokenCommand extends Command
+{
+    public function getName(): string { return 'broken'; }
+}
+
+function make(): Missing
+{
+    return new Missing();
+}
+`,
+	})
+
+	if !hasIssueContaining(issues, level0ClassModelCode, `Cannot override final method Acme\Console\Command::run()`) {
+		t.Fatalf("expected the final method of an autoloaded class to be enforced, got %#v", issues)
+	}
+	if !hasIssueContaining(issues, level0ClassModelCode, "must implement method execute()") {
+		t.Fatalf("expected abstract methods of an autoloaded class to be known, got %#v", issues)
+	}
+	if hasIssueContaining(issues, level0ClassModelCode, "extends unknown class") || hasIssueContaining(issues, level0SymbolsCode, "SUCCESS") {
+		t.Fatalf("expected autoloaded class and constant to be known, got %#v", issues)
+	}
+	if !hasIssueContaining(issues, level0SymbolsCode, `Acme\Console\Missing`) {
+		t.Fatalf("expected a class the loader cannot find to stay unknown, got %#v", issues)
+	}
+	for _, issue := range issues {
+		if strings.HasPrefix(issue.Filename, "vendor/") || strings.Contains(issue.Message, "missing_vendor_function") {
+			t.Fatalf("expected autoloaded files never to be reported on, got %#v", issue)
+		}
+	}
+	for path, count := range loader.loads {
+		if count != 1 {
+			t.Fatalf("expected %s to be parsed once, parsed %d times", path, count)
+		}
+	}
+}
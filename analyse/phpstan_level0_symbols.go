@@ -54,7 +54,7 @@ func (r *PHPStanLevel0Rule) checkSymbolsAndCalls(filename string, nodes []ast.No
 					issues = append(issues, issue(filename, n.GetPos(), level0SymbolsCode, fmt.Sprintf("Call to static method %s() on an unknown class %s.", methodName, resolvedClass)))
 					return
 				}
-				method, ok := ctx.Resolver.ResolveMethod(resolvedClass, methodName)
+				method, ok := resolveStaticCallMethod(ctx, className, resolvedClass, methodName)
 				if !ok {
 					if guards.hasMethod(resolvedClass, methodName) {
 						return
@@ -142,7 +142,7 @@ func (r *PHPStanLevel0Rule) checkSymbolsAndCalls(filename string, nodes []ast.No
 			if strings.HasPrefix(n.Const, "$") {
 				propertyName := strings.TrimPrefix(n.Const, "$")
 				property, ok := ctx.Resolver.ResolveProperty(className, propertyName)
-				if !ok {
+				if !ok || property.Magic {
 					issues = append(issues, issue(filename, n.GetPos(), level0SymbolsCode, fmt.Sprintf("Access to undefined static property %s::$%s.", className, propertyName)))
 					return
 				}
@@ -2,178 +2,74 @@ package analyse
 
 import (
 	"fmt"
+	"github.com/ayanozturk/go-php-parser/analyse/cfg"
 	"github.com/ayanozturk/go-php-parser/ast"
-	"strings"
 )
 
+// checkUndefinedVariables reports reads of variables that no path assigns.
+// Reads that only some paths assign are reported at level 1 instead.
 func (r *PHPStanLevel0Rule) checkUndefinedVariables(filename string, nodes []ast.Node, ctx *AnalysisContext, fileCtx fileTypeContext) []AnalysisIssue {
-	var issues []AnalysisIssue
-	var walkStatements func([]ast.Node, map[string]bool, *ast.ClassNode, bool) map[string]bool
-	walkStatements = func(stmts []ast.Node, defined map[string]bool, class *ast.ClassNode, inFunction bool) map[string]bool {
-		for _, stmt := range stmts {
-			switch n := stmt.(type) {
-			case *ast.FunctionNode:
-				local := map[string]bool{}
-				for _, param := range n.Params {
-					if p, ok := param.(*ast.ParamNode); ok {
-						local[p.Name] = true
-					}
-				}
-				if class != nil && !hasModifier(n.Modifiers, "static") {
-					local["this"] = true
-				}
-				walkStatements(n.Body, local, class, true)
-			case *ast.ClassNode:
-				for _, method := range n.Methods {
-					if fn, ok := method.(*ast.FunctionNode); ok {
-						walkStatements([]ast.Node{fn}, defined, n, false)
-					}
-				}
-			case *ast.NamespaceNode:
-				defined = walkStatements(n.Body, defined, class, inFunction)
-			case *ast.StaticVarDeclNode:
-				for _, entry := range n.Vars {
-					if entry.Init != nil {
-						checkExprVars(filename, entry.Init, defined, &issues)
-					}
-					defined[entry.Name] = true
-				}
-			case *ast.AssignmentNode:
-				checkExprVars(filename, n.Right, defined, &issues)
-				defineAssignmentTarget(n.Left, defined)
-			case *ast.ExpressionStmt:
-				if assign, ok := n.Expr.(*ast.AssignmentNode); ok {
-					checkExprVars(filename, assign.Right, defined, &issues)
-					defineAssignmentTarget(assign.Left, defined)
-				} else {
-					checkExprVars(filename, n.Expr, defined, &issues)
-				}
-			case *ast.ReturnNode:
-				checkExprVars(filename, n.Expr, defined, &issues)
-			case *ast.ThrowNode:
-				checkExprVars(filename, n.Expr, defined, &issues)
-			case *ast.IfNode:
-				checkExprVars(filename, n.Condition, defined, &issues)
-				before := cloneBoolMap(defined)
-				thenDefined := walkStatements(n.Body, cloneBoolMap(defined), class, inFunction)
-				branchUnion := cloneBoolMap(before)
-				for k := range thenDefined {
-					branchUnion[k] = true
-				}
-				for _, elseif := range n.ElseIfs {
-					checkExprVars(filename, elseif.Condition, before, &issues)
-					ed := walkStatements(elseif.Body, cloneBoolMap(before), class, inFunction)
-					for k := range ed {
-						branchUnion[k] = true
-					}
-				}
-				if n.Else != nil {
-					ed := walkStatements(n.Else.Body, cloneBoolMap(before), class, inFunction)
-					for k := range ed {
-						branchUnion[k] = true
-					}
-				}
-				defined = branchUnion
-			case *ast.ForeachNode:
-				checkExprVars(filename, n.Expr, defined, &issues)
-				defineAssignmentTarget(n.KeyVar, defined)
-				defineAssignmentTarget(n.ValueVar, defined)
-				defined = walkStatements(n.Body, defined, class, inFunction)
-			case *ast.TryNode:
-				defined = walkStatements(n.Body, defined, class, inFunction)
-				for _, catchNode := range n.Catches {
-					catchDefined := cloneBoolMap(defined)
-					if catchNode.Variable != "" {
-						catchDefined[strings.TrimPrefix(catchNode.Variable, "$")] = true
-					}
-					walkStatements(catchNode.Body, catchDefined, class, inFunction)
-				}
-				defined = walkStatements(n.Finally, defined, class, inFunction)
-			}
-		}
-		return defined
-	}
-	defined := map[string]bool{"GLOBALS": true, "_SERVER": true, "_GET": true, "_POST": true, "_FILES": true, "_COOKIE": true, "_SESSION": true, "_REQUEST": true, "_ENV": true, "argc": true, "argv": true}
-	walkStatements(nodes, defined, nil, false)
-	return issues
+	return filterIssuesByCode(analysisVariableIssues(filename, nodes, ctx, fileCtx), level0VariablesCode)
 }
 
-func checkExprVars(filename string, node ast.Node, defined map[string]bool, issues *[]AnalysisIssue) {
-	if node == nil {
-		return
+// analysisVariableIssues runs the definite-assignment analysis over the
+// top-level code and every function body of a file. Results are cached on
+// ctx so the level 0 and level 1 rules share one pass.
+func analysisVariableIssues(filename string, nodes []ast.Node, ctx *AnalysisContext, fileCtx fileTypeContext) []AnalysisIssue {
+	if ctx != nil && ctx.hasVariableIssues {
+		return ctx.variableIssues
 	}
-	switch n := node.(type) {
-	case *ast.VariableNode:
-		if !defined[n.Name] {
-			*issues = append(*issues, issue(filename, n.GetPos(), level0VariablesCode, fmt.Sprintf("Undefined variable: $%s", n.Name)))
-		}
-	case *ast.AssignmentNode:
-		checkExprVars(filename, n.Right, defined, issues)
-		defineAssignmentTarget(n.Left, defined)
-	case *ast.FunctionCallNode:
-		name := strings.ToLower(functionCallName(n))
-		if name == "isset" || name == "empty" {
+	var issues []AnalysisIssue
+
+	topLevel := newAssignmentState()
+	topLevel.vars["argc"] = definitelyDefined
+	topLevel.vars["argv"] = definitelyDefined
+	issues = appendVariableIssues(issues, filename, analyseDefiniteAssignment(cfg.New(nodes, nil), topLevel, nil, nil, fileCtx, ctx))
+
+	walkAll(nodes, func(node ast.Node, class *ast.ClassNode, _ *ast.FunctionNode, ft fileTypeContext) {
+		fn, ok := node.(*ast.FunctionNode)
+		if !ok || fn.Name == "" {
+			// Closures are skipped: the parser does not keep their use() list.
 			return
 		}
-		if name == "compact" {
-			for _, arg := range n.Args {
-				if variableName, ok := stringLiteralValue(argumentValue(arg)); ok && !defined[variableName] {
-					*issues = append(*issues, issue(filename, arg.GetPos(), level0VariablesCode, fmt.Sprintf("Undefined variable: $%s", variableName)))
-				}
+		entry := newAssignmentState()
+		for _, param := range fn.Params {
+			if p, ok := param.(*ast.ParamNode); ok {
+				entry.vars[p.Name] = definitelyDefined
 			}
-			return
 		}
-		for _, arg := range n.Args {
-			checkExprVars(filename, argumentValue(arg), defined, issues)
+		if class != nil && !hasModifier(fn.Modifiers, "static") {
+			entry.vars["this"] = definitelyDefined
 		}
-	case *ast.MethodCallNode:
-		checkExprVars(filename, n.Object, defined, issues)
-		for _, arg := range n.Args {
-			checkExprVars(filename, argumentValue(arg), defined, issues)
-		}
-	case *ast.NewNode:
-		for _, arg := range n.Args {
-			checkExprVars(filename, argumentValue(arg), defined, issues)
-		}
-	case *ast.PropertyFetchNode:
-		checkExprVars(filename, n.Object, defined, issues)
-	case *ast.ArrayAccessNode:
-		checkExprVars(filename, n.Var, defined, issues)
-		checkExprVars(filename, n.Index, defined, issues)
-	case *ast.BinaryExpr:
-		checkExprVars(filename, n.Left, defined, issues)
-		checkExprVars(filename, n.Right, defined, issues)
-	case *ast.UnaryExpr:
-		checkExprVars(filename, n.Operand, defined, issues)
-	case *ast.TernaryExpr:
-		checkExprVars(filename, n.Condition, defined, issues)
-		checkExprVars(filename, n.IfTrue, defined, issues)
-		checkExprVars(filename, n.IfFalse, defined, issues)
-	case *ast.ArrayNode:
-		for _, element := range n.Elements {
-			checkExprVars(filename, element, defined, issues)
-		}
-	case *ast.ArrayItemNode:
-		checkExprVars(filename, n.Key, defined, issues)
-		checkExprVars(filename, n.Value, defined, issues)
-	case *ast.NamedArgumentNode:
-		checkExprVars(filename, n.Value, defined, issues)
-	case *ast.UnpackedArgumentNode:
-		checkExprVars(filename, n.Expr, defined, issues)
-	case *ast.ConcatNode:
-		for _, part := range n.Parts {
-			checkExprVars(filename, part, defined, issues)
+		graph := analysisControlFlow(ctx, class, fn, ft)
+		issues = appendVariableIssues(issues, filename, analyseDefiniteAssignment(graph, entry, class, fn, ft, ctx))
+	})
+
+	if ctx != nil {
+		ctx.variableIssues = issues
+		ctx.hasVariableIssues = true
+	}
+	return issues
+}
+
+func appendVariableIssues(issues []AnalysisIssue, filename string, uses []*variableUse) []AnalysisIssue {
+	for _, use := range uses {
+		switch {
+		case !use.mayDefined:
+			issues = append(issues, issue(filename, use.pos, level0VariablesCode, fmt.Sprintf("Undefined variable: $%s", use.name)))
+		case use.mayUndefined:
+			issues = append(issues, issue(filename, use.pos, level1VariablesCode, fmt.Sprintf("Variable $%s might not be defined.", use.name)))
 		}
 	}
+	return issues
 }
 
-func defineAssignmentTarget(node ast.Node, defined map[string]bool) {
-	switch n := node.(type) {
-	case *ast.VariableNode:
-		defined[n.Name] = true
-	case *ast.ArrayAccessNode:
-		defineAssignmentTarget(n.Var, defined)
-	case *ast.PropertyFetchNode:
-		defineAssignmentTarget(n.Object, defined)
+func filterIssuesByCode(issues []AnalysisIssue, code string) []AnalysisIssue {
+	var out []AnalysisIssue
+	for _, issue := range issues {
+		if issue.Code == code {
+			out = append(out, issue)
+		}
 	}
+	return out
 }
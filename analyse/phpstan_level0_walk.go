@@ -80,6 +80,23 @@ func walkAll(nodes []ast.Node, fn func(ast.Node, *ast.ClassNode, *ast.FunctionNo
 			for _, child := range n.Body {
 				walk(child, class, currentFn, ft)
 			}
+		case *ast.DoWhileNode:
+			for _, child := range n.Body {
+				walk(child, class, currentFn, ft)
+			}
+			walk(n.Condition, class, currentFn, ft)
+		case *ast.BlockNode:
+			for _, child := range n.Statements {
+				walk(child, class, currentFn, ft)
+			}
+		case *ast.SwitchNode:
+			walk(n.Expr, class, currentFn, ft)
+			for _, c := range n.Cases {
+				walk(c.Expr, class, currentFn, ft)
+				for _, child := range c.Body {
+					walk(child, class, currentFn, ft)
+				}
+			}
 		case *ast.ForeachNode:
 			walk(n.Expr, class, currentFn, ft)
 			walk(n.KeyVar, class, currentFn, ft)
@@ -161,6 +178,9 @@ func walkAll(nodes []ast.Node, fn func(ast.Node, *ast.ClassNode, *ast.FunctionNo
 				}
 				walk(arm.Body, class, currentFn, ft)
 			}
+		case *ast.YieldNode:
+			walk(n.Key, class, currentFn, ft)
+			walk(n.Value, class, currentFn, ft)
 		case *ast.ArrowFunctionNode:
 			for _, param := range n.Params {
 				walk(param, class, currentFn, ft)
@@ -177,3 +197,170 @@ func walkAll(nodes []ast.Node, fn func(ast.Node, *ast.ClassNode, *ast.FunctionNo
 func isStaticMethod(fn *ast.FunctionNode) bool {
 	return fn != nil && hasModifier(fn.Modifiers, "static")
 }
+
+// childNodes returns the direct children of a node, including the bodies of
+// nested classes and closures, in source order.
+func childNodes(node ast.Node) []ast.Node {
+	var out []ast.Node
+	add := func(children ...ast.Node) {
+		for _, child := range children {
+			if child != nil {
+				out = append(out, child)
+			}
+		}
+	}
+	switch n := node.(type) {
+	case *ast.NamespaceNode:
+		add(n.Body...)
+	case *ast.ClassNode:
+		add(n.Constants...)
+		add(n.Properties...)
+		add(n.Methods...)
+	case *ast.InterfaceNode:
+		add(n.Members...)
+	case *ast.TraitNode:
+		add(n.Body...)
+	case *ast.EnumNode:
+		for _, enumCase := range n.Cases {
+			if enumCase != nil {
+				add(enumCase.Value)
+			}
+		}
+		add(n.Methods...)
+	case *ast.FunctionNode:
+		for _, attribute := range n.Attributes {
+			if attribute != nil {
+				add(attribute)
+			}
+		}
+		add(n.Params...)
+		add(n.Body...)
+	case *ast.ParamNode:
+		for _, attribute := range n.Attributes {
+			if attribute != nil {
+				add(attribute)
+			}
+		}
+		add(n.DefaultValue)
+	case *ast.PropertyNode:
+		add(n.DefaultValue)
+		for i := range n.Hooks {
+			add(n.Hooks[i].Expr)
+			add(n.Hooks[i].Body...)
+		}
+	case *ast.ConstantNode:
+		add(n.Value)
+	case *ast.AttributeNode:
+		add(n.Arguments...)
+	case *ast.ExpressionStmt:
+		add(n.Expr)
+	case *ast.AssignmentNode:
+		add(n.Left, n.Right)
+	case *ast.ReturnNode:
+		add(n.Expr)
+	case *ast.ThrowNode:
+		add(n.Expr)
+	case *ast.IfNode:
+		add(n.Condition)
+		add(n.Body...)
+		for _, elseif := range n.ElseIfs {
+			if elseif != nil {
+				add(elseif.Condition)
+				add(elseif.Body...)
+			}
+		}
+		if n.Else != nil {
+			add(n.Else.Body...)
+		}
+	case *ast.WhileNode:
+		add(n.Condition)
+		add(n.Body...)
+	case *ast.DoWhileNode:
+		add(n.Body...)
+		add(n.Condition)
+	case *ast.BlockNode:
+		add(n.Statements...)
+	case *ast.DeclareNode:
+		add(n.Body)
+	case *ast.SwitchNode:
+		add(n.Expr)
+		for _, c := range n.Cases {
+			if c != nil {
+				add(c.Expr)
+				add(c.Body...)
+			}
+		}
+	case *ast.ForeachNode:
+		add(n.Expr, n.KeyVar, n.ValueVar)
+		add(n.Body...)
+	case *ast.TryNode:
+		add(n.Body...)
+		for _, catchNode := range n.Catches {
+			if catchNode != nil {
+				add(catchNode.Body...)
+			}
+		}
+		add(n.Finally...)
+	case *ast.CatchNode:
+		add(n.Body...)
+	case *ast.StaticVarDeclNode:
+		for _, entry := range n.Vars {
+			add(entry.Init)
+		}
+	case *ast.GlobalNode:
+		for _, variable := range n.Vars {
+			if variable != nil {
+				add(variable)
+			}
+		}
+	case *ast.FunctionCallNode:
+		add(n.Name)
+		add(n.Args...)
+	case *ast.MethodCallNode:
+		add(n.Object)
+		add(n.Args...)
+	case *ast.NewNode:
+		add(n.ClassExpr)
+		add(n.Args...)
+	case *ast.NamedArgumentNode:
+		add(n.Value)
+	case *ast.UnpackedArgumentNode:
+		add(n.Expr)
+	case *ast.BinaryExpr:
+		add(n.Left, n.Right)
+	case *ast.UnaryExpr:
+		add(n.Operand)
+	case *ast.TernaryExpr:
+		add(n.Condition, n.IfTrue, n.IfFalse)
+	case *ast.TypeCastNode:
+		add(n.Expr)
+	case *ast.ArrayNode:
+		add(n.Elements...)
+	case *ast.ArrayItemNode:
+		add(n.Key, n.Value)
+	case *ast.KeyValueNode:
+		add(n.Key, n.Value)
+	case *ast.ArrayAccessNode:
+		add(n.Var, n.Index)
+	case *ast.PropertyFetchNode:
+		add(n.Object)
+	case *ast.ConcatNode:
+		add(n.Parts...)
+	case *ast.InterpolatedStringLiteral:
+		add(n.Parts...)
+	case *ast.HeredocNode:
+		add(n.Parts...)
+	case *ast.MatchNode:
+		add(n.Condition)
+		for _, arm := range n.Arms {
+			add(arm.Conditions...)
+			add(arm.Body)
+		}
+	case *ast.YieldNode:
+		add(n.Key, n.Value)
+	case *ast.ArrowFunctionNode:
+		add(n.Params...)
+		add(n.Expr)
+	}
+	return out
+}
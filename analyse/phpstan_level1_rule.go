@@ -0,0 +1,21 @@
+package analyse
+
+import "github.com/ayanozturk/go-php-parser/ast"
+
+const level1VariablesCode = "PHPStan.Level1.Variables"
+
+// PHPStanLevel1Rule reports variables that are defined on some paths only,
+// the level 1 counterpart of the level 0 undefined-variable check.
+type PHPStanLevel1Rule struct{}
+
+func (r *PHPStanLevel1Rule) CheckIssues(filename string, nodes []ast.Node, ctx *AnalysisContext) []AnalysisIssue {
+	ctx = ensureLevel0Context(filename, nodes, ctx)
+	fileCtx := analysisFileTypeContext(ctx, nodes)
+	return filterIssuesByCode(analysisVariableIssues(filename, nodes, ctx, fileCtx), level1VariablesCode)
+}
+
+func init() {
+	RegisterAnalysisRuleWithLevel(level1VariablesCode, 1, "phpstan.level1", func(filename string, nodes []ast.Node, ctx *AnalysisContext) []AnalysisIssue {
+		return (&PHPStanLevel1Rule{}).CheckIssues(filename, nodes, ctx)
+	})
+}
@@ -0,0 +1,166 @@
+package analyse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+)
+
+const (
+	level2MethodsCode    = "PHPStan.Level2.Methods"
+	level2PropertiesCode = "PHPStan.Level2.Properties"
+	level2InvocationCode = "PHPStan.Level2.Invocation"
+)
+
+// PHPStanLevel2Rule checks method calls and property fetches on any
+// expression whose type is a known class: results of method and static
+// calls, typed properties, array elements and flow-typed variables. Calls on
+// $this and on class-name receivers are left to the level 0 checks, except
+// that unknown methods on class-name receivers are reported here.
+type PHPStanLevel2Rule struct{}
+
+func (r *PHPStanLevel2Rule) CheckIssues(filename string, nodes []ast.Node, ctx *AnalysisContext) []AnalysisIssue {
+	ctx = ensureLevel0Context(filename, nodes, ctx)
+	checker := &level2Checker{
+		filename: filename,
+		ctx:      ctx,
+		guards:   collectReflectionGuards(nodes, analysisFileTypeContext(ctx, nodes)),
+	}
+	walkTypedExpressions(nodes, ctx, func(node ast.Node, at typedPosition) {
+		switch n := node.(type) {
+		case *ast.MethodCallNode:
+			checker.checkMethodCall(n, at)
+		case *ast.PropertyFetchNode:
+			checker.checkPropertyFetch(n, at)
+		}
+	})
+	return checker.issues
+}
+
+type level2Checker struct {
+	filename string
+	ctx      *AnalysisContext
+	guards   reflectionGuards
+	issues   []AnalysisIssue
+}
+
+func (c *level2Checker) checkMethodCall(n *ast.MethodCallNode, at typedPosition) {
+	if isThisVariable(n.Object) || !isStaticMemberName(n.Method) {
+		return
+	}
+	knownReceiver := methodCallClassName(n.Object, at.ft) != ""
+	className, ok := c.receiverClass(n.Object, at)
+	if !ok {
+		return
+	}
+	method, ok := c.ctx.Resolver.ResolveMethod(className, n.Method)
+	if !ok {
+		if c.guards.hasMethod(className, n.Method) {
+			return
+		}
+		c.issues = append(c.issues, issue(c.filename, n.GetPos(), level2MethodsCode, fmt.Sprintf("Call to an undefined method %s::%s().", className, n.Method)))
+		return
+	}
+	if knownReceiver {
+		return
+	}
+	var issues []AnalysisIssue
+	checkMethodVisibility(c.filename, n.GetPos(), method, className, at.class, at.ft, c.ctx.Project, false, &issues)
+	checkCallArguments(c.filename, n.GetPos(), "Method "+className+"::"+method.Name+"()", method.Name, n.Args, method, &issues)
+	for _, found := range issues {
+		found.Code = level2InvocationCode
+		c.issues = append(c.issues, found)
+	}
+}
+
+func (c *level2Checker) checkPropertyFetch(n *ast.PropertyFetchNode, at typedPosition) {
+	if isThisVariable(n.Object) || !isStaticMemberName(n.Property) {
+		return
+	}
+	className, ok := c.receiverClass(n.Object, at)
+	if !ok || strings.EqualFold(className, "stdClass") {
+		return
+	}
+	property, ok := c.ctx.Resolver.ResolveProperty(className, n.Property)
+	if !ok {
+		c.issues = append(c.issues, issue(c.filename, n.GetPos(), level2PropertiesCode, fmt.Sprintf("Access to an undefined property %s::$%s.", className, n.Property)))
+		return
+	}
+	if property.Magic || property.Visibility == "public" || property.Visibility == "" {
+		return
+	}
+	declaringClass := c.propertyDeclaringClass(className, property.Name)
+	caller := callerClassName(at.class, at.ft)
+	switch property.Visibility {
+	case "private":
+		if caller == "" || (indexKey(caller) != indexKey(declaringClass) && !classUsesTrait(c.ctx.Project, caller, declaringClass)) {
+			c.issues = append(c.issues, issue(c.filename, n.GetPos(), level2PropertiesCode, fmt.Sprintf("Access to private property %s::$%s.", declaringClass, property.Name)))
+		}
+	case "protected":
+		if caller == "" || (!isSubclassOf(c.ctx.Project, caller, declaringClass) && !isSubclassOf(c.ctx.Project, declaringClass, caller) && !classUsesTrait(c.ctx.Project, caller, declaringClass)) {
+			c.issues = append(c.issues, issue(c.filename, n.GetPos(), level2PropertiesCode, fmt.Sprintf("Access to protected property %s::$%s.", declaringClass, property.Name)))
+		}
+	}
+}
+
+// receiverClass returns the class a receiver expression evaluates to. It
+// fails unless that class and every class-like it inherits from are known,
+// so members of unknown ancestors are never reported as missing.
+func (c *level2Checker) receiverClass(object ast.Node, at typedPosition) (string, bool) {
+	className := methodCallClassName(object, at.ft)
+	if className == "" {
+		inferred, ok := objectClassName(inferType(object, at.scope, c.ctx))
+		if !ok {
+			return "", false
+		}
+		className = canonicalClassName(inferred, at.scope, c.ctx)
+	}
+	if className == "" || isSpecialClassName(className) {
+		return "", false
+	}
+	resolved, ok := resolveKnownClass(className, c.ctx)
+	return resolved.Name, ok
+}
+
+// resolveKnownClass resolves a class-like whose whole lineage is known.
+func resolveKnownClass(className string, ctx *AnalysisContext) (ResolvedClass, bool) {
+	resolved, ok := ctx.Resolver.ResolveClass(className)
+	if !ok {
+		return ResolvedClass{}, false
+	}
+	if ctx.Project != nil {
+		for _, ancestor := range ctx.Project.classLineage(resolved.Name) {
+			if _, ok := ctx.Resolver.ResolveClass(ancestor); !ok {
+				return ResolvedClass{}, false
+			}
+		}
+	}
+	return resolved, true
+}
+
+// propertyDeclaringClass returns the first class of the lineage declaring a
+// property.
+func (c *level2Checker) propertyDeclaringClass(className, propertyName string) string {
+	if c.ctx.Project == nil {
+		return className
+	}
+	for _, candidate := range c.ctx.Project.classLineage(className) {
+		if _, ok := c.ctx.Project.classProperties(candidate)[strings.ToLower(propertyName)]; ok {
+			return candidate
+		}
+	}
+	return className
+}
+
+// isStaticMemberName reports whether a member name is written literally
+// rather than computed, as in $object->$name or $object->{'name'}.
+func isStaticMemberName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, "${}")
+}
+
+func init() {
+	RegisterAnalysisRuleWithLevel(level2MethodsCode, 2, "phpstan.level2", func(filename string, nodes []ast.Node, ctx *AnalysisContext) []AnalysisIssue {
+		return (&PHPStanLevel2Rule{}).CheckIssues(filename, nodes, ctx)
+	})
+}
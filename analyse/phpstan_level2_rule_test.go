@@ -0,0 +1,133 @@
+package analyse
+
+import "testing"
+
+const level2Fixture = `<?php
+class User {
+    public string $name = '';
+    private int $secret = 0;
+    public function getName(): string { return $this->name; }
+    public function save(): void {}
+    private function hidden(): void {}
+}
+class Repository {
+    public function find(int $id): ?User { return null; }
+    /** @return User[] */
+    public function all(): array { return []; }
+    public static function create(): static { return new static(); }
+}
+/** @template T */
+class Collection {
+    /** @return T|null */
+    public function first() { return null; }
+}
+class Controller {
+    private Repository $repository;
+    /** @var Collection<User> */
+    private Collection $users;
+    public function __construct() { $this->repository = new Repository(); }
+    public function repo(): Repository { return $this->repository; }
+`
+
+func TestLevel2ChecksChainedReceivers(t *testing.T) {
+	issues := runLevelOnFiles(t, 2, map[string]string{
+		"test.php": level2Fixture + `
+    public function show(): void {
+        $this->repo()->find(1)->getName();
+        $this->repo()->find(1)->getTitle();
+        $this->repository->missing();
+        Repository::create()->all();
+        Repository::create()->purge();
+        $this->repo()->find();
+        $this->repo()->find(1)->hidden();
+    }
+    public function list(): void {
+        $items = $this->repository->all();
+        $items[0]->save();
+        $items[0]->delete();
+        foreach ($items as $item) {
+            $item->publish();
+        }
+        $this->users->first()->archive();
+    }
+}
+`,
+	})
+
+	for _, needle := range []string{
+		"Call to an undefined method User::getTitle().",
+		"Call to an undefined method Repository::missing().",
+		"Call to an undefined method Repository::purge().",
+		"Call to an undefined method User::delete().",
+		"Call to an undefined method User::publish().",
+		"Call to an undefined method User::archive().",
+	} {
+		if !hasIssueContaining(issues, level2MethodsCode, needle) {
+			t.Errorf("expected %q, got %#v", needle, issues)
+		}
+	}
+	if countIssueContaining(issues, level2MethodsCode, "") != 6 {
+		t.Errorf("expected six unknown methods, got %#v", issues)
+	}
+	if !hasIssueContaining(issues, level2InvocationCode, "Method Repository::find() invoked with 0 parameters") {
+		t.Errorf("expected argument count issue, got %#v", issues)
+	}
+	if !hasIssueContaining(issues, level2InvocationCode, "Call to private method User::hidden().") {
+		t.Errorf("expected private method issue, got %#v", issues)
+	}
+}
+
+func TestLevel2ChecksPropertiesOnTypedExpressions(t *testing.T) {
+	issues := runLevelOnFiles(t, 2, map[string]string{
+		"test.php": level2Fixture + `
+    public function show(User $user): string {
+        $user->secret;
+        $this->repo()->find(1)->title;
+        return $user->name;
+    }
+}
+enum Suit: string {
+    case Hearts = 'H';
+}
+function suit(Suit $suit): string {
+    return $suit->name . $suit->value;
+}
+`,
+	})
+
+	if !hasIssueContaining(issues, level2PropertiesCode, "Access to private property User::$secret.") {
+		t.Errorf("expected private property issue, got %#v", issues)
+	}
+	if !hasIssueContaining(issues, level2PropertiesCode, "Access to an undefined property User::$title.") {
+		t.Errorf("expected undefined property issue, got %#v", issues)
+	}
+	if countIssueContaining(issues, level2PropertiesCode, "") != 2 {
+		t.Errorf("expected two property issues, got %#v", issues)
+	}
+}
+
+func TestLevel2SkipsUnknownAndNarrowedReceivers(t *testing.T) {
+	issues := runLevelOnFiles(t, 2, map[string]string{
+		"test.php": level2Fixture + `
+    public function show(mixed $value, User|Repository $either): void {
+        $value->anything();
+        $either->save();
+        if ($value instanceof User) {
+            $value->save();
+        }
+        $value instanceof Repository && $value->all();
+        $external = new Vendor\Client();
+        $external->send();
+        (new Child())->inherited();
+    }
+}
+class Child extends Vendor\Base {}
+`,
+	})
+
+	for _, code := range []string{level2MethodsCode, level2PropertiesCode, level2InvocationCode} {
+		if countIssueContaining(issues, code, "") != 0 {
+			t.Fatalf("expected no level 2 issues, got %#v", issues)
+		}
+	}
+}
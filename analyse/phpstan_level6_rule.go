@@ -0,0 +1,225 @@
+package analyse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+)
+
+const (
+	missingParameterTypeCode     = "PHPStan.Level6.MissingParameterType"
+	missingReturnTypeCode        = "PHPStan.Level6.MissingReturnType"
+	missingClosureTypeCode       = "PHPStan.Level6.MissingClosureType"
+	missingPropertyTypeCode      = "PHPStan.Level6.MissingPropertyType"
+	missingIterableValueTypeCode = "PHPStan.Level6.MissingIterableValueType"
+)
+
+// PHPStanLevel6Rule reports missing type declarations: function and method
+// parameters and return types with neither a native type nor a PHPDoc tag,
+// the same for closures and arrow functions, untyped properties, and array
+// or iterable types that do not say what they contain. Constructors,
+// destructors and __clone() need no return type. A method without its own
+// @param or @return tag inherits the type from the parent class or
+// interface method it overrides. Each kind has its own code so they can be
+// enabled one at a time.
+type PHPStanLevel6Rule struct{}
+
+func (r *PHPStanLevel6Rule) CheckIssues(filename string, nodes []ast.Node, ctx *AnalysisContext) []AnalysisIssue {
+	ctx = ensureLevel0Context(filename, nodes, ctx)
+	var issues []AnalysisIssue
+	walkAll(nodes, func(node ast.Node, class *ast.ClassNode, currentFn *ast.FunctionNode, ft fileTypeContext) {
+		className := currentClassName(class, ft)
+		report := func(pos ast.Position, code, message string) {
+			found := issue(filename, pos, code, message)
+			if className != "" {
+				found.SubjectKind = "class"
+				found.SubjectName = className
+			}
+			issues = append(issues, found)
+		}
+		switch n := node.(type) {
+		case *ast.FunctionNode:
+			if n.Name == "" {
+				checkSignatureTypes("Anonymous function", n.Pos, n.Params, n.ReturnType, n.PHPDoc, nil, missingClosureTypeCode, missingClosureTypeCode, report)
+				return
+			}
+			subject := "Function " + ft.resolveClassLike(n.Name) + "()"
+			var inherited *ResolvedMethod
+			if class != nil && currentFn == nil {
+				subject = "Method " + className + "::" + n.Name + "()"
+				inherited = inheritedMethod(className, n.Name, ctx)
+			}
+			returnType := n.ReturnType
+			if isImplicitReturnMethod(n.Name) && class != nil {
+				returnType = "void"
+			}
+			checkSignatureTypes(subject, n.Pos, n.Params, returnType, n.PHPDoc, inherited, missingParameterTypeCode, missingReturnTypeCode, report)
+		case *ast.ArrowFunctionNode:
+			checkSignatureTypes("Arrow function", n.Pos, n.Params, n.ReturnType, nil, nil, missingClosureTypeCode, missingClosureTypeCode, report)
+		case *ast.InterfaceNode:
+			interfaceName := ft.resolveClassLike(n.Name)
+			for _, member := range n.Members {
+				method, ok := member.(*ast.InterfaceMethodNode)
+				if !ok {
+					continue
+				}
+				returnType := ""
+				if method.ReturnType != nil {
+					returnType = method.ReturnType.TokenLiteral()
+				}
+				if isImplicitReturnMethod(method.Name) {
+					returnType = "void"
+				}
+				checkSignatureTypes("Method "+interfaceName+"::"+method.Name+"()", method.Pos, method.Params, returnType, method.PHPDoc, inheritedMethod(interfaceName, method.Name, ctx), missingParameterTypeCode, missingReturnTypeCode, func(pos ast.Position, code, message string) {
+					found := issue(filename, pos, code, message)
+					found.SubjectKind = "class"
+					found.SubjectName = interfaceName
+					issues = append(issues, found)
+				})
+			}
+		case *ast.PropertyNode:
+			if class == nil {
+				return
+			}
+			subject := "Property " + className + "::$" + strings.TrimPrefix(n.Name, "$")
+			typ := n.TypeHint
+			if n.PHPDoc != nil && n.PHPDoc.VarType != "" {
+				typ = n.PHPDoc.VarType
+			}
+			if typ == "" {
+				report(n.Pos, missingPropertyTypeCode, subject+" has no type specified.")
+				return
+			}
+			if iterable, ok := iterableWithoutValueType(typ); ok {
+				report(n.Pos, missingIterableValueTypeCode, fmt.Sprintf("%s type has no value type specified in iterable type %s.", subject, iterable))
+			}
+		}
+	})
+	return issues
+}
+
+// checkSignatureTypes reports the parameters and return type of a function,
+// method or closure that are not declared natively or in PHPDoc, under
+// paramCode and returnCode, and array or iterable types without a value type.
+// Types missing from doc are taken from inherited when it is set.
+func checkSignatureTypes(subject string, pos ast.Position, params []ast.Node, nativeReturn string, doc *ast.PHPDocNode, inherited *ResolvedMethod, paramCode, returnCode string, report func(ast.Position, string, string)) {
+	for i, node := range params {
+		param, ok := node.(*ast.ParamNode)
+		if !ok {
+			continue
+		}
+		name := strings.TrimPrefix(param.Name, "$")
+		typ := documentedParamType(param, doc)
+		if inherited != nil && i < len(inherited.Params) && inherited.Params[i].Type != "" && !hasParamTag(doc, name) {
+			typ = inherited.Params[i].Type
+		}
+		if typ == "" {
+			report(param.Pos, paramCode, fmt.Sprintf("%s has parameter $%s with no type specified.", subject, name))
+			continue
+		}
+		if iterable, ok := iterableWithoutValueType(typ); ok {
+			report(param.Pos, missingIterableValueTypeCode, fmt.Sprintf("%s has parameter $%s with no value type specified in iterable type %s.", subject, name, iterable))
+		}
+	}
+	typ := declaredReturnType(nativeReturn, doc)
+	if inherited != nil && inherited.ReturnType != "" && (doc == nil || strings.TrimSpace(doc.ReturnType) == "") {
+		typ = inherited.ReturnType
+	}
+	if typ == "" {
+		report(pos, returnCode, subject+" has no return type specified.")
+		return
+	}
+	if iterable, ok := iterableWithoutValueType(typ); ok {
+		report(pos, missingIterableValueTypeCode, fmt.Sprintf("%s return type has no value type specified in iterable type %s.", subject, iterable))
+	}
+}
+
+// documentedParamType returns the PHPDoc type of a parameter, or its native
+// type when there is no @param tag for it.
+func documentedParamType(param *ast.ParamNode, doc *ast.PHPDocNode) string {
+	if typ := paramTagType(doc, strings.TrimPrefix(param.Name, "$")); typ != "" {
+		return typ
+	}
+	if param.TypeHint != "" {
+		return param.TypeHint
+	}
+	if param.UnionType != nil {
+		return param.UnionType.TokenLiteral()
+	}
+	return ""
+}
+
+func hasParamTag(doc *ast.PHPDocNode, name string) bool {
+	return paramTagType(doc, name) != ""
+}
+
+func paramTagType(doc *ast.PHPDocNode, name string) string {
+	if doc == nil {
+		return ""
+	}
+	for _, documented := range doc.Params {
+		if strings.TrimPrefix(documented.Name, "$") == name && strings.TrimSpace(documented.Type) != "" {
+			return documented.Type
+		}
+	}
+	return ""
+}
+
+// inheritedMethod returns the method a class or interface method overrides
+// or implements, looking at parent classes before interfaces.
+func inheritedMethod(className, methodName string, ctx *AnalysisContext) *ResolvedMethod {
+	if ctx == nil || ctx.Resolver == nil || className == "" {
+		return nil
+	}
+	class, ok := ctx.Resolver.ResolveClass(className)
+	if !ok {
+		return nil
+	}
+	for _, parent := range append(append([]string(nil), class.Extends...), class.Implements...) {
+		if method, ok := ctx.Resolver.ResolveMethod(parent, methodName); ok && !method.Magic {
+			return &method
+		}
+	}
+	return nil
+}
+
+func declaredReturnType(native string, doc *ast.PHPDocNode) string {
+	if doc != nil && strings.TrimSpace(doc.ReturnType) != "" {
+		return doc.ReturnType
+	}
+	return strings.TrimSpace(native)
+}
+
+// iterableWithoutValueType reports whether a type contains a plain array or
+// iterable, as opposed to array<int, Foo>, Foo[], list<Foo> or a shape.
+func iterableWithoutValueType(raw string) (string, bool) {
+	for _, atom := range ParseType(raw).sortedAtoms() {
+		if atom.kind == typeKindBuiltin && (atom.key == "array" || atom.key == "iterable") {
+			return atom.key, true
+		}
+	}
+	return "", false
+}
+
+// isImplicitReturnMethod reports whether a method is exempt from declaring
+// a return type.
+func isImplicitReturnMethod(name string) bool {
+	switch strings.ToLower(name) {
+	case "__construct", "__destruct", "__clone":
+		return true
+	}
+	return false
+}
+
+func init() {
+	RegisterAnalysisRuleWithMeta(AnalysisRuleMeta{
+		Code:           missingParameterTypeCode,
+		Reports:        []string{missingReturnTypeCode, missingClosureTypeCode, missingPropertyTypeCode, missingIterableValueTypeCode},
+		Level:          6,
+		Category:       "phpstan.level6",
+		DefaultEnabled: true,
+	}, func(filename string, nodes []ast.Node, ctx *AnalysisContext) []AnalysisIssue {
+		return (&PHPStanLevel6Rule{}).CheckIssues(filename, nodes, ctx)
+	})
+}
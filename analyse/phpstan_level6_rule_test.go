@@ -0,0 +1,168 @@
+package analyse
+
+import "testing"
+
+const level6Fixture = `<?php
+namespace App;
+
+interface Repository {
+    public function find($id);
+    /** @return array */
+    public function all(): array;
+}
+
+class Report {
+    public $title;
+    /** @var array */
+    public $rows = [];
+    /** @var list<string> */
+    public array $tags = [];
+    public int $count = 0;
+
+    public function __construct($owner, private array $meta = []) {}
+
+    public function render($format) {
+        return '';
+    }
+
+    /**
+     * @param string $format
+     * @return string
+     */
+    public function documented($format) {
+        return $format;
+    }
+
+    public function rows(array $filter, iterable $source, array|string $key): array {
+        return [];
+    }
+
+    /**
+     * @param array<string, int> $filter
+     * @return int[]
+     */
+    public function typed(array $filter): array {
+        return [];
+    }
+}
+
+function helper($value) {
+    $double = function ($x) { return $x * 2; };
+    $triple = fn($x) => $x * 3;
+    $typed = fn(int $x): int => $x;
+    return $double($value) + $triple($value) + $typed(1);
+}
+`
+
+func TestLevel6ReportsMissingTypes(t *testing.T) {
+	issues := runLevelOnFiles(t, 6, map[string]string{"test.php": level6Fixture})
+
+	expected := map[string][]string{
+		missingParameterTypeCode: {
+			`Method App\Repository::find() has parameter $id with no type specified.`,
+			`Method App\Report::__construct() has parameter $owner with no type specified.`,
+			`Method App\Report::render() has parameter $format with no type specified.`,
+			`Function App\helper() has parameter $value with no type specified.`,
+		},
+		missingReturnTypeCode: {
+			`Method App\Repository::find() has no return type specified.`,
+			`Method App\Report::render() has no return type specified.`,
+			`Function App\helper() has no return type specified.`,
+		},
+		missingClosureTypeCode: {
+			"Anonymous function has parameter $x with no type specified.",
+			"Anonymous function has no return type specified.",
+			"Arrow function has parameter $x with no type specified.",
+			"Arrow function has no return type specified.",
+		},
+		missingPropertyTypeCode: {
+			`Property App\Report::$title has no type specified.`,
+		},
+		missingIterableValueTypeCode: {
+			`Method App\Repository::all() return type has no value type specified in iterable type array.`,
+			`Property App\Report::$rows type has no value type specified in iterable type array.`,
+			`Method App\Report::__construct() has parameter $meta with no value type specified in iterable type array.`,
+			`Method App\Report::rows() has parameter $filter with no value type specified in iterable type array.`,
+			`Method App\Report::rows() has parameter $source with no value type specified in iterable type iterable.`,
+			`Method App\Report::rows() has parameter $key with no value type specified in iterable type array.`,
+			`Method App\Report::rows() return type has no value type specified in iterable type array.`,
+		},
+	}
+	for code, needles := range expected {
+		for _, needle := range needles {
+			if !hasIssueContaining(issues, code, needle) {
+				t.Errorf("expected %s %q, got %#v", code, needle, issues)
+			}
+		}
+		if got := countIssueContaining(issues, code, ""); got != len(needles) {
+			t.Errorf("expected %d %s issues, got %d: %#v", len(needles), code, got, issues)
+		}
+	}
+}
+
+func TestLevel6IssuesAreAttributedToTheClass(t *testing.T) {
+	issues := runLevelOnFiles(t, 6, map[string]string{"test.php": level6Fixture})
+
+	for _, found := range issues {
+		if found.Code != missingPropertyTypeCode {
+			continue
+		}
+		if found.SubjectKind != "class" || found.SubjectName != `App\Report` {
+			t.Fatalf("expected property issue to name its class, got %#v", found)
+		}
+	}
+}
+
+func TestLevel6UsesInheritedPHPDocTypes(t *testing.T) {
+	issues := runLevelOnFiles(t, 6, map[string]string{
+		"test.php": `<?php
+namespace App;
+
+interface Source {
+    /**
+     * @param array<string, int> $filter
+     * @return array<int, string>
+     */
+    public function all(array $filter): array;
+}
+
+class Base {
+    /** @return list<string> */
+    public function names(): array {
+        return [];
+    }
+}
+
+class Plain extends Base implements Source {
+    public function all(array $filter): array {
+        return [];
+    }
+
+    public function names(): array {
+        return [];
+    }
+}
+
+class Documented implements Source {
+    /** {@inheritDoc} */
+    public function all(array $filter): array {
+        return [];
+    }
+}
+
+class Overridden implements Source {
+    /** @return array */
+    public function all(array $filter): array {
+        return [];
+    }
+}
+`,
+	})
+
+	if got := countIssueContaining(issues, missingIterableValueTypeCode, ""); got != 1 {
+		t.Fatalf("expected only the overriding @return to be reported, got %#v", issues)
+	}
+	if !hasIssueContaining(issues, missingIterableValueTypeCode, `Method App\Overridden::all() return type has no value type specified in iterable type array.`) {
+		t.Fatalf("expected the overriding @return to be reported, got %#v", issues)
+	}
+}
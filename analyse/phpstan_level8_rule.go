@@ -0,0 +1,157 @@
+package analyse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+)
+
+const level8NullSafetyCode = "PHPStan.Level8.NullSafety"
+
+// PHPStanLevel8Rule reports uses of values that may be null where null is an
+// error: method calls, property fetches and offset reads on a nullable value,
+// and nullable arguments to functions and static methods whose parameter
+// does not accept null. Instance method and constructor arguments are
+// checked by A.ARG.TYPE. Values narrowed by null comparisons, instanceof,
+// isset, truthiness or an early return are not nullable, and nothing is
+// reported under ?->, on the left of ?? or inside isset() and empty().
+type PHPStanLevel8Rule struct{}
+
+func (r *PHPStanLevel8Rule) CheckIssues(filename string, nodes []ast.Node, ctx *AnalysisContext) []AnalysisIssue {
+	ctx = ensureLevel0Context(filename, nodes, ctx)
+	var issues []AnalysisIssue
+	report := func(node ast.Node, format string, args ...interface{}) {
+		issues = append(issues, issue(filename, node.GetPos(), level8NullSafetyCode, fmt.Sprintf(format, args...)))
+	}
+	walkTypedExpressions(nodes, ctx, func(node ast.Node, at typedPosition) {
+		if at.guarded {
+			return
+		}
+		switch n := node.(type) {
+		case *ast.MethodCallNode:
+			if typ, ok := nullableReceiverType(n.Object, n.NullSafe, at.scope, ctx); ok {
+				report(n, "Cannot call method %s() on %s.", n.Method, typ)
+			}
+		case *ast.PropertyFetchNode:
+			if typ, ok := nullableReceiverType(n.Object, n.NullSafe, at.scope, ctx); ok {
+				report(n, "Cannot access property $%s on %s.", n.Property, typ)
+			}
+		case *ast.ArrayAccessNode:
+			if at.write || n.Index == nil {
+				return
+			}
+			if typ, ok := nullableReceiverType(n.Var, false, at.scope, ctx); ok {
+				report(n, "Cannot access offset %s on %s.", describeOffset(n.Index, at.scope, ctx), typ)
+			}
+		case *ast.FunctionCallNode:
+			checkNullableCallArguments(n, at, ctx, report)
+		}
+	})
+	return issues
+}
+
+// nullableReceiverType returns the type of a receiver that may be null. A
+// receiver reached through ?-> is typed without the null the short circuit
+// adds, since the rest of the chain is skipped in that case.
+func nullableReceiverType(object ast.Node, nullSafe bool, scope *functionScope, ctx *AnalysisContext) (string, bool) {
+	if nullSafe || isThisVariable(object) {
+		return "", false
+	}
+	var typ Type
+	switch n := object.(type) {
+	case *ast.MethodCallNode:
+		typ = inferMethodCallType(n, scope, ctx)
+	case *ast.PropertyFetchNode:
+		typ = inferPropertyFetchType(n, scope, ctx)
+	default:
+		typ = inferType(object, scope, ctx)
+	}
+	if !isNullableValue(typ) {
+		return "", false
+	}
+	return nullableDisplay(typ), true
+}
+
+// isNullableValue reports whether a type is null together with a known
+// non-null type. null alone and mixed are left to other checks.
+func isNullableValue(typ Type) bool {
+	if !typ.hasBuiltin("null") || typ.hasBuiltin("mixed") {
+		return false
+	}
+	return !typ.withoutBuiltin("null").IsEmpty()
+}
+
+// nullableDisplay renders a nullable type with null last, as in User|null.
+func nullableDisplay(typ Type) string {
+	return typ.withoutBuiltin("null").String() + "|null"
+}
+
+// checkNullableCallArguments reports nullable arguments passed to function
+// and static method parameters that do not accept null.
+func checkNullableCallArguments(call *ast.FunctionCallNode, at typedPosition, ctx *AnalysisContext, report func(ast.Node, string, ...interface{})) {
+	name := functionCallName(call)
+	if name == "" || at.scope == nil {
+		return
+	}
+	var target string
+	var method ResolvedMethod
+	if strings.Contains(name, "::") {
+		className, resolved, ok := resolveStaticCall(name, at.scope, ctx)
+		if !ok {
+			return
+		}
+		target = "method " + className + "::" + resolved.Name + "()"
+		method = resolved
+	} else {
+		fn, ok := ctx.Resolver.ResolveFunction(resolveFunctionNameForCall(name, at.ft, ctx))
+		if !ok {
+			return
+		}
+		target = "function " + fn.Name
+		method = ResolvedMethod{Name: fn.Name, Params: fn.Params, Templates: fn.Templates}
+	}
+	method = instantiateTemplates(method, call.Args, at.scope, ctx)
+	for i, paramIndex := range callArgumentParams(method.Params, call.Args) {
+		if paramIndex < 0 {
+			continue
+		}
+		param := method.Params[paramIndex]
+		expected := ParseType(param.Type)
+		if expected.IsEmpty() || expected.hasBuiltin("mixed") || expected.hasBuiltin("null") || param.ByRef {
+			continue
+		}
+		value := argumentValue(call.Args[i])
+		actual := inferTypeAgainst(expected, value, at.scope, ctx)
+		if !isNullableValue(actual) || !expected.AcceptsWithContext(actual.withoutBuiltin("null"), at.scope, ctx) {
+			continue
+		}
+		report(value, "Parameter #%d $%s of %s expects %s, %s given.", paramIndex+1, param.Name, target, expected.String(), nullableDisplay(actual))
+	}
+}
+
+// describeOffset renders an array offset the way PHPStan messages do: a
+// literal key as written, anything else by its type.
+func describeOffset(index ast.Node, scope *functionScope, ctx *AnalysisContext) string {
+	switch n := index.(type) {
+	case *ast.IntegerLiteral:
+		return strconv.FormatInt(n.Value, 10)
+	case *ast.IntegerNode:
+		return strconv.FormatInt(n.Value, 10)
+	case *ast.StringLiteral:
+		return quoteLiteral(strings.Trim(n.Value, `'"`))
+	case *ast.StringNode:
+		return quoteLiteral(strings.Trim(n.Value, `'"`))
+	}
+	if typ := inferType(index, scope, ctx); !typ.IsEmpty() {
+		return typ.String()
+	}
+	return "mixed"
+}
+
+func init() {
+	RegisterAnalysisRuleWithLevel(level8NullSafetyCode, 8, "phpstan.level8", func(filename string, nodes []ast.Node, ctx *AnalysisContext) []AnalysisIssue {
+		return (&PHPStanLevel8Rule{}).CheckIssues(filename, nodes, ctx)
+	})
+}
@@ -0,0 +1,117 @@
+package analyse
+
+import "testing"
+
+const level8Fixture = `<?php
+class User {
+    public ?Address $address = null;
+    public string $name = '';
+    public ?string $email = null;
+    public function getName(): string { return $this->name; }
+}
+class Address {
+    public string $city = '';
+}
+class Repository {
+    public function find(int $id): ?User { return null; }
+    public static function greet(string $name): string { return $name; }
+}
+function shout(string $text): string { return $text; }
+/** @return array<string, int>|null */
+function counts(): ?array { return null; }
+`
+
+func TestLevel8ReportsNullableUses(t *testing.T) {
+	issues := runLevelOnFiles(t, 8, map[string]string{
+		"test.php": level8Fixture + `
+function show(?User $user, Repository $repository, User|null $other): void {
+    $user->getName();
+    $repository->find(1)->getName();
+    $other->name;
+    $repository->find(2)->address->city;
+    counts()['total'];
+    shout($user?->name);
+    Repository::greet($repository->find(3)?->getName());
+}
+`,
+	})
+
+	for _, needle := range []string{
+		"Cannot call method getName() on User|null.",
+		"Cannot access property $name on User|null.",
+		"Cannot access property $address on User|null.",
+		"Cannot access property $city on Address|null.",
+		"Cannot access offset 'total' on array<string, int>|null.",
+		"Parameter #1 $text of function shout expects string, string|null given.",
+		"Parameter #1 $name of method Repository::greet() expects string, string|null given.",
+	} {
+		if !hasIssueContaining(issues, level8NullSafetyCode, needle) {
+			t.Errorf("expected %q, got %#v", needle, issues)
+		}
+	}
+	if countIssueContaining(issues, level8NullSafetyCode, "getName() on User|null") != 2 {
+		t.Errorf("expected both nullable getName() calls, got %#v", issues)
+	}
+}
+
+func TestLevel8RespectsNarrowing(t *testing.T) {
+	issues := runLevelOnFiles(t, 8, map[string]string{
+		"test.php": level8Fixture + `
+function show(?User $user, ?User $other, ?User $third, mixed $value, Repository $repository): string {
+    if ($user !== null) {
+        $user->getName();
+    }
+    if ($other instanceof User) {
+        $other->address?->city;
+    }
+    $user?->getName();
+    $user?->address?->city;
+    $name = $user->name ?? 'anonymous';
+    if (isset($third->address)) {
+        $third->name;
+    }
+    $user !== null && $user->getName();
+    $value->anything();
+    $found = $repository->find(1);
+    if ($found === null) {
+        return '';
+    }
+    shout($found->getName());
+    if (!$third) {
+        return $name;
+    }
+    return $third->getName();
+}
+function email(?User $user): string {
+    if ($user === null || $user->email === null) {
+        return '';
+    }
+    return strtoupper($user->email);
+}
+function greet(?User $user): void {
+    if (empty($user)) {
+        return;
+    }
+    $user->getName();
+}
+`,
+	})
+
+	if countIssueContaining(issues, level8NullSafetyCode, "") != 0 {
+		t.Fatalf("expected no null safety issues, got %#v", issues)
+	}
+}
+
+func TestLevel8TreatsNullDefaultsAsNullable(t *testing.T) {
+	issues := runLevelOnFiles(t, 8, map[string]string{
+		"test.php": level8Fixture + `
+function show(User $user = null): string {
+    return shout($user?->name) . $user->getName();
+}
+`,
+	})
+
+	if !hasIssueContaining(issues, level8NullSafetyCode, "Cannot call method getName() on User|null.") {
+		t.Fatalf("expected a parameter defaulting to null to be nullable, got %#v", issues)
+	}
+}
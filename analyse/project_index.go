@@ -14,6 +14,16 @@ type ProjectIndex struct {
 	Constants   map[string]struct{}
 	FileTypes   map[string]fileTypeContext
 	Duplicates  []DuplicateSymbol
+
+	// stubClasses holds the keys of classes declared by user stubs; a project
+	// declaration replaces them instead of being reported as a duplicate.
+	stubClasses map[string]struct{}
+	// lazy holds classes loaded on demand through the configured ClassLoader.
+	lazy *lazySymbols
+	// classPos records where each indexed class was declared.
+	classPos map[string]ast.Position
+	// recorder, set on views returned by WithRecorder, collects lookups.
+	recorder *DependencyRecorder
 }
 
 type DuplicateSymbol struct {
@@ -23,7 +33,15 @@ type DuplicateSymbol struct {
 }
 
 func NewProjectIndex() *ProjectIndex {
-	idx := &ProjectIndex{
+	idx := newEmptyProjectIndex()
+	idx.seedBuiltins()
+	idx.lazy = currentAutoloadSymbols()
+	return idx
+}
+
+// newEmptyProjectIndex returns an index without builtin symbols.
+func newEmptyProjectIndex() *ProjectIndex {
+	return &ProjectIndex{
 		Classes:     make(map[string]ResolvedClass),
 		Methods:     make(map[string]map[string]ResolvedMethod),
 		Properties:  make(map[string]map[string]ResolvedProperty),
@@ -31,17 +49,19 @@ func NewProjectIndex() *ProjectIndex {
 		Functions:   make(map[string]ResolvedFunction),
 		Constants:   make(map[string]struct{}),
 		FileTypes:   make(map[string]fileTypeContext),
+		stubClasses: make(map[string]struct{}),
+		classPos:    make(map[string]ast.Position),
 	}
-	idx.seedBuiltins()
-	return idx
 }
 
+// BuildProjectIndex indexes the parsed files in path order, so the first
+// declaration of a class wins regardless of map iteration.
 func BuildProjectIndex(parsed map[string][]ast.Node) *ProjectIndex {
 	idx := NewProjectIndex()
-	for filename, nodes := range parsed {
-		ft := collectFileTypeContext(nodes)
-		idx.FileTypes[filename] = ft
-		idx.indexNodes(filename, nodes, ft, "")
+	for _, filename := range sortedKeys(parsed) {
+		nodes := parsed[filename]
+		idx.FileTypes[filename] = collectFileTypeContext(nodes)
+		idx.AddFileSymbols(filename, CollectFileSymbols(filename, nodes))
 	}
 	return idx
 }
@@ -57,6 +77,7 @@ func (idx *ProjectIndex) FunctionExists(name string) bool {
 }
 
 func (idx *ProjectIndex) ConstantExists(name string) bool {
+	idx.recorder.record(constantDependency, name)
 	if _, ok := idx.Constants[indexKey(name)]; ok {
 		return true
 	}
@@ -68,10 +89,14 @@ func (idx *ProjectIndex) ConstantExists(name string) bool {
 }
 
 func (idx *ProjectIndex) ResolveClass(name string) (ResolvedClass, bool) {
+	idx.recorder.record(classDependency, name)
 	key := indexKey(name)
 	if class, ok := idx.Classes[key]; ok {
 		return class, true
 	}
+	if class, ok := idx.lazy.class(name); ok {
+		return class, true
+	}
 	if short := unqualifiedName(key); short != key && isBuiltinClassName(short) {
 		class, ok := idx.Classes[short]
 		return class, ok
@@ -82,8 +107,22 @@ func (idx *ProjectIndex) ResolveClass(name string) (ResolvedClass, bool) {
 	return ResolvedClass{}, false
 }
 
+// ResolveMethod finds a method declared in the class lineage, falling back
+// to the magic methods the lineage provides.
 func (idx *ProjectIndex) ResolveMethod(className, methodName string) (ResolvedMethod, bool) {
-	return idx.resolveMethodWithTemplates(className, methodName, nil, make(map[string]struct{}))
+	if method, ok := idx.resolveMethodWithTemplates(className, methodName, nil, make(map[string]struct{})); ok {
+		return method, true
+	}
+	return idx.resolveMagicMethod(className, methodName, false, make(map[string]struct{}))
+}
+
+// ResolveStaticMethod is ResolveMethod for a Foo::bar() call, which falls
+// back to __callStatic rather than __call.
+func (idx *ProjectIndex) ResolveStaticMethod(className, methodName string) (ResolvedMethod, bool) {
+	if method, ok := idx.resolveMethodWithTemplates(className, methodName, nil, make(map[string]struct{})); ok {
+		return method, true
+	}
+	return idx.resolveMagicMethod(className, methodName, true, make(map[string]struct{}))
 }
 
 func (idx *ProjectIndex) resolveMethodWithTemplates(className, methodName string, bindings map[string]string, seen map[string]struct{}) (ResolvedMethod, bool) {
@@ -97,14 +136,23 @@ func (idx *ProjectIndex) resolveMethodWithTemplates(className, methodName string
 	}
 	seen[key] = struct{}{}
 	defer delete(seen, key)
-	if method, found := idx.Methods[key][strings.ToLower(methodName)]; found {
+	if method, found := idx.classMethods(class.Name)[strings.ToLower(methodName)]; found {
 		method.DeclaringClass = class.Name
+		bindings = withoutTemplates(bindings, method.Templates)
 		method.ReturnType = ApplyTemplateBindings(method.ReturnType, bindings)
+		method.Templates = append([]ResolvedTemplate(nil), method.Templates...)
+		for i := range method.Templates {
+			method.Templates[i].Bound = ApplyTemplateBindings(method.Templates[i].Bound, bindings)
+		}
+		method.Params = append([]ResolvedParam(nil), method.Params...)
 		for i := range method.Params {
 			method.Params[i].Type = ApplyTemplateBindings(method.Params[i].Type, bindings)
 		}
 		return method, true
 	}
+	if method, found := idx.resolveTraitMethod(class, methodName, seen); found {
+		return method, true
+	}
 	parents := append(append([]string(nil), class.Extends...), class.Implements...)
 	for _, parentName := range parents {
 		parent, parentOK := idx.ResolveClass(parentName)
@@ -123,15 +171,29 @@ func (idx *ProjectIndex) resolveMethodWithTemplates(className, methodName string
 }
 
 func (idx *ProjectIndex) ResolveProperty(className, propertyName string) (ResolvedProperty, bool) {
-	if class, ok := idx.ResolveClass(className); ok && class.Kind == "enum" && strings.EqualFold(strings.TrimPrefix(propertyName, "$"), "value") {
-		return ResolvedProperty{Name: "value", Visibility: "public", Readonly: true}, true
+	if class, ok := idx.ResolveClass(className); ok && class.Kind == "enum" {
+		switch strings.ToLower(strings.TrimPrefix(propertyName, "$")) {
+		case "name":
+			return ResolvedProperty{Name: "name", Type: "string", Visibility: "public", Readonly: true}, true
+		case "value":
+			return ResolvedProperty{Name: "value", Visibility: "public", Readonly: true}, true
+		}
+	}
+	propertyName = strings.TrimPrefix(propertyName, "$")
+	if property, ok := idx.declaredProperty(className, propertyName); ok {
+		return property, true
 	}
+	return idx.resolveMagicProperty(className, propertyName, make(map[string]struct{}))
+}
+
+// declaredProperty finds a property declared in the class lineage.
+func (idx *ProjectIndex) declaredProperty(className, propertyName string) (ResolvedProperty, bool) {
 	for _, candidate := range idx.classLineage(className) {
-		properties := idx.Properties[indexKey(candidate)]
+		properties := idx.classProperties(candidate)
 		if properties == nil {
 			continue
 		}
-		if property, ok := properties[strings.ToLower(strings.TrimPrefix(propertyName, "$"))]; ok {
+		if property, ok := properties[strings.ToLower(propertyName)]; ok {
 			return property, true
 		}
 	}
@@ -140,7 +202,7 @@ func (idx *ProjectIndex) ResolveProperty(className, propertyName string) (Resolv
 
 func (idx *ProjectIndex) ResolveConstant(className, constantName string) (ResolvedConstant, bool) {
 	for _, candidate := range idx.classLineage(className) {
-		constants := idx.ClassConsts[indexKey(candidate)]
+		constants := idx.classConstants(candidate)
 		if constants == nil {
 			continue
 		}
@@ -153,6 +215,7 @@ func (idx *ProjectIndex) ResolveConstant(className, constantName string) (Resolv
 }
 
 func (idx *ProjectIndex) ResolveFunction(name string) (ResolvedFunction, bool) {
+	idx.recorder.record(functionDependency, name)
 	fn, ok := idx.Functions[indexKey(name)]
 	return fn, ok
 }
@@ -212,28 +275,36 @@ func (idx *ProjectIndex) indexNodes(filename string, nodes []ast.Node, ft fileTy
 				TemplateParams:        templates,
 				GenericParents:        genericParents,
 				Traits:                traitUsesFromMembers(n.Properties, ft),
+				TraitRules:            traitRulesFromMembers(n.Properties, ft),
 				Kind:                  "class",
 				Final:                 strings.Contains(n.Modifier, "final"),
 				Abstract:              strings.Contains(n.Modifier, "abstract"),
 				Readonly:              strings.Contains(n.Modifier, "readonly"),
 				ConsistentConstructor: hasPHPStanConsistentConstructorTag(n.PHPDoc),
 			}
+			class.Mixins, class.MagicMethods, class.MagicProperties = magicMembersFromPHPDoc(name, n.PHPDoc, ft, templates)
 			idx.addClass(filename, class, n.Pos)
 			idx.indexClassMembers(name, n.Properties, n.Methods, n.Constants, ft, templates)
 		case *ast.InterfaceNode:
 			name := ft.resolveClassLike(n.Name)
 			templates, genericParents := resolvedGenericMetadata(n.PHPDoc, ft)
-			idx.addClass(filename, ResolvedClass{Name: name, Extends: resolvedList(ft, n.Extends), TemplateParams: templates, GenericParents: genericParents, Kind: "interface"}, n.Pos)
+			iface := ResolvedClass{Name: name, Extends: resolvedList(ft, n.Extends), TemplateParams: templates, GenericParents: genericParents, Kind: "interface"}
+			iface.Mixins, iface.MagicMethods, iface.MagicProperties = magicMembersFromPHPDoc(name, n.PHPDoc, ft, templates)
+			idx.addClass(filename, iface, n.Pos)
 			idx.indexInterfaceMembers(name, n.Members, ft, templates)
 		case *ast.TraitNode:
 			if n.Name != nil {
 				name := ft.resolveClassLike(n.Name.Name)
-				idx.addClass(filename, ResolvedClass{Name: name, Kind: "trait"}, n.Pos)
+				idx.addClass(filename, ResolvedClass{Name: name, Traits: traitUsesFromMembers(n.Body, ft), TraitRules: traitRulesFromMembers(n.Body, ft), Kind: "trait"}, n.Pos)
 				idx.indexClassMembers(name, n.Body, nil, nil, ft, nil)
 			}
 		case *ast.EnumNode:
 			name := ft.resolveClassLike(n.Name)
-			idx.addClass(filename, ResolvedClass{Name: name, Implements: resolvedList(ft, n.Implements), Kind: "enum", Final: true}, n.Pos)
+			cases := make([]string, 0, len(n.Cases))
+			for _, enumCase := range n.Cases {
+				cases = append(cases, enumCase.Name)
+			}
+			idx.addClass(filename, ResolvedClass{Name: name, Implements: resolvedList(ft, n.Implements), Kind: "enum", Final: true, Cases: cases}, n.Pos)
 			idx.indexClassMembers(name, nil, n.Methods, nil, ft, nil)
 			for _, enumCase := range n.Cases {
 				idx.addClassConstant(name, ResolvedConstant{Name: enumCase.Name, DeclaringClass: name, Visibility: "public"})
@@ -246,8 +317,7 @@ func (idx *ProjectIndex) indexNodes(filename string, nodes []ast.Node, ft fileTy
 				idx.addMethod(currentClass, methodFromFunction(currentClass, n, ft, nil))
 				continue
 			}
-			name := ft.resolveClassLike(n.Name)
-			idx.addFunction(ResolvedFunction{Name: name, ReturnType: normalizeTypeWithContext(n.ReturnType, ft), Params: paramsFromNodes(n.Params, ft)})
+			idx.addFunction(functionFromNode(ft.resolveClassLike(n.Name), n, ft))
 		case *ast.ConstantNode:
 			idx.Constants[indexKey(ft.resolveClassLike(n.Name))] = struct{}{}
 		}
@@ -258,22 +328,27 @@ func (idx *ProjectIndex) indexClassMembers(className string, properties, methods
 	for _, propNode := range properties {
 		switch p := propNode.(type) {
 		case *ast.PropertyNode:
+			typ := p.TypeHint
+			if p.PHPDoc != nil && p.PHPDoc.VarType != "" {
+				typ = p.PHPDoc.VarType
+			}
 			idx.addProperty(className, ResolvedProperty{
-				Name:       p.Name,
-				Type:       normalizeTypeWithContext(p.TypeHint, ft),
-				Visibility: defaultVisibility(p.Visibility),
-				IsStatic:   p.IsStatic,
-				Readonly:   p.IsReadonly,
+				Name:        p.Name,
+				Type:        normalizeTemplateAwareType(typ, ft, templateNames(templateParams)),
+				Visibility:  defaultVisibility(p.Visibility),
+				IsStatic:    p.IsStatic,
+				Readonly:    p.IsReadonly,
+				Initialized: p.TypeHint != "" && p.DefaultValue != nil && len(p.Hooks) == 0,
 			})
 		case *ast.TraitUseNode:
 			// Trait use is checked by level-0 rules; no index entry needed.
 		case *ast.FunctionNode:
-			idx.addMethod(className, methodFromFunction(className, p, ft, templateParams))
+			idx.addMethodAndPromotedProperties(className, p, ft, templateParams)
 		}
 	}
 	for _, methodNode := range methods {
 		if fn, ok := methodNode.(*ast.FunctionNode); ok {
-			idx.addMethod(className, methodFromFunction(className, fn, ft, templateParams))
+			idx.addMethodAndPromotedProperties(className, fn, ft, templateParams)
 		}
 	}
 	for _, constNode := range constants {
@@ -283,6 +358,29 @@ func (idx *ProjectIndex) indexClassMembers(className string, properties, methods
 	}
 }
 
+// addMethodAndPromotedProperties indexes a method and, for a constructor,
+// the properties its promoted parameters declare.
+func (idx *ProjectIndex) addMethodAndPromotedProperties(className string, fn *ast.FunctionNode, ft fileTypeContext, templateParams []string) {
+	method := methodFromFunction(className, fn, ft, templateParams)
+	idx.addMethod(className, method)
+	if !strings.EqualFold(fn.Name, "__construct") {
+		return
+	}
+	for i, node := range fn.Params {
+		param, ok := node.(*ast.ParamNode)
+		if !ok || !param.IsPromoted || i >= len(method.Params) {
+			continue
+		}
+		idx.addProperty(className, ResolvedProperty{
+			Name:        strings.TrimPrefix(param.Name, "$"),
+			Type:        method.Params[i].Type,
+			Visibility:  defaultVisibility(param.Visibility),
+			Readonly:    param.IsReadonly,
+			Initialized: param.TypeHint != "" || param.UnionType != nil,
+		})
+	}
+}
+
 func (idx *ProjectIndex) indexInterfaceMembers(className string, members []ast.Node, ft fileTypeContext, templateParams []string) {
 	templates := templateNames(templateParams)
 	for _, member := range members {
@@ -295,7 +393,9 @@ func (idx *ProjectIndex) indexInterfaceMembers(className string, members []ast.N
 			if m.PHPDoc != nil && m.PHPDoc.ReturnType != "" {
 				returnType = m.PHPDoc.ReturnType
 			}
-			idx.addMethod(className, ResolvedMethod{Name: m.Name, DeclaringClass: className, ReturnType: normalizeTemplateAwareType(returnType, ft, templates), Params: paramsFromNodesWithPHPDoc(m.Params, m.PHPDoc, ft, templates), Visibility: "public", Abstract: true})
+			methodTemplates := resolvedTemplates(m.PHPDoc, ft, templates)
+			scoped := withTemplates(templates, methodTemplates)
+			idx.addMethod(className, ResolvedMethod{Name: m.Name, DeclaringClass: className, ReturnType: normalizeTemplateAwareType(returnType, ft, scoped), Params: paramsFromNodesWithPHPDoc(m.Params, m.PHPDoc, ft, scoped), Visibility: "public", Abstract: true, Templates: methodTemplates})
 		case *ast.ConstantNode:
 			idx.addClassConstant(className, constantFromNode(className, m, ft))
 		}
@@ -305,10 +405,17 @@ func (idx *ProjectIndex) indexInterfaceMembers(className string, members []ast.N
 func (idx *ProjectIndex) addClass(filename string, class ResolvedClass, pos ast.Position) {
 	key := indexKey(class.Name)
 	if _, exists := idx.Classes[key]; exists {
-		idx.Duplicates = append(idx.Duplicates, DuplicateSymbol{File: filename, Name: class.Name, Pos: pos})
-		return
+		if _, stub := idx.stubClasses[key]; !stub {
+			idx.Duplicates = append(idx.Duplicates, DuplicateSymbol{File: filename, Name: class.Name, Pos: pos})
+			return
+		}
+		delete(idx.stubClasses, key)
+		delete(idx.Methods, key)
+		delete(idx.Properties, key)
+		delete(idx.ClassConsts, key)
 	}
 	idx.Classes[key] = class
+	idx.classPos[key] = pos
 }
 
 func (idx *ProjectIndex) addFunction(fn ResolvedFunction) {
@@ -345,7 +452,9 @@ func methodFromFunction(className string, fn *ast.FunctionNode, ft fileTypeConte
 	if fn.PHPDoc != nil && fn.PHPDoc.ReturnType != "" {
 		returnType = fn.PHPDoc.ReturnType
 	}
-	templates := templateNames(templateParams)
+	classTemplates := templateNames(templateParams)
+	methodTemplates := resolvedTemplates(fn.PHPDoc, ft, classTemplates)
+	templates := withTemplates(classTemplates, methodTemplates)
 	return ResolvedMethod{
 		Name:           fn.Name,
 		DeclaringClass: className,
@@ -355,6 +464,24 @@ func methodFromFunction(className string, fn *ast.FunctionNode, ft fileTypeConte
 		IsStatic:       hasModifier(fn.Modifiers, "static"),
 		Abstract:       hasModifier(fn.Modifiers, "abstract"),
 		Final:          hasModifier(fn.Modifiers, "final"),
+		Templates:      methodTemplates,
+	}
+}
+
+// functionFromNode resolves a function declaration. Like methods, PHPDoc
+// types take precedence over native ones.
+func functionFromNode(name string, fn *ast.FunctionNode, ft fileTypeContext) ResolvedFunction {
+	returnType := fn.ReturnType
+	if fn.PHPDoc != nil && fn.PHPDoc.ReturnType != "" {
+		returnType = fn.PHPDoc.ReturnType
+	}
+	templates := resolvedTemplates(fn.PHPDoc, ft, nil)
+	names := withTemplates(nil, templates)
+	return ResolvedFunction{
+		Name:       name,
+		ReturnType: normalizeTemplateAwareType(returnType, ft, names),
+		Params:     paramsFromNodesWithPHPDoc(fn.Params, fn.PHPDoc, ft, names),
+		Templates:  templates,
 	}
 }
 
@@ -422,11 +549,18 @@ func paramsFromNodesWithPHPDoc(nodes []ast.Node, doc *ast.PHPDocNode, ft fileTyp
 				typ = documented
 			}
 		}
+		if isImplicitlyNullable(param, ParseType(typ)) {
+			if strings.Contains(typ, "&") {
+				typ = "(" + typ + ")"
+			}
+			typ += "|null"
+		}
 		params = append(params, ResolvedParam{
 			Name:       param.Name,
 			Type:       normalizeTemplateAwareType(typ, ft, templates),
 			HasDefault: param.DefaultValue != nil,
 			IsVariadic: param.IsVariadic,
+			ByRef:      param.IsByRef,
 		})
 	}
 	return params
@@ -457,6 +591,29 @@ func traitUsesFromMembers(members []ast.Node, ft fileTypeContext) []string {
 	return traits
 }
 
+func traitRulesFromMembers(members []ast.Node, ft fileTypeContext) []ResolvedTraitRule {
+	var rules []ResolvedTraitRule
+	for _, member := range members {
+		use, ok := member.(*ast.TraitUseNode)
+		if !ok {
+			continue
+		}
+		for _, adaptation := range use.Adaptations {
+			rule := ResolvedTraitRule{
+				Method:     adaptation.Method,
+				InsteadOf:  resolvedList(ft, adaptation.InsteadOf),
+				Alias:      adaptation.Alias,
+				Visibility: adaptation.Visibility,
+			}
+			if adaptation.Trait != "" {
+				rule.Trait = ft.resolveClassLike(adaptation.Trait)
+			}
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
 func optionalList(value string) []string {
 	if strings.TrimSpace(value) == "" {
 		return nil
@@ -542,216 +699,3 @@ func (idx *ProjectIndex) resolveKnownClassSuffix(key string) (ResolvedClass, boo
 	}
 	return ResolvedClass{}, false
 }
-
-func (idx *ProjectIndex) seedBuiltins() {
-	for _, class := range []ResolvedClass{
-		{Name: "stdClass", Kind: "class"},
-		{Name: "Exception", Kind: "class", Extends: []string{"Throwable"}},
-		{Name: "Throwable", Kind: "interface"},
-		{Name: "Error", Kind: "class", Extends: []string{"Throwable"}},
-		{Name: "DateTime", Kind: "class", Implements: []string{"DateTimeInterface"}},
-		{Name: "DateTimeImmutable", Kind: "class", Implements: []string{"DateTimeInterface"}},
-		{Name: "DateTimeInterface", Kind: "interface"},
-		{Name: "DateTimeZone", Kind: "class"},
-		{Name: "Closure", Kind: "class", Final: true},
-		{Name: "Stringable", Kind: "interface"},
-		{Name: "ReflectionClass", Kind: "class"},
-		{Name: "ReflectionException", Kind: "class", Extends: []string{"Exception"}},
-		{Name: "ReflectionFunction", Kind: "class"},
-		{Name: "ReflectionMethod", Kind: "class"},
-		{Name: "ReflectionNamedType", Kind: "class"},
-		{Name: "ReflectionObject", Kind: "class", Extends: []string{"ReflectionClass"}},
-		{Name: "ReflectionParameter", Kind: "class"},
-		{Name: "ReflectionProperty", Kind: "class"},
-		{Name: "ArrayAccess", Kind: "interface"},
-		{Name: "ArrayIterator", Kind: "class", Implements: []string{"Iterator", "Traversable"}},
-		{Name: "ArrayObject", Kind: "class", Implements: []string{"IteratorAggregate", "Traversable"}},
-		{Name: "Countable", Kind: "interface"},
-		{Name: "DateInterval", Kind: "class"},
-		{Name: "Generator", Kind: "class", Final: true},
-		{Name: "Iterator", Kind: "interface", Extends: []string{"Traversable"}},
-		{Name: "IteratorAggregate", Kind: "interface", Extends: []string{"Traversable"}},
-		{Name: "JsonException", Kind: "class", Extends: []string{"Exception"}},
-		{Name: "JsonSerializable", Kind: "interface"},
-		{Name: "SensitiveParameter", Kind: "class", Final: true},
-		{Name: "SimpleXMLElement", Kind: "class"},
-		{Name: "Traversable", Kind: "interface"},
-		{Name: "ValueError", Kind: "class", Extends: []string{"Error"}},
-	} {
-		idx.Classes[indexKey(class.Name)] = class
-	}
-	for _, className := range []string{"DateTime", "DateTimeImmutable"} {
-		idx.addMethod(className, ResolvedMethod{Name: "createFromFormat", DeclaringClass: className, ReturnType: className + "|false", Params: []ResolvedParam{{Name: "format"}, {Name: "datetime"}, {Name: "timezone", HasDefault: true}}, Visibility: "public", IsStatic: true})
-		idx.addMethod(className, ResolvedMethod{Name: "createFromInterface", DeclaringClass: className, ReturnType: className, Params: []ResolvedParam{{Name: "object"}}, Visibility: "public", IsStatic: true})
-		idx.addMethod(className, ResolvedMethod{Name: "getLastErrors", DeclaringClass: className, ReturnType: "array|false", Visibility: "public", IsStatic: true})
-	}
-	idx.addMethod("DateTime", ResolvedMethod{Name: "createFromImmutable", DeclaringClass: "DateTime", ReturnType: "DateTime", Params: []ResolvedParam{{Name: "object"}}, Visibility: "public", IsStatic: true})
-	idx.addMethod("DateTimeImmutable", ResolvedMethod{Name: "createFromMutable", DeclaringClass: "DateTimeImmutable", ReturnType: "DateTimeImmutable", Params: []ResolvedParam{{Name: "object"}}, Visibility: "public", IsStatic: true})
-	idx.addMethod("Closure", ResolvedMethod{Name: "fromCallable", DeclaringClass: "Closure", ReturnType: "Closure", Params: []ResolvedParam{{Name: "callback"}}, Visibility: "public", IsStatic: true})
-	idx.addMethod("DateTimeZone", ResolvedMethod{Name: "__construct", DeclaringClass: "DateTimeZone", Params: []ResolvedParam{{Name: "timezone"}}, Visibility: "public"})
-	idx.addMethod("DateInterval", ResolvedMethod{Name: "__construct", DeclaringClass: "DateInterval", Params: []ResolvedParam{{Name: "duration"}}, Visibility: "public"})
-	idx.addMethod("ArrayObject", ResolvedMethod{Name: "__construct", DeclaringClass: "ArrayObject", Params: []ResolvedParam{{Name: "array", HasDefault: true}, {Name: "flags", HasDefault: true}, {Name: "iteratorClass", HasDefault: true}}, Visibility: "public"})
-	idx.addMethod("Error", ResolvedMethod{Name: "__construct", DeclaringClass: "Error", Params: []ResolvedParam{{Name: "message", HasDefault: true}, {Name: "code", HasDefault: true}, {Name: "previous", HasDefault: true}}, Visibility: "public"})
-	idx.addMethod("Exception", ResolvedMethod{Name: "__construct", DeclaringClass: "Exception", Params: []ResolvedParam{{Name: "message", HasDefault: true}, {Name: "code", HasDefault: true}, {Name: "previous", HasDefault: true}}, Visibility: "public"})
-	idx.addMethod("ReflectionClass", ResolvedMethod{Name: "__construct", DeclaringClass: "ReflectionClass", Params: []ResolvedParam{{Name: "objectOrClass"}}, Visibility: "public"})
-	idx.addMethod("ReflectionMethod", ResolvedMethod{Name: "__construct", DeclaringClass: "ReflectionMethod", Params: []ResolvedParam{{Name: "objectOrMethod"}, {Name: "method", HasDefault: true}}, Visibility: "public"})
-	idx.addMethod("ReflectionProperty", ResolvedMethod{Name: "__construct", DeclaringClass: "ReflectionProperty", Params: []ResolvedParam{{Name: "class"}, {Name: "property"}}, Visibility: "public"})
-	idx.addMethod("ReflectionObject", ResolvedMethod{Name: "__construct", DeclaringClass: "ReflectionObject", Params: []ResolvedParam{{Name: "object"}}, Visibility: "public"})
-	for _, constant := range []string{"ATOM", "COOKIE", "ISO8601", "RFC822", "RFC850", "RFC1036", "RFC1123", "RFC7231", "RFC2822", "RFC3339", "RFC3339_EXTENDED", "RSS", "W3C"} {
-		idx.addClassConstant("DateTime", ResolvedConstant{Name: constant, DeclaringClass: "DateTime", Visibility: "public"})
-		idx.addClassConstant("DateTimeImmutable", ResolvedConstant{Name: constant, DeclaringClass: "DateTimeImmutable", Visibility: "public"})
-		idx.addClassConstant("DateTimeInterface", ResolvedConstant{Name: constant, DeclaringClass: "DateTimeInterface", Visibility: "public"})
-	}
-	for _, fn := range []ResolvedFunction{
-		{Name: "abs", Params: []ResolvedParam{{Name: "num"}}},
-		{Name: "addslashes", Params: []ResolvedParam{{Name: "string"}}},
-		{Name: "array_any", Params: []ResolvedParam{{Name: "array"}, {Name: "callback"}}},
-		{Name: "array_chunk", Params: []ResolvedParam{{Name: "array"}, {Name: "length"}, {Name: "preserve_keys", HasDefault: true}}},
-		{Name: "array_column", Params: []ResolvedParam{{Name: "array"}, {Name: "column_key"}, {Name: "index_key", HasDefault: true}}},
-		{Name: "array_diff", Params: []ResolvedParam{{Name: "array"}, {Name: "arrays", IsVariadic: true}}},
-		{Name: "array_fill", Params: []ResolvedParam{{Name: "start_index"}, {Name: "count"}, {Name: "value"}}},
-		{Name: "array_fill_keys", Params: []ResolvedParam{{Name: "keys"}, {Name: "value"}}},
-		{Name: "array_filter", Params: []ResolvedParam{{Name: "array"}, {Name: "callback", HasDefault: true}, {Name: "mode", HasDefault: true}}},
-		{Name: "array_intersect_assoc", Params: []ResolvedParam{{Name: "array"}, {Name: "arrays", IsVariadic: true}}},
-		{Name: "array_intersect_key", Params: []ResolvedParam{{Name: "array"}, {Name: "arrays", IsVariadic: true}}},
-		{Name: "array_key_exists", Params: []ResolvedParam{{Name: "key"}, {Name: "array"}}},
-		{Name: "array_key_first", Params: []ResolvedParam{{Name: "array"}}},
-		{Name: "array_map", Params: []ResolvedParam{{Name: "callback"}, {Name: "array"}, {Name: "arrays", IsVariadic: true}}},
-		{Name: "array_merge", Params: []ResolvedParam{{Name: "arrays", IsVariadic: true}}},
-		{Name: "array_merge_recursive", Params: []ResolvedParam{{Name: "arrays", IsVariadic: true}}},
-		{Name: "array_pop", Params: []ResolvedParam{{Name: "array"}}},
-		{Name: "array_push", Params: []ResolvedParam{{Name: "array"}, {Name: "values", IsVariadic: true}}},
-		{Name: "array_reduce", Params: []ResolvedParam{{Name: "array"}, {Name: "callback"}, {Name: "initial", HasDefault: true}}},
-		{Name: "array_search", Params: []ResolvedParam{{Name: "needle"}, {Name: "haystack"}, {Name: "strict", HasDefault: true}}},
-		{Name: "array_shift", Params: []ResolvedParam{{Name: "array"}}},
-		{Name: "array_keys", Params: []ResolvedParam{{Name: "array"}, {Name: "filter_value", HasDefault: true}, {Name: "strict", HasDefault: true}}},
-		{Name: "array_slice", Params: []ResolvedParam{{Name: "array"}, {Name: "offset"}, {Name: "length", HasDefault: true}, {Name: "preserve_keys", HasDefault: true}}},
-		{Name: "array_unique", Params: []ResolvedParam{{Name: "array"}, {Name: "flags", HasDefault: true}}},
-		{Name: "array_unshift", Params: []ResolvedParam{{Name: "array"}, {Name: "values", IsVariadic: true}}},
-		{Name: "array_sum", Params: []ResolvedParam{{Name: "array"}}},
-		{Name: "array_values", Params: []ResolvedParam{{Name: "array"}}},
-		{Name: "assert", Params: []ResolvedParam{{Name: "assertion"}, {Name: "description", HasDefault: true}}},
-		{Name: "base64_encode", Params: []ResolvedParam{{Name: "string"}}},
-		{Name: "basename", Params: []ResolvedParam{{Name: "path"}, {Name: "suffix", HasDefault: true}}},
-		{Name: "bin2hex", Params: []ResolvedParam{{Name: "string"}}},
-		{Name: "ceil", Params: []ResolvedParam{{Name: "num"}}},
-		{Name: "checkdate", Params: []ResolvedParam{{Name: "month"}, {Name: "day"}, {Name: "year"}}},
-		{Name: "class_exists", Params: []ResolvedParam{{Name: "class"}, {Name: "autoload", HasDefault: true}}},
-		{Name: "compact", Params: []ResolvedParam{{Name: "var_name"}, {Name: "var_names", IsVariadic: true}}},
-		{Name: "constant", Params: []ResolvedParam{{Name: "name"}}},
-		{Name: "count", Params: []ResolvedParam{{Name: "value"}, {Name: "mode", HasDefault: true}}},
-		{Name: "crc32", Params: []ResolvedParam{{Name: "string"}}},
-		{Name: "define", Params: []ResolvedParam{{Name: "constant_name"}, {Name: "value"}, {Name: "case_insensitive", HasDefault: true}}},
-		{Name: "defined", Params: []ResolvedParam{{Name: "constant_name"}}},
-		{Name: "die", Params: []ResolvedParam{{Name: "status", HasDefault: true}}},
-		{Name: "dirname", Params: []ResolvedParam{{Name: "path"}, {Name: "levels", HasDefault: true}}},
-		{Name: "empty", Params: []ResolvedParam{{Name: "var"}}},
-		{Name: "enum_exists", Params: []ResolvedParam{{Name: "enum"}, {Name: "autoload", HasDefault: true}}},
-		{Name: "end", Params: []ResolvedParam{{Name: "array"}}},
-		{Name: "eval", Params: []ResolvedParam{{Name: "code"}}},
-		{Name: "exit", Params: []ResolvedParam{{Name: "status", HasDefault: true}}},
-		{Name: "explode", Params: []ResolvedParam{{Name: "separator"}, {Name: "string"}, {Name: "limit", HasDefault: true}}},
-		{Name: "extension_loaded", Params: []ResolvedParam{{Name: "extension"}}},
-		{Name: "file_exists", Params: []ResolvedParam{{Name: "filename"}}},
-		{Name: "filter_var", Params: []ResolvedParam{{Name: "value"}, {Name: "filter", HasDefault: true}, {Name: "options", HasDefault: true}}},
-		{Name: "floor", Params: []ResolvedParam{{Name: "num"}}},
-		{Name: "fpassthru", Params: []ResolvedParam{{Name: "stream"}}},
-		{Name: "func_get_args"},
-		{Name: "function_exists", Params: []ResolvedParam{{Name: "function"}}},
-		{Name: "get_class", Params: []ResolvedParam{{Name: "object", HasDefault: true}}},
-		{Name: "get_object_vars", Params: []ResolvedParam{{Name: "object"}}},
-		{Name: "getenv", Params: []ResolvedParam{{Name: "name", HasDefault: true}, {Name: "local_only", HasDefault: true}}},
-		{Name: "glob", Params: []ResolvedParam{{Name: "pattern"}, {Name: "flags", HasDefault: true}}},
-		{Name: "hash", Params: []ResolvedParam{{Name: "algo"}, {Name: "data"}, {Name: "binary", HasDefault: true}, {Name: "options", HasDefault: true}}},
-		{Name: "http_build_query", Params: []ResolvedParam{{Name: "data"}, {Name: "numeric_prefix", HasDefault: true}, {Name: "arg_separator", HasDefault: true}, {Name: "encoding_type", HasDefault: true}}},
-		{Name: "htmlspecialchars", Params: []ResolvedParam{{Name: "string"}, {Name: "flags", HasDefault: true}, {Name: "encoding", HasDefault: true}, {Name: "double_encode", HasDefault: true}}},
-		{Name: "implode", Params: []ResolvedParam{{Name: "separator"}, {Name: "array", HasDefault: true}}},
-		{Name: "in_array", Params: []ResolvedParam{{Name: "needle"}, {Name: "haystack"}, {Name: "strict", HasDefault: true}}},
-		{Name: "intdiv", Params: []ResolvedParam{{Name: "num1"}, {Name: "num2"}}},
-		{Name: "intval", Params: []ResolvedParam{{Name: "value"}, {Name: "base", HasDefault: true}}},
-		{Name: "interface_exists", Params: []ResolvedParam{{Name: "interface"}, {Name: "autoload", HasDefault: true}}},
-		{Name: "isset", Params: []ResolvedParam{{Name: "var"}, {Name: "vars", IsVariadic: true}}},
-		{Name: "is_array", Params: []ResolvedParam{{Name: "value"}}},
-		{Name: "is_bool", Params: []ResolvedParam{{Name: "value"}}},
-		{Name: "is_callable", Params: []ResolvedParam{{Name: "value"}, {Name: "syntax_only", HasDefault: true}, {Name: "callable_name", HasDefault: true}}},
-		{Name: "is_countable", Params: []ResolvedParam{{Name: "value"}}},
-		{Name: "is_dir", Params: []ResolvedParam{{Name: "filename"}}},
-		{Name: "is_file", Params: []ResolvedParam{{Name: "filename"}}},
-		{Name: "is_finite", Params: []ResolvedParam{{Name: "num"}}},
-		{Name: "is_float", Params: []ResolvedParam{{Name: "value"}}},
-		{Name: "is_int", Params: []ResolvedParam{{Name: "value"}}},
-		{Name: "is_null", Params: []ResolvedParam{{Name: "value"}}},
-		{Name: "is_numeric", Params: []ResolvedParam{{Name: "value"}}},
-		{Name: "is_object", Params: []ResolvedParam{{Name: "value"}}},
-		{Name: "is_resource", Params: []ResolvedParam{{Name: "value"}}},
-		{Name: "is_scalar", Params: []ResolvedParam{{Name: "value"}}},
-		{Name: "is_string", Params: []ResolvedParam{{Name: "value"}}},
-		{Name: "iterator_count", Params: []ResolvedParam{{Name: "iterator"}}},
-		{Name: "iterator_to_array", Params: []ResolvedParam{{Name: "iterator"}, {Name: "preserve_keys", HasDefault: true}}},
-		{Name: "json_last_error"},
-		{Name: "ksort", Params: []ResolvedParam{{Name: "array"}, {Name: "flags", HasDefault: true}}},
-		{Name: "lcfirst", Params: []ResolvedParam{{Name: "string"}}},
-		{Name: "libxml_clear_errors"},
-		{Name: "libxml_get_errors"},
-		{Name: "libxml_use_internal_errors", Params: []ResolvedParam{{Name: "use_errors", HasDefault: true}}},
-		{Name: "ltrim", Params: []ResolvedParam{{Name: "string"}, {Name: "characters", HasDefault: true}}},
-		{Name: "max", Params: []ResolvedParam{{Name: "value"}, {Name: "values", IsVariadic: true}}},
-		{Name: "mb_strtoupper", Params: []ResolvedParam{{Name: "string"}, {Name: "encoding", HasDefault: true}}},
-		{Name: "mb_strlen", Params: []ResolvedParam{{Name: "string"}, {Name: "encoding", HasDefault: true}}},
-		{Name: "mb_substr", Params: []ResolvedParam{{Name: "string"}, {Name: "start"}, {Name: "length", HasDefault: true}, {Name: "encoding", HasDefault: true}}},
-		{Name: "md5", Params: []ResolvedParam{{Name: "string"}, {Name: "binary", HasDefault: true}}},
-		{Name: "method_exists", Params: []ResolvedParam{{Name: "object_or_class"}, {Name: "method"}}},
-		{Name: "microtime", Params: []ResolvedParam{{Name: "as_float", HasDefault: true}}},
-		{Name: "min", Params: []ResolvedParam{{Name: "value"}, {Name: "values", IsVariadic: true}}},
-		{Name: "number_format", Params: []ResolvedParam{{Name: "num"}, {Name: "decimals", HasDefault: true}, {Name: "decimal_separator", HasDefault: true}, {Name: "thousands_separator", HasDefault: true}}},
-		{Name: "parse_str", Params: []ResolvedParam{{Name: "string"}, {Name: "result"}}},
-		{Name: "pathinfo", Params: []ResolvedParam{{Name: "path"}, {Name: "flags", HasDefault: true}}},
-		{Name: "preg_match", Params: []ResolvedParam{{Name: "pattern"}, {Name: "subject"}, {Name: "matches", HasDefault: true}}},
-		{Name: "preg_quote", Params: []ResolvedParam{{Name: "str"}, {Name: "delimiter", HasDefault: true}}},
-		{Name: "printf", Params: []ResolvedParam{{Name: "format"}, {Name: "values", IsVariadic: true}}},
-		{Name: "random_bytes", Params: []ResolvedParam{{Name: "length"}}},
-		{Name: "reset", Params: []ResolvedParam{{Name: "array"}}},
-		{Name: "range", Params: []ResolvedParam{{Name: "start"}, {Name: "end"}, {Name: "step", HasDefault: true}}},
-		{Name: "round", Params: []ResolvedParam{{Name: "num"}, {Name: "precision", HasDefault: true}, {Name: "mode", HasDefault: true}}},
-		{Name: "rtrim", Params: []ResolvedParam{{Name: "string"}, {Name: "characters", HasDefault: true}}},
-		{Name: "serialize", Params: []ResolvedParam{{Name: "value"}}},
-		{Name: "sha1", Params: []ResolvedParam{{Name: "string"}, {Name: "binary", HasDefault: true}}},
-		{Name: "sort", Params: []ResolvedParam{{Name: "array"}, {Name: "flags", HasDefault: true}}},
-		{Name: "sprintf", Params: []ResolvedParam{{Name: "format"}, {Name: "values", IsVariadic: true}}},
-		{Name: "stripos", Params: []ResolvedParam{{Name: "haystack"}, {Name: "needle"}, {Name: "offset", HasDefault: true}}},
-		{Name: "str_contains", Params: []ResolvedParam{{Name: "haystack"}, {Name: "needle"}}},
-		{Name: "str_ends_with", Params: []ResolvedParam{{Name: "haystack"}, {Name: "needle"}}},
-		{Name: "str_pad", Params: []ResolvedParam{{Name: "string"}, {Name: "length"}, {Name: "pad_string", HasDefault: true}, {Name: "pad_type", HasDefault: true}}},
-		{Name: "str_repeat", Params: []ResolvedParam{{Name: "string"}, {Name: "times"}}},
-		{Name: "str_replace", Params: []ResolvedParam{{Name: "search"}, {Name: "replace"}, {Name: "subject"}, {Name: "count", HasDefault: true}}},
-		{Name: "str_starts_with", Params: []ResolvedParam{{Name: "haystack"}, {Name: "needle"}}},
-		{Name: "strcasecmp", Params: []ResolvedParam{{Name: "string1"}, {Name: "string2"}}},
-		{Name: "strcmp", Params: []ResolvedParam{{Name: "string1"}, {Name: "string2"}}},
-		{Name: "strlen", Params: []ResolvedParam{{Name: "string"}}},
-		{Name: "strpos", Params: []ResolvedParam{{Name: "haystack"}, {Name: "needle"}, {Name: "offset", HasDefault: true}}},
-		{Name: "strrpos", Params: []ResolvedParam{{Name: "haystack"}, {Name: "needle"}, {Name: "offset", HasDefault: true}}},
-		{Name: "strtolower", Params: []ResolvedParam{{Name: "string"}}},
-		{Name: "strtoupper", Params: []ResolvedParam{{Name: "string"}}},
-		{Name: "strtr", Params: []ResolvedParam{{Name: "string"}, {Name: "from"}, {Name: "to", HasDefault: true}}},
-		{Name: "substr", Params: []ResolvedParam{{Name: "string"}, {Name: "offset"}, {Name: "length", HasDefault: true}}},
-		{Name: "substr_count", Params: []ResolvedParam{{Name: "haystack"}, {Name: "needle"}, {Name: "offset", HasDefault: true}, {Name: "length", HasDefault: true}}},
-		{Name: "sys_get_temp_dir"},
-		{Name: "time"},
-		{Name: "trait_exists", Params: []ResolvedParam{{Name: "trait"}, {Name: "autoload", HasDefault: true}}},
-		{Name: "trim", Params: []ResolvedParam{{Name: "string"}, {Name: "characters", HasDefault: true}}},
-		{Name: "trigger_error", Params: []ResolvedParam{{Name: "message"}, {Name: "error_level", HasDefault: true}}},
-		{Name: "uasort", Params: []ResolvedParam{{Name: "array"}, {Name: "callback"}}},
-		{Name: "ucfirst", Params: []ResolvedParam{{Name: "string"}}},
-		{Name: "ucwords", Params: []ResolvedParam{{Name: "string"}, {Name: "separators", HasDefault: true}}},
-		{Name: "uniqid", Params: []ResolvedParam{{Name: "prefix", HasDefault: true}, {Name: "more_entropy", HasDefault: true}}},
-		{Name: "urlencode", Params: []ResolvedParam{{Name: "string"}}},
-		{Name: "unset", Params: []ResolvedParam{{Name: "var"}, {Name: "vars", IsVariadic: true}}},
-		{Name: "usleep", Params: []ResolvedParam{{Name: "microseconds"}}},
-		{Name: "usort", Params: []ResolvedParam{{Name: "array"}, {Name: "callback"}}},
-	} {
-		idx.Functions[indexKey(fn.Name)] = fn
-	}
-	for _, constant := range []string{"PHP_VERSION", "PHP_VERSION_ID", "PHP_MAJOR_VERSION", "PHP_MINOR_VERSION", "PHP_OS", "PHP_EOL", "true", "false", "null"} {
-		idx.Constants[indexKey(constant)] = struct{}{}
-	}
-}
@@ -0,0 +1,157 @@
+package analyse
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+)
+
+// ClassLoader finds and parses the file that declares a class the analysed
+// files do not, for example through Composer's autoload maps.
+type ClassLoader interface {
+	LoadClass(name string) (path string, nodes []ast.Node, ok bool)
+}
+
+var (
+	autoloadMu      sync.Mutex
+	autoloadSymbols *lazySymbols
+)
+
+// ConfigureClassLoader sets the loader consulted when a class is not in the
+// index; nil disables it. Symbols loaded earlier are forgotten. It must be
+// called before analysis starts.
+func ConfigureClassLoader(loader ClassLoader) {
+	autoloadMu.Lock()
+	defer autoloadMu.Unlock()
+	if loader == nil {
+		autoloadSymbols = nil
+		return
+	}
+	autoloadSymbols = &lazySymbols{loader: loader, files: make(map[string]struct{}), missing: make(map[string]struct{})}
+}
+
+func currentAutoloadSymbols() *lazySymbols {
+	autoloadMu.Lock()
+	defer autoloadMu.Unlock()
+	return autoloadSymbols
+}
+
+// lazySymbols holds the declarations of files loaded on demand. It is shared
+// by every index, including those read concurrently by analysis workers, so
+// published entries are never modified and loading is serialised.
+type lazySymbols struct {
+	loader ClassLoader
+
+	mu      sync.Mutex
+	files   map[string]struct{}
+	missing map[string]struct{}
+
+	classes     sync.Map // key -> ResolvedClass
+	methods     sync.Map // key -> map[string]ResolvedMethod
+	properties  sync.Map // key -> map[string]ResolvedProperty
+	classConsts sync.Map // key -> map[string]ResolvedConstant
+}
+
+func (l *lazySymbols) class(name string) (ResolvedClass, bool) {
+	if l == nil {
+		return ResolvedClass{}, false
+	}
+	key := indexKey(name)
+	if key == "" {
+		return ResolvedClass{}, false
+	}
+	if class, ok := l.classes.Load(key); ok {
+		return class.(ResolvedClass), true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if class, ok := l.classes.Load(key); ok {
+		return class.(ResolvedClass), true
+	}
+	if _, ok := l.missing[key]; ok {
+		return ResolvedClass{}, false
+	}
+	path, nodes, ok := l.loader.LoadClass(strings.TrimPrefix(strings.TrimSpace(name), `\`))
+	if ok {
+		if _, loaded := l.files[path]; !loaded {
+			l.files[path] = struct{}{}
+			l.publish(path, nodes)
+		}
+	}
+	if class, ok := l.classes.Load(key); ok {
+		return class.(ResolvedClass), true
+	}
+	l.missing[key] = struct{}{}
+	return ResolvedClass{}, false
+}
+
+// publish indexes a loaded file on its own and adds the classes nobody
+// declared before. Loaded files are never analysed, so duplicates are not
+// reported either.
+func (l *lazySymbols) publish(path string, nodes []ast.Node) {
+	file := newEmptyProjectIndex()
+	file.indexNodes(path, nodes, collectFileTypeContext(nodes), "")
+	for key, class := range file.Classes {
+		if _, exists := l.classes.LoadOrStore(key, class); exists {
+			continue
+		}
+		if methods, ok := file.Methods[key]; ok {
+			l.methods.Store(key, methods)
+		}
+		if properties, ok := file.Properties[key]; ok {
+			l.properties.Store(key, properties)
+		}
+		if constants, ok := file.ClassConsts[key]; ok {
+			l.classConsts.Store(key, constants)
+		}
+	}
+}
+
+// classMethods returns the methods declared by the class itself.
+func (idx *ProjectIndex) classMethods(className string) map[string]ResolvedMethod {
+	idx.recorder.record(classDependency, className)
+	key := indexKey(className)
+	if methods, ok := idx.Methods[key]; ok {
+		return methods
+	}
+	if _, ok := idx.Classes[key]; ok || idx.lazy == nil {
+		return nil
+	}
+	if methods, ok := idx.lazy.methods.Load(key); ok {
+		return methods.(map[string]ResolvedMethod)
+	}
+	return nil
+}
+
+// classProperties returns the properties declared by the class itself.
+func (idx *ProjectIndex) classProperties(className string) map[string]ResolvedProperty {
+	idx.recorder.record(classDependency, className)
+	key := indexKey(className)
+	if properties, ok := idx.Properties[key]; ok {
+		return properties
+	}
+	if _, ok := idx.Classes[key]; ok || idx.lazy == nil {
+		return nil
+	}
+	if properties, ok := idx.lazy.properties.Load(key); ok {
+		return properties.(map[string]ResolvedProperty)
+	}
+	return nil
+}
+
+// classConstants returns the constants declared by the class itself.
+func (idx *ProjectIndex) classConstants(className string) map[string]ResolvedConstant {
+	idx.recorder.record(classDependency, className)
+	key := indexKey(className)
+	if constants, ok := idx.ClassConsts[key]; ok {
+		return constants
+	}
+	if _, ok := idx.Classes[key]; ok || idx.lazy == nil {
+		return nil
+	}
+	if constants, ok := idx.lazy.classConsts.Load(key); ok {
+		return constants.(map[string]ResolvedConstant)
+	}
+	return nil
+}
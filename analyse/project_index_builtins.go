@@ -0,0 +1,241 @@
+package analyse
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ayanozturk/go-php-parser/analyse/builtins"
+	"github.com/ayanozturk/go-php-parser/ast"
+)
+
+// languageConstructs are parsed like function calls but have no stub, so the
+// generated database does not know them.
+var languageConstructs = []ResolvedFunction{
+	{Name: "die", Params: []ResolvedParam{{Name: "status", HasDefault: true}}},
+	{Name: "empty", Params: []ResolvedParam{{Name: "var"}}},
+	{Name: "eval", Params: []ResolvedParam{{Name: "code"}}},
+	{Name: "exit", Params: []ResolvedParam{{Name: "status", HasDefault: true}}},
+	{Name: "isset", Params: []ResolvedParam{{Name: "var"}, {Name: "vars", IsVariadic: true}}},
+	{Name: "unset", Params: []ResolvedParam{{Name: "var"}, {Name: "vars", IsVariadic: true}}},
+}
+
+// BuiltinOptions selects the symbols every ProjectIndex starts with.
+type BuiltinOptions struct {
+	// Extensions enables (true) or disables (false) extensions of the builtin
+	// database by name. Unlisted extensions keep their default: enabled when
+	// they ship with PHP.
+	Extensions map[string]bool
+	// Stubs are parsed stub files, keyed by path. Their declarations are
+	// known to every index but are never analysed or reported as duplicates.
+	Stubs map[string][]ast.Node
+}
+
+var (
+	builtinIndexMu sync.Mutex
+	builtinIndex   *ProjectIndex
+)
+
+// ConfigureBuiltins replaces the builtin symbols seeded into new indexes. It
+// must be called before analysis starts. It returns the configured
+// extensions the builtin database does not know, in sorted order; they are
+// ignored, since a project may depend on extensions without stubs.
+func ConfigureBuiltins(opts BuiltinOptions) []string {
+	db := builtins.Default()
+	var unknown []string
+	for name := range opts.Extensions {
+		if _, ok := db.Extension(name); !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	idx := indexFromDatabase(db, "", func(name string) bool {
+		for configured, enabled := range opts.Extensions {
+			if strings.EqualFold(configured, name) {
+				return enabled
+			}
+		}
+		return builtins.IsBundled(name)
+	})
+	idx.addStubs(opts.Stubs)
+
+	builtinIndexMu.Lock()
+	defer builtinIndexMu.Unlock()
+	builtinIndex = idx
+	return unknown
+}
+
+func builtinPrototype() *ProjectIndex {
+	builtinIndexMu.Lock()
+	defer builtinIndexMu.Unlock()
+	if builtinIndex == nil {
+		builtinIndex = indexFromDatabase(builtins.Default(), "", builtins.IsBundled)
+	}
+	return builtinIndex
+}
+
+// seedBuiltins copies the builtin and stub symbols into the index. The
+// database is converted once; every index gets its own maps so project
+// symbols never leak into the shared copy.
+func (idx *ProjectIndex) seedBuiltins() {
+	prototype := builtinPrototype()
+	for key, class := range prototype.Classes {
+		idx.Classes[key] = class
+	}
+	for key, methods := range prototype.Methods {
+		idx.Methods[key] = copyMap(methods)
+	}
+	for key, properties := range prototype.Properties {
+		idx.Properties[key] = copyMap(properties)
+	}
+	for key, constants := range prototype.ClassConsts {
+		idx.ClassConsts[key] = copyMap(constants)
+	}
+	for key, fn := range prototype.Functions {
+		idx.Functions[key] = fn
+	}
+	for key := range prototype.Constants {
+		idx.Constants[key] = struct{}{}
+	}
+	for key := range prototype.stubClasses {
+		idx.stubClasses[key] = struct{}{}
+	}
+}
+
+// addStubs indexes stub files in path order. Members a stub declares for a
+// builtin class are added to it; duplicate classes are otherwise ignored.
+func (idx *ProjectIndex) addStubs(stubs map[string][]ast.Node) {
+	paths := make([]string, 0, len(stubs))
+	for path := range stubs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	known := make(map[string]struct{}, len(idx.Classes))
+	for key := range idx.Classes {
+		known[key] = struct{}{}
+	}
+	for _, path := range paths {
+		nodes := stubs[path]
+		idx.indexNodes(path, nodes, collectFileTypeContext(nodes), "")
+	}
+	for key := range idx.Classes {
+		if _, builtin := known[key]; !builtin {
+			idx.stubClasses[key] = struct{}{}
+		}
+	}
+	idx.Duplicates = nil
+	idx.FileTypes = make(map[string]fileTypeContext)
+}
+
+// indexFromDatabase builds an index of the symbols of the enabled extensions
+// available in the given PHP version; an empty version means the newest
+// release.
+func indexFromDatabase(db *builtins.Database, version string, enabled func(extension string) bool) *ProjectIndex {
+	idx := newEmptyProjectIndex()
+	for _, ext := range db.Extensions {
+		if enabled(ext.Name) {
+			idx.addBuiltinExtension(ext, version)
+		}
+	}
+	for _, fn := range languageConstructs {
+		idx.addFunction(fn)
+	}
+	for _, constant := range []string{"true", "false", "null"} {
+		idx.Constants[constant] = struct{}{}
+	}
+	return idx
+}
+
+func (idx *ProjectIndex) addBuiltinExtension(ext builtins.Extension, version string) {
+	for _, class := range ext.Classes {
+		if !class.AvailableIn(version) {
+			continue
+		}
+		idx.Classes[indexKey(class.Name)] = ResolvedClass{
+			Name:       class.Name,
+			Extends:    class.Extends,
+			Implements: class.Implements,
+			Kind:       class.Kind,
+			Final:      class.Final,
+			Abstract:   class.Abstract,
+			Readonly:   class.Readonly,
+		}
+		for _, method := range class.Methods {
+			if !method.AvailableIn(version) {
+				continue
+			}
+			idx.addMethod(class.Name, ResolvedMethod{
+				Name:           method.Name,
+				DeclaringClass: class.Name,
+				ReturnType:     normalizeTypeWithContext(method.ReturnType, fileTypeContext{}),
+				Params:         builtinParams(method, version),
+				Visibility:     defaultVisibility(method.Visibility),
+				IsStatic:       method.Static,
+				Abstract:       method.Abstract,
+				Final:          method.Final,
+			})
+		}
+		for _, property := range class.Properties {
+			idx.addProperty(class.Name, ResolvedProperty{
+				Name:       property.Name,
+				Type:       normalizeTypeWithContext(property.Type, fileTypeContext{}),
+				Visibility: defaultVisibility(property.Visibility),
+				IsStatic:   property.Static,
+				Readonly:   property.Readonly,
+			})
+		}
+		for _, constant := range class.Constants {
+			if !constant.AvailableIn(version) {
+				continue
+			}
+			idx.addClassConstant(class.Name, ResolvedConstant{
+				Name:           constant.Name,
+				DeclaringClass: class.Name,
+				Type:           normalizeTypeWithContext(constant.Type, fileTypeContext{}),
+				Visibility:     defaultVisibility(constant.Visibility),
+				Final:          constant.Final,
+			})
+		}
+		if class.Kind == "enum" {
+			idx.addMethod(class.Name, ResolvedMethod{Name: "cases", DeclaringClass: class.Name, ReturnType: "array", Visibility: "public", IsStatic: true})
+		}
+	}
+	for _, fn := range ext.Functions {
+		if !fn.AvailableIn(version) {
+			continue
+		}
+		idx.addFunction(ResolvedFunction{
+			Name:       fn.Name,
+			ReturnType: normalizeTypeWithContext(fn.ReturnType, fileTypeContext{}),
+			Params:     builtinParams(fn, version),
+		})
+	}
+	for _, constant := range ext.Constants {
+		if constant.AvailableIn(version) {
+			idx.Constants[indexKey(constant.Name)] = struct{}{}
+		}
+	}
+}
+
+func builtinParams(fn builtins.Function, version string) []ResolvedParam {
+	params := fn.ParamsIn(version)
+	out := make([]ResolvedParam, 0, len(params))
+	for _, param := range params {
+		out = append(out, ResolvedParam{
+			Name:       strings.TrimPrefix(param.Name, "$"),
+			Type:       normalizeTypeWithContext(param.Type, fileTypeContext{}),
+			HasDefault: param.Optional,
+			IsVariadic: param.Variadic,
+			ByRef:      param.ByRef,
+		})
+	}
+	return out
+}
+
+func copyMap[K comparable, V any](in map[K]V) map[K]V {
+	out := make(map[K]V, len(in))
+	for key, value := range in {
+		out[key] = value
+	}
+	return out
+}
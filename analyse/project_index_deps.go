@@ -0,0 +1,160 @@
+package analyse
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+// Dependency kinds, the prefix of a recorded dependency.
+const (
+	classDependency      = "class:"
+	functionDependency   = "function:"
+	constantDependency   = "constant:"
+	duplicatesDependency = "duplicates:"
+)
+
+// DependencyRecorder collects the project symbols an analysis looked up, so
+// a later run can tell whether the analysis of an unchanged file would still
+// give the same result.
+type DependencyRecorder struct {
+	mu   sync.Mutex
+	deps map[string]struct{}
+}
+
+func NewDependencyRecorder() *DependencyRecorder {
+	return &DependencyRecorder{deps: make(map[string]struct{})}
+}
+
+// Dependencies returns the recorded dependencies in sorted order.
+func (r *DependencyRecorder) Dependencies() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return sortedKeys(r.deps)
+}
+
+func (r *DependencyRecorder) record(kind, name string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deps[kind+strings.TrimPrefix(strings.TrimSpace(name), `\`)] = struct{}{}
+}
+
+// WithRecorder returns a view of the index that records every lookup in rec.
+// The view shares the index's symbols and must not be indexed into.
+func (idx *ProjectIndex) WithRecorder(rec *DependencyRecorder) *ProjectIndex {
+	view := *idx
+	view.recorder = rec
+	return &view
+}
+
+// DependencyShape fingerprints what the index currently resolves a recorded
+// dependency to. A dependency whose shape is unchanged between two runs
+// resolves identically in both. The fingerprint ignores zero values, so
+// symbols loaded from the index cache, where gob turns empty slices and maps
+// into nil, keep the shape they had when they were collected.
+func (idx *ProjectIndex) DependencyShape(dep string) string {
+	base := *idx
+	base.recorder = nil
+	var shape any
+	switch {
+	case strings.HasPrefix(dep, classDependency):
+		name := strings.TrimPrefix(dep, classDependency)
+		class, ok := base.ResolveClass(name)
+		shape = []any{ok, class, base.classMembersShape(name), base.classMembersShape(class.Name)}
+	case strings.HasPrefix(dep, functionDependency):
+		fn, ok := base.ResolveFunction(strings.TrimPrefix(dep, functionDependency))
+		shape = []any{ok, fn}
+	case strings.HasPrefix(dep, constantDependency):
+		_, ok := base.Constants[indexKey(strings.TrimPrefix(dep, constantDependency))]
+		shape = ok
+	case strings.HasPrefix(dep, duplicatesDependency):
+		shape = base.duplicatesIn(strings.TrimPrefix(dep, duplicatesDependency))
+	default:
+		return ""
+	}
+	data, err := canonicalShape(shape)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalShape encodes a shape as JSON with zero values left out of
+// objects and written as null elsewhere, the way gob leaves them out.
+func canonicalShape(shape any) ([]byte, error) {
+	data, err := json.Marshal(shape)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	value, _ := withoutZeroValues(decoded)
+	return json.Marshal(value)
+}
+
+// withoutZeroValues drops zero members from decoded JSON and reports whether
+// what is left is a zero value itself.
+func withoutZeroValues(value any) (any, bool) {
+	switch v := value.(type) {
+	case nil:
+		return nil, true
+	case bool:
+		return v, !v
+	case float64:
+		return v, v == 0
+	case string:
+		return v, v == ""
+	case []any:
+		if len(v) == 0 {
+			return nil, true
+		}
+		for i, element := range v {
+			if element, zero := withoutZeroValues(element); zero {
+				v[i] = nil
+			} else {
+				v[i] = element
+			}
+		}
+		return v, false
+	case map[string]any:
+		for key, member := range v {
+			if member, zero := withoutZeroValues(member); zero {
+				delete(v, key)
+			} else {
+				v[key] = member
+			}
+		}
+		if len(v) == 0 {
+			return nil, true
+		}
+		return v, false
+	}
+	return value, false
+}
+
+func (idx *ProjectIndex) classMembersShape(className string) []any {
+	if className == "" {
+		return nil
+	}
+	return []any{idx.classMethods(className), idx.classProperties(className), idx.classConstants(className)}
+}
+
+// duplicatesIn returns the duplicate class declarations of a file.
+func (idx *ProjectIndex) duplicatesIn(filename string) []DuplicateSymbol {
+	idx.recorder.record(duplicatesDependency, filename)
+	var out []DuplicateSymbol
+	for _, duplicate := range idx.Duplicates {
+		if duplicate.File == filename {
+			out = append(out, duplicate)
+		}
+	}
+	return out
+}
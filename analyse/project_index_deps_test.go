@@ -0,0 +1,46 @@
+package analyse
+
+import (
+	"testing"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+)
+
+func TestDependencyRecorderTracksLookupsAndShapes(t *testing.T) {
+	build := func(source string) *ProjectIndex {
+		return BuildProjectIndex(map[string][]ast.Node{"lib.php": parsePHPForLevel0(t, source)})
+	}
+	before := build("<?php\nclass Base { public function run(int $a): void {} }\nclass Child extends Base {}\n")
+
+	rec := NewDependencyRecorder()
+	view := before.WithRecorder(rec)
+	if _, ok := view.ResolveMethod("Child", "run"); !ok {
+		t.Fatal("expected inherited method to resolve")
+	}
+	view.FunctionExists("helper")
+	deps := rec.Dependencies()
+	for _, want := range []string{"class:Base", "class:Child", "function:helper"} {
+		found := false
+		for _, dep := range deps {
+			found = found || dep == want
+		}
+		if !found {
+			t.Fatalf("expected dependency %s to be recorded, got %v", want, deps)
+		}
+	}
+	if before.recorder != nil {
+		t.Fatal("expected the view not to change the shared index")
+	}
+
+	same := build("<?php\nclass Base { public function run(int $a): void {} }\nclass Child extends Base {}\n")
+	changed := build("<?php\nclass Base { public function run(int $a, int $b): void {} }\nclass Child extends Base {}\n")
+	if before.DependencyShape("class:Base") != same.DependencyShape("class:Base") {
+		t.Fatal("expected an identical declaration to keep its shape")
+	}
+	if before.DependencyShape("class:Base") == changed.DependencyShape("class:Base") {
+		t.Fatal("expected a changed method signature to change the class shape")
+	}
+	if before.DependencyShape("class:Child") != changed.DependencyShape("class:Child") {
+		t.Fatal("expected the shape of a class to cover its own declaration only")
+	}
+}
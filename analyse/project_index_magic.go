@@ -0,0 +1,171 @@
+package analyse
+
+import (
+	"strings"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+)
+
+// magicMembersFromPHPDoc resolves the @mixin, @method and @property tags of
+// a class-like PHPDoc.
+func magicMembersFromPHPDoc(className string, doc *ast.PHPDocNode, ft fileTypeContext, templateParams []string) ([]string, []ResolvedMethod, []ResolvedProperty) {
+	if doc == nil {
+		return nil, nil, nil
+	}
+	templates := templateNames(templateParams)
+	var mixins []string
+	for _, mixin := range doc.Mixins {
+		mixins = append(mixins, normalizeTemplateAwareType(mixin, ft, templates))
+	}
+	var methods []ResolvedMethod
+	for _, tag := range doc.Methods {
+		method := ResolvedMethod{
+			Name:           tag.Name,
+			DeclaringClass: className,
+			ReturnType:     normalizeTemplateAwareType(tag.ReturnType, ft, templates),
+			Visibility:     "public",
+			IsStatic:       tag.Static,
+			Magic:          true,
+		}
+		for _, param := range tag.Params {
+			method.Params = append(method.Params, ResolvedParam{
+				Name:       param.Name,
+				Type:       normalizeTemplateAwareType(param.Type, ft, templates),
+				HasDefault: param.HasDefault,
+				IsVariadic: param.IsVariadic,
+				ByRef:      param.ByRef,
+			})
+		}
+		methods = append(methods, method)
+	}
+	var properties []ResolvedProperty
+	for _, tag := range doc.Properties {
+		properties = append(properties, ResolvedProperty{
+			Name:       tag.Name,
+			Type:       normalizeTemplateAwareType(tag.Type, ft, templates),
+			Visibility: "public",
+			Readonly:   tag.ReadOnly,
+			Magic:      true,
+		})
+	}
+	return mixins, methods, properties
+}
+
+// resolveMagicMethod looks a method up in the @method tags of the lineage,
+// then in its @mixin classes, and finally accepts any name when the lineage
+// declares __call or __callStatic: __callStatic for static calls and __call
+// for instance calls, or whichever of the two exists. Constructors are never
+// magic.
+func (idx *ProjectIndex) resolveMagicMethod(className, methodName string, static bool, seen map[string]struct{}) (ResolvedMethod, bool) {
+	key := indexKey(className)
+	if _, exists := seen[key]; exists || strings.EqualFold(methodName, "__construct") {
+		return ResolvedMethod{}, false
+	}
+	seen[key] = struct{}{}
+	lineage := idx.classLineage(className)
+	for _, candidate := range lineage {
+		class, ok := idx.ResolveClass(candidate)
+		if !ok {
+			continue
+		}
+		for _, method := range class.MagicMethods {
+			if strings.EqualFold(method.Name, methodName) {
+				return method, true
+			}
+		}
+	}
+	for _, candidate := range lineage {
+		class, ok := idx.ResolveClass(candidate)
+		if !ok {
+			continue
+		}
+		for _, mixin := range class.Mixins {
+			if method, ok := idx.resolveMethodWithTemplates(mixin, methodName, nil, make(map[string]struct{})); ok {
+				method.Magic = true
+				return method, true
+			}
+			if method, ok := idx.resolveMagicMethod(mixin, methodName, static, seen); ok {
+				return method, true
+			}
+		}
+	}
+	var instanceHandler, staticHandler ResolvedMethod
+	for _, candidate := range lineage {
+		methods := idx.classMethods(candidate)
+		if call, ok := methods["__call"]; ok && instanceHandler.Name == "" {
+			instanceHandler = call
+		}
+		if call, ok := methods["__callstatic"]; ok && staticHandler.Name == "" {
+			staticHandler = call
+		}
+	}
+	handler, isStatic := instanceHandler, false
+	if (static && staticHandler.Name != "") || instanceHandler.Name == "" {
+		handler, isStatic = staticHandler, true
+	}
+	if handler.Name == "" {
+		return ResolvedMethod{}, false
+	}
+	return ResolvedMethod{
+		Name:           methodName,
+		DeclaringClass: handler.DeclaringClass,
+		ReturnType:     handler.ReturnType,
+		Params:         []ResolvedParam{{Name: "arguments", IsVariadic: true}},
+		Visibility:     "public",
+		IsStatic:       isStatic,
+		Magic:          true,
+	}, true
+}
+
+// resolveMagicProperty is the property counterpart of resolveMagicMethod:
+// @property tags first, then @mixin classes, then __get or __set.
+func (idx *ProjectIndex) resolveMagicProperty(className, propertyName string, seen map[string]struct{}) (ResolvedProperty, bool) {
+	key := indexKey(className)
+	if _, exists := seen[key]; exists {
+		return ResolvedProperty{}, false
+	}
+	seen[key] = struct{}{}
+	lineage := idx.classLineage(className)
+	for _, candidate := range lineage {
+		class, ok := idx.ResolveClass(candidate)
+		if !ok {
+			continue
+		}
+		for _, property := range class.MagicProperties {
+			if strings.EqualFold(property.Name, propertyName) {
+				return property, true
+			}
+		}
+	}
+	for _, candidate := range lineage {
+		class, ok := idx.ResolveClass(candidate)
+		if !ok {
+			continue
+		}
+		for _, mixin := range class.Mixins {
+			if property, ok := idx.declaredProperty(mixin, propertyName); ok && !property.IsStatic {
+				property.Magic = true
+				return property, true
+			}
+			if property, ok := idx.resolveMagicProperty(mixin, propertyName, seen); ok {
+				return property, true
+			}
+		}
+	}
+	for _, candidate := range lineage {
+		methods := idx.classMethods(candidate)
+		getter, hasGetter := methods["__get"]
+		setter, hasSetter := methods["__set"]
+		if !hasGetter && !hasSetter {
+			continue
+		}
+		property := ResolvedProperty{Name: propertyName, Visibility: "public", Magic: true}
+		if hasGetter {
+			property.Type = getter.ReturnType
+		} else if len(setter.Params) > 1 {
+			property.Type = setter.Params[1].Type
+		}
+		return property, true
+	}
+	return ResolvedProperty{}, false
+}
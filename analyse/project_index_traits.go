@@ -0,0 +1,106 @@
+package analyse
+
+import "strings"
+
+// resolveTraitMethod finds a method a class imports from its traits. Trait
+// methods behave as if the using class declared them, so the class is
+// reported as their declaring class. insteadof rules pick between colliding
+// traits; as rules add aliases or change the visibility.
+func (idx *ProjectIndex) resolveTraitMethod(class ResolvedClass, methodName string, seen map[string]struct{}) (ResolvedMethod, bool) {
+	for _, rule := range class.TraitRules {
+		if rule.Alias == "" || !strings.EqualFold(rule.Alias, methodName) {
+			continue
+		}
+		if method, ok := idx.traitMethod(class, rule.Trait, rule.Method, seen); ok {
+			method.Name = rule.Alias
+			if rule.Visibility != "" {
+				method.Visibility = rule.Visibility
+			}
+			method.DeclaringClass = class.Name
+			return method, true
+		}
+	}
+	for _, trait := range class.Traits {
+		if class.excludesTraitMethod(trait, methodName) {
+			continue
+		}
+		method, ok := idx.resolveMethodWithTemplates(trait, methodName, nil, seen)
+		if !ok {
+			continue
+		}
+		for _, rule := range class.TraitRules {
+			if rule.Alias == "" && rule.Visibility != "" && strings.EqualFold(rule.Method, methodName) && (rule.Trait == "" || indexKey(rule.Trait) == indexKey(trait)) {
+				method.Visibility = rule.Visibility
+			}
+		}
+		method.DeclaringClass = class.Name
+		return method, true
+	}
+	return ResolvedMethod{}, false
+}
+
+// traitMethod resolves the method an adaptation rule refers to, in the named
+// trait or, for an unqualified rule, in the first used trait declaring it.
+func (idx *ProjectIndex) traitMethod(class ResolvedClass, trait, methodName string, seen map[string]struct{}) (ResolvedMethod, bool) {
+	if trait != "" {
+		return idx.resolveMethodWithTemplates(trait, methodName, nil, seen)
+	}
+	for _, candidate := range class.Traits {
+		if method, ok := idx.resolveMethodWithTemplates(candidate, methodName, nil, seen); ok {
+			return method, true
+		}
+	}
+	return ResolvedMethod{}, false
+}
+
+// excludesTraitMethod reports whether an insteadof rule excludes the method
+// of the given trait.
+func (class ResolvedClass) excludesTraitMethod(trait, methodName string) bool {
+	for _, rule := range class.TraitRules {
+		if !strings.EqualFold(rule.Method, methodName) {
+			continue
+		}
+		for _, excluded := range rule.InsteadOf {
+			if indexKey(excluded) == indexKey(trait) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// traitCollision is a method two used traits declare that neither the class
+// nor an insteadof rule resolves.
+type traitCollision struct {
+	Method  string
+	Trait   string
+	Applied string
+}
+
+// traitCollisions lists the unresolved trait method collisions of a class.
+// Abstract trait methods never collide.
+func (idx *ProjectIndex) traitCollisions(class ResolvedClass) []traitCollision {
+	own := idx.classMethods(class.Name)
+	applied := map[string]string{}
+	var collisions []traitCollision
+	for _, trait := range class.Traits {
+		methods := idx.classMethods(trait)
+		for _, key := range sortedKeys(methods) {
+			method := methods[key]
+			if method.Abstract || class.excludesTraitMethod(trait, key) {
+				continue
+			}
+			if _, declared := own[key]; declared {
+				continue
+			}
+			if first, exists := applied[key]; exists {
+				if indexKey(first) != indexKey(trait) {
+					collisions = append(collisions, traitCollision{Method: method.Name, Trait: trait, Applied: first})
+				}
+				continue
+			}
+			applied[key] = trait
+		}
+	}
+	return collisions
+}
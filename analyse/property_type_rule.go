@@ -127,7 +127,7 @@ func walkAssignmentForPropertyTypes(assign *ast.AssignmentNode, scope *functionS
 		return
 	}
 
-	actual := inferType(assign.Right, scope, ctx)
+	actual := inferTypeAgainst(expected, assign.Right, scope, ctx)
 	if expected.AcceptsWithContext(actual, scope, ctx) {
 		return
 	}
@@ -0,0 +1,186 @@
+package analyse
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+)
+
+const psr4AutoloadMismatchCode = "PSR4.Autoload.Mismatch"
+
+// PSR4Mapping maps a namespace prefix to the directories holding its classes,
+// as in the autoload.psr-4 section of composer.json.
+type PSR4Mapping struct {
+	Prefix string
+	Dirs   []string
+}
+
+var (
+	psr4MappingsMu sync.RWMutex
+	psr4Mappings   []PSR4Mapping
+)
+
+// ConfigurePSR4 sets the mappings PSR4.Autoload.Mismatch checks files
+// against. Without mappings the rule reports nothing.
+func ConfigurePSR4(mappings []PSR4Mapping) {
+	psr4MappingsMu.Lock()
+	defer psr4MappingsMu.Unlock()
+	psr4Mappings = mappings
+}
+
+func configuredPSR4Mappings() []PSR4Mapping {
+	psr4MappingsMu.RLock()
+	defer psr4MappingsMu.RUnlock()
+	return psr4Mappings
+}
+
+// PSR4AutoloadRule reports files below a PSR-4 directory that an autoloader
+// could not load: files declaring more than one class-like, a namespace that
+// does not follow the directory, or a class named differently from the file.
+// Files without class-likes are left alone.
+type PSR4AutoloadRule struct {
+	Mappings []PSR4Mapping
+}
+
+type psr4Declaration struct {
+	namespace string
+	name      string
+	pos       ast.Position
+}
+
+type psr4Expectation struct {
+	namespace string
+	name      string
+	dirLen    int
+}
+
+func (r *PSR4AutoloadRule) CheckIssues(filename string, nodes []ast.Node) []AnalysisIssue {
+	if len(r.Mappings) == 0 || !strings.EqualFold(filepath.Ext(filename), ".php") {
+		return nil
+	}
+	expectations := r.expectationsFor(filename)
+	if len(expectations) == 0 {
+		return nil
+	}
+	declarations := collectPSR4Declarations(nodes, "", nil)
+	if len(declarations) == 0 {
+		return nil
+	}
+	if len(declarations) > 1 {
+		names := make([]string, 0, len(declarations))
+		for _, decl := range declarations {
+			names = append(names, qualifiedPSR4Name(decl.namespace, decl.name))
+		}
+		second := declarations[1]
+		return []AnalysisIssue{psr4Issue(filename, second.pos, fmt.Sprintf("File declares %d class-likes (%s); PSR-4 autoloading requires exactly one per file.", len(declarations), strings.Join(names, ", ")))}
+	}
+
+	decl := declarations[0]
+	best := expectations[0]
+	for _, expected := range expectations {
+		if expected.namespace == decl.namespace && expected.name == decl.name {
+			return nil
+		}
+		if expected.dirLen > best.dirLen {
+			best = expected
+		}
+	}
+	if decl.namespace != best.namespace {
+		return []AnalysisIssue{psr4Issue(filename, decl.pos, fmt.Sprintf("Namespace of %s does not match its PSR-4 path; expected namespace %s.", qualifiedPSR4Name(decl.namespace, decl.name), displayPSR4Namespace(best.namespace)))}
+	}
+	expectedPath := filepath.Join(filepath.Dir(filename), decl.name+".php")
+	return []AnalysisIssue{psr4Issue(filename, decl.pos, fmt.Sprintf("Class %s does not match its file name; expected path %s.", qualifiedPSR4Name(decl.namespace, decl.name), expectedPath))}
+}
+
+// expectationsFor returns the namespace and class name each mapping whose
+// directory contains the file requires.
+func (r *PSR4AutoloadRule) expectationsFor(filename string) []psr4Expectation {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil
+	}
+	var out []psr4Expectation
+	for _, mapping := range r.Mappings {
+		prefix := strings.Trim(mapping.Prefix, `\`)
+		for _, dir := range mapping.Dirs {
+			dir, err := filepath.Abs(dir)
+			if err != nil {
+				continue
+			}
+			rel, err := filepath.Rel(dir, abs)
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				continue
+			}
+			parts := []string{}
+			if prefix != "" {
+				parts = append(parts, prefix)
+			}
+			if relDir := filepath.Dir(rel); relDir != "." {
+				parts = append(parts, strings.Split(relDir, string(filepath.Separator))...)
+			}
+			out = append(out, psr4Expectation{
+				namespace: strings.Join(parts, `\`),
+				name:      strings.TrimSuffix(filepath.Base(rel), filepath.Ext(rel)),
+				dirLen:    len(dir),
+			})
+		}
+	}
+	return out
+}
+
+func collectPSR4Declarations(nodes []ast.Node, namespace string, out []psr4Declaration) []psr4Declaration {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *ast.NamespaceNode:
+			if len(n.Body) > 0 {
+				out = collectPSR4Declarations(n.Body, n.Name, out)
+				continue
+			}
+			namespace = n.Name
+		case *ast.ClassNode:
+			out = append(out, psr4Declaration{namespace: namespace, name: n.Name, pos: n.Pos})
+		case *ast.InterfaceNode:
+			out = append(out, psr4Declaration{namespace: namespace, name: n.Name, pos: n.Pos})
+		case *ast.EnumNode:
+			out = append(out, psr4Declaration{namespace: namespace, name: n.Name, pos: n.Pos})
+		case *ast.TraitNode:
+			if n.Name != nil {
+				out = append(out, psr4Declaration{namespace: namespace, name: n.Name.Name, pos: n.Pos})
+			}
+		}
+	}
+	return out
+}
+
+func qualifiedPSR4Name(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + `\` + name
+}
+
+func displayPSR4Namespace(namespace string) string {
+	if namespace == "" {
+		return "(global)"
+	}
+	return namespace
+}
+
+func psr4Issue(filename string, pos ast.Position, message string) AnalysisIssue {
+	return AnalysisIssue{
+		Filename: filename,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Code:     psr4AutoloadMismatchCode,
+		Message:  message,
+	}
+}
+
+func init() {
+	RegisterAnalysisRuleWithLevel(psr4AutoloadMismatchCode, 0, "psr4", func(filename string, nodes []ast.Node, _ *AnalysisContext) []AnalysisIssue {
+		return (&PSR4AutoloadRule{Mappings: configuredPSR4Mappings()}).CheckIssues(filename, nodes)
+	})
+}
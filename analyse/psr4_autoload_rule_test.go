@@ -0,0 +1,111 @@
+package analyse
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ayanozturk/go-php-parser/lexer"
+	"github.com/ayanozturk/go-php-parser/parser"
+)
+
+func runPSR4Rule(t *testing.T, mappings []PSR4Mapping, filename, php string) []AnalysisIssue {
+	t.Helper()
+	p := parser.New(lexer.New(php), false)
+	nodes := p.Parse()
+	if errs := p.Errors(); len(errs) > 0 {
+		t.Fatalf("unexpected parse errors: %v", errs)
+	}
+	return (&PSR4AutoloadRule{Mappings: mappings}).CheckIssues(filename, nodes)
+}
+
+func TestPSR4AutoloadRule(t *testing.T) {
+	root := t.TempDir()
+	mappings := []PSR4Mapping{
+		{Prefix: `App\`, Dirs: []string{filepath.Join(root, "src")}},
+		{Prefix: `App\Tests\`, Dirs: []string{filepath.Join(root, "tests")}},
+	}
+	mailer := filepath.Join(root, "src", "Service", "Mailer.php")
+
+	tests := []struct {
+		name     string
+		filename string
+		php      string
+		want     string
+	}{
+		{
+			name:     "matching class",
+			filename: mailer,
+			php:      "<?php\nnamespace App\\Service;\n\nfinal class Mailer {}\n",
+		},
+		{
+			name:     "braced namespace enum",
+			filename: filepath.Join(root, "tests", "Status.php"),
+			php:      "<?php\nnamespace App\\Tests {\n    enum Status {}\n}\n",
+		},
+		{
+			name:     "file outside mapped directories",
+			filename: filepath.Join(root, "bin", "console.php"),
+			php:      "<?php\nclass Console {}\n",
+		},
+		{
+			name:     "file without class-likes",
+			filename: filepath.Join(root, "src", "functions.php"),
+			php:      "<?php\nnamespace App;\n\nfunction helper(): void {}\n",
+		},
+		{
+			name:     "namespace mismatch",
+			filename: mailer,
+			php:      "<?php\nnamespace App\\Services;\n\nclass Mailer {}\n",
+			want:     `Namespace of App\Services\Mailer does not match its PSR-4 path; expected namespace App\Service.`,
+		},
+		{
+			name:     "namespace case mismatch",
+			filename: mailer,
+			php:      "<?php\nnamespace App\\service;\n\ninterface Mailer {}\n",
+			want:     "expected namespace App\\Service.",
+		},
+		{
+			name:     "class name mismatch",
+			filename: mailer,
+			php:      "<?php\nnamespace App\\Service;\n\ntrait Mailr {}\n",
+			want:     "Class App\\Service\\Mailr does not match its file name; expected path " + filepath.Join(root, "src", "Service", "Mailr.php") + ".",
+		},
+		{
+			name:     "more than one class-like",
+			filename: mailer,
+			php:      "<?php\nnamespace App\\Service;\n\nclass Mailer {}\n\ninterface Transport {}\n",
+			want:     `File declares 2 class-likes (App\Service\Mailer, App\Service\Transport); PSR-4 autoloading requires exactly one per file.`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := runPSR4Rule(t, mappings, tt.filename, tt.php)
+			if tt.want == "" {
+				if len(issues) != 0 {
+					t.Fatalf("expected no issues, got %#v", issues)
+				}
+				return
+			}
+			if len(issues) != 1 || issues[0].Code != psr4AutoloadMismatchCode || !strings.Contains(issues[0].Message, tt.want) {
+				t.Fatalf("expected one %s issue containing %q, got %#v", psr4AutoloadMismatchCode, tt.want, issues)
+			}
+		})
+	}
+}
+
+func TestPSR4AutoloadRulePrefersMostSpecificDirectory(t *testing.T) {
+	root := t.TempDir()
+	mappings := []PSR4Mapping{
+		{Prefix: `Legacy\`, Dirs: []string{root}},
+		{Prefix: `App\`, Dirs: []string{filepath.Join(root, "src")}},
+	}
+	filename := filepath.Join(root, "src", "Kernel.php")
+	if issues := runPSR4Rule(t, mappings, filename, "<?php\nnamespace Legacy\\src;\nclass Kernel {}\n"); len(issues) != 0 {
+		t.Fatalf("expected a file matching any mapping to pass, got %#v", issues)
+	}
+	issues := runPSR4Rule(t, mappings, filename, "<?php\nnamespace Other;\nclass Kernel {}\n")
+	if len(issues) != 1 || !strings.Contains(issues[0].Message, "expected namespace App.") {
+		t.Fatalf("expected the deepest mapped directory to name the namespace, got %#v", issues)
+	}
+}
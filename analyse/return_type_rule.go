@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/ayanozturk/go-php-parser/ast"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -22,6 +23,10 @@ type functionScope struct {
 	properties    map[string]Type
 	methods       map[string]ResolvedMethod
 	methodReturns map[string]Type
+	// fetchNull records what null checks proved about property fetches on
+	// local variables, keyed "var->prop": true when the property is null,
+	// false when it is not.
+	fetchNull map[string]bool
 }
 
 type classScopeData struct {
@@ -37,9 +42,14 @@ type ReturnTypeError struct {
 	DeclaredType string
 	ActualType   string
 	Pos          ast.Position
+	// MissingReturn is set when some path falls off the end of the body.
+	MissingReturn bool
 }
 
 func (e *ReturnTypeError) Error() string {
+	if e.MissingReturn {
+		return fmt.Sprintf("Function %s: declared return type %s but not all code paths return a value at %d:%d", e.FuncName, e.DeclaredType, e.Pos.Line, e.Pos.Column)
+	}
 	return fmt.Sprintf("Function %s: return type mismatch, declared: %s, actual: %s at %d:%d", e.FuncName, e.DeclaredType, e.ActualType, e.Pos.Line, e.Pos.Column)
 }
 
@@ -52,8 +62,9 @@ func (r *ReturnTypeRule) CheckFunctionReturnType(fn *ast.FunctionNode, class *as
 	// Collect all actual return types
 	returnTypes := map[string]int{}
 	var firstMismatch *ReturnTypeError
-	scope := analysisFunctionScope(ctx, class, fn, typeCtx)
-	for _, ret := range collectObservedReturns(fn.Body, scope, ctx) {
+	flow := analysisTypeFlow(ctx, class, fn, typeCtx)
+	scope := flow.entry
+	for _, ret := range collectObservedReturns(fn.Body, declaredType, flow, ctx) {
 		actualType := ret.Type
 		actualLabel := actualType.String()
 		if actualLabel == "" {
@@ -114,9 +125,42 @@ func (r *ReturnTypeRule) CheckFunctionReturnType(fn *ast.FunctionNode, class *as
 	if firstMismatch != nil {
 		return []error{firstMismatch}
 	}
+	if requiresExplicitReturn(fn, declaredType) && analysisControlFlow(ctx, class, fn, typeCtx).CanFallOffEnd() {
+		return []error{&ReturnTypeError{
+			FuncName:      fn.Name,
+			DeclaredType:  declaredLabel,
+			Pos:           fn.GetPos(),
+			MissingReturn: true,
+		}}
+	}
 	return nil
 }
 
+// requiresExplicitReturn reports whether falling off the end of fn is a
+// runtime error: only native return types are enforced, and void, never,
+// mixed and generators are exempt.
+func requiresExplicitReturn(fn *ast.FunctionNode, declaredType Type) bool {
+	if fn.ReturnType == "" || fn.Body == nil {
+		return false
+	}
+	for _, exempt := range []string{"void", "never", "mixed"} {
+		if declaredType.hasBuiltin(exempt) {
+			return false
+		}
+	}
+	return !containsYield(fn.Body)
+}
+
+func containsYield(body []ast.Node) bool {
+	found := false
+	walkAll(body, func(node ast.Node, _ *ast.ClassNode, currentFn *ast.FunctionNode, _ fileTypeContext) {
+		if _, ok := node.(*ast.YieldNode); ok && currentFn == nil {
+			found = true
+		}
+	})
+	return found
+}
+
 func (r *ReturnTypeRule) CheckIssues(nodes []ast.Node, filename string, ctx *AnalysisContext) []AnalysisIssue {
 	var issues []AnalysisIssue
 	fileCtx := analysisFileTypeContext(ctx, nodes)
@@ -150,31 +194,26 @@ func (r *ReturnTypeRule) CheckIssues(nodes []ast.Node, filename string, ctx *Ana
 	return issues
 }
 
-func collectObservedReturns(nodes []ast.Node, scope *functionScope, ctx *AnalysisContext) []observedReturn {
+func collectObservedReturns(nodes []ast.Node, declared Type, flow *typeFlow, ctx *AnalysisContext) []observedReturn {
 	var returns []observedReturn
 	for _, n := range nodes {
 		switch n := n.(type) {
-		case *ast.ExpressionStmt:
-			applyExpressionScope(scope, n.Expr, ctx)
 		case *ast.ReturnNode:
-			returns = append(returns, observedReturn{Type: inferType(n.Expr, scope, ctx), Pos: n.GetPos()})
-		case *ast.AssignmentNode:
-			applyAssignmentScope(scope, n, ctx)
+			returns = append(returns, observedReturn{Type: inferTypeAgainst(declared, n.Expr, flow.scopeAt(n), ctx), Pos: n.GetPos()})
 		case *ast.IfNode:
-			returns = append(returns, collectObservedReturns(n.Body, scope.clone(), ctx)...)
+			returns = append(returns, collectObservedReturns(n.Body, declared, flow, ctx)...)
 			for _, elseif := range n.ElseIfs {
-				returns = append(returns, collectObservedReturns(elseif.Body, scope.clone(), ctx)...)
+				returns = append(returns, collectObservedReturns(elseif.Body, declared, flow, ctx)...)
 			}
 			if n.Else != nil {
-				returns = append(returns, collectObservedReturns(n.Else.Body, scope.clone(), ctx)...)
+				returns = append(returns, collectObservedReturns(n.Else.Body, declared, flow, ctx)...)
 			}
-			applyLazyInitPropertyScope(scope, n, ctx)
 		case *ast.BlockNode:
-			returns = append(returns, collectObservedReturns(n.Statements, scope.clone(), ctx)...)
+			returns = append(returns, collectObservedReturns(n.Statements, declared, flow, ctx)...)
 		case *ast.WhileNode:
-			returns = append(returns, collectObservedReturns(n.Body, scope.clone(), ctx)...)
+			returns = append(returns, collectObservedReturns(n.Body, declared, flow, ctx)...)
 		case *ast.ForeachNode:
-			returns = append(returns, collectObservedReturns(n.Body, scope.clone(), ctx)...)
+			returns = append(returns, collectObservedReturns(n.Body, declared, flow, ctx)...)
 		}
 	}
 	return returns
@@ -185,19 +224,31 @@ func collectObservedReturns(nodes []ast.Node, scope *functionScope, ctx *Analysi
 func inferType(expr ast.Node, scope *functionScope, ctx *AnalysisContext) Type {
 	switch n := expr.(type) {
 	case *ast.FunctionCallNode:
-		return inferFunctionCallType(n)
+		return inferCallType(n, scope, ctx)
 	case *ast.MethodCallNode:
-		return inferMethodCallType(n, scope, ctx)
+		return nullSafeType(n.NullSafe, n.Object, inferMethodCallType(n, scope, ctx), scope, ctx)
 	case *ast.NewNode:
 		return inferNewTypeWithScope(n, scope)
 	case *ast.PropertyFetchNode:
-		return inferPropertyFetchType(n, scope, ctx)
+		return nullSafeType(n.NullSafe, n.Object, inferPropertyFetchType(n, scope, ctx), scope, ctx)
+	case *ast.ArrayAccessNode:
+		return inferArrayAccessType(n, scope, ctx)
 	case *ast.ConcatNode:
 		return ParseType("string")
+	case *ast.BinaryExpr:
+		if n.Operator == "??" {
+			return inferCoalesceType(n, scope, ctx)
+		}
+		return MixedType()
 	case *ast.ExpressionStmt:
 		return inferType(n.Expr, scope, ctx)
 	case *ast.TypeCastNode:
 		return ParseType(n.Type)
+	case *ast.ClassConstFetchNode:
+		if typ, ok := enumCaseType(n, scope, ctx); ok {
+			return typ
+		}
+		return MixedType()
 	case *ast.VariableNode:
 		if scope != nil {
 			if t, ok := scope.variables[n.Name]; ok {
@@ -215,6 +266,95 @@ func inferType(expr ast.Node, scope *functionScope, ctx *AnalysisContext) Type {
 	}
 }
 
+// enumCaseType types an enum case fetch such as Suit::Hearts.
+func enumCaseType(n *ast.ClassConstFetchNode, scope *functionScope, ctx *AnalysisContext) (Type, bool) {
+	if ctx == nil || ctx.Resolver == nil || strings.HasPrefix(n.Class, "$") {
+		return EmptyType(), false
+	}
+	className := n.Class
+	if scope != nil && !isSpecialClassName(className) {
+		className = scope.typeCtx.resolveClassLike(className)
+	}
+	resolved, ok := ctx.Resolver.ResolveClass(canonicalClassName(className, scope, ctx))
+	if !ok || resolved.Kind != "enum" {
+		return EmptyType(), false
+	}
+	for _, name := range resolved.Cases {
+		if name == n.Const {
+			return typeFromAtoms([]typeAtom{classConstantAtom(strings.TrimPrefix(resolved.Name, `\`), name)}), true
+		}
+	}
+	return EmptyType(), false
+}
+
+// nullSafeType adds null to the result of a ?-> call or fetch whose receiver
+// may be null.
+func nullSafeType(nullSafe bool, object ast.Node, result Type, scope *functionScope, ctx *AnalysisContext) Type {
+	if !nullSafe || result.hasBuiltin("mixed") || !inferType(object, scope, ctx).hasBuiltin("null") {
+		return result
+	}
+	return result.union(ParseType("null"))
+}
+
+// inferCoalesceType infers $left ?? $right: the left type without null,
+// joined with the right type, which is used when the left side is null or
+// undefined.
+func inferCoalesceType(n *ast.BinaryExpr, scope *functionScope, ctx *AnalysisContext) Type {
+	left := inferType(n.Left, scope, ctx)
+	if left.hasBuiltin("mixed") {
+		return MixedType()
+	}
+	return left.withoutBuiltin("null").union(inferType(n.Right, scope, ctx))
+}
+
+// inferCallType infers the result of a function or static method call from
+// the known builtins and the declared return types of resolved functions and
+// methods, instantiating @template tags from the arguments.
+func inferCallType(n *ast.FunctionCallNode, scope *functionScope, ctx *AnalysisContext) Type {
+	if typ := inferFunctionCallType(n); !typ.hasBuiltin("mixed") || scope == nil || ctx == nil || ctx.Resolver == nil {
+		return typ
+	}
+	name := functionCallName(n)
+	if strings.Contains(name, "::") {
+		if className, method, ok := resolveStaticCall(name, scope, ctx); ok {
+			return callReturnType(method, n.Args, scope, ctx).boundTo(className, method.DeclaringClass)
+		}
+		return MixedType()
+	}
+	fn, ok := ctx.Resolver.ResolveFunction(resolveFunctionNameForCall(name, scope.typeCtx, ctx))
+	if !ok || fn.ReturnType == "" {
+		return MixedType()
+	}
+	return callReturnType(ResolvedMethod{Name: fn.Name, ReturnType: fn.ReturnType, Params: fn.Params, Templates: fn.Templates}, n.Args, scope, ctx)
+}
+
+// resolveStaticCall resolves the class and method of a static call name such
+// as Foo::bar or parent::bar. Calls on a variable class are not resolved.
+func resolveStaticCall(name string, scope *functionScope, ctx *AnalysisContext) (string, ResolvedMethod, bool) {
+	className, methodName, ok := strings.Cut(name, "::")
+	if !ok || strings.HasPrefix(className, "$") || scope == nil || ctx == nil || ctx.Resolver == nil {
+		return "", ResolvedMethod{}, false
+	}
+	switch strings.ToLower(className) {
+	case "self", "static":
+		className = scope.className
+	case "parent":
+		className = canonicalClassName("parent", scope, ctx)
+	default:
+		className = scope.typeCtx.resolveClassLike(className)
+		method, ok := ctx.Resolver.ResolveStaticMethod(className, methodName)
+		return className, method, ok
+	}
+	method, ok := ctx.Resolver.ResolveMethod(className, methodName)
+	return className, method, ok
+}
+
+// callReturnType is the return type of a call to method with the given
+// arguments.
+func callReturnType(method ResolvedMethod, args []ast.Node, scope *functionScope, ctx *AnalysisContext) Type {
+	return ParseType(instantiateTemplates(method, args, scope, ctx).ReturnType)
+}
+
 // inferFunctionCallType handles known function-call return types.
 func inferFunctionCallType(n *ast.FunctionCallNode) Type {
 	if n == nil || n.Name == nil {
@@ -275,17 +415,122 @@ func inferFallbackType(n ast.Node) string {
 	return "mixed"
 }
 
+// inferTypeAgainst infers the type of expr for comparison with expected. When
+// expected is refined, such as 'asc'|'desc', list<User> or array{id: int},
+// literals are typed by value and array literals by their elements.
+func inferTypeAgainst(expected Type, expr ast.Node, scope *functionScope, ctx *AnalysisContext) Type {
+	for _, atom := range expected.atoms {
+		if atom.isRefined() {
+			return inferPreciseType(expr, scope, ctx)
+		}
+	}
+	return inferType(expr, scope, ctx)
+}
+
+func inferPreciseType(expr ast.Node, scope *functionScope, ctx *AnalysisContext) Type {
+	switch n := expr.(type) {
+	case *ast.StringLiteral:
+		return typeFromAtoms([]typeAtom{literalAtom("string", quoteLiteral(n.Value))})
+	case *ast.StringNode:
+		return typeFromAtoms([]typeAtom{literalAtom("string", quoteLiteral(n.Value))})
+	case *ast.IntegerLiteral:
+		return typeFromAtoms([]typeAtom{literalAtom("int", strconv.FormatInt(n.Value, 10))})
+	case *ast.IntegerNode:
+		return typeFromAtoms([]typeAtom{literalAtom("int", strconv.FormatInt(n.Value, 10))})
+	case *ast.UnaryExpr:
+		if value, ok := n.Operand.(*ast.IntegerNode); ok && n.Operator == "-" {
+			return typeFromAtoms([]typeAtom{literalAtom("int", strconv.FormatInt(-value.Value, 10))})
+		}
+	case *ast.ArrayNode:
+		if typ, ok := inferArrayLiteralType(n, scope, ctx); ok {
+			return typ
+		}
+	}
+	return inferType(expr, scope, ctx)
+}
+
+// inferArrayLiteralType types an array literal as a shape when its keys are
+// literals, and as array<K, V> otherwise.
+func inferArrayLiteralType(array *ast.ArrayNode, scope *functionScope, ctx *AnalysisContext) (Type, bool) {
+	var entries []shapeEntry
+	shaped := true
+	next := int64(0)
+	keys, values := EmptyType(), EmptyType()
+	for _, element := range array.Elements {
+		var key, value ast.Node
+		switch e := element.(type) {
+		case *ast.ArrayItemNode:
+			if e.Unpack {
+				return EmptyType(), false
+			}
+			key, value = e.Key, e.Value
+		case *ast.KeyValueNode:
+			key, value = e.Key, e.Value
+		default:
+			value = element
+		}
+		valueType := inferPreciseType(value, scope, ctx)
+		keyType := ParseType("int")
+		entry := shapeEntry{typ: valueType}
+		switch k := key.(type) {
+		case nil:
+			entry.key = strconv.FormatInt(next, 10)
+			next++
+		case *ast.StringLiteral:
+			keyType, entry.key = inferPreciseType(k, scope, ctx), k.Value
+		case *ast.StringNode:
+			keyType, entry.key = inferPreciseType(k, scope, ctx), k.Value
+		case *ast.IntegerNode:
+			keyType = inferPreciseType(k, scope, ctx)
+			entry.key = strconv.FormatInt(k.Value, 10)
+			if k.Value >= next {
+				next = k.Value + 1
+			}
+		default:
+			shaped = false
+			keyType = inferType(k, scope, ctx)
+		}
+		entries = append(entries, entry)
+		keys, values = keys.union(keyType), values.union(valueType)
+	}
+	if shaped {
+		return typeFromAtoms([]typeAtom{shapeAtom(entries, false)}), true
+	}
+	return typeFromAtoms([]typeAtom{arrayAtom("", values, keys, false, len(entries) > 0)}), true
+}
+
 func declaredFunctionReturnType(fn *ast.FunctionNode, typeCtx fileTypeContext) Type {
 	if fn == nil {
 		return EmptyType()
 	}
-	if fn.ReturnType != "" {
-		return ParseType(normalizeTypeWithContext(fn.ReturnType, typeCtx))
-	}
+	native := ParseType(normalizeTypeWithContext(fn.ReturnType, typeCtx))
 	if fn.PHPDoc != nil && fn.PHPDoc.ReturnType != "" {
-		return ParseType(normalizeTypeWithContext(fn.PHPDoc.ReturnType, typeCtx))
+		return native.refinedBy(ParseType(normalizeTypeWithContext(fn.PHPDoc.ReturnType, typeCtx)))
+	}
+	return native
+}
+
+// declaredParamType returns the type of a parameter: its native type, narrowed
+// by its @param type, or the @param type alone. A parameter whose default is
+// null is implicitly nullable.
+func declaredParamType(param *ast.ParamNode, doc *ast.PHPDocNode, typeCtx fileTypeContext) Type {
+	paramType := ParseType(normalizeTypeWithContext(param.TypeHint, typeCtx))
+	if paramType.IsEmpty() && param.UnionType != nil {
+		paramType = ParseType(normalizeTypeWithContext(param.UnionType.TokenLiteral(), typeCtx))
+	}
+	if doc != nil {
+		paramType = paramType.refinedBy(ParseType(normalizeTypeWithContext(doc.GetParamTypeFromPHPDoc(param.Name), typeCtx)))
 	}
-	return EmptyType()
+	if isImplicitlyNullable(param, paramType) {
+		paramType = paramType.union(ParseType("null"))
+	}
+	return paramType
+}
+
+// isImplicitlyNullable reports whether a typed parameter defaults to null
+// without null in its type, as in `Foo $foo = null`.
+func isImplicitlyNullable(param *ast.ParamNode, typ Type) bool {
+	return isNullLiteral(param.DefaultValue) && !typ.IsEmpty() && !typ.hasBuiltin("null") && !typ.hasBuiltin("mixed")
 }
 
 func newFunctionScope(class *ast.ClassNode, fn *ast.FunctionNode, typeCtx fileTypeContext) *functionScope {
@@ -316,13 +561,7 @@ func newFunctionScopeWithContext(ctx *AnalysisContext, class *ast.ClassNode, fn
 		if !ok {
 			continue
 		}
-		paramType := ParseType(normalizeTypeWithContext(param.TypeHint, typeCtx))
-		if paramType.IsEmpty() && param.UnionType != nil {
-			paramType = ParseType(normalizeTypeWithContext(param.UnionType.TokenLiteral(), typeCtx))
-		}
-		if paramType.IsEmpty() && fn.PHPDoc != nil {
-			paramType = ParseType(normalizeTypeWithContext(fn.PHPDoc.GetParamTypeFromPHPDoc(param.Name), typeCtx))
-		}
+		paramType := declaredParamType(param, fn.PHPDoc, typeCtx)
 		if paramType.IsEmpty() && param.DefaultValue != nil {
 			paramType = inferType(param.DefaultValue, scope, nil)
 		}
@@ -375,6 +614,9 @@ func buildClassScopeDataWithSeen(class *ast.ClassNode, typeCtx fileTypeContext,
 			continue
 		}
 		propertyType := ParseType(normalizeTypeWithContext(property.TypeHint, typeCtx))
+		if property.PHPDoc != nil && property.PHPDoc.VarType != "" {
+			propertyType = propertyType.refinedBy(ParseType(normalizeTypeWithContext(property.PHPDoc.VarType, typeCtx)))
+		}
 		if propertyType.IsEmpty() && property.DefaultValue != nil {
 			propertyType = inferType(property.DefaultValue, scope, nil)
 		}
@@ -387,6 +629,7 @@ func buildClassScopeDataWithSeen(class *ast.ClassNode, typeCtx fileTypeContext,
 		data.propertyDecls[promoted.name] = promoted.typ
 		data.properties[promoted.name] = promoted.typ
 	}
+	classTemplates, _ := resolvedGenericMetadata(class.PHPDoc, typeCtx)
 	for _, methodNode := range class.Methods {
 		method, ok := methodNode.(*ast.FunctionNode)
 		if !ok {
@@ -403,20 +646,21 @@ func buildClassScopeDataWithSeen(class *ast.ClassNode, typeCtx fileTypeContext,
 			if !ok {
 				continue
 			}
-			paramType := ParseType(normalizeTypeWithContext(param.TypeHint, typeCtx))
-			if paramType.IsEmpty() && param.UnionType != nil {
-				paramType = ParseType(normalizeTypeWithContext(param.UnionType.TokenLiteral(), typeCtx))
-			}
-			if paramType.IsEmpty() && method.PHPDoc != nil {
-				paramType = ParseType(normalizeTypeWithContext(method.PHPDoc.GetParamTypeFromPHPDoc(param.Name), typeCtx))
-			}
+			paramType := declaredParamType(param, method.PHPDoc, typeCtx)
 			resolved.Params = append(resolved.Params, ResolvedParam{
 				Name:       param.Name,
 				Type:       paramType.String(),
 				HasDefault: param.DefaultValue != nil,
 				IsVariadic: param.IsVariadic,
+				ByRef:      param.IsByRef,
 			})
 		}
+		if method.PHPDoc != nil && len(method.PHPDoc.Templates) > 0 {
+			// Generic methods keep their PHPDoc types unresolved, so each call
+			// can bind the templates.
+			generic := methodFromFunction(data.className, method, typeCtx, classTemplates)
+			resolved.ReturnType, resolved.Params, resolved.Templates = generic.ReturnType, generic.Params, generic.Templates
+		}
 		data.methods[strings.ToLower(method.Name)] = resolved
 		if !methodType.IsEmpty() {
 			data.methodReturns[strings.ToLower(method.Name)] = methodType
@@ -469,13 +713,7 @@ func promotedClassProperties(class *ast.ClassNode, typeCtx fileTypeContext, scop
 			if !ok || !param.IsPromoted {
 				continue
 			}
-			paramType := ParseType(normalizeTypeWithContext(param.TypeHint, typeCtx))
-			if paramType.IsEmpty() && param.UnionType != nil {
-				paramType = ParseType(normalizeTypeWithContext(param.UnionType.TokenLiteral(), typeCtx))
-			}
-			if paramType.IsEmpty() && method.PHPDoc != nil {
-				paramType = ParseType(normalizeTypeWithContext(method.PHPDoc.GetParamTypeFromPHPDoc(param.Name), typeCtx))
-			}
+			paramType := declaredParamType(param, method.PHPDoc, typeCtx)
 			if paramType.IsEmpty() && param.DefaultValue != nil {
 				paramType = inferType(param.DefaultValue, scope, nil)
 			}
@@ -507,6 +745,12 @@ func (s *functionScope) clone() *functionScope {
 	for name, typ := range s.properties {
 		clone.properties[name] = typ
 	}
+	if len(s.fetchNull) > 0 {
+		clone.fetchNull = make(map[string]bool, len(s.fetchNull))
+		for key, isNull := range s.fetchNull {
+			clone.fetchNull[key] = isNull
+		}
+	}
 	return clone
 }
 
@@ -515,6 +759,15 @@ func applyExpressionScope(scope *functionScope, expr ast.Node, ctx *AnalysisCont
 		applyConditionTrueScope(scope, condition)
 		return
 	}
+	if call, ok := expr.(*ast.FunctionCallNode); ok && scope != nil && strings.EqualFold(functionCallName(call), "unset") {
+		for _, arg := range call.Args {
+			if variable, ok := argumentValue(arg).(*ast.VariableNode); ok {
+				delete(scope.variables, variable.Name)
+				forgetPropertyFetches(scope, variable.Name)
+			}
+		}
+		return
+	}
 	assignment, ok := expr.(*ast.AssignmentNode)
 	if !ok {
 		return
@@ -547,16 +800,51 @@ func applyAssignmentScope(scope *functionScope, assignment *ast.AssignmentNode,
 	}
 
 	assignedType := inferType(assignment.Right, scope, ctx)
+	switch assignment.Operator {
+	case "", "=":
+	case "??=":
+		// The old value is kept unless it was null.
+		if current, ok := assignedTargetType(scope, assignment.Left); ok {
+			if nonNull := current.withoutBuiltin("null"); !nonNull.IsEmpty() {
+				assignedType = nonNull.union(assignedType)
+			}
+		}
+	case ".=":
+		assignedType = ParseType("string")
+	default:
+		assignedType = MixedType()
+	}
 	switch left := assignment.Left.(type) {
 	case *ast.VariableNode:
 		scope.variables[left.Name] = assignedType
+		forgetPropertyFetches(scope, left.Name)
 	case *ast.PropertyFetchNode:
 		if object, ok := left.Object.(*ast.VariableNode); ok && object.Name == "this" {
 			scope.properties[left.Property] = assignedType
+		} else if key, ok := propertyFetchKey(left); ok {
+			delete(scope.fetchNull, key)
 		}
 	}
 }
 
+// assignedTargetType returns the current type of a variable or $this
+// property assignment target.
+func assignedTargetType(scope *functionScope, target ast.Node) (Type, bool) {
+	switch n := target.(type) {
+	case *ast.VariableNode:
+		typ, ok := scope.variables[n.Name]
+		return typ, ok
+	case *ast.PropertyFetchNode:
+		if object, ok := n.Object.(*ast.VariableNode); ok && object.Name == "this" {
+			if typ, ok := scope.properties[n.Property]; ok {
+				return typ, true
+			}
+			return resolveSameClassPropertyType(scope, n.Property)
+		}
+	}
+	return EmptyType(), false
+}
+
 func inferNewType(node *ast.NewNode) Type {
 	return inferNewTypeWithScope(node, nil)
 }
@@ -593,10 +881,31 @@ func inferPropertyFetchType(node *ast.PropertyFetchNode, scope *functionScope, c
 		if propertyType, ok := resolveSameClassPropertyType(scope, node.Property); ok {
 			return propertyType
 		}
+		if scope != nil && ctx != nil && ctx.Resolver != nil {
+			if property, ok := ctx.Resolver.ResolveProperty(scope.className, node.Property); ok {
+				return ParseType(property.Type)
+			}
+		}
 	}
 
-	objectType := inferType(node.Object, scope, ctx)
-	className, ok := objectType.SingleClassName()
+	propertyType := objectPropertyType(node, scope, ctx)
+	if key, ok := propertyFetchKey(node); ok && scope != nil {
+		if isNull, known := scope.fetchNull[key]; known {
+			if isNull {
+				return ParseType("null")
+			}
+			if refined := propertyType.withoutBuiltin("null"); !refined.IsEmpty() {
+				return refined
+			}
+		}
+	}
+	return propertyType
+}
+
+// objectPropertyType is the declared type of a property fetched on an object
+// whose class can be inferred.
+func objectPropertyType(node *ast.PropertyFetchNode, scope *functionScope, ctx *AnalysisContext) Type {
+	className, ok := objectClassName(inferType(node.Object, scope, ctx))
 	if !ok {
 		return MixedType()
 	}
@@ -620,40 +929,110 @@ func inferMethodCallType(node *ast.MethodCallNode, scope *functionScope, ctx *An
 	if node == nil {
 		return MixedType()
 	}
-	if object, ok := node.Object.(*ast.VariableNode); ok && object.Name == "this" {
+	if object, ok := node.Object.(*ast.VariableNode); ok && object.Name == "this" && scope != nil {
 		if method, ok := resolveSameClassMethod(scope, node.Method); ok {
-			return ParseType(method.ReturnType)
+			return callReturnType(method, node.Args, scope, ctx).boundTo(scope.className, method.DeclaringClass)
 		}
-		if scope != nil && ctx != nil && ctx.Resolver != nil {
+		if ctx != nil && ctx.Resolver != nil {
 			if method, ok := ctx.Resolver.ResolveMethod(scope.className, node.Method); ok {
-				return ParseType(method.ReturnType)
+				return callReturnType(method, node.Args, scope, ctx).boundTo(scope.className, method.DeclaringClass)
 			}
 		}
 	}
 
 	objectType := inferType(node.Object, scope, ctx)
-	className, ok := objectType.SingleClassName()
+	className, ok := objectClassName(objectType)
 	if !ok {
 		return MixedType()
 	}
+	if bindings := classTemplateBindings(objectType, ctx); bindings != nil {
+		if method, ok := ctx.Project.resolveMethodWithTemplates(className, node.Method, bindings, make(map[string]struct{})); ok {
+			return callReturnType(method, node.Args, scope, ctx).boundTo(className, method.DeclaringClass)
+		}
+	}
+	if method, ok := resolveMethodOnClass(className, node.Method, scope, ctx); ok {
+		return callReturnType(method, node.Args, scope, ctx).boundTo(className, method.DeclaringClass)
+	}
+	return MixedType()
+}
+
+// objectClassName returns the class of a method call or property fetch
+// receiver. A nullable receiver is looked up on its class; null itself is
+// the concern of the null-safety checks.
+func objectClassName(t Type) (string, bool) {
+	return t.withoutBuiltin("null").SingleClassName()
+}
+
+// classTemplateBindings maps the template parameters of a generic receiver
+// class, such as Collection<User>, to its type arguments.
+func classTemplateBindings(t Type, ctx *AnalysisContext) map[string]string {
+	if ctx == nil || ctx.Project == nil {
+		return nil
+	}
+	for _, atom := range t.withoutBuiltin("null").atoms {
+		if atom.kind != typeKindClass || len(atom.args) == 0 {
+			return nil
+		}
+		class, ok := ctx.Project.ResolveClass(atom.className())
+		if !ok || len(class.TemplateParams) == 0 {
+			return nil
+		}
+		bindings := make(map[string]string, len(class.TemplateParams))
+		for i, name := range class.TemplateParams {
+			if i < len(atom.args) {
+				bindings[name] = atom.args[i].String()
+			}
+		}
+		return bindings
+	}
+	return nil
+}
+
+// resolveMethodOnClass finds a method of a receiver class, preferring the
+// scope's own declarations for the current class.
+func resolveMethodOnClass(className, methodName string, scope *functionScope, ctx *AnalysisContext) (ResolvedMethod, bool) {
 	if scope != nil && strings.EqualFold(className, scope.className) {
-		if method, ok := resolveSameClassMethod(scope, node.Method); ok {
-			return ParseType(method.ReturnType)
+		if method, ok := resolveSameClassMethod(scope, methodName); ok {
+			return method, true
 		}
 	}
 	if ctx != nil && ctx.Resolver != nil {
-		if method, ok := ctx.Resolver.ResolveMethod(className, node.Method); ok {
-			return ParseType(method.ReturnType)
+		if method, ok := ctx.Resolver.ResolveMethod(className, methodName); ok {
+			return method, true
 		}
 	}
 	if scope != nil {
 		if classData, ok := analysisClassScopeDataByName(ctx, className, scope.typeCtx); ok {
-			if method, ok := classData.methods[strings.ToLower(node.Method)]; ok {
-				return ParseType(method.ReturnType)
+			if method, ok := classData.methods[strings.ToLower(methodName)]; ok {
+				return method, true
 			}
 		}
 	}
-	return MixedType()
+	return ResolvedMethod{}, false
+}
+
+// inferArrayAccessType infers an element read from a typed array.
+func inferArrayAccessType(node *ast.ArrayAccessNode, scope *functionScope, ctx *AnalysisContext) Type {
+	if node == nil || node.Index == nil {
+		return MixedType()
+	}
+	key, hasKey := literalOffset(node.Index)
+	return inferType(node.Var, scope, ctx).offsetValueType(key, hasKey)
+}
+
+// literalOffset returns the key an array offset names, if it is a literal.
+func literalOffset(index ast.Node) (string, bool) {
+	switch n := index.(type) {
+	case *ast.IntegerLiteral:
+		return strconv.FormatInt(n.Value, 10), true
+	case *ast.IntegerNode:
+		return strconv.FormatInt(n.Value, 10), true
+	case *ast.StringLiteral:
+		return strings.Trim(n.Value, `'"`), true
+	case *ast.StringNode:
+		return strings.Trim(n.Value, `'"`), true
+	}
+	return "", false
 }
 
 func resolveSameClassMethod(scope *functionScope, methodName string) (ResolvedMethod, bool) {
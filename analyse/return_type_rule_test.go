@@ -167,3 +167,133 @@ class Example {
 		t.Fatalf("expected no A.RETURN.TYPE issue for lazy-init property, got: %#v", issues)
 	}
 }
+
+func TestMissingReturnOnSomePathReported(t *testing.T) {
+	php := `<?php
+function pick(int $x): int {
+    if ($x > 0) {
+        return $x;
+    }
+}`
+	issues := analysePHP(t, php)
+	if !hasReturnTypeIssue(issues) {
+		t.Fatalf("expected A.RETURN.TYPE issue for missing return, got: %#v", issues)
+	}
+
+	php = `<?php
+function first(array $rows): int {
+    for ($i = 0; $i < count($rows);) {
+        return $rows[$i];
+    }
+}`
+	issues = analysePHP(t, php)
+	if !hasReturnTypeIssue(issues) {
+		t.Fatalf("expected A.RETURN.TYPE issue for a for loop that may not run, got: %#v", issues)
+	}
+}
+
+func TestAllPathsReturnOrThrowNoMissingReturn(t *testing.T) {
+	php := `<?php
+function pick(int $x): int {
+    switch ($x) {
+        case 1:
+            return 1;
+        default:
+            throw new InvalidArgumentException();
+    }
+}
+
+function loop(): int {
+    while (true) {
+        if (ready()) {
+            return 1;
+        }
+    }
+}
+
+function items(): iterable {
+    foreach ([1, 2] as $i) {
+        yield $i;
+    }
+}
+
+class Poller {
+    public function poll(): int {
+        for (;;) {
+            return 1;
+        }
+    }
+}`
+	issues := analysePHP(t, php)
+	if hasReturnTypeIssue(issues) {
+		t.Fatalf("expected no A.RETURN.TYPE issue, got: %#v", issues)
+	}
+}
+
+func TestReturnTypeNarrowedByGuardsAndMergedAtJoins(t *testing.T) {
+	php := `<?php
+class Item {}
+class Repository {
+    public function require(?Item $item): Item
+    {
+        if ($item === null) {
+            throw new RuntimeException();
+        }
+        return $item;
+    }
+
+    public function orDefault(?Item $item): Item
+    {
+        if (!$item instanceof Item) {
+            $item = new Item();
+        }
+        return $item;
+    }
+}`
+	issues := analysePHP(t, php)
+	if hasReturnTypeIssue(issues) {
+		t.Fatalf("expected narrowed return values to match, got: %#v", issues)
+	}
+}
+
+func TestReturnTypeNarrowedByPropertyNullChecksOnVariables(t *testing.T) {
+	php := `<?php
+class User {
+    public ?string $email = null;
+}
+function email(?User $u): string {
+    if ($u !== null && $u->email !== null) {
+        return $u->email;
+    }
+    return '';
+}
+function reassigned(?User $u, User $other): string {
+    if ($u !== null && $u->email !== null) {
+        $u = $other;
+        return $u->email;
+    }
+    return '';
+}`
+	issues := runLevelOnFiles(t, 3, map[string]string{"test.php": php})
+	if hasIssueContaining(issues, "A.RETURN.TYPE", "Function email:") {
+		t.Fatalf("expected $u->email to be narrowed to string, got: %#v", issues)
+	}
+	if !hasIssueContaining(issues, "A.RETURN.TYPE", "Function reassigned:") {
+		t.Fatalf("expected narrowing to be forgotten once $u is reassigned, got: %#v", issues)
+	}
+}
+
+func TestReturnTypeMismatchAfterBranchesJoin(t *testing.T) {
+	php := `<?php
+function label(bool $flag): string {
+    $value = 'yes';
+    if ($flag) {
+        $value = 1;
+    }
+    return $value;
+}`
+	issues := analysePHP(t, php)
+	if !hasReturnTypeIssue(issues) {
+		t.Fatalf("expected A.RETURN.TYPE issue for int|string, got: %#v", issues)
+	}
+}
@@ -14,13 +14,20 @@ type AnalysisIssue struct {
 	Message     string
 	SubjectKind string
 	SubjectName string
+	// Warning marks an issue that is reported as a warning instead of an
+	// error.
+	Warning bool
 }
 
 type AnalysisRuleFunc func(filename string, nodes []ast.Node) []AnalysisIssue
 type AnalysisRuleWithContextFunc func(filename string, nodes []ast.Node, ctx *AnalysisContext) []AnalysisIssue
 
 type AnalysisRuleMeta struct {
-	Code           string
+	Code string
+	// Reports lists the other issue codes the rule emits besides Code. The
+	// rule runs once per file for all of them, and each code can still be
+	// disabled on its own through DisabledIssueCodes.
+	Reports        []string
 	Level          int
 	Category       string
 	DefaultEnabled bool
@@ -36,6 +43,7 @@ var (
 	analysisRuleRegistry     = map[string]analysisRuleEntry{}
 	analysisRuleRegistryLock sync.RWMutex
 	sortedRuleCodesCache     []string
+	issueCodesCache          []string
 	sortedRuleCodesDirty     = true
 )
 
@@ -64,13 +72,22 @@ func RegisterAnalysisRuleWithMeta(meta AnalysisRuleMeta, fn AnalysisRuleWithCont
 	sortedRuleCodesDirty = true
 }
 
-// ListRegisteredAnalysisRuleCodes returns registered analysis rule codes in sorted order.
+// ListRegisteredAnalysisRuleCodes returns the issue codes of registered
+// analysis rules in sorted order, including the extra codes each rule
+// declares in AnalysisRuleMeta.Reports.
 func ListRegisteredAnalysisRuleCodes() []string {
+	_, codes := registeredRuleCodes()
+	return append([]string(nil), codes...)
+}
+
+// registeredRuleCodes returns the sorted registry keys, which decide the order
+// rules run in, and the sorted issue codes those rules can report.
+func registeredRuleCodes() ([]string, []string) {
 	analysisRuleRegistryLock.RLock()
 	if !sortedRuleCodesDirty {
-		codes := append([]string(nil), sortedRuleCodesCache...)
+		rules, codes := sortedRuleCodesCache, issueCodesCache
 		analysisRuleRegistryLock.RUnlock()
-		return codes
+		return rules, codes
 	}
 	analysisRuleRegistryLock.RUnlock()
 
@@ -78,16 +95,26 @@ func ListRegisteredAnalysisRuleCodes() []string {
 	defer analysisRuleRegistryLock.Unlock()
 
 	if sortedRuleCodesDirty {
-		codes := make([]string, 0, len(analysisRuleRegistry))
-		for c := range analysisRuleRegistry {
-			codes = append(codes, c)
+		rules := make([]string, 0, len(analysisRuleRegistry))
+		seen := map[string]bool{}
+		var codes []string
+		for c, entry := range analysisRuleRegistry {
+			rules = append(rules, c)
+			for _, code := range append([]string{c}, entry.meta.Reports...) {
+				if !seen[code] {
+					seen[code] = true
+					codes = append(codes, code)
+				}
+			}
 		}
+		sort.Strings(rules)
 		sort.Strings(codes)
-		sortedRuleCodesCache = codes
+		sortedRuleCodesCache = rules
+		issueCodesCache = codes
 		sortedRuleCodesDirty = false
 	}
 
-	return append([]string(nil), sortedRuleCodesCache...)
+	return sortedRuleCodesCache, issueCodesCache
 }
 
 // ClearAnalysisRules removes all registered analysis rules. Useful for test isolation.
@@ -99,6 +126,7 @@ func ClearAnalysisRules() {
 		delete(analysisRuleRegistry, k)
 	}
 	sortedRuleCodesCache = nil
+	issueCodesCache = nil
 	sortedRuleCodesDirty = true
 }
 
@@ -118,7 +146,7 @@ func RunAnalysisRulesWithContext(filename string, nodes []ast.Node, ctx *Analysi
 			DisabledIssueCodes: ctx.DisabledIssueCodes,
 		}
 	}
-	codes := ListRegisteredAnalysisRuleCodes()
+	codes, _ := registeredRuleCodes()
 
 	issues := make([]AnalysisIssue, 0, 8)
 	analysisRuleRegistryLock.RLock()
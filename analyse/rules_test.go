@@ -2,12 +2,36 @@ package analyse
 
 import (
 	"github.com/ayanozturk/go-php-parser/ast"
+	"reflect"
 	"sort"
 	"testing"
 )
 
-func TestListRegisteredAnalysisRuleCodes(t *testing.T) {
+// isolateAnalysisRules empties the rule registry for a test and restores the
+// rules registered by init functions afterwards, so later tests still run
+// them.
+func isolateAnalysisRules(t *testing.T) {
+	t.Helper()
+	analysisRuleRegistryLock.Lock()
+	saved := make(map[string]analysisRuleEntry, len(analysisRuleRegistry))
+	for code, entry := range analysisRuleRegistry {
+		saved[code] = entry
+	}
+	analysisRuleRegistryLock.Unlock()
 	ClearAnalysisRules()
+	t.Cleanup(func() {
+		ClearAnalysisRules()
+		analysisRuleRegistryLock.Lock()
+		defer analysisRuleRegistryLock.Unlock()
+		for code, entry := range saved {
+			analysisRuleRegistry[code] = entry
+		}
+		sortedRuleCodesDirty = true
+	})
+}
+
+func TestListRegisteredAnalysisRuleCodes(t *testing.T) {
+	isolateAnalysisRules(t)
 
 	RegisterAnalysisRule("Z.TEST.RULE", func(filename string, nodes []ast.Node) []AnalysisIssue { return nil })
 	RegisterAnalysisRule("A.TEST.RULE", func(filename string, nodes []ast.Node) []AnalysisIssue { return nil })
@@ -34,8 +58,37 @@ func TestListRegisteredAnalysisRuleCodes(t *testing.T) {
 	}
 }
 
+func TestListRegisteredAnalysisRuleCodesIncludesReportedCodes(t *testing.T) {
+	isolateAnalysisRules(t)
+
+	runs := 0
+	RegisterAnalysisRuleWithMeta(AnalysisRuleMeta{Code: "B.RULE", Reports: []string{"C.RULE", "A.RULE"}, Level: 1, DefaultEnabled: true},
+		func(filename string, nodes []ast.Node, ctx *AnalysisContext) []AnalysisIssue {
+			runs++
+			return []AnalysisIssue{{Filename: filename, Code: "C.RULE"}, {Filename: filename, Code: "A.RULE"}}
+		})
+	RegisterAnalysisRule("A.RULE", func(filename string, nodes []ast.Node) []AnalysisIssue { return nil })
+
+	codes := ListRegisteredAnalysisRuleCodes()
+	if want := []string{"A.RULE", "B.RULE", "C.RULE"}; !reflect.DeepEqual(codes, want) {
+		t.Fatalf("codes = %v, want %v", codes, want)
+	}
+
+	level := 1
+	issues := RunAnalysisRulesWithContext("test.php", nil, &AnalysisContext{
+		AnalysisLevel:      &level,
+		DisabledIssueCodes: map[string]bool{"A.RULE": true},
+	})
+	if runs != 1 {
+		t.Fatalf("expected the rule to run once, ran %d times", runs)
+	}
+	if len(issues) != 1 || issues[0].Code != "C.RULE" {
+		t.Fatalf("expected only the enabled reported code, got %#v", issues)
+	}
+}
+
 func TestRunAnalysisRulesDeterministicOrder(t *testing.T) {
-	ClearAnalysisRules()
+	isolateAnalysisRules(t)
 
 	RegisterAnalysisRule("B.RULE", func(filename string, nodes []ast.Node) []AnalysisIssue {
 		return []AnalysisIssue{{Filename: filename, Code: "B.RULE", Message: "B"}}
@@ -57,8 +110,7 @@ func TestRunAnalysisRulesDeterministicOrder(t *testing.T) {
 }
 
 func TestRunAnalysisRulesPreservesContextPHPVersion(t *testing.T) {
-	ClearAnalysisRules()
-	defer ClearAnalysisRules()
+	isolateAnalysisRules(t)
 
 	RegisterAnalysisRuleWithContext("PHP.VERSION", func(filename string, nodes []ast.Node, ctx *AnalysisContext) []AnalysisIssue {
 		return []AnalysisIssue{{Filename: filename, Code: "PHP.VERSION", Message: ctx.PHPVersion}}
@@ -74,8 +126,7 @@ func TestRunAnalysisRulesPreservesContextPHPVersion(t *testing.T) {
 }
 
 func TestRunAnalysisRulesFiltersDisabledIssueCodes(t *testing.T) {
-	ClearAnalysisRules()
-	defer ClearAnalysisRules()
+	isolateAnalysisRules(t)
 
 	RegisterAnalysisRule("GROUPED.RULE", func(filename string, nodes []ast.Node) []AnalysisIssue {
 		return []AnalysisIssue{
@@ -93,7 +144,7 @@ func TestRunAnalysisRulesFiltersDisabledIssueCodes(t *testing.T) {
 }
 
 func TestClearAnalysisRules(t *testing.T) {
-	ClearAnalysisRules()
+	isolateAnalysisRules(t)
 
 	RegisterAnalysisRule("SOME.RULE", func(filename string, nodes []ast.Node) []AnalysisIssue { return nil })
 	if len(ListRegisteredAnalysisRuleCodes()) != 1 {
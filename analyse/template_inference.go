@@ -0,0 +1,366 @@
+package analyse
+
+import (
+	"strings"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+)
+
+// ResolvedTemplate is a function- or method-level @template parameter.
+type ResolvedTemplate struct {
+	Name string
+	// Bound is the type after "of" in @template T of Bound, if any.
+	Bound string
+}
+
+// resolvedTemplates returns the @template tags of a function or method
+// PHPDoc. Bounds may refer to the templates in scope.
+func resolvedTemplates(doc *ast.PHPDocNode, ft fileTypeContext, templates map[string]struct{}) []ResolvedTemplate {
+	if doc == nil || len(doc.Templates) == 0 {
+		return nil
+	}
+	out := make([]ResolvedTemplate, 0, len(doc.Templates))
+	for _, template := range doc.Templates {
+		out = append(out, ResolvedTemplate{Name: template.Name, Bound: normalizeTemplateAwareType(template.Bound, ft, templates)})
+	}
+	return out
+}
+
+// withTemplates adds the names of method-level templates to the class-level
+// ones in scope.
+func withTemplates(scope map[string]struct{}, templates []ResolvedTemplate) map[string]struct{} {
+	if len(templates) == 0 {
+		return scope
+	}
+	names := make(map[string]struct{}, len(scope)+len(templates))
+	for name := range scope {
+		names[name] = struct{}{}
+	}
+	for _, template := range templates {
+		names[template.Name] = struct{}{}
+	}
+	return names
+}
+
+// withoutTemplates drops class-level bindings shadowed by method-level
+// templates of the same name.
+func withoutTemplates(bindings map[string]string, templates []ResolvedTemplate) map[string]string {
+	if len(bindings) == 0 || len(templates) == 0 {
+		return bindings
+	}
+	out := make(map[string]string, len(bindings))
+	for name, binding := range bindings {
+		out[name] = binding
+	}
+	for _, template := range templates {
+		delete(out, template.Name)
+	}
+	return out
+}
+
+func templateParamNames(templates []ResolvedTemplate) []string {
+	names := make([]string, 0, len(templates))
+	for _, template := range templates {
+		names = append(names, template.Name)
+	}
+	return names
+}
+
+// mentionsTemplate reports whether a PHPDoc type refers to one of the
+// templates, for example class-string<T> or callable(T): R.
+func mentionsTemplate(raw string, templates map[string]struct{}) bool {
+	if len(templates) == 0 {
+		return false
+	}
+	for _, token := range strings.FieldsFunc(raw, func(r rune) bool { return !isTemplateIdentifierRune(r) }) {
+		if _, ok := templates[token]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// instantiateTemplates infers the method's own templates from the arguments
+// of a call and substitutes them in its parameter and return types. A
+// template no argument determines, or whose inferred type violates its bound,
+// becomes its bound, or mixed when it has none.
+func instantiateTemplates(method ResolvedMethod, args []ast.Node, scope *functionScope, ctx *AnalysisContext) ResolvedMethod {
+	if len(method.Templates) == 0 {
+		return method
+	}
+	names := templateNames(templateParamNames(method.Templates))
+	inferred := map[string]Type{}
+	for i, paramIndex := range callArgumentParams(method.Params, args) {
+		if paramIndex < 0 {
+			continue
+		}
+		arg := argumentValue(args[i])
+		if arg == nil {
+			continue
+		}
+		inferTemplateBindings(method.Params[paramIndex].Type, arg, inferType(arg, scope, ctx), names, inferred, scope, ctx)
+	}
+
+	bindings := make(map[string]string, len(method.Templates))
+	for _, template := range method.Templates {
+		bound := template.Bound
+		if bound == "" {
+			bound = "mixed"
+		}
+		bindings[template.Name] = bound
+		typ, ok := inferred[template.Name]
+		if !ok || typ.IsEmpty() {
+			continue
+		}
+		if template.Bound != "" && !ParseType(template.Bound).AcceptsWithContext(typ, scope, ctx) {
+			continue
+		}
+		bindings[template.Name] = typ.String()
+	}
+
+	method.ReturnType = ApplyTemplateBindings(method.ReturnType, bindings)
+	method.Params = append([]ResolvedParam(nil), method.Params...)
+	for i := range method.Params {
+		method.Params[i].Type = ApplyTemplateBindings(method.Params[i].Type, bindings)
+	}
+	method.Templates = nil
+	return method
+}
+
+// callArgumentParams returns the index of the parameter each argument binds
+// to, or -1. Named arguments bind by name; positional arguments past the
+// last parameter bind to a variadic one.
+func callArgumentParams(params []ResolvedParam, args []ast.Node) []int {
+	out := make([]int, len(args))
+	byName := make(map[string]int, len(params))
+	variadic := -1
+	for i, param := range params {
+		byName[strings.ToLower(param.Name)] = i
+		if param.IsVariadic {
+			variadic = i
+		}
+	}
+	next := 0
+	for i, arg := range args {
+		out[i] = -1
+		switch a := arg.(type) {
+		case *ast.NamedArgumentNode:
+			if idx, ok := byName[strings.ToLower(a.Name)]; ok {
+				out[i] = idx
+			}
+		case *ast.UnpackedArgumentNode:
+			// The elements cannot be matched to parameters.
+		default:
+			if next < len(params) {
+				out[i] = next
+				next++
+			} else if variadic >= 0 {
+				out[i] = variadic
+			}
+		}
+	}
+	return out
+}
+
+// inferTemplateBindings matches a parameter's PHPDoc type against the
+// argument passed for it, unioning what each template is bound to into
+// inferred. arg may be nil when only the type of a value is known, such as
+// an array element.
+func inferTemplateBindings(pattern string, arg ast.Node, actual Type, names map[string]struct{}, inferred map[string]Type, scope *functionScope, ctx *AnalysisContext) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" || !mentionsTemplate(pattern, names) {
+		return
+	}
+	nullable := strings.HasPrefix(pattern, "?")
+	pattern = strings.TrimPrefix(pattern, "?")
+	parts := splitTopLevelTypes(pattern, '|')
+	for _, part := range parts {
+		if strings.EqualFold(strings.TrimSpace(part), "null") {
+			nullable = true
+		}
+	}
+	if nullable {
+		actual = actual.withoutBuiltin("null")
+	}
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if _, ok := names[part]; ok {
+			if len(parts) == 1 || !actual.hasBuiltin("mixed") {
+				bindTemplate(inferred, part, actual)
+			}
+			continue
+		}
+		base, typeArgs, ok := splitGenericType(part)
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(strings.TrimPrefix(base, `\`)) {
+		case "class-string", "interface-string":
+			if len(typeArgs) == 1 {
+				if _, ok := names[typeArgs[0]]; ok {
+					if className := classStringArgument(arg, scope); className != "" {
+						bindTemplate(inferred, typeArgs[0], ClassType(className))
+					}
+				}
+			}
+		case "array", "non-empty-array", "list", "non-empty-list", "iterable", "[]":
+			keys, values, ok := arrayLiteralTypes(arg, scope, ctx)
+			if !ok {
+				continue
+			}
+			inferTemplateBindings(typeArgs[len(typeArgs)-1], nil, values, names, inferred, scope, ctx)
+			if len(typeArgs) == 2 {
+				inferTemplateBindings(typeArgs[0], nil, keys, names, inferred, scope, ctx)
+			}
+		case "callable", "closure":
+			inferCallableTemplateBindings(typeArgs, arg, names, inferred, scope, ctx)
+		}
+	}
+}
+
+func bindTemplate(inferred map[string]Type, name string, typ Type) {
+	if typ.IsEmpty() {
+		return
+	}
+	inferred[name] = inferred[name].union(typ)
+}
+
+// splitGenericType splits class-string<T>, array<K, V>, T[] and
+// callable(A): R into their base and type arguments. For callables the last
+// argument is the return type, or "" when none is declared.
+func splitGenericType(raw string) (string, []string, bool) {
+	raw = strings.TrimSpace(raw)
+	if strings.HasSuffix(raw, "[]") {
+		return "[]", []string{strings.TrimSuffix(raw, "[]")}, true
+	}
+	paren := strings.Index(raw, "(")
+	if open := strings.Index(raw, "<"); open > 0 && (paren < 0 || open < paren) && strings.HasSuffix(raw, ">") {
+		var args []string
+		for _, arg := range splitTopLevelTypes(raw[open+1:len(raw)-1], ',') {
+			args = append(args, strings.TrimSpace(arg))
+		}
+		return raw[:open], args, true
+	}
+	open := paren
+	if open <= 0 {
+		return "", nil, false
+	}
+	closing := matchingParen(raw, open)
+	if closing < 0 {
+		return "", nil, false
+	}
+	var args []string
+	if inner := strings.TrimSpace(raw[open+1 : closing]); inner != "" {
+		for _, param := range splitTopLevelTypes(inner, ',') {
+			param = strings.TrimSpace(param)
+			if idx := strings.Index(param, " "); idx > 0 {
+				param = param[:idx]
+			}
+			args = append(args, param)
+		}
+	}
+	ret := strings.TrimSpace(raw[closing+1:])
+	args = append(args, strings.TrimSpace(strings.TrimPrefix(ret, ":")))
+	return raw[:open], args, true
+}
+
+func matchingParen(raw string, open int) int {
+	depth := 0
+	for i := open; i < len(raw); i++ {
+		switch raw[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// classStringArgument returns the class named by a Foo::class argument.
+func classStringArgument(arg ast.Node, scope *functionScope) string {
+	fetch, ok := arg.(*ast.ClassConstFetchNode)
+	if !ok || !strings.EqualFold(fetch.Const, "class") {
+		return ""
+	}
+	if scope == nil {
+		return strings.TrimPrefix(fetch.Class, `\`)
+	}
+	switch strings.ToLower(fetch.Class) {
+	case "self", "static":
+		return scope.className
+	}
+	return scope.typeCtx.resolveClassLike(fetch.Class)
+}
+
+// arrayLiteralTypes returns the key and value types of an array literal.
+func arrayLiteralTypes(arg ast.Node, scope *functionScope, ctx *AnalysisContext) (Type, Type, bool) {
+	array, ok := arg.(*ast.ArrayNode)
+	if !ok || len(array.Elements) == 0 {
+		return EmptyType(), EmptyType(), false
+	}
+	keys, values := EmptyType(), EmptyType()
+	for _, element := range array.Elements {
+		var key, value ast.Node
+		switch e := element.(type) {
+		case *ast.ArrayItemNode:
+			if e.Unpack {
+				return EmptyType(), EmptyType(), false
+			}
+			key, value = e.Key, e.Value
+		case *ast.KeyValueNode:
+			key, value = e.Key, e.Value
+		default:
+			value = element
+		}
+		if key == nil {
+			keys = keys.union(ParseType("int"))
+		} else {
+			keys = keys.union(inferType(key, scope, ctx))
+		}
+		values = values.union(inferType(value, scope, ctx))
+	}
+	return keys, values, true
+}
+
+// inferCallableTemplateBindings binds the templates of callable(A): R from
+// the declared parameter and return types of a closure or arrow function.
+func inferCallableTemplateBindings(signature []string, arg ast.Node, names map[string]struct{}, inferred map[string]Type, scope *functionScope, ctx *AnalysisContext) {
+	if len(signature) == 0 {
+		return
+	}
+	var params []ast.Node
+	returnType := EmptyType()
+	switch fn := arg.(type) {
+	case *ast.FunctionNode:
+		params = fn.Params
+		if scope != nil {
+			returnType = declaredFunctionReturnType(fn, scope.typeCtx)
+		}
+	case *ast.ArrowFunctionNode:
+		params = fn.Params
+		if fn.ReturnType != "" && scope != nil {
+			returnType = ParseType(normalizeTypeWithContext(fn.ReturnType, scope.typeCtx))
+		} else if fn.ReturnType == "" {
+			returnType = inferType(fn.Expr, scope, ctx)
+		}
+	default:
+		return
+	}
+	for i, pattern := range signature[:len(signature)-1] {
+		if i >= len(params) {
+			break
+		}
+		param, ok := params[i].(*ast.ParamNode)
+		if !ok || param.TypeHint == "" || scope == nil {
+			continue
+		}
+		inferTemplateBindings(pattern, nil, ParseType(normalizeTypeWithContext(param.TypeHint, scope.typeCtx)), names, inferred, scope, ctx)
+	}
+	if ret := signature[len(signature)-1]; ret != "" && !returnType.hasBuiltin("mixed") {
+		inferTemplateBindings(ret, nil, returnType, names, inferred, scope, ctx)
+	}
+}
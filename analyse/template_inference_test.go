@@ -0,0 +1,106 @@
+package analyse
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+)
+
+const templateInferenceFixture = `<?php
+namespace App;
+
+interface Entity {}
+class User implements Entity {}
+class Order implements Entity {}
+class Report {}
+
+class Repository {
+    /**
+     * @template T of Entity
+     * @param class-string<T> $class
+     * @return T
+     */
+    public function find(string $class, int $id): object {}
+
+    /**
+     * @template T of Entity
+     * @param T $entity
+     * @return T
+     */
+    public function save(object $entity): object {}
+}
+
+/**
+ * @template T
+ * @param T[] $items
+ * @return T
+ */
+function first(array $items) {}
+
+/**
+ * @template T
+ * @template R
+ * @param callable(T): R $fn
+ * @param T $value
+ * @return R
+ */
+function apply(callable $fn, $value) {}
+
+class Service {
+    private Repository $repo;
+
+    public function run(): void {
+        $user = $this->repo->find(User::class, 1);
+        $order = first([new Order(), new Order()]);
+        $label = apply(fn(int $n): string => 'n', 1);
+        $saved = $this->repo->save(new Report());
+    }
+
+    public function order(): Order {
+        return $this->repo->find(User::class, 2);
+    }
+
+    public function user(): User {
+        return $this->repo->find(User::class, 3);
+    }
+}`
+
+func TestTemplateInferenceBindsMethodAndFunctionTemplatesFromArguments(t *testing.T) {
+	nodes := parseHoverFixture(t, templateInferenceFixture)
+	project := BuildProjectIndex(map[string][]ast.Node{"test.php": nodes})
+	ctx := &AnalysisContext{Resolver: project, Project: project}
+
+	cases := []struct {
+		line, column int
+		ident, want  string
+	}{
+		{45, 10, "user", `App\User`},
+		{46, 10, "order", `App\Order`},
+		{47, 10, "label", "string"},
+		// Report violates the bound, so the result is the bound.
+		{48, 10, "saved", `App\Entity`},
+	}
+	for _, tc := range cases {
+		target, ok := InferHoverTargetAtPosition(nodes, tc.line, tc.column, tc.ident, ctx)
+		if !ok || target.Type != tc.want {
+			t.Errorf("$%s: expected %s, got %#v, %t", tc.ident, tc.want, target, ok)
+		}
+	}
+}
+
+func TestTemplateInferenceFeedsArgumentAndReturnTypeChecks(t *testing.T) {
+	nodes := parseHoverFixture(t, templateInferenceFixture)
+	project := BuildProjectIndex(map[string][]ast.Node{"test.php": nodes})
+	ctx := &AnalysisContext{Resolver: project, Project: project}
+
+	argIssues := (&ArgumentTypeRule{}).CheckIssues(nodes, "test.php", ctx)
+	if len(argIssues) != 1 || argIssues[0].Line != 48 || !strings.Contains(argIssues[0].Message, `expects App\Entity, got App\Report`) {
+		t.Fatalf("expected the bound violation to be reported, got %#v", argIssues)
+	}
+
+	returnIssues := (&ReturnTypeRule{}).CheckIssues(nodes, "test.php", ctx)
+	if len(returnIssues) != 1 || returnIssues[0].Line != 51 {
+		t.Fatalf("expected only the mismatched inferred return to be reported, got %#v", returnIssues)
+	}
+}
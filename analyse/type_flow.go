@@ -0,0 +1,293 @@
+package analyse
+
+import (
+	"github.com/ayanozturk/go-php-parser/analyse/cfg"
+	"github.com/ayanozturk/go-php-parser/ast"
+	"strings"
+)
+
+// typeFlow holds the variable and $this property types in effect before each
+// node of a function's control-flow graph. Types are narrowed along branch
+// edges (instanceof, null checks, is_* predicates, and === against enum
+// cases and literals) and by assert(), and are unioned where paths join.
+type typeFlow struct {
+	entry  *functionScope
+	before map[ast.Node]*functionScope
+}
+
+// analysisTypeFlow returns the cached type flow of a function, method or
+// closure body.
+func analysisTypeFlow(ctx *AnalysisContext, class *ast.ClassNode, fn *ast.FunctionNode, typeCtx fileTypeContext) *typeFlow {
+	if ctx != nil {
+		if flow, ok := ctx.typeFlowByNode[fn]; ok {
+			return flow
+		}
+	}
+	flow := &typeFlow{
+		entry:  analysisFunctionScope(ctx, class, fn, typeCtx),
+		before: map[ast.Node]*functionScope{},
+	}
+	flow.run(analysisControlFlow(ctx, class, fn, typeCtx), ctx)
+	if ctx != nil {
+		if ctx.typeFlowByNode == nil {
+			ctx.typeFlowByNode = make(map[*ast.FunctionNode]*typeFlow)
+		}
+		ctx.typeFlowByNode[fn] = flow
+	}
+	return flow
+}
+
+// scopeAt returns the scope in effect just before node runs. Statements and
+// the conditions of if, while, switch and foreach are recorded; other nodes,
+// including unreachable ones, see the scope on entry to the body. Callers
+// must clone the result before changing it.
+func (f *typeFlow) scopeAt(node ast.Node) *functionScope {
+	if f == nil {
+		return nil
+	}
+	if scope, ok := f.before[node]; ok {
+		return scope
+	}
+	return f.entry
+}
+
+func (f *typeFlow) run(graph *cfg.Graph, ctx *AnalysisContext) {
+	if graph == nil {
+		return
+	}
+	in := make(map[*cfg.Block]*functionScope, len(graph.Blocks))
+	in[graph.Entry] = f.entry
+	worklist := []*cfg.Block{graph.Entry}
+	queued := map[*cfg.Block]bool{graph.Entry: true}
+	for len(worklist) > 0 {
+		block := worklist[0]
+		worklist = worklist[1:]
+		queued[block] = false
+		out := f.transferBlock(block, in[block].clone(), ctx, false)
+		for _, edge := range block.Succs {
+			next := narrowScopeForEdge(out, block, edge, ctx)
+			merged := mergeFunctionScopes(in[edge.To], next)
+			if in[edge.To] != nil && sameScopeTypes(merged, in[edge.To]) {
+				continue
+			}
+			in[edge.To] = merged
+			if !queued[edge.To] {
+				queued[edge.To] = true
+				worklist = append(worklist, edge.To)
+			}
+		}
+	}
+
+	for _, block := range graph.Blocks {
+		if state := in[block]; state != nil && block.Reachable {
+			f.transferBlock(block, state.clone(), ctx, true)
+		}
+	}
+}
+
+func (f *typeFlow) transferBlock(block *cfg.Block, scope *functionScope, ctx *AnalysisContext, record bool) *functionScope {
+	for _, node := range block.Nodes {
+		if record {
+			// finally bodies appear in more than one block.
+			f.before[node] = mergeFunctionScopes(f.before[node], scope)
+		}
+		applyFlowNodeScope(scope, node, ctx)
+	}
+	return scope
+}
+
+// applyFlowNodeScope updates scope for one node of a block.
+func applyFlowNodeScope(scope *functionScope, node ast.Node, ctx *AnalysisContext) {
+	switch n := node.(type) {
+	case *ast.ExpressionStmt:
+		applyExpressionScope(scope, n.Expr, ctx)
+	case *ast.AssignmentNode:
+		applyAssignmentScope(scope, n, ctx)
+	case *ast.StaticVarDeclNode:
+		for _, entry := range n.Vars {
+			scope.variables[entry.Name] = MixedType()
+			forgetPropertyFetches(scope, entry.Name)
+		}
+	case *ast.GlobalNode:
+		for _, v := range n.Vars {
+			scope.variables[v.Name] = MixedType()
+			forgetPropertyFetches(scope, v.Name)
+		}
+	case *ast.ForeachNode:
+		// Marker at the start of the loop body.
+		forgetAssignedVariables(scope, n.KeyVar)
+		forgetAssignedVariables(scope, n.ValueVar)
+		if value, ok := n.ValueVar.(*ast.VariableNode); ok && !n.ByRef {
+			scope.variables[value.Name] = inferType(n.Expr, scope, ctx).offsetValueType("", false)
+		}
+	case *ast.CatchNode:
+		if n.Variable == "" {
+			return
+		}
+		caught := EmptyType()
+		for _, name := range n.Types {
+			caught = caught.union(ClassType(scope.typeCtx.resolveClassLike(name)))
+		}
+		if caught.IsEmpty() {
+			caught = MixedType()
+		}
+		name := strings.TrimPrefix(n.Variable, "$")
+		scope.variables[name] = caught
+		forgetPropertyFetches(scope, name)
+	case *ast.BlockNode:
+		// Marker for a for loop, whose control expressions are not parsed.
+		for _, v := range n.ControlVars {
+			scope.variables[v.Name] = MixedType()
+			forgetPropertyFetches(scope, v.Name)
+		}
+	}
+}
+
+// forgetAssignedVariables marks the variables written by an assignment target
+// whose value is not inferred as mixed.
+func forgetAssignedVariables(scope *functionScope, target ast.Node) {
+	switch n := target.(type) {
+	case *ast.VariableNode:
+		scope.variables[n.Name] = MixedType()
+		forgetPropertyFetches(scope, n.Name)
+	case *ast.ArrayNode:
+		for _, element := range n.Elements {
+			forgetAssignedVariables(scope, element)
+		}
+	case *ast.ArrayItemNode:
+		forgetAssignedVariables(scope, n.Value)
+	}
+}
+
+// narrowScopeForEdge applies what taking a branch edge proves about the
+// source block's condition.
+func narrowScopeForEdge(scope *functionScope, block *cfg.Block, edge *cfg.Edge, ctx *AnalysisContext) *functionScope {
+	if block.Cond == nil || (edge.Kind != cfg.True && edge.Kind != cfg.False) {
+		return scope
+	}
+	narrowed := scope.clone()
+	if edge.Kind == cfg.True {
+		applyConditionTrueScope(narrowed, block.Cond)
+	} else {
+		applyConditionFalseScope(narrowed, block.Cond)
+	}
+	applyIdentityExclusionScope(narrowed, block.Cond, edge.Kind == cfg.True, ctx)
+	return narrowed
+}
+
+// applyIdentityExclusionScope removes a single value, such as an enum case
+// or a literal, from a variable on the path where the variable is known not
+// to be identical to it, e.g. after `if ($suit === Suit::Hearts) { return; }`.
+// Enums are expanded into their cases first, so the remaining cases are
+// what is left.
+func applyIdentityExclusionScope(scope *functionScope, condition ast.Node, truth bool, ctx *AnalysisContext) {
+	switch n := condition.(type) {
+	case *ast.UnaryExpr:
+		if n.Operator == "!" {
+			applyIdentityExclusionScope(scope, n.Operand, !truth, ctx)
+		}
+	case *ast.BinaryExpr:
+		switch n.Operator {
+		case "&&", "and":
+			if truth {
+				applyIdentityExclusionScope(scope, n.Left, true, ctx)
+				applyIdentityExclusionScope(scope, n.Right, true, ctx)
+			}
+		case "||", "or":
+			if !truth {
+				applyIdentityExclusionScope(scope, n.Left, false, ctx)
+				applyIdentityExclusionScope(scope, n.Right, false, ctx)
+			}
+		case "===":
+			if !truth {
+				excludeIdenticalValue(scope, n.Left, n.Right, ctx)
+				excludeIdenticalValue(scope, n.Right, n.Left, ctx)
+			}
+		case "!==":
+			if truth {
+				excludeIdenticalValue(scope, n.Left, n.Right, ctx)
+				excludeIdenticalValue(scope, n.Right, n.Left, ctx)
+			}
+		}
+	}
+}
+
+func excludeIdenticalValue(scope *functionScope, target, value ast.Node, ctx *AnalysisContext) {
+	variable, ok := target.(*ast.VariableNode)
+	if !ok || ctx == nil || ctx.Resolver == nil {
+		return
+	}
+	if _, ok := value.(*ast.VariableNode); ok {
+		return
+	}
+	excluded := inferPreciseType(value, scope, ctx)
+	if !isSingleValueType(excluded) {
+		return
+	}
+	current := scope.variables[variable.Name]
+	if current.IsEmpty() || current.hasBuiltin("mixed") {
+		return
+	}
+	expanded := expandFiniteType(current, scope, ctx)
+	if refined := expanded.without(excluded); !refined.IsEmpty() && refined.String() != expanded.String() {
+		scope.variables[variable.Name] = refined
+	}
+}
+
+// mergeFunctionScopes joins the scopes of two paths. A nil scope stands for a
+// path that has not been reached. Variables assigned on only one path keep
+// that path's type.
+func mergeFunctionScopes(a, b *functionScope) *functionScope {
+	if a == nil {
+		return b.clone()
+	}
+	if b == nil {
+		return a.clone()
+	}
+	merged := a.clone()
+	for name, typ := range b.variables {
+		merged.variables[name] = merged.variables[name].union(typ)
+	}
+	for name, typ := range b.properties {
+		current, ok := merged.properties[name]
+		if !ok {
+			current = merged.propertyDecls[name]
+		}
+		merged.properties[name] = current.union(typ)
+	}
+	for name, typ := range a.properties {
+		if _, ok := b.properties[name]; !ok {
+			merged.properties[name] = typ.union(b.propertyDecls[name])
+		}
+	}
+	for key, isNull := range merged.fetchNull {
+		if other, ok := b.fetchNull[key]; !ok || other != isNull {
+			delete(merged.fetchNull, key)
+		}
+	}
+	return merged
+}
+
+func sameScopeTypes(a, b *functionScope) bool {
+	if len(a.variables) != len(b.variables) || len(a.properties) != len(b.properties) || len(a.fetchNull) != len(b.fetchNull) {
+		return false
+	}
+	for key, isNull := range a.fetchNull {
+		if other, ok := b.fetchNull[key]; !ok || other != isNull {
+			return false
+		}
+	}
+	for name, typ := range a.variables {
+		other, ok := b.variables[name]
+		if !ok || other.String() != typ.String() {
+			return false
+		}
+	}
+	for name, typ := range a.properties {
+		other, ok := b.properties[name]
+		if !ok || other.String() != typ.String() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,658 @@
+package analyse
+
+import (
+	"strconv"
+	"strings"
+)
+
+// shapeEntry is one key of an array{...} shape.
+type shapeEntry struct {
+	key      string
+	optional bool
+	typ      Type
+}
+
+// typeParser reads PHPDoc type syntax: unions, intersections, nullable and
+// parenthesised types, T[], generics such as array<K, V>, list<T> and
+// Collection<T>, array{...} shapes, int<min, max> ranges, literals and
+// callable(A): R signatures.
+type typeParser struct {
+	src string
+	pos int
+	// resolve maps a class name as written to its fully qualified name.
+	resolve func(string) string
+}
+
+// parseTypeAtoms parses raw into atoms, resolving class names with resolve
+// when it is not nil. It fails on syntax it does not model, such as
+// conditional types.
+func parseTypeAtoms(raw string, resolve func(string) string) ([]typeAtom, bool) {
+	p := &typeParser{src: raw, resolve: resolve}
+	atoms, ok := p.parseUnion()
+	p.skipSpace()
+	if !ok || p.pos != len(p.src) || len(atoms) == 0 {
+		return nil, false
+	}
+	return atoms, true
+}
+
+func (p *typeParser) parseUnion() ([]typeAtom, bool) {
+	var atoms []typeAtom
+	for {
+		part, ok := p.parsePostfix()
+		if !ok {
+			return nil, false
+		}
+		atoms = append(atoms, part...)
+		p.skipSpace()
+		if !p.consume("|") && !p.consumeIntersection() {
+			return atoms, true
+		}
+	}
+}
+
+// consumeIntersection consumes & unless it starts a by-reference parameter
+// in a callable signature.
+func (p *typeParser) consumeIntersection() bool {
+	if !strings.HasPrefix(p.src[p.pos:], "&") || strings.HasPrefix(strings.TrimSpace(p.src[p.pos+1:]), "$") {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+func (p *typeParser) parsePostfix() ([]typeAtom, bool) {
+	atoms, ok := p.parsePrimary()
+	if !ok {
+		return nil, false
+	}
+	for {
+		p.skipSpace()
+		if !p.consume("[]") {
+			return atoms, true
+		}
+		atoms = []typeAtom{arrayAtom("", typeFromAtoms(atoms), EmptyType(), false, false)}
+	}
+}
+
+func (p *typeParser) parsePrimary() ([]typeAtom, bool) {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return nil, false
+	}
+	switch c := p.src[p.pos]; {
+	case c == '?':
+		p.pos++
+		atoms, ok := p.parsePostfix()
+		if !ok {
+			return nil, false
+		}
+		return append(atoms, builtinAtom("null")), true
+	case c == '(':
+		p.pos++
+		atoms, ok := p.parseUnion()
+		p.skipSpace()
+		if !ok || !p.consume(")") {
+			return nil, false
+		}
+		return atoms, true
+	case c == '\'' || c == '"':
+		value, ok := p.parseQuoted()
+		if !ok {
+			return nil, false
+		}
+		return []typeAtom{literalAtom("string", quoteLiteral(value))}, true
+	case c == '-' || c >= '0' && c <= '9':
+		number, ok := p.parseNumber()
+		if !ok {
+			return nil, false
+		}
+		if strings.ContainsAny(number, ".eE") {
+			return []typeAtom{literalAtom("float", number)}, true
+		}
+		return []typeAtom{literalAtom("int", number)}, true
+	}
+	start := p.pos
+	name := p.parseName()
+	if name == "" {
+		return nil, false
+	}
+	if p.consume("::") {
+		member := p.parseMember()
+		if member == "" {
+			return nil, false
+		}
+		return []typeAtom{classConstantAtom(p.className(name), member)}, true
+	}
+	lower := strings.ToLower(strings.TrimPrefix(name, `\`))
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], "<"):
+		if lower == "int" {
+			return p.parseIntRange()
+		}
+		p.pos++
+		args, ok := p.parseTypeList(">")
+		if !ok {
+			return nil, false
+		}
+		return p.genericAtoms(name, lower, args), true
+	case strings.HasPrefix(p.src[p.pos:], "{"):
+		p.pos++
+		entries, ok := p.parseShape()
+		if !ok {
+			return nil, false
+		}
+		switch lower {
+		case "array", "non-empty-array":
+			return []typeAtom{shapeAtom(entries, false)}, true
+		case "list", "non-empty-list":
+			return []typeAtom{shapeAtom(entries, true)}, true
+		}
+		return []typeAtom{builtinAtom("object")}, true
+	case strings.HasPrefix(p.src[p.pos:], "("):
+		if !p.skipBalanced('(', ')') {
+			return nil, false
+		}
+		p.skipSpace()
+		if p.consume(":") {
+			if _, ok := p.parsePostfix(); !ok {
+				return nil, false
+			}
+		}
+		return []typeAtom{callableAtom(p.className(name), strings.TrimSpace(p.src[start:p.pos]))}, true
+	}
+	return p.namedAtoms(name, lower), true
+}
+
+// namedAtoms returns the atoms of a type name without arguments.
+func (p *typeParser) namedAtoms(name, lower string) []typeAtom {
+	switch lower {
+	case "boolean":
+		return []typeAtom{builtinAtom("bool")}
+	case "integer":
+		return []typeAtom{builtinAtom("int")}
+	case "double", "real":
+		return []typeAtom{builtinAtom("float")}
+	case "callback":
+		return []typeAtom{builtinAtom("callable")}
+	case "array-key":
+		return []typeAtom{builtinAtom("int"), builtinAtom("string")}
+	case "scalar":
+		return []typeAtom{builtinAtom("bool"), builtinAtom("float"), builtinAtom("int"), builtinAtom("string")}
+	case "associative-array", "array-shape":
+		return []typeAtom{builtinAtom("array")}
+	case "list", "non-empty-list", "non-empty-array":
+		return []typeAtom{arrayAtom(lower, MixedType(), EmptyType(), lower != "non-empty-array", lower != "list")}
+	case "class-string", "interface-string", "trait-string", "enum-string", "callable-string",
+		"literal-string", "lowercase-string", "numeric-string", "non-empty-string", "non-falsy-string", "truthy-string":
+		return []typeAtom{stringRefinementAtom(lower, EmptyType())}
+	case "positive-int":
+		return []typeAtom{intRangeAtom(lower, int64Ptr(1), nil)}
+	case "negative-int":
+		return []typeAtom{intRangeAtom(lower, nil, int64Ptr(-1))}
+	case "non-negative-int":
+		return []typeAtom{intRangeAtom(lower, int64Ptr(0), nil)}
+	case "non-positive-int":
+		return []typeAtom{intRangeAtom(lower, nil, int64Ptr(0))}
+	}
+	if _, ok := builtinTypeNames[lower]; ok {
+		return []typeAtom{builtinAtom(lower)}
+	}
+	if strings.Contains(lower, "-") {
+		// Utility types such as key-of<T> or int-mask are not modelled.
+		return []typeAtom{builtinAtom("mixed")}
+	}
+	return []typeAtom{classAtom(p.className(name), nil)}
+}
+
+// genericAtoms returns the atoms of name<args>.
+func (p *typeParser) genericAtoms(name, lower string, args []Type) []typeAtom {
+	switch lower {
+	case "array", "non-empty-array", "iterable":
+		value, key := args[len(args)-1], EmptyType()
+		if len(args) > 1 {
+			key = args[0]
+		}
+		if lower == "iterable" {
+			atom := arrayAtom("", value, key, false, false)
+			atom.base = "iterable"
+			atom.display = "iterable" + strings.TrimPrefix(atom.display, "array")
+			atom.key = strings.ToLower(atom.display)
+			return []typeAtom{atom}
+		}
+		return []typeAtom{arrayAtom(lower, value, key, false, lower == "non-empty-array")}
+	case "list", "non-empty-list":
+		return []typeAtom{arrayAtom(lower, args[len(args)-1], EmptyType(), true, lower == "non-empty-list")}
+	case "class-string", "interface-string", "trait-string", "enum-string":
+		return []typeAtom{stringRefinementAtom(lower, args[0])}
+	}
+	atoms := p.namedAtoms(name, lower)
+	if len(atoms) == 1 && atoms[0].kind == typeKindClass {
+		return []typeAtom{classAtom(atoms[0].base, args)}
+	}
+	return atoms
+}
+
+func (p *typeParser) parseTypeList(closing string) ([]Type, bool) {
+	var types []Type
+	for {
+		atoms, ok := p.parseUnion()
+		if !ok {
+			return nil, false
+		}
+		types = append(types, typeFromAtoms(atoms))
+		p.skipSpace()
+		if p.consume(closing) {
+			return types, true
+		}
+		if !p.consume(",") {
+			return nil, false
+		}
+	}
+}
+
+// parseIntRange parses the <min, max> of int<min, max>.
+func (p *typeParser) parseIntRange() ([]typeAtom, bool) {
+	p.pos++
+	bound := func(open string) (*int64, bool) {
+		p.skipSpace()
+		if strings.HasPrefix(p.src[p.pos:], open) {
+			p.pos += len(open)
+			return nil, true
+		}
+		number, ok := p.parseNumber()
+		if !ok {
+			return nil, false
+		}
+		value, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		return &value, true
+	}
+	low, ok := bound("min")
+	if !ok {
+		return nil, false
+	}
+	p.skipSpace()
+	if !p.consume(",") {
+		return nil, false
+	}
+	high, ok := bound("max")
+	if !ok {
+		return nil, false
+	}
+	p.skipSpace()
+	if !p.consume(">") {
+		return nil, false
+	}
+	return []typeAtom{intRangeAtom("", low, high)}, true
+}
+
+// parseShape parses the entries of array{...} up to the closing brace.
+// Entries without a key are numbered from zero.
+func (p *typeParser) parseShape() ([]shapeEntry, bool) {
+	var entries []shapeEntry
+	next := 0
+	for {
+		p.skipSpace()
+		if p.consume("}") {
+			return entries, true
+		}
+		if p.consume("...") {
+			p.skipSpace()
+			if strings.HasPrefix(p.src[p.pos:], "<") && !p.skipBalanced('<', '>') {
+				return nil, false
+			}
+			p.skipSpace()
+			p.consume(",")
+			continue
+		}
+		entry := shapeEntry{}
+		if key, optional, ok := p.parseShapeKey(); ok {
+			entry.key, entry.optional = key, optional
+		} else {
+			entry.key = strconv.Itoa(next)
+			next++
+		}
+		atoms, ok := p.parseUnion()
+		if !ok {
+			return nil, false
+		}
+		entry.typ = typeFromAtoms(atoms)
+		entries = append(entries, entry)
+		p.skipSpace()
+		if !p.consume(",") {
+			p.skipSpace()
+			if !p.consume("}") {
+				return nil, false
+			}
+			return entries, true
+		}
+	}
+}
+
+// parseShapeKey consumes "key:" or "key?:" when present.
+func (p *typeParser) parseShapeKey() (string, bool, bool) {
+	start := p.pos
+	var key string
+	switch {
+	case p.pos < len(p.src) && (p.src[p.pos] == '\'' || p.src[p.pos] == '"'):
+		value, ok := p.parseQuoted()
+		if !ok {
+			p.pos = start
+			return "", false, false
+		}
+		key = value
+	default:
+		end := p.pos
+		for end < len(p.src) && (isTypeNameByte(p.src[end]) && p.src[end] != '\\') {
+			end++
+		}
+		key = p.src[p.pos:end]
+		p.pos = end
+	}
+	p.skipSpace()
+	optional := p.consume("?")
+	p.skipSpace()
+	if key == "" || !strings.HasPrefix(p.src[p.pos:], ":") || strings.HasPrefix(p.src[p.pos:], "::") {
+		p.pos = start
+		return "", false, false
+	}
+	p.pos++
+	return key, optional, true
+}
+
+func (p *typeParser) parseName() string {
+	start := p.pos
+	if p.pos < len(p.src) && p.src[p.pos] == '$' {
+		p.pos++
+	}
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		// Hyphens join keyword names such as non-empty-string, not ranges.
+		if c == '-' && p.pos > start && p.pos+1 < len(p.src) && isLetterByte(p.src[p.pos+1]) {
+			p.pos++
+			continue
+		}
+		if !isTypeNameByte(c) {
+			break
+		}
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+// parseMember parses the constant or case after Foo::, which may use *
+// wildcards.
+func (p *typeParser) parseMember() string {
+	start := p.pos
+	for p.pos < len(p.src) && (isTypeNameByte(p.src[p.pos]) || p.src[p.pos] == '*') && p.src[p.pos] != '\\' {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *typeParser) parseQuoted() (string, bool) {
+	quote := p.src[p.pos]
+	var out strings.Builder
+	for i := p.pos + 1; i < len(p.src); i++ {
+		switch c := p.src[i]; {
+		case c == '\\' && i+1 < len(p.src):
+			i++
+			out.WriteByte(p.src[i])
+		case c == quote:
+			p.pos = i + 1
+			return out.String(), true
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return "", false
+}
+
+func (p *typeParser) parseNumber() (string, bool) {
+	start := p.pos
+	if p.pos < len(p.src) && p.src[p.pos] == '-' {
+		p.pos++
+	}
+	digits := p.pos
+	for p.pos < len(p.src) && (p.src[p.pos] >= '0' && p.src[p.pos] <= '9' || p.src[p.pos] == '.' || p.src[p.pos] == '_') {
+		p.pos++
+	}
+	if p.pos == digits {
+		p.pos = start
+		return "", false
+	}
+	return strings.ReplaceAll(p.src[start:p.pos], "_", ""), true
+}
+
+func (p *typeParser) skipBalanced(open, closing byte) bool {
+	depth := 0
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case open:
+			depth++
+		case closing:
+			depth--
+			if depth == 0 {
+				p.pos++
+				return true
+			}
+		}
+		p.pos++
+	}
+	return false
+}
+
+func (p *typeParser) className(name string) string {
+	name = strings.TrimPrefix(name, `\`)
+	if p.resolve == nil {
+		return name
+	}
+	return strings.TrimPrefix(p.resolve(name), `\`)
+}
+
+func (p *typeParser) consume(token string) bool {
+	if strings.HasPrefix(p.src[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *typeParser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t' || p.src[p.pos] == '\n' || p.src[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+func isTypeNameByte(c byte) bool {
+	return isLetterByte(c) || c >= '0' && c <= '9' || c == '_' || c == '\\' || c >= 0x80
+}
+
+func isLetterByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// typeFromAtoms builds a type from parsed atoms.
+func typeFromAtoms(atoms []typeAtom) Type {
+	if len(atoms) == 0 {
+		return EmptyType()
+	}
+	t := Type{atoms: make(map[string]typeAtom, len(atoms))}
+	for _, atom := range atoms {
+		t.atoms[atom.key] = atom
+	}
+	return t
+}
+
+func builtinAtom(name string) typeAtom {
+	return typeAtom{key: name, display: name, kind: typeKindBuiltin}
+}
+
+func classAtom(name string, args []Type) typeAtom {
+	display := name
+	if len(args) > 0 {
+		display += "<" + joinTypes(args) + ">"
+	}
+	return typeAtom{key: "class:" + strings.ToLower(display), display: display, kind: typeKindClass, base: name, args: args}
+}
+
+// classConstantAtom is an enum case or class constant type such as
+// Suit::Hearts.
+func classConstantAtom(className, member string) typeAtom {
+	display := className + "::" + member
+	return typeAtom{key: "class:" + strings.ToLower(className) + "::" + member, display: display, kind: typeKindClass, base: className, literal: member}
+}
+
+func literalAtom(base, value string) typeAtom {
+	return typeAtom{key: "literal:" + value, display: value, kind: typeKindBuiltin, base: base, literal: value}
+}
+
+func stringRefinementAtom(name string, class Type) typeAtom {
+	display := name
+	var args []Type
+	if !class.IsEmpty() {
+		display += "<" + class.String() + ">"
+		args = []Type{class}
+	}
+	return typeAtom{
+		key:      strings.ToLower(display),
+		display:  display,
+		kind:     typeKindBuiltin,
+		base:     "string",
+		args:     args,
+		refined:  name,
+		nonEmpty: name != "literal-string" && name != "lowercase-string",
+	}
+}
+
+func intRangeAtom(name string, low, high *int64) typeAtom {
+	display := name
+	if display == "" {
+		display = "int<" + rangeBound(low, "min") + ", " + rangeBound(high, "max") + ">"
+	}
+	return typeAtom{key: display, display: display, kind: typeKindBuiltin, base: "int", refined: "int-range", min: low, max: high}
+}
+
+func rangeBound(bound *int64, unbounded string) string {
+	if bound == nil {
+		return unbounded
+	}
+	return strconv.FormatInt(*bound, 10)
+}
+
+// arrayAtom is array<K, V>, list<V> or one of the non-empty variants. An
+// empty key type stands for int|string. name is the keyword written, or ""
+// for array.
+func arrayAtom(name string, value, key Type, list, nonEmpty bool) typeAtom {
+	if name == "" {
+		name = "array"
+	}
+	display := name
+	if !value.IsEmpty() && !(value.hasBuiltin("mixed") && key.IsEmpty()) {
+		display += "<"
+		if !key.IsEmpty() {
+			display += key.String() + ", "
+		}
+		display += value.String() + ">"
+	}
+	if key.IsEmpty() {
+		key = ParseType("int|string")
+		if list {
+			key = ParseType("int")
+		}
+	}
+	return typeAtom{
+		key:      strings.ToLower(display),
+		display:  display,
+		kind:     typeKindBuiltin,
+		base:     "array",
+		refined:  "array",
+		args:     []Type{key, value},
+		list:     list,
+		nonEmpty: nonEmpty,
+	}
+}
+
+func shapeAtom(entries []shapeEntry, list bool) typeAtom {
+	name := "array"
+	if list {
+		name = "list"
+	}
+	parts := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		key := entry.key
+		if !isShapeIdentifier(key) {
+			key = quoteLiteral(key)
+		}
+		if entry.optional {
+			key += "?"
+		}
+		parts = append(parts, key+": "+entry.typ.String())
+	}
+	display := name + "{" + strings.Join(parts, ", ") + "}"
+	nonEmpty := false
+	for _, entry := range entries {
+		if !entry.optional {
+			nonEmpty = true
+		}
+	}
+	return typeAtom{
+		key:      strings.ToLower(display),
+		display:  display,
+		kind:     typeKindBuiltin,
+		base:     "array",
+		refined:  "shape",
+		shape:    entries,
+		list:     list,
+		nonEmpty: nonEmpty,
+	}
+}
+
+func isShapeIdentifier(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if !isTypeNameByte(key[i]) || key[i] == '\\' {
+			return false
+		}
+	}
+	return true
+}
+
+// callableAtom keeps a callable(A): R or Closure(A): R signature as written;
+// parameters and return types are not compared.
+func callableAtom(name, signature string) typeAtom {
+	if strings.EqualFold(name, "closure") {
+		return typeAtom{key: "class:" + strings.ToLower(signature), display: signature, kind: typeKindClass, base: "Closure", refined: "callable"}
+	}
+	return typeAtom{key: strings.ToLower(signature), display: signature, kind: typeKindBuiltin, base: "callable", refined: "callable"}
+}
+
+// renderTypeAtoms writes parsed atoms back as a union in parse order.
+func renderTypeAtoms(atoms []typeAtom) string {
+	parts := make([]string, 0, len(atoms))
+	for _, atom := range atoms {
+		parts = append(parts, atom.display)
+	}
+	return strings.Join(parts, "|")
+}
+
+func joinTypes(types []Type) string {
+	parts := make([]string, 0, len(types))
+	for _, typ := range types {
+		parts = append(parts, typ.String())
+	}
+	return strings.Join(parts, ", ")
+}
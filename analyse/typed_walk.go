@@ -0,0 +1,122 @@
+package analyse
+
+import (
+	"strings"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+)
+
+// typedPosition is where walkTypedExpressions visits an expression.
+type typedPosition struct {
+	scope *functionScope
+	// fn and flow are the innermost function, method or closure and its
+	// type flow.
+	fn    *ast.FunctionNode
+	flow  *typeFlow
+	class *ast.ClassNode
+	ft    fileTypeContext
+	// guarded is set inside isset(), empty() and unset() and on the left of
+	// ??, where null receivers and missing offsets are not errors.
+	guarded bool
+	// write is set for the target of an assignment.
+	write bool
+}
+
+// walkTypedExpressions calls visit for every node in the bodies of the
+// functions, methods and closures of a file, with the flow-sensitive scope
+// in effect where the node is evaluated. The right operand of && and || and
+// the branches of ?: see their condition narrowed. Arrow functions and
+// top-level statements are not visited.
+func walkTypedExpressions(nodes []ast.Node, ctx *AnalysisContext, visit func(ast.Node, typedPosition)) {
+	walkAll(nodes, func(node ast.Node, class *ast.ClassNode, currentFn *ast.FunctionNode, ft fileTypeContext) {
+		fn, ok := node.(*ast.FunctionNode)
+		if !ok || currentFn != nil {
+			// Closures are visited with the function that contains them.
+			return
+		}
+		walker := &typedWalker{ctx: ctx, visit: visit, class: class, ft: ft}
+		walker.function(fn)
+	})
+}
+
+type typedWalker struct {
+	ctx   *AnalysisContext
+	visit func(ast.Node, typedPosition)
+	class *ast.ClassNode
+	ft    fileTypeContext
+}
+
+func (w *typedWalker) function(fn *ast.FunctionNode) {
+	flow := analysisTypeFlow(w.ctx, w.class, fn, w.ft)
+	at := typedPosition{scope: flow.entry, fn: fn, flow: flow, class: w.class, ft: w.ft}
+	for _, child := range fn.Body {
+		w.node(child, flow, at)
+	}
+}
+
+func (w *typedWalker) node(node ast.Node, flow *typeFlow, at typedPosition) {
+	if node == nil {
+		return
+	}
+	if before, ok := flow.before[node]; ok {
+		at.scope = before
+	}
+	switch n := node.(type) {
+	case *ast.FunctionNode:
+		w.function(n)
+		return
+	case *ast.ClassNode, *ast.ArrowFunctionNode:
+		return
+	}
+	w.visit(node, at)
+	at.write = false
+	switch n := node.(type) {
+	case *ast.AssignmentNode:
+		target := at
+		target.write = true
+		w.node(n.Left, flow, target)
+		w.node(n.Right, flow, at)
+		return
+	case *ast.BinaryExpr:
+		switch n.Operator {
+		case "&&", "and":
+			w.node(n.Left, flow, at)
+			w.node(n.Right, flow, at.narrowed(n.Left, true))
+			return
+		case "||", "or":
+			w.node(n.Left, flow, at)
+			w.node(n.Right, flow, at.narrowed(n.Left, false))
+			return
+		case "??":
+			left := at
+			left.guarded = true
+			w.node(n.Left, flow, left)
+			w.node(n.Right, flow, at)
+			return
+		}
+	case *ast.TernaryExpr:
+		w.node(n.Condition, flow, at)
+		w.node(n.IfTrue, flow, at.narrowed(n.Condition, true))
+		w.node(n.IfFalse, flow, at.narrowed(n.Condition, false))
+		return
+	case *ast.FunctionCallNode:
+		switch strings.ToLower(functionCallName(n)) {
+		case "isset", "empty", "unset":
+			at.guarded = true
+		}
+	}
+	for _, child := range childNodes(node) {
+		w.node(child, flow, at)
+	}
+}
+
+// narrowed returns the position with its scope narrowed by condition having
+// evaluated to truth.
+func (at typedPosition) narrowed(condition ast.Node, truth bool) typedPosition {
+	if truth {
+		at.scope = scopeForConditionTrue(at.scope, condition)
+	} else {
+		at.scope = scopeForConditionFalse(at.scope, condition)
+	}
+	return at
+}
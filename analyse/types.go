@@ -2,6 +2,7 @@ package analyse
 
 import (
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -21,6 +22,22 @@ type typeAtom struct {
 	key     string
 	display string
 	kind    typeKind
+	// base is the builtin a refined atom narrows, such as string for
+	// class-string<Foo> or 'foo', or the class name of a class atom.
+	base string
+	// refined names the refinement of a builtin atom: a keyword such as
+	// non-empty-string, or array, shape, int-range or callable.
+	refined string
+	// args holds class-string<T> and generic class arguments, and the key and
+	// value types of array<K, V>, list<V> and iterable<K, V>.
+	args  []Type
+	shape []shapeEntry
+	// literal is the value of a literal atom as written, or the case or
+	// constant name of Foo::BAR.
+	literal  string
+	min, max *int64
+	list     bool
+	nonEmpty bool
 }
 
 var builtinTypeNames = map[string]struct{}{
@@ -69,6 +86,12 @@ func ParseType(raw string) Type {
 		return cached.(Type)
 	}
 
+	if atoms, ok := parseTypeAtoms(raw, nil); ok {
+		t := typeFromAtoms(atoms)
+		parsedTypeCache.Store(raw, t)
+		return t
+	}
+
 	t := Type{atoms: make(map[string]typeAtom)}
 	for _, part := range splitTopLevelTypes(raw, '|') {
 		for _, intersectionPart := range splitTopLevelTypes(part, '&') {
@@ -201,7 +224,7 @@ func (t Type) acceptsObjectLike() bool {
 
 func (t Type) hasMockObjectType() bool {
 	for _, atom := range t.atoms {
-		if isMockObjectType(atom.display) {
+		if atom.kind == typeKindClass && isMockObjectType(atom.className()) {
 			return true
 		}
 	}
@@ -230,7 +253,7 @@ func (t Type) SingleClassName() (string, bool) {
 	}
 	for _, atom := range t.atoms {
 		if atom.kind == typeKindClass {
-			return atom.display, true
+			return atom.className(), true
 		}
 	}
 	return "", false
@@ -256,6 +279,143 @@ func (t Type) withoutBuiltin(name string) Type {
 	return refined
 }
 
+// union returns the type of a value that has either type.
+func (t Type) union(other Type) Type {
+	if t.IsEmpty() {
+		return other
+	}
+	if other.IsEmpty() {
+		return t
+	}
+	merged := Type{atoms: make(map[string]typeAtom, len(t.atoms)+len(other.atoms))}
+	for key, atom := range t.atoms {
+		merged.atoms[key] = atom
+	}
+	for key, atom := range other.atoms {
+		merged.atoms[key] = atom
+	}
+	return merged
+}
+
+// without removes the atoms of other from t, along with the refinements of
+// its plain atoms, so removing array also removes list<int>.
+func (t Type) without(other Type) Type {
+	refined := Type{atoms: make(map[string]typeAtom, len(t.atoms))}
+	for key, atom := range t.atoms {
+		if _, ok := other.atoms[key]; ok {
+			continue
+		}
+		if plain, ok := other.atoms[atom.plainKey()]; ok && !plain.isRefined() {
+			continue
+		}
+		refined.atoms[key] = atom
+	}
+	if len(refined.atoms) == 0 {
+		return EmptyType()
+	}
+	return refined
+}
+
+// refinedBy narrows a native declared type with its PHPDoc type, such as
+// array with list<User> or string with 'asc'|'desc'. Doc atoms that do not
+// narrow a native atom, such as template names, leave t unchanged; native
+// atoms the doc type does not mention, such as null, are kept.
+func (t Type) refinedBy(doc Type) Type {
+	if t.IsEmpty() {
+		return doc
+	}
+	if doc.IsEmpty() {
+		return t
+	}
+	covered := make(map[string]struct{}, len(doc.atoms))
+	for key, atom := range doc.atoms {
+		if _, ok := t.atoms[key]; ok {
+			covered[key] = struct{}{}
+			continue
+		}
+		if _, ok := t.atoms[atom.plainKey()]; !ok {
+			return t
+		}
+		covered[atom.plainKey()] = struct{}{}
+	}
+	refined := Type{atoms: make(map[string]typeAtom, len(t.atoms)+len(doc.atoms))}
+	for key, atom := range doc.atoms {
+		refined.atoms[key] = atom
+	}
+	for key, atom := range t.atoms {
+		if _, ok := covered[key]; !ok {
+			refined.atoms[key] = atom
+		}
+	}
+	return refined
+}
+
+// boundTo replaces static and $this in t with the receiver class of a call,
+// and self with the class that declared the member.
+func (t Type) boundTo(receiver, declaring string) Type {
+	if receiver == "" {
+		return t
+	}
+	if declaring == "" {
+		declaring = receiver
+	}
+	bound := Type{atoms: make(map[string]typeAtom, len(t.atoms))}
+	changed := false
+	for key, atom := range t.atoms {
+		target := ""
+		switch key {
+		case "class:static", "class:$this":
+			target = receiver
+		case "class:self":
+			target = declaring
+		}
+		if target == "" {
+			bound.atoms[key] = atom
+			continue
+		}
+		changed = true
+		for targetKey, targetAtom := range ClassType(target).atoms {
+			bound.atoms[targetKey] = targetAtom
+		}
+	}
+	if !changed {
+		return t
+	}
+	return bound
+}
+
+// offsetValueType returns the type of an element read from a value of type
+// t, using the shape entry when the offset is a known literal key. It is
+// mixed unless every non-null atom is array-like.
+func (t Type) offsetValueType(key string, hasKey bool) Type {
+	value := EmptyType()
+	for atomKey, atom := range t.atoms {
+		if atomKey == "null" {
+			continue
+		}
+		if atom.kind != typeKindBuiltin || (atom.refined != "array" && atom.refined != "shape") {
+			return MixedType()
+		}
+		if atom.refined == "shape" && hasKey {
+			entry, ok := atom.shapeEntry(key)
+			if !ok {
+				return MixedType()
+			}
+			value = value.union(entry.typ)
+			continue
+		}
+		_, elementType := atom.arrayKeyValue()
+		if elementType.IsEmpty() {
+			return MixedType()
+		}
+		value = value.union(elementType)
+	}
+	if value.IsEmpty() {
+		return MixedType()
+	}
+	return value
+}
+
 func (t Type) sortedAtoms() []typeAtom {
 	atoms := make([]typeAtom, 0, len(t.atoms))
 	for _, atom := range t.atoms {
@@ -302,33 +462,229 @@ func atomsCompatibleWithContext(declared, actual typeAtom, scope *functionScope,
 		return true
 	}
 	if declared.kind == typeKindBuiltin && actual.kind == typeKindBuiltin {
-		if declared.key == "float" && actual.key == "int" {
+		return builtinAtomsCompatible(declared, actual, scope, ctx)
+	}
+	if declared.kind == typeKindBuiltin && actual.kind == typeKindClass {
+		switch declared.plainKey() {
+		case "object":
 			return true
+		case "callable":
+			return strings.EqualFold(actual.className(), "Closure")
+		case "iterable":
+			return classHierarchyCompatible("Traversable", actual.className(), scope, ctx)
 		}
-		if declared.key == "void" && actual.key == "null" {
-			return true
+		return false
+	}
+	if declared.kind == typeKindClass && actual.kind == typeKindClass {
+		// An enum case or class constant type only accepts itself, but a value
+		// of the enum itself is not known to be a different case.
+		if declared.literal != "" && actual.literal != "" {
+			return false
 		}
-		if declared.key == "bool" && (actual.key == "true" || actual.key == "false") {
-			return true
+		if !classHierarchyCompatible(declared.className(), actual.className(), scope, ctx) {
+			return false
 		}
-		if declared.key == "iterable" && actual.key == "array" {
-			return true
+		return classArgsCompatible(declared, actual, scope, ctx)
+	}
+	return false
+}
+
+// classArgsCompatible compares the type arguments of Collection<User> with
+// those of the same generic class, covariantly. Arguments of a subclass
+// cannot be mapped without its @extends bindings, so they are not compared,
+// nor are missing arguments.
+func classArgsCompatible(declared, actual typeAtom, scope *functionScope, ctx *AnalysisContext) bool {
+	if len(declared.args) == 0 || len(declared.args) != len(actual.args) {
+		return true
+	}
+	if !strings.EqualFold(canonicalClassName(declared.className(), scope, ctx), canonicalClassName(actual.className(), scope, ctx)) {
+		return true
+	}
+	for i := range declared.args {
+		if !declared.args[i].AcceptsWithContext(actual.args[i], scope, ctx) {
+			return false
 		}
 	}
-	if declared.kind == typeKindBuiltin && actual.kind == typeKindClass {
-		if declared.key == "object" {
+	return true
+}
+
+// builtinAtomsCompatible compares builtin atoms. A plain declared type
+// accepts every refinement of it, such as 'foo' for string, and a refined
+// declared type accepts a plain actual value of the same type, whose
+// refinement is unknown rather than known to differ.
+func builtinAtomsCompatible(declared, actual typeAtom, scope *functionScope, ctx *AnalysisContext) bool {
+	declaredPlain, actualPlain := declared.plainKey(), actual.plainKey()
+	switch {
+	case declaredPlain == "float" && actualPlain == "int":
+		return !declared.isRefined()
+	case declaredPlain == "void" && actualPlain == "null":
+		return true
+	case declaredPlain == "bool" && (actualPlain == "true" || actualPlain == "false"):
+		return true
+	case declaredPlain == "iterable" && actualPlain == "array":
+		return !declared.isRefined() || !actual.isRefined() || arrayAtomsCompatible(declared, actual, scope, ctx)
+	}
+	if declaredPlain != actualPlain {
+		return false
+	}
+	if !declared.isRefined() || !actual.isRefined() {
+		return true
+	}
+	if declared.literal != "" {
+		// Distinct literals never match; equal ones have the same key.
+		return declaredPlain == "int" && actual.refined == "int-range" && actual.min != nil && actual.max != nil &&
+			*actual.min == *actual.max && strconv.FormatInt(*actual.min, 10) == declared.literal
+	}
+	switch declaredPlain {
+	case "string":
+		return stringAtomsCompatible(declared, actual, scope, ctx)
+	case "int":
+		low, high, ok := actual.intBounds()
+		return ok && withinBounds(declared.min, declared.max, low, high)
+	case "array", "iterable":
+		return arrayAtomsCompatible(declared, actual, scope, ctx)
+	}
+	return true
+}
+
+func stringAtomsCompatible(declared, actual typeAtom, scope *functionScope, ctx *AnalysisContext) bool {
+	if actual.literal != "" {
+		return !declared.nonEmpty || actual.literal != "''"
+	}
+	if declared.refined == actual.refined || declared.isClassString() && actual.isClassString() && declared.refined == "class-string" {
+		if len(declared.args) == 1 && len(actual.args) == 1 {
+			return declared.args[0].AcceptsWithContext(actual.args[0], scope, ctx)
+		}
+		return true
+	}
+	return declared.refined == "non-empty-string" && actual.nonEmpty
+}
+
+// arrayAtomsCompatible compares the keys and values of array<K, V>, list<V>,
+// iterable<K, V> and array shapes.
+func arrayAtomsCompatible(declared, actual typeAtom, scope *functionScope, ctx *AnalysisContext) bool {
+	if declared.nonEmpty && actual.refined == "shape" && !actual.nonEmpty {
+		return false
+	}
+	if declared.list && !actual.list && actual.refined == "shape" && !actual.isListShape() {
+		return false
+	}
+	if declared.refined == "shape" {
+		if actual.refined != "shape" {
 			return true
 		}
-		if declared.key == "iterable" {
-			return classHierarchyCompatible("Traversable", actual.display, scope, ctx)
+		for _, entry := range declared.shape {
+			actualEntry, ok := actual.shapeEntry(entry.key)
+			if !ok || actualEntry.optional && !entry.optional {
+				if entry.optional {
+					continue
+				}
+				return false
+			}
+			if !entry.typ.AcceptsWithContext(actualEntry.typ, scope, ctx) {
+				return false
+			}
 		}
+		return true
 	}
-	if declared.kind == typeKindClass && actual.kind == typeKindClass {
-		return classHierarchyCompatible(declared.display, actual.display, scope, ctx)
+	declaredKey, declaredValue := declared.arrayKeyValue()
+	actualKey, actualValue := actual.arrayKeyValue()
+	return declaredKey.AcceptsWithContext(actualKey, scope, ctx) && declaredValue.AcceptsWithContext(actualValue, scope, ctx)
+}
+
+func withinBounds(declaredMin, declaredMax, low, high *int64) bool {
+	if declaredMin != nil && (low == nil || *low < *declaredMin) {
+		return false
+	}
+	if declaredMax != nil && (high == nil || *high > *declaredMax) {
+		return false
+	}
+	return true
+}
+
+// className returns the class of a class atom, without its type arguments.
+func (a typeAtom) className() string {
+	if a.base != "" {
+		return a.base
+	}
+	return a.display
+}
+
+// plainKey returns the key of the unrefined atom an atom narrows, such as
+// string for 'foo' or class:collection for Collection<User>.
+func (a typeAtom) plainKey() string {
+	if a.kind == typeKindClass {
+		return "class:" + strings.ToLower(a.className())
+	}
+	if a.base != "" {
+		return a.base
+	}
+	return a.key
+}
+
+func (a typeAtom) isRefined() bool {
+	return a.refined != "" || a.literal != "" || len(a.args) > 0
+}
+
+func (a typeAtom) isClassString() bool {
+	switch a.refined {
+	case "class-string", "interface-string", "trait-string", "enum-string":
+		return true
 	}
 	return false
 }
 
+// intBounds returns the range of an int literal or range atom.
+func (a typeAtom) intBounds() (*int64, *int64, bool) {
+	if a.literal != "" {
+		value, err := strconv.ParseInt(a.literal, 10, 64)
+		if err != nil {
+			return nil, nil, false
+		}
+		return &value, &value, true
+	}
+	return a.min, a.max, a.refined == "int-range"
+}
+
+// arrayKeyValue returns the key and value types of an array-like atom.
+func (a typeAtom) arrayKeyValue() (Type, Type) {
+	if a.refined == "shape" {
+		keys, values := EmptyType(), EmptyType()
+		for _, entry := range a.shape {
+			if _, err := strconv.ParseInt(entry.key, 10, 64); err == nil {
+				keys = keys.union(typeFromAtoms([]typeAtom{literalAtom("int", entry.key)}))
+			} else {
+				keys = keys.union(typeFromAtoms([]typeAtom{literalAtom("string", quoteLiteral(entry.key))}))
+			}
+			values = values.union(entry.typ)
+		}
+		return keys, values
+	}
+	if len(a.args) == 2 {
+		return a.args[0], a.args[1]
+	}
+	return EmptyType(), EmptyType()
+}
+
+func (a typeAtom) shapeEntry(key string) (shapeEntry, bool) {
+	for _, entry := range a.shape {
+		if entry.key == key {
+			return entry, true
+		}
+	}
+	return shapeEntry{}, false
+}
+
+// isListShape reports whether a shape's keys are 0, 1, 2 and so on.
+func (a typeAtom) isListShape() bool {
+	for i, entry := range a.shape {
+		if entry.key != strconv.Itoa(i) || entry.optional {
+			return false
+		}
+	}
+	return true
+}
+
 func canonicalizeDocType(raw string) string {
 	lower := strings.ToLower(strings.TrimSpace(raw))
 	if strings.HasPrefix(lower, "[") && strings.HasSuffix(lower, "]") {
@@ -0,0 +1,142 @@
+package analyse
+
+import "testing"
+
+func TestParseTypeKeepsPrecisePHPDocTypes(t *testing.T) {
+	cases := map[string]string{
+		`array<int, User>`:              `array<int, User>`,
+		`list<string>`:                  `list<string>`,
+		`User[]`:                        `array<User>`,
+		`array{id: int, name?: string}`: `array{id: int, name?: string}`,
+		`non-empty-string`:              `non-empty-string`,
+		`int<1, 10>`:                    `int<1, 10>`,
+		`int<min, 0>`:                   `int<min, 0>`,
+		`'foo'|'bar'`:                   `'bar'|'foo'`,
+		`class-string<\App\Foo>`:        `class-string<App\Foo>`,
+		`Collection<int, User>`:         `Collection<int, User>`,
+		`?list<int>`:                    `list<int>|null`,
+		`array-key`:                     `int|string`,
+		`key-of<Foo>`:                   `mixed`,
+		`callable(int): string`:         `callable(int): string`,
+		`Suit::Hearts`:                  `Suit::Hearts`,
+	}
+	for raw, want := range cases {
+		if got := ParseType(raw).String(); got != want {
+			t.Errorf("ParseType(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
+
+func TestTypeAcceptsRefinedTypes(t *testing.T) {
+	cases := []struct {
+		declared, actual string
+		want             bool
+	}{
+		{`list<User>`, `list<User>`, true},
+		{`list<User>`, `list<string>`, false},
+		{`list<User>`, `array`, true},
+		{`list<int>`, `array<string, int>`, false},
+		{`list<int>`, `array{0: int, 1: int}`, true},
+		{`list<int>`, `array{a: int}`, false},
+		{`array<int, User>`, `list<User>`, true},
+		{`array`, `list<User>`, true},
+		{`iterable<User>`, `list<User>`, true},
+		{`iterable<User>`, `list<string>`, false},
+		{`array{id: int}`, `array{id: int, name: string}`, true},
+		{`array{id: int}`, `array{id: string}`, false},
+		{`array{id: int}`, `array{name: string}`, false},
+		{`array{id: int, name?: string}`, `array{id: int}`, true},
+		{`non-empty-array`, `array{}`, false},
+		{`string`, `'foo'`, true},
+		{`'foo'|'bar'`, `'foo'`, true},
+		{`'foo'|'bar'`, `'baz'`, false},
+		{`'foo'|'bar'`, `string`, true},
+		{`non-empty-string`, `''`, false},
+		{`non-empty-string`, `class-string`, true},
+		{`int`, `int<1, 10>`, true},
+		{`int<1, 10>`, `5`, true},
+		{`int<1, 10>`, `11`, false},
+		{`positive-int`, `int<1, 10>`, true},
+		{`positive-int`, `int<0, 10>`, false},
+		{`float`, `int<1, 10>`, true},
+		{`class-string<Foo>`, `class-string<Foo>`, true},
+		{`class-string<Foo>`, `class-string<Bar>`, false},
+		{`class-string<Foo>`, `string`, true},
+		{`Collection<User>`, `Collection<User>`, true},
+		{`Collection<User>`, `Collection<string>`, false},
+		{`Collection<User>`, `Collection`, true},
+		{`Collection`, `Collection<User>`, true},
+		{`Suit`, `Suit::Hearts`, true},
+		{`Suit::Hearts`, `Suit::Spades`, false},
+	}
+	for _, tc := range cases {
+		if got := ParseType(tc.declared).Accepts(ParseType(tc.actual)); got != tc.want {
+			t.Errorf("%s accepts %s = %v, want %v", tc.declared, tc.actual, got, tc.want)
+		}
+	}
+}
+
+func TestTypeWithoutRemovesRefinementsOfPlainAtoms(t *testing.T) {
+	got := ParseType(`list<int>|string|null`).without(ParseType("array"))
+	if got.String() != "null|string" {
+		t.Fatalf("expected null|string, got %s", got)
+	}
+}
+
+func TestTypeRefinedByPHPDocType(t *testing.T) {
+	cases := []struct {
+		native, doc, want string
+	}{
+		{`array`, `list<User>`, `list<User>`},
+		{`?array`, `list<User>`, `list<User>|null`},
+		{`string`, `'asc'|'desc'`, `'asc'|'desc'`},
+		{`object`, `T`, `object`},
+		{`Collection`, `Collection<User>`, `Collection<User>`},
+		{``, `list<User>`, `list<User>`},
+	}
+	for _, tc := range cases {
+		if got := ParseType(tc.native).refinedBy(ParseType(tc.doc)).String(); got != tc.want {
+			t.Errorf("%q refined by %q = %q, want %q", tc.native, tc.doc, got, tc.want)
+		}
+	}
+}
+
+func TestArgumentTypeUsesPHPDocPrecision(t *testing.T) {
+	php := `<?php
+    class User {}
+
+    class Example {
+        /**
+         * @param list<User> $users
+         * @param 'asc'|'desc' $direction
+         * @param array{id: int} $row
+         */
+        public function sort(array $users, string $direction, array $row): void {
+        }
+
+        /** @param list<string> $names */
+        public function run(array $names): void {
+            $this->sort([new User()], 'asc', ['id' => 1]);
+            $this->sort($names, 'up', ['id' => 'one']);
+        }
+    }`
+	var got []string
+	for _, issue := range analysePHPArgTypesWithProject(t, php) {
+		if issue.Code == "A.ARG.TYPE" {
+			got = append(got, issue.Message)
+		}
+	}
+	want := []string{
+		"Method sort argument 1 expects list<User>, got list<string>",
+		"Method sort argument 2 expects 'asc'|'desc', got 'up'",
+		"Method sort argument 3 expects array{id: int}, got array{id: 'one'}",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d issues, got %#v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("issue %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
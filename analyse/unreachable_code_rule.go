@@ -1,27 +1,38 @@
 package analyse
 
 import (
+	"github.com/ayanozturk/go-php-parser/analyse/cfg"
 	"github.com/ayanozturk/go-php-parser/ast"
-	"strings"
 )
 
-// UnreachableCodeRule reports statements that can never execute because a
-// previous statement in the same block always terminates control flow.
+// UnreachableCodeRule reports statements that can never execute because
+// every path leading to them returns, throws, jumps away or calls a function
+// that never returns.
 //
-// Inspired by modern static analyzers (like mago), this keeps the check cheap by
-// performing a single pass per block and short-circuiting once a terminator is found.
+// Reachability comes from the control-flow graph of the enclosing function
+// body (or of the file for top-level code). Only the outermost unreachable
+// statement is reported; its children are not visited again.
 type UnreachableCodeRule struct{}
 
 func (r *UnreachableCodeRule) CheckIssues(nodes []ast.Node, filename string) []AnalysisIssue {
+	return r.CheckIssuesWithContext(nodes, filename, nil)
+}
+
+// CheckIssuesWithContext is CheckIssues with symbol information, which lets
+// calls to never-returning functions and methods terminate control flow.
+func (r *UnreachableCodeRule) CheckIssuesWithContext(nodes []ast.Node, filename string, ctx *AnalysisContext) []AnalysisIssue {
 	issues := make([]AnalysisIssue, 0, 4)
-	r.walkStatements(nodes, filename, &issues)
+	typeCtx := analysisFileTypeContext(ctx, nodes)
+	r.walkStatements(nodes, cfg.New(nodes, nil), nil, filename, typeCtx, ctx, &issues)
 	return issues
 }
 
-func (r *UnreachableCodeRule) walkStatements(stmts []ast.Node, filename string, issues *[]AnalysisIssue) {
-	terminated := false
+func (r *UnreachableCodeRule) walkStatements(stmts []ast.Node, graph *cfg.Graph, class *ast.ClassNode, filename string, typeCtx fileTypeContext, ctx *AnalysisContext, issues *[]AnalysisIssue) {
 	for _, stmt := range stmts {
-		if terminated {
+		if stmt == nil {
+			continue
+		}
+		if !graph.Reachable(stmt) && !isHoistedDeclaration(stmt) {
 			pos := stmt.GetPos()
 			*issues = append(*issues, AnalysisIssue{
 				Filename: filename,
@@ -32,95 +43,64 @@ func (r *UnreachableCodeRule) walkStatements(stmts []ast.Node, filename string,
 			})
 			continue
 		}
-
-		r.walkChildren(stmt, filename, issues)
-
-		// Only mark as terminated if this statement always terminates control flow in all code paths
-		if isTerminatingStatement(stmt) {
-			terminated = true
-		}
+		r.walkChildren(stmt, graph, class, filename, typeCtx, ctx, issues)
 	}
 }
 
-func (r *UnreachableCodeRule) walkChildren(node ast.Node, filename string, issues *[]AnalysisIssue) {
+func (r *UnreachableCodeRule) walkChildren(node ast.Node, graph *cfg.Graph, class *ast.ClassNode, filename string, typeCtx fileTypeContext, ctx *AnalysisContext, issues *[]AnalysisIssue) {
 	switch n := node.(type) {
 	case *ast.FunctionNode:
-		r.walkStatements(n.Body, filename, issues)
+		if body := analysisControlFlow(ctx, class, n, typeCtx); body != nil {
+			r.walkStatements(n.Body, body, class, filename, typeCtx, ctx, issues)
+		}
 	case *ast.ClassNode:
 		for _, m := range n.Methods {
-			r.walkChildren(m, filename, issues)
+			r.walkChildren(m, graph, n, filename, typeCtx, ctx, issues)
 		}
 	case *ast.BlockNode:
-		r.walkStatements(n.Statements, filename, issues)
+		r.walkStatements(n.Statements, graph, class, filename, typeCtx, ctx, issues)
 	case *ast.IfNode:
-		r.walkStatements(n.Body, filename, issues)
+		r.walkStatements(n.Body, graph, class, filename, typeCtx, ctx, issues)
 		for _, elseif := range n.ElseIfs {
-			r.walkStatements(elseif.Body, filename, issues)
+			r.walkStatements(elseif.Body, graph, class, filename, typeCtx, ctx, issues)
 		}
 		if n.Else != nil {
-			r.walkStatements(n.Else.Body, filename, issues)
+			r.walkStatements(n.Else.Body, graph, class, filename, typeCtx, ctx, issues)
 		}
 	case *ast.WhileNode:
-		r.walkStatements(n.Body, filename, issues)
+		r.walkStatements(n.Body, graph, class, filename, typeCtx, ctx, issues)
+	case *ast.DoWhileNode:
+		r.walkStatements(n.Body, graph, class, filename, typeCtx, ctx, issues)
 	case *ast.ForeachNode:
-		r.walkStatements(n.Body, filename, issues)
-	case *ast.NamespaceNode:
-		r.walkStatements(n.Body, filename, issues)
-	}
-}
-
-func isTerminatingStatement(node ast.Node) bool {
-	switch n := node.(type) {
-	case *ast.ReturnNode, *ast.ThrowNode:
-		return true
-	case *ast.ExpressionStmt:
-		return isTerminatingStatement(n.Expr)
-	case *ast.FunctionCallNode:
-		return isBuiltinTerminatorCall(n)
-	case *ast.IfNode:
-		if n.Else == nil {
-			return false
-		}
-		if !statementsTerminate(n.Body) {
-			return false
+		r.walkStatements(n.Body, graph, class, filename, typeCtx, ctx, issues)
+	case *ast.SwitchNode:
+		for _, c := range n.Cases {
+			r.walkStatements(c.Body, graph, class, filename, typeCtx, ctx, issues)
 		}
-		for _, elseif := range n.ElseIfs {
-			if !statementsTerminate(elseif.Body) {
-				return false
-			}
+	case *ast.TryNode:
+		r.walkStatements(n.Body, graph, class, filename, typeCtx, ctx, issues)
+		for _, c := range n.Catches {
+			r.walkStatements(c.Body, graph, class, filename, typeCtx, ctx, issues)
 		}
-		return statementsTerminate(n.Else.Body)
+		r.walkStatements(n.Finally, graph, class, filename, typeCtx, ctx, issues)
+	case *ast.NamespaceNode:
+		r.walkStatements(n.Body, graph, class, filename, typeCtx, ctx, issues)
 	}
-	return false
 }
 
-func statementsTerminate(stmts []ast.Node) bool {
-	if len(stmts) == 0 {
-		return false
-	}
-	for _, stmt := range stmts {
-		if isTerminatingStatement(stmt) {
-			return true
-		}
+// isHoistedDeclaration reports declarations PHP binds at compile time, which
+// take effect even when the statement itself is never executed.
+func isHoistedDeclaration(node ast.Node) bool {
+	switch node.(type) {
+	case *ast.FunctionNode, *ast.ClassNode, *ast.InterfaceNode, *ast.TraitNode, *ast.EnumNode:
+		return true
 	}
 	return false
 }
 
-func isBuiltinTerminatorCall(call *ast.FunctionCallNode) bool {
-	if call == nil || call.Name == nil {
-		return false
-	}
-	identifier, ok := call.Name.(*ast.IdentifierNode)
-	if !ok {
-		return false
-	}
-	name := strings.TrimLeft(strings.ToLower(identifier.Value), `\`)
-	return name == "exit" || name == "die"
-}
-
 func init() {
-	RegisterAnalysisRuleWithLevel("Generic.CodeAnalysis.UnreachableCode", 4, "phpstan.deadCode", func(filename string, nodes []ast.Node, _ *AnalysisContext) []AnalysisIssue {
+	RegisterAnalysisRuleWithLevel("Generic.CodeAnalysis.UnreachableCode", 4, "phpstan.deadCode", func(filename string, nodes []ast.Node, ctx *AnalysisContext) []AnalysisIssue {
 		rule := &UnreachableCodeRule{}
-		return rule.CheckIssues(nodes, filename)
+		return rule.CheckIssuesWithContext(nodes, filename, ctx)
 	})
 }
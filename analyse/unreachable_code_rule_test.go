@@ -1,6 +1,7 @@
 package analyse
 
 import (
+	"github.com/ayanozturk/go-php-parser/ast"
 	"github.com/ayanozturk/go-php-parser/lexer"
 	"github.com/ayanozturk/go-php-parser/parser"
 	"testing"
@@ -18,6 +19,18 @@ func analyseUnreachablePHP(t *testing.T, code string) []AnalysisIssue {
 	return rule.CheckIssues(nodes, "test.php")
 }
 
+func analyseUnreachablePHPWithProject(t *testing.T, code string) []AnalysisIssue {
+	t.Helper()
+	p := parser.New(lexer.New(code), false)
+	nodes := p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("parser errors: %v", p.Errors())
+	}
+	project := BuildProjectIndex(map[string][]ast.Node{"test.php": nodes})
+	ctx := &AnalysisContext{Resolver: project, Project: project}
+	return (&UnreachableCodeRule{}).CheckIssuesWithContext(nodes, "test.php", ctx)
+}
+
 func countUnreachableIssues(issues []AnalysisIssue) int {
 	count := 0
 	for _, issue := range issues {
@@ -234,3 +247,86 @@ class PolicyComplianceService {
 		t.Fatalf("expected 0 unreachable issues after foreach assignment, got %d (%#v)", got, issues)
 	}
 }
+
+func TestUnreachableAfterInfiniteLoop(t *testing.T) {
+	php := `<?php
+function serve() {
+    while (true) {
+        handle();
+    }
+    cleanup();
+}`
+	issues := analyseUnreachablePHP(t, php)
+	if got := countUnreachableIssues(issues); got != 1 {
+		t.Fatalf("expected 1 unreachable issue after while(true), got %d (%#v)", got, issues)
+	}
+}
+
+func TestReachableAfterInfiniteLoopWithBreakNotReported(t *testing.T) {
+	php := `<?php
+function serve() {
+    for (;;) {
+        if (done()) {
+            break;
+        }
+    }
+    while (true) {
+        break;
+    }
+    cleanup();
+}`
+	issues := analyseUnreachablePHP(t, php)
+	if got := countUnreachableIssues(issues); got != 0 {
+		t.Fatalf("expected 0 unreachable issues, got %d (%#v)", got, issues)
+	}
+}
+
+func TestUnreachableAfterTryFinallyThatReturns(t *testing.T) {
+	php := `<?php
+function load() {
+    try {
+        return fetch();
+    } finally {
+        release();
+    }
+    log();
+}`
+	issues := analyseUnreachablePHP(t, php)
+	if got := countUnreachableIssues(issues); got != 1 {
+		t.Fatalf("expected 1 unreachable issue after try/finally, got %d (%#v)", got, issues)
+	}
+}
+
+func TestUnreachableAfterNeverReturningMethod(t *testing.T) {
+	php := `<?php
+class Controller {
+    private function fail(string $message): never {
+        throw new RuntimeException($message);
+    }
+
+    public function show($id) {
+        if ($id === null) {
+            $this->fail('missing id');
+            return null;
+        }
+        return $id;
+    }
+}`
+	issues := analyseUnreachablePHPWithProject(t, php)
+	if got := countUnreachableIssues(issues); got != 1 {
+		t.Fatalf("expected 1 unreachable issue after never-returning call, got %d (%#v)", got, issues)
+	}
+}
+
+func TestReachableAfterGotoLabelNotReported(t *testing.T) {
+	php := `<?php
+function retry() {
+    goto attempt;
+    attempt:
+    run();
+}`
+	issues := analyseUnreachablePHP(t, php)
+	if got := countUnreachableIssues(issues); got != 0 {
+		t.Fatalf("expected 0 unreachable issues, got %d (%#v)", got, issues)
+	}
+}
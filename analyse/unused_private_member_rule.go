@@ -0,0 +1,319 @@
+package analyse
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+)
+
+const unusedPrivateMemberCode = "PHPStan.DeadCode.UnusedPrivateMember"
+
+// UnusedPrivateMemberRule reports private methods, properties and constants
+// that nothing in their class, or in the traits it uses, refers to.
+//
+// References are matched by name: $this->m(), $other->m(), self::m(),
+// static::m(), [$this, 'm'], $this->m(...), self::$p and self::C all count.
+// Dynamic access such as $this->$name, $this->$name() or [$this, $name]
+// suppresses the reports for that kind of member, since any of them may be
+// the one used. Classes using a trait declared in another file are skipped.
+type UnusedPrivateMemberRule struct{}
+
+// privateMemberRefs collects the member names referenced from a class body.
+type privateMemberRefs struct {
+	methods    map[string]struct{}
+	properties map[string]struct{}
+	constants  map[string]struct{}
+	// dynamic* are set by access whose member name is only known at runtime.
+	dynamicMethods    bool
+	dynamicProperties bool
+	dynamicConstants  bool
+}
+
+var interpolatedThisMember = regexp.MustCompile(`\$this->([A-Za-z_][A-Za-z0-9_]*)`)
+
+func (r *UnusedPrivateMemberRule) CheckIssues(nodes []ast.Node, filename string) []AnalysisIssue {
+	var issues []AnalysisIssue
+	traits := map[string]*ast.TraitNode{}
+	collectTraits(nodes, collectFileTypeContext(nodes), traits)
+	r.walk(nodes, collectFileTypeContext(nodes), traits, filename, &issues)
+	return issues
+}
+
+func collectTraits(nodes []ast.Node, ft fileTypeContext, traits map[string]*ast.TraitNode) {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *ast.NamespaceNode:
+			collectTraits(n.Body, namespaceTypeContext(n), traits)
+		case *ast.TraitNode:
+			if n.Name != nil {
+				traits[strings.ToLower(ft.resolveClassLike(n.Name.Name))] = n
+			}
+		}
+	}
+}
+
+func namespaceTypeContext(n *ast.NamespaceNode) fileTypeContext {
+	ft := collectFileTypeContext(n.Body)
+	if ft.namespace == "" {
+		ft.namespace = n.Name
+	}
+	return ft
+}
+
+func (r *UnusedPrivateMemberRule) walk(nodes []ast.Node, ft fileTypeContext, traits map[string]*ast.TraitNode, filename string, issues *[]AnalysisIssue) {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *ast.NamespaceNode:
+			r.walk(n.Body, namespaceTypeContext(n), traits, filename, issues)
+		case *ast.ClassNode:
+			members := append(append(append([]ast.Node(nil), n.Constants...), n.Properties...), n.Methods...)
+			r.checkClass(ft.resolveClassLike(n.Name), members, ft, traits, filename, issues)
+		case *ast.EnumNode:
+			r.checkClass(ft.resolveClassLike(n.Name), n.Methods, ft, traits, filename, issues)
+		}
+	}
+}
+
+func (r *UnusedPrivateMemberRule) checkClass(className string, members []ast.Node, ft fileTypeContext, traits map[string]*ast.TraitNode, filename string, issues *[]AnalysisIssue) {
+	refs := privateMemberRefs{
+		methods:    map[string]struct{}{},
+		properties: map[string]struct{}{},
+		constants:  map[string]struct{}{},
+	}
+	if !collectTraitMemberRefs(members, ft, traits, map[string]struct{}{}, &refs) {
+		return
+	}
+	for _, member := range members {
+		refs.collect(member)
+	}
+
+	report := func(pos ast.Position, message string) {
+		*issues = append(*issues, AnalysisIssue{
+			Filename:    filename,
+			Line:        pos.Line,
+			Column:      pos.Column,
+			Code:        unusedPrivateMemberCode,
+			Message:     message,
+			SubjectKind: "class",
+			SubjectName: className,
+		})
+	}
+	for _, member := range members {
+		switch m := member.(type) {
+		case *ast.FunctionNode:
+			if !refs.dynamicMethods && isPrivateMethod(m) && !strings.HasPrefix(m.Name, "__") {
+				if _, ok := refs.methods[strings.ToLower(m.Name)]; !ok {
+					report(m.Pos, fmt.Sprintf("Method %s::%s() is unused.", className, m.Name))
+				}
+			}
+			if strings.EqualFold(m.Name, "__construct") && !refs.dynamicProperties {
+				for _, paramNode := range m.Params {
+					if param, ok := paramNode.(*ast.ParamNode); ok && param.IsPromoted && strings.EqualFold(param.Visibility, "private") {
+						if _, ok := refs.properties[param.Name]; !ok {
+							report(param.Pos, fmt.Sprintf("Property %s::$%s is unused.", className, param.Name))
+						}
+					}
+				}
+			}
+		case *ast.PropertyNode:
+			if !refs.dynamicProperties && strings.EqualFold(m.Visibility, "private") {
+				if _, ok := refs.properties[m.Name]; !ok {
+					report(m.Pos, fmt.Sprintf("Property %s::$%s is unused.", className, m.Name))
+				}
+			}
+		case *ast.ConstantNode:
+			if !refs.dynamicConstants && strings.EqualFold(m.Visibility, "private") {
+				if _, ok := refs.constants[m.Name]; !ok {
+					report(m.Pos, fmt.Sprintf("Constant %s::%s is unused.", className, m.Name))
+				}
+			}
+		}
+	}
+}
+
+// collectTraitMemberRefs adds the references made by the traits a class
+// uses, and by the traits they use. It returns false when a trait is not
+// declared in this file, so its references are unknown.
+func collectTraitMemberRefs(members []ast.Node, ft fileTypeContext, traits map[string]*ast.TraitNode, seen map[string]struct{}, refs *privateMemberRefs) bool {
+	for _, member := range members {
+		use, ok := member.(*ast.TraitUseNode)
+		if !ok {
+			continue
+		}
+		for _, name := range use.Traits {
+			key := strings.ToLower(ft.resolveClassLike(name))
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			trait, ok := traits[key]
+			if !ok {
+				return false
+			}
+			for _, child := range trait.Body {
+				refs.collect(child)
+			}
+			if !collectTraitMemberRefs(trait.Body, ft, traits, seen, refs) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isPrivateMethod(fn *ast.FunctionNode) bool {
+	return strings.EqualFold(fn.Visibility, "private") || hasModifier(fn.Modifiers, "private")
+}
+
+func (refs *privateMemberRefs) collect(node ast.Node) {
+	switch n := node.(type) {
+	case *ast.MethodCallNode:
+		refs.method(n.Method)
+	case *ast.FirstClassCallableNode:
+		if n.Name != nil {
+			name := n.Name.Value
+			if idx := strings.LastIndex(name, "->"); idx >= 0 {
+				refs.method(name[idx+2:])
+			} else if idx := strings.LastIndex(name, "::"); idx >= 0 {
+				refs.method(name[idx+2:])
+			}
+		}
+	case *ast.FunctionCallNode:
+		switch name := n.Name.(type) {
+		case *ast.IdentifierNode:
+			if idx := strings.LastIndex(name.Value, "::"); idx >= 0 {
+				refs.method(name.Value[idx+2:])
+			} else {
+				refs.collectFunctionCall(strings.ToLower(strings.TrimPrefix(name.Value, `\`)), n.Args)
+			}
+		case *ast.ClassConstFetchNode:
+			// Foo::$method() calls a method named by a variable.
+			refs.dynamicMethods = true
+			for _, arg := range n.Args {
+				refs.collect(arg)
+			}
+			return
+		case *ast.PropertyFetchNode:
+			// $this->{$name}() and ($this->handler)() parse alike: the
+			// callee is either a dynamic method or a closure property.
+			if isThisVariable(name.Object) {
+				refs.dynamicMethods = true
+			}
+		}
+	case *ast.PropertyFetchNode:
+		refs.property(n.Property)
+	case *ast.ClassConstFetchNode:
+		switch {
+		case strings.HasPrefix(n.Const, "$"):
+			refs.property(strings.TrimPrefix(n.Const, "$"))
+		case strings.EqualFold(n.Const, "class"):
+		default:
+			refs.constants[n.Const] = struct{}{}
+		}
+	case *ast.ArrayNode:
+		refs.collectCallableArray(n)
+	case *ast.StringNode:
+		for _, match := range interpolatedThisMember.FindAllStringSubmatch(n.Value, -1) {
+			refs.properties[match[1]] = struct{}{}
+			refs.methods[strings.ToLower(match[1])] = struct{}{}
+		}
+	}
+	for _, child := range childNodes(node) {
+		refs.collect(child)
+	}
+}
+
+func (refs *privateMemberRefs) method(name string) {
+	if name == "" || strings.ContainsAny(name, "${") {
+		refs.dynamicMethods = true
+		return
+	}
+	refs.methods[strings.ToLower(name)] = struct{}{}
+}
+
+func (refs *privateMemberRefs) property(name string) {
+	if name == "" || strings.ContainsAny(name, "${") {
+		refs.dynamicProperties = true
+		return
+	}
+	refs.properties[name] = struct{}{}
+}
+
+// collectFunctionCall handles functions that reach members by name, such as
+// constant('self::NAME') and get_object_vars($this).
+func (refs *privateMemberRefs) collectFunctionCall(name string, args []ast.Node) {
+	switch name {
+	case "constant":
+		if len(args) == 0 {
+			return
+		}
+		value, ok := stringLiteralValue(argumentValue(args[0]))
+		if !ok {
+			refs.dynamicConstants = true
+			return
+		}
+		if idx := strings.LastIndex(value, "::"); idx >= 0 {
+			refs.constants[value[idx+2:]] = struct{}{}
+		}
+	case "get_object_vars", "get_class_vars":
+		refs.dynamicProperties = true
+	case "get_class_methods":
+		refs.dynamicMethods = true
+	}
+}
+
+// collectCallableArray handles [$this, 'method'] and [self::class, 'method'].
+func (refs *privateMemberRefs) collectCallableArray(array *ast.ArrayNode) {
+	if len(array.Elements) != 2 {
+		return
+	}
+	var target, method ast.Node
+	for i, element := range array.Elements {
+		value := element
+		switch e := element.(type) {
+		case *ast.ArrayItemNode:
+			if e.Key != nil || e.Unpack {
+				return
+			}
+			value = e.Value
+		case *ast.KeyValueNode:
+			return
+		}
+		if i == 0 {
+			target = value
+		} else {
+			method = value
+		}
+	}
+	switch t := target.(type) {
+	case *ast.VariableNode:
+		if !isThisVariable(t) {
+			return
+		}
+	case *ast.ClassConstFetchNode:
+		if !strings.EqualFold(t.Const, "class") {
+			return
+		}
+	default:
+		return
+	}
+	if name, ok := stringLiteralValue(method); ok {
+		refs.method(name)
+		return
+	}
+	refs.dynamicMethods = true
+}
+
+func isThisVariable(node ast.Node) bool {
+	variable, ok := node.(*ast.VariableNode)
+	return ok && variable.Name == "this"
+}
+
+func init() {
+	RegisterAnalysisRuleWithLevel(unusedPrivateMemberCode, 4, "phpstan.deadCode", func(filename string, nodes []ast.Node, _ *AnalysisContext) []AnalysisIssue {
+		rule := &UnusedPrivateMemberRule{}
+		return rule.CheckIssues(nodes, filename)
+	})
+}
@@ -0,0 +1,153 @@
+package analyse
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ayanozturk/go-php-parser/lexer"
+	"github.com/ayanozturk/go-php-parser/overrides"
+	"github.com/ayanozturk/go-php-parser/parser"
+)
+
+func unusedPrivateMemberMessages(t *testing.T, code string) []string {
+	t.Helper()
+	p := parser.New(lexer.New(code), false)
+	nodes := p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("parser errors: %v", p.Errors())
+	}
+	var messages []string
+	for _, issue := range (&UnusedPrivateMemberRule{}).CheckIssues(nodes, "test.php") {
+		messages = append(messages, issue.Message)
+	}
+	return messages
+}
+
+func TestUnusedPrivateMembersAreReported(t *testing.T) {
+	php := `<?php
+namespace App;
+
+class Service {
+    private const USED = 1;
+    private const UNUSED = 2;
+    private static $cache;
+    private $unusedProperty;
+    private $name;
+
+    public function __construct(private int $id, private string $label) {
+    }
+
+    public function run(): int {
+        static::$cache = $this->name . $this->label;
+        $this->helper();
+        return self::USED;
+    }
+
+    private function helper(): void {
+    }
+
+    private function unusedHelper(): void {
+    }
+
+    private function __clone() {
+    }
+}`
+	want := []string{
+		`Constant App\Service::UNUSED is unused.`,
+		`Property App\Service::$unusedProperty is unused.`,
+		`Property App\Service::$id is unused.`,
+		`Method App\Service::unusedHelper() is unused.`,
+	}
+	if got := unusedPrivateMemberMessages(t, php); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+}
+
+func TestUnusedPrivateMethodsCountCallableReferences(t *testing.T) {
+	php := `<?php
+class Handlers {
+    public function register(): array {
+        return [
+            [$this, 'onCreate'],
+            [self::class, 'onUpdate'],
+            $this->onDelete(...),
+            static::onArchive(),
+            "{$this->onRestore()}",
+        ];
+    }
+
+    private function onCreate() {}
+    private function onUpdate() {}
+    private function onDelete() {}
+    private static function onArchive() {}
+    private function onRestore() {}
+}`
+	if got := unusedPrivateMemberMessages(t, php); len(got) != 0 {
+		t.Fatalf("expected no issues, got %#v", got)
+	}
+}
+
+func TestUnusedPrivateMembersDynamicAccessSuppressesReports(t *testing.T) {
+	php := `<?php
+class Dynamic {
+    private $a;
+    private const B = 1;
+
+    public function get(string $name) {
+        return [$this->$name, constant('self::' . $name), $this->$name()];
+    }
+
+    private function c() {}
+}`
+	if got := unusedPrivateMemberMessages(t, php); len(got) != 0 {
+		t.Fatalf("expected no issues, got %#v", got)
+	}
+}
+
+func TestUnusedPrivateMembersCountTraitReferences(t *testing.T) {
+	php := `<?php
+trait UsesHelper {
+    public function run() {
+        return $this->helper();
+    }
+}
+
+class WithTrait {
+    use UsesHelper;
+
+    private function helper() {}
+    private function unused() {}
+}
+
+class WithForeignTrait {
+    use \Vendor\Unknown;
+
+    private function unused() {}
+}`
+	want := []string{`Method WithTrait::unused() is unused.`}
+	if got := unusedPrivateMemberMessages(t, php); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+}
+
+func TestUnusedPrivateMembersCanBeSuppressedByOverrides(t *testing.T) {
+	p := parser.New(lexer.New(`<?php
+namespace App\Legacy;
+
+class Old {
+    private function unused() {}
+}`), false)
+	issues := (&UnusedPrivateMemberRule{}).CheckIssues(p.Parse(), "test.php")
+	matcher, err := overrides.Compile(overrides.RuleOverrides{
+		unusedPrivateMemberCode: {Classes: []string{`/^App\\Legacy\\/`}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue before filtering, got %#v", issues)
+	}
+	if filtered := FilterIssues(issues, matcher); len(filtered) != 0 {
+		t.Fatalf("expected the override to suppress the issue, got %#v", filtered)
+	}
+}
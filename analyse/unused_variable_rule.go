@@ -0,0 +1,709 @@
+package analyse
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ayanozturk/go-php-parser/analyse/cfg"
+	"github.com/ayanozturk/go-php-parser/ast"
+)
+
+const (
+	unusedVariableCode  = "PHPStan.DeadCode.UnusedVariable"
+	unusedParameterCode = "PHPStan.DeadCode.UnusedParameter"
+)
+
+// UnusedVariableRule reports values stored in local variables that are never
+// read, and method parameters that are never used.
+//
+// Each function and closure body is analysed on its own: a forward
+// reaching-definitions pass over its control-flow graph marks every write
+// that some later read may observe. Writes no read observes are reported,
+// including assignments overwritten before being read, foreach keys and
+// caught exceptions. Parameters are only reported for methods that neither
+// override a parent method nor implement an interface, since their signature
+// is otherwise not theirs to change.
+//
+// Bodies using extract(), compact() with computed names, get_defined_vars(),
+// func_get_args() or include are skipped, and variables shared by reference
+// (global, static, &$x, use (&$x)) are never reported.
+type UnusedVariableRule struct{}
+
+// localWriteKind says where a tracked value came from.
+type localWriteKind uint8
+
+const (
+	assignedWrite localWriteKind = iota
+	foreachKeyWrite
+	caughtWrite
+	parameterWrite
+)
+
+// localWrite is one value stored in a variable.
+type localWrite struct {
+	name string
+	kind localWriteKind
+	pos  ast.Position
+	read bool
+}
+
+// reachingWrites maps each variable to the writes whose value may still be
+// read at a program point.
+type reachingWrites map[string]map[*localWrite]struct{}
+
+func (s reachingWrites) clone() reachingWrites {
+	out := make(reachingWrites, len(s))
+	for name, writes := range s {
+		copied := make(map[*localWrite]struct{}, len(writes))
+		for write := range writes {
+			copied[write] = struct{}{}
+		}
+		out[name] = copied
+	}
+	return out
+}
+
+// mergeReachingWrites joins two states at a control-flow merge point. A nil
+// state stands for a path that has not been reached.
+func mergeReachingWrites(a, b reachingWrites) reachingWrites {
+	if a == nil {
+		if b == nil {
+			return nil
+		}
+		return b.clone()
+	}
+	out := a.clone()
+	for name, writes := range b {
+		if out[name] == nil {
+			out[name] = map[*localWrite]struct{}{}
+		}
+		for write := range writes {
+			out[name][write] = struct{}{}
+		}
+	}
+	return out
+}
+
+func (s reachingWrites) equal(other reachingWrites) bool {
+	if s == nil || other == nil {
+		return s == nil && other == nil
+	}
+	if len(s) != len(other) {
+		return false
+	}
+	for name, writes := range s {
+		if len(other[name]) != len(writes) {
+			return false
+		}
+		for write := range writes {
+			if _, ok := other[name][write]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// unusedWrites runs the reaching-definitions pass over one body.
+type unusedWrites struct {
+	// calls resolves by-reference parameters the way the definite-assignment
+	// analysis does, so out-parameters are not mistaken for reads.
+	calls  *definiteAssignment
+	writes map[ast.Node]*localWrite
+	order  []*localWrite
+	// reads holds every variable name read anywhere in the body.
+	reads map[string]bool
+	// escaped holds variables shared by reference; their writes are visible
+	// outside the body.
+	escaped map[string]bool
+	// thrown collects the writes made in the current block without killing
+	// earlier ones, for the exception edges leaving it mid-way.
+	thrown reachingWrites
+}
+
+var interpolatedVariable = regexp.MustCompile(`\$\{?([A-Za-z_][A-Za-z0-9_]*)`)
+
+func (r *UnusedVariableRule) CheckIssues(nodes []ast.Node, filename string) []AnalysisIssue {
+	return r.CheckIssuesWithContext(nodes, filename, nil)
+}
+
+// CheckIssuesWithContext is CheckIssues with symbol information, which is
+// needed to tell overriding methods and by-reference arguments apart.
+func (r *UnusedVariableRule) CheckIssuesWithContext(nodes []ast.Node, filename string, ctx *AnalysisContext) []AnalysisIssue {
+	var issues []AnalysisIssue
+	owners := map[*ast.FunctionNode]methodOwner{}
+	collectMethodOwners(nodes, collectFileTypeContext(nodes), owners)
+
+	walkAll(nodes, func(node ast.Node, class *ast.ClassNode, _ *ast.FunctionNode, ft fileTypeContext) {
+		fn, ok := node.(*ast.FunctionNode)
+		if !ok || hasDynamicVariables(fn.Body) {
+			return
+		}
+		owner, isMethod := owners[fn]
+		if fn.Body == nil && (!isMethod || hasModifier(fn.Modifiers, "abstract")) {
+			// Abstract and interface methods have no body to use anything in.
+			return
+		}
+		reportParams := isMethod && owner.reportsParameters(fn, ctx)
+		graph := analysisControlFlow(ctx, class, fn, ft)
+		uw := &unusedWrites{
+			writes:  map[ast.Node]*localWrite{},
+			reads:   map[string]bool{},
+			escaped: map[string]bool{},
+		}
+		uw.calls = &definiteAssignment{ctx: ctx, typeCtx: ft, class: class, fn: fn, split: map[ast.Node]bool{}}
+		final := uw.run(graph, uw.entry(fn, reportParams))
+
+		subject := ""
+		if class != nil {
+			subject = currentClassName(class, ft)
+		}
+		for _, write := range uw.order {
+			if write.read || uw.escaped[write.name] || strings.HasPrefix(write.name, "_") {
+				continue
+			}
+			code, message := unusedVariableCode, ""
+			switch write.kind {
+			case parameterWrite:
+				code = unusedParameterCode
+				message = fmt.Sprintf("Parameter $%s of method %s::%s() is unused.", write.name, owner.name, fn.Name)
+			case foreachKeyWrite:
+				message = fmt.Sprintf("Foreach key $%s is unused.", write.name)
+			case caughtWrite:
+				message = fmt.Sprintf("Caught exception $%s is unused.", write.name)
+				if supportsNonCapturingCatch(ctx) {
+					message = fmt.Sprintf("Caught exception $%s is unused, use a non-capturing catch instead.", write.name)
+				}
+			default:
+				switch {
+				case !uw.reads[write.name]:
+					message = fmt.Sprintf("Variable $%s is assigned but never read.", write.name)
+				case final.reaches(write):
+					message = fmt.Sprintf("Value assigned to $%s is never read.", write.name)
+				default:
+					message = fmt.Sprintf("Value assigned to $%s is overwritten before it is read.", write.name)
+				}
+			}
+			issue := AnalysisIssue{
+				Filename: filename,
+				Line:     write.pos.Line,
+				Column:   write.pos.Column,
+				Code:     code,
+				Message:  message,
+			}
+			if subject != "" {
+				issue.SubjectKind = "class"
+				issue.SubjectName = subject
+			}
+			issues = append(issues, issue)
+		}
+	})
+	return issues
+}
+
+func (s reachingWrites) reaches(write *localWrite) bool {
+	_, ok := s[write.name][write]
+	return ok
+}
+
+// supportsNonCapturingCatch reports whether catch (Foo) without a variable
+// can be suggested, which needs PHP 8.0.
+func supportsNonCapturingCatch(ctx *AnalysisContext) bool {
+	if ctx == nil || ctx.PHPVersion == "" {
+		return true
+	}
+	return !strings.HasPrefix(ctx.PHPVersion, "5.") && !strings.HasPrefix(ctx.PHPVersion, "7.")
+}
+
+// methodOwner is the class-like declaring a method, with the resolved names
+// of the classes and interfaces it inherits from.
+type methodOwner struct {
+	name    string
+	parents []string
+	final   bool
+}
+
+// collectMethodOwners records the methods of the classes and enums in nodes.
+// Trait methods are left out: the class using the trait decides whether
+// they implement an interface.
+func collectMethodOwners(nodes []ast.Node, ft fileTypeContext, owners map[*ast.FunctionNode]methodOwner) {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *ast.NamespaceNode:
+			collectMethodOwners(n.Body, namespaceTypeContext(n), owners)
+		case *ast.ClassNode:
+			owner := methodOwner{name: ft.resolveClassLike(n.Name), final: hasClassModifier(n, "final")}
+			if n.Extends != "" {
+				owner.parents = append(owner.parents, ft.resolveClassLike(n.Extends))
+			}
+			for _, iface := range n.Implements {
+				owner.parents = append(owner.parents, ft.resolveClassLike(iface))
+			}
+			for _, member := range n.Methods {
+				if fn, ok := member.(*ast.FunctionNode); ok {
+					owners[fn] = owner
+				}
+			}
+		case *ast.EnumNode:
+			owner := methodOwner{name: ft.resolveClassLike(n.Name), final: true}
+			for _, iface := range n.Implements {
+				owner.parents = append(owner.parents, ft.resolveClassLike(iface))
+			}
+			for _, member := range n.Methods {
+				if fn, ok := member.(*ast.FunctionNode); ok {
+					owners[fn] = owner
+				}
+			}
+		}
+	}
+}
+
+// reportsParameters reports whether the parameters of fn are the method's
+// own to remove: it is not a magic method or an empty hook meant to be
+// overridden, carries no #[Override] and no parent or interface declares it.
+// Unknown parents count as declaring it.
+func (owner methodOwner) reportsParameters(fn *ast.FunctionNode, ctx *AnalysisContext) bool {
+	if strings.HasPrefix(fn.Name, "__") && !strings.EqualFold(fn.Name, "__construct") {
+		return false
+	}
+	if len(fn.Body) == 0 && !owner.final && !isPrivateMethod(fn) && !hasModifier(fn.Modifiers, "final") {
+		return false
+	}
+	for _, attribute := range fn.Attributes {
+		if attribute != nil && strings.EqualFold(strings.TrimPrefix(attribute.Name, `\`), "Override") {
+			return false
+		}
+	}
+	for _, parent := range owner.parents {
+		if ctx == nil || ctx.Resolver == nil {
+			return false
+		}
+		if _, ok := ctx.Resolver.ResolveClass(parent); !ok {
+			return false
+		}
+		if method, ok := ctx.Resolver.ResolveMethod(parent, fn.Name); ok && !method.Magic {
+			return false
+		}
+	}
+	return true
+}
+
+// hasDynamicVariables reports whether a body may read or write variables by
+// computed name.
+func hasDynamicVariables(body []ast.Node) bool {
+	found := false
+	var visit func(ast.Node)
+	visit = func(node ast.Node) {
+		if found || node == nil {
+			return
+		}
+		switch n := node.(type) {
+		case *ast.FunctionCallNode:
+			switch strings.ToLower(functionCallName(n)) {
+			case "extract", "get_defined_vars", "func_get_args", "func_get_arg", "eval", "parse_str":
+				found = true
+				return
+			case "compact":
+				for _, arg := range n.Args {
+					if _, ok := stringLiteralValue(argumentValue(arg)); !ok {
+						found = true
+						return
+					}
+				}
+			}
+		case *ast.UnaryExpr:
+			switch strings.ToLower(n.Operator) {
+			case "include", "include_once", "require", "require_once", "eval":
+				found = true
+				return
+			}
+		case *ast.VariableNode:
+			if strings.ContainsAny(n.Name, "${") {
+				found = true
+				return
+			}
+		case *ast.FunctionNode, *ast.ClassNode:
+			// Nested declarations have their own scope.
+			return
+		}
+		for _, child := range childNodes(node) {
+			visit(child)
+		}
+	}
+	for _, stmt := range body {
+		visit(stmt)
+	}
+	return found
+}
+
+// entry builds the state on entry to fn: its parameters and, for closures,
+// the variables imported with use. Only the parameters of reportParams
+// methods are tracked as writes that may go unused.
+func (uw *unusedWrites) entry(fn *ast.FunctionNode, reportParams bool) reachingWrites {
+	state := reachingWrites{}
+	uw.thrown = reachingWrites{}
+	for _, paramNode := range fn.Params {
+		param, ok := paramNode.(*ast.ParamNode)
+		if !ok {
+			continue
+		}
+		if param.IsByRef {
+			uw.escaped[param.Name] = true
+		}
+		if reportParams && !param.IsPromoted && !param.IsByRef {
+			uw.gen(param, param.Name, parameterWrite, param.Pos, state)
+		}
+	}
+	for _, use := range fn.Uses {
+		if use.ByRef {
+			uw.escaped[use.Name] = true
+		}
+	}
+	return state
+}
+
+func (uw *unusedWrites) run(graph *cfg.Graph, entry reachingWrites) reachingWrites {
+	if graph == nil {
+		return nil
+	}
+	for _, block := range graph.Blocks {
+		for _, node := range block.Nodes {
+			uw.calls.split[node] = true
+		}
+	}
+	in := make(map[*cfg.Block]reachingWrites, len(graph.Blocks))
+	in[graph.Entry] = entry
+	worklist := []*cfg.Block{graph.Entry}
+	queued := map[*cfg.Block]bool{graph.Entry: true}
+	for len(worklist) > 0 {
+		block := worklist[0]
+		worklist = worklist[1:]
+		queued[block] = false
+		uw.thrown = in[block].clone()
+		out := in[block].clone()
+		for _, node := range block.Nodes {
+			uw.statement(node, out)
+		}
+		thrown := uw.thrown
+		for _, edge := range block.Succs {
+			next := out
+			if edge.Kind == cfg.Exception {
+				next = thrown
+			}
+			merged := mergeReachingWrites(in[edge.To], next)
+			if in[edge.To] != nil && merged.equal(in[edge.To]) {
+				continue
+			}
+			in[edge.To] = merged
+			if !queued[edge.To] {
+				queued[edge.To] = true
+				worklist = append(worklist, edge.To)
+			}
+		}
+	}
+	return mergeReachingWrites(in[graph.Exit], in[graph.Throw])
+}
+
+// gen records a write of name, replacing the writes that reached it.
+func (uw *unusedWrites) gen(node ast.Node, name string, kind localWriteKind, pos ast.Position, state reachingWrites) {
+	if name == "this" || superglobalVariables[name] {
+		return
+	}
+	write := uw.writes[node]
+	if write == nil {
+		write = &localWrite{name: name, kind: kind, pos: pos}
+		uw.writes[node] = write
+		uw.order = append(uw.order, write)
+	}
+	state[name] = map[*localWrite]struct{}{write: {}}
+	if uw.thrown[name] == nil {
+		uw.thrown[name] = map[*localWrite]struct{}{}
+	}
+	uw.thrown[name][write] = struct{}{}
+}
+
+// kill forgets the writes reaching a rebinding whose own value is not
+// tracked, such as a foreach value.
+func (uw *unusedWrites) kill(name string, state reachingWrites) {
+	delete(state, name)
+}
+
+func (uw *unusedWrites) read(name string, state reachingWrites) {
+	uw.reads[name] = true
+	for write := range state[name] {
+		write.read = true
+	}
+}
+
+func (uw *unusedWrites) statement(node ast.Node, state reachingWrites) {
+	switch n := node.(type) {
+	case *ast.ExpressionStmt:
+		uw.expr(n.Expr, state)
+	case *ast.ReturnNode:
+		uw.expr(n.Expr, state)
+	case *ast.ThrowNode:
+		uw.expr(n.Expr, state)
+	case *ast.StaticVarDeclNode:
+		for _, entry := range n.Vars {
+			uw.expr(entry.Init, state)
+			uw.escaped[entry.Name] = true
+		}
+	case *ast.GlobalNode:
+		for _, v := range n.Vars {
+			uw.escaped[v.Name] = true
+		}
+	case *ast.ForeachNode:
+		// Marker at the start of the loop body.
+		if key, ok := n.KeyVar.(*ast.VariableNode); ok {
+			uw.gen(key, key.Name, foreachKeyWrite, key.Pos, state)
+		} else {
+			uw.assign(n.KeyVar, state)
+		}
+		if value, ok := n.ValueVar.(*ast.VariableNode); ok {
+			if n.ByRef {
+				uw.escaped[value.Name] = true
+			}
+			uw.kill(value.Name, state)
+		} else {
+			uw.assign(n.ValueVar, state)
+		}
+	case *ast.CatchNode:
+		if n.Variable != "" {
+			uw.gen(n, strings.TrimPrefix(n.Variable, "$"), caughtWrite, n.Pos, state)
+		}
+	case *ast.BlockNode:
+		// Marker for a for loop, whose control expressions are not parsed.
+		for _, v := range n.ControlVars {
+			uw.read(v.Name, state)
+		}
+	case *ast.FunctionNode, *ast.ClassNode, *ast.InterfaceNode, *ast.TraitNode, *ast.EnumNode:
+		// Declarations have their own scope.
+	default:
+		uw.expr(n, state)
+	}
+}
+
+func (uw *unusedWrites) expr(node ast.Node, state reachingWrites) {
+	switch n := node.(type) {
+	case nil:
+	case *ast.VariableNode:
+		uw.read(n.Name, state)
+	case *ast.IdentifierNode:
+		// $class::method() and similar keep the receiver in the name.
+		if strings.HasPrefix(n.Value, "$") {
+			uw.read(variableNamePrefix(n.Value[1:]), state)
+		}
+	case *ast.ClassConstFetchNode:
+		if strings.HasPrefix(n.Class, "$") {
+			uw.read(variableNamePrefix(n.Class[1:]), state)
+		}
+	case *ast.StringNode:
+		for _, match := range interpolatedVariable.FindAllStringSubmatch(n.Value, -1) {
+			uw.read(match[1], state)
+		}
+	case *ast.AssignmentNode:
+		if ref, ok := n.Right.(*ast.UnaryExpr); ok && ref.Operator == "&" {
+			// $a = &$b makes both names aliases of one value.
+			uw.escaped[baseVariableName(n.Left)] = true
+			uw.escaped[baseVariableName(ref.Operand)] = true
+			uw.expr(ref.Operand, state)
+			uw.expr(n.Left, state)
+			return
+		}
+		if n.Operator != "=" {
+			uw.expr(n.Left, state)
+		}
+		uw.expr(n.Right, state)
+		uw.assign(n.Left, state)
+	case *ast.FunctionCallNode:
+		uw.call(n, state)
+	case *ast.MethodCallNode:
+		uw.expr(n.Object, state)
+		// $obj->$name() keeps the variable in the method name.
+		if strings.HasPrefix(n.Method, "$") {
+			uw.read(variableNamePrefix(n.Method[1:]), state)
+		}
+		uw.args(n.Args, uw.calls.methodParams(n), state)
+	case *ast.PropertyFetchNode:
+		uw.expr(n.Object, state)
+		if strings.HasPrefix(n.Property, "$") {
+			uw.read(variableNamePrefix(n.Property[1:]), state)
+		}
+	case *ast.NewNode:
+		if strings.HasPrefix(n.ClassName, "$") {
+			uw.read(variableNamePrefix(n.ClassName[1:]), state)
+		}
+		uw.expr(n.ClassExpr, state)
+		uw.args(n.Args, nil, state)
+	case *ast.BinaryExpr:
+		switch strings.ToLower(n.Operator) {
+		case "??", "&&", "and", "||", "or":
+			uw.expr(n.Left, state)
+			rhs := state.clone()
+			uw.expr(n.Right, rhs)
+			uw.replace(state, mergeReachingWrites(state, rhs))
+		default:
+			uw.expr(n.Left, state)
+			uw.expr(n.Right, state)
+		}
+	case *ast.TernaryExpr:
+		uw.expr(n.Condition, state)
+		whenTrue := state.clone()
+		uw.expr(n.IfTrue, whenTrue)
+		whenFalse := state.clone()
+		uw.expr(n.IfFalse, whenFalse)
+		uw.replace(state, mergeReachingWrites(whenTrue, whenFalse))
+	case *ast.MatchNode:
+		if uw.calls.split[n.Condition] {
+			// The graph already models this match arm by arm.
+			return
+		}
+		uw.expr(n.Condition, state)
+		var merged reachingWrites
+		for _, arm := range n.Arms {
+			armState := state.clone()
+			for _, condition := range arm.Conditions {
+				uw.expr(condition, armState)
+			}
+			uw.expr(arm.Body, armState)
+			merged = mergeReachingWrites(merged, armState)
+		}
+		if merged != nil {
+			uw.replace(state, merged)
+		}
+	case *ast.ArrayItemNode:
+		if n.ByRef {
+			uw.escaped[baseVariableName(n.Value)] = true
+		}
+		uw.expr(n.Key, state)
+		uw.expr(n.Value, state)
+	case *ast.FunctionNode:
+		// A closure reads the variables it imports when it is created.
+		for _, use := range n.Uses {
+			uw.read(use.Name, state)
+		}
+	case *ast.ArrowFunctionNode:
+		// Arrow functions capture every outer variable they mention.
+		uw.captured(n.Expr, state)
+	case *ast.ClassNode, *ast.InterfaceNode, *ast.TraitNode, *ast.EnumNode:
+	default:
+		for _, child := range childNodes(node) {
+			uw.expr(child, state)
+		}
+	}
+}
+
+// replace overwrites state in place with merged.
+func (uw *unusedWrites) replace(state, merged reachingWrites) {
+	for name := range state {
+		delete(state, name)
+	}
+	for name, writes := range merged {
+		state[name] = writes
+	}
+}
+
+// captured reads every variable mentioned in an arrow function body.
+func (uw *unusedWrites) captured(node ast.Node, state reachingWrites) {
+	switch n := node.(type) {
+	case nil:
+		return
+	case *ast.VariableNode:
+		uw.read(n.Name, state)
+	case *ast.StringNode:
+		uw.expr(n, state)
+	case *ast.FunctionNode:
+		uw.expr(n, state)
+		return
+	}
+	for _, child := range childNodes(node) {
+		uw.captured(child, state)
+	}
+}
+
+func (uw *unusedWrites) call(n *ast.FunctionCallNode, state reachingWrites) {
+	name := functionCallName(n)
+	switch strings.ToLower(name) {
+	case "compact":
+		for _, arg := range n.Args {
+			if variableName, ok := stringLiteralValue(argumentValue(arg)); ok {
+				uw.read(variableName, state)
+			}
+		}
+		return
+	case "unset":
+		// Unsetting a value counts as its last use.
+		uw.args(n.Args, nil, state)
+		return
+	}
+	// Foo::$name() calls the method named by $name, unlike the static
+	// property Foo::$name.
+	if method, ok := n.Name.(*ast.ClassConstFetchNode); ok && strings.HasPrefix(method.Const, "$") {
+		uw.read(variableNamePrefix(method.Const[1:]), state)
+	}
+	uw.expr(n.Name, state)
+	uw.args(n.Args, uw.calls.functionParams(name), state)
+}
+
+// args evaluates call arguments. A variable bound to a by-reference
+// parameter is both read and written by the callee; the written value is
+// not tracked, since out-parameters are often ignored on purpose.
+func (uw *unusedWrites) args(args []ast.Node, params []ResolvedParam, state reachingWrites) {
+	for i, arg := range args {
+		if param, ok := parameterForArgument(params, i, arg); ok && param.ByRef {
+			value := argumentValue(arg)
+			uw.expr(value, state)
+			if v, ok := value.(*ast.VariableNode); ok {
+				uw.kill(v.Name, state)
+			}
+			continue
+		}
+		uw.expr(arg, state)
+	}
+}
+
+// assign records the variables written by an assignment target. Writes into
+// an array element or a property read the container instead.
+func (uw *unusedWrites) assign(target ast.Node, state reachingWrites) {
+	switch n := target.(type) {
+	case *ast.VariableNode:
+		uw.gen(n, n.Name, assignedWrite, n.Pos, state)
+	case *ast.ArrayNode:
+		// list() and [...] destructuring.
+		for _, element := range n.Elements {
+			uw.assign(element, state)
+		}
+	case *ast.ArrayItemNode:
+		if n.ByRef {
+			uw.escaped[baseVariableName(n.Value)] = true
+		}
+		uw.expr(n.Key, state)
+		uw.assign(n.Value, state)
+	default:
+		uw.expr(target, state)
+	}
+}
+
+// variableNamePrefix returns the variable name at the start of s, as in
+// "class::method" for $class::method().
+func variableNamePrefix(s string) string {
+	for i, r := range s {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r >= 0x80) {
+			return s[:i]
+		}
+	}
+	return s
+}
+
+func init() {
+	RegisterAnalysisRuleWithMeta(AnalysisRuleMeta{
+		Code:           unusedVariableCode,
+		Reports:        []string{unusedParameterCode},
+		Level:          4,
+		Category:       "phpstan.deadCode",
+		DefaultEnabled: true,
+	}, func(filename string, nodes []ast.Node, ctx *AnalysisContext) []AnalysisIssue {
+		return (&UnusedVariableRule{}).CheckIssuesWithContext(nodes, filename, ctx)
+	})
+}
@@ -0,0 +1,202 @@
+package analyse
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+	"github.com/ayanozturk/go-php-parser/lexer"
+	"github.com/ayanozturk/go-php-parser/parser"
+)
+
+func unusedVariableMessages(t *testing.T, code string) []string {
+	t.Helper()
+	p := parser.New(lexer.New(code), false)
+	nodes := p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("parser errors: %v", p.Errors())
+	}
+	project := BuildProjectIndex(map[string][]ast.Node{"test.php": nodes})
+	ctx := &AnalysisContext{Resolver: project, Project: project}
+	var messages []string
+	for _, issue := range (&UnusedVariableRule{}).CheckIssuesWithContext(nodes, "test.php", ctx) {
+		messages = append(messages, issue.Message)
+	}
+	return messages
+}
+
+func TestUnusedVariablesAreReported(t *testing.T) {
+	php := `<?php
+function run(array $items) {
+    $unused = 1;
+    $total = 0;
+    $total = count($items);
+    foreach ($items as $key => $item) {
+        echo $item;
+    }
+    try {
+        $result = $total;
+        echo $result;
+    } catch (\Exception $e) {
+        return null;
+    }
+    $result = 2;
+    return $total;
+}`
+	want := []string{
+		"Variable $unused is assigned but never read.",
+		"Value assigned to $total is overwritten before it is read.",
+		"Foreach key $key is unused.",
+		"Caught exception $e is unused, use a non-capturing catch instead.",
+		"Value assigned to $result is never read.",
+	}
+	if got := unusedVariableMessages(t, php); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+}
+
+func TestUnusedVariablesFollowControlFlow(t *testing.T) {
+	php := `<?php
+function loop(array $rows) {
+    $last = null;
+    $count = 0;
+    while ($count < 10) {
+        if ($last) {
+            echo $last;
+        }
+        $last = $rows[$count] ?? null;
+        $count += 1;
+    }
+
+    $value = 1;
+    try {
+        $value = compute();
+    } catch (\Throwable $error) {
+        log($error);
+    }
+    echo $value;
+
+    $label = 'a';
+    $format = fn() => $label;
+    $prefix = 'p';
+    $closure = function () use ($prefix, &$collected) {
+        $collected = $prefix;
+    };
+    $name = 'n';
+    $message = "Hello {$name}";
+    preg_match('/x/', $message, $matches);
+    return [$format, $closure];
+}`
+	if got := unusedVariableMessages(t, php); len(got) != 0 {
+		t.Fatalf("expected no issues, got %#v", got)
+	}
+}
+
+func TestUnusedVariablesCountDynamicMemberNames(t *testing.T) {
+	php := `<?php
+class Runner {
+    public function run($target) {
+        $name = 'run';
+        $this->$name();
+        $property = 'id';
+        echo $target->$property;
+        $method = 'create';
+        Runner::$method();
+        $static = 'unused';
+        echo Runner::$static;
+    }
+}`
+	want := []string{"Variable $static is assigned but never read."}
+	if got := unusedVariableMessages(t, php); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+}
+
+func TestUnusedVariablesSkipDynamicScopes(t *testing.T) {
+	php := `<?php
+function render(array $data) {
+    $title = 'x';
+    extract($data);
+    include 'template.php';
+}
+
+function shared() {
+    global $config;
+    static $calls = 0;
+    $config = 1;
+    $calls = $calls + 1;
+    $alias = &$config;
+    $alias = 2;
+}`
+	if got := unusedVariableMessages(t, php); len(got) != 0 {
+		t.Fatalf("expected no issues, got %#v", got)
+	}
+}
+
+func TestUnusedParametersOnlyInOwnMethods(t *testing.T) {
+	php := `<?php
+namespace App;
+
+interface Handler {
+    public function handle($event, $context);
+}
+
+abstract class Base {
+    public function boot($kernel) {
+    }
+}
+
+final class Listener extends Base implements Handler {
+    public function __construct(private int $id, $unusedDependency) {
+    }
+
+    public function handle($event, $context) {
+        return $this->id;
+    }
+
+    public function boot($kernel) {
+    }
+
+    public function own($used, $unused, &$out) {
+        return array_map(function ($item, $index) {
+            return $item;
+        }, $used);
+    }
+
+    public function __call($name, $arguments) {
+    }
+}`
+	want := []string{
+		`Parameter $unusedDependency of method App\Listener::__construct() is unused.`,
+		`Parameter $unused of method App\Listener::own() is unused.`,
+	}
+	if got := unusedVariableMessages(t, php); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+}
+
+func TestUnusedVariableCodesAreReportedOnceAndDisabledSeparately(t *testing.T) {
+	php := `<?php
+final class Job {
+    public function run($unusedParameter) {
+        $unused = 1;
+    }
+}`
+	nodes := parsePHPForLevel0(t, php)
+	project := BuildProjectIndex(map[string][]ast.Node{"test.php": nodes})
+	level := 4
+	issues := RunAnalysisRulesWithContext("test.php", nodes, &AnalysisContext{Resolver: project, Project: project, AnalysisLevel: &level})
+	if countIssueContaining(issues, unusedVariableCode, "") != 1 || countIssueContaining(issues, unusedParameterCode, "") != 1 {
+		t.Fatalf("expected one issue per code, got %#v", issues)
+	}
+
+	issues = RunAnalysisRulesWithContext("test.php", nodes, &AnalysisContext{
+		Resolver:           project,
+		Project:            project,
+		AnalysisLevel:      &level,
+		DisabledIssueCodes: map[string]bool{unusedParameterCode: true},
+	})
+	if countIssueContaining(issues, unusedVariableCode, "") != 1 || countIssueContaining(issues, unusedParameterCode, "") != 0 {
+		t.Fatalf("expected only the unused variable, got %#v", issues)
+	}
+}
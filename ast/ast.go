@@ -25,7 +25,16 @@ type Node interface {
 // BlockNode represents a block of statements (e.g., {...})
 type BlockNode struct {
 	Statements []Node
-	Pos        Position
+	// Loop is set when the block is the body of a for loop, whose control
+	// expressions are not modelled. break/continue inside it target the loop.
+	Loop bool
+	// ControlVars lists the variables named in a for loop's control
+	// expressions, in order of first appearance.
+	ControlVars []*VariableNode
+	// NoCondition is set when a for loop has no condition, as in for (;;),
+	// so it only ends through break, return or throw.
+	NoCondition bool
+	Pos         Position
 }
 
 func (b *BlockNode) NodeType() string    { return "Block" }
@@ -619,6 +628,7 @@ func (t *TernaryExpr) TokenLiteral() string { return "?" }
 type PropertyFetchNode struct {
 	Object   Node   // The object being accessed, e.g., VariableNode for $this
 	Property string // The property name being accessed, e.g., "name"
+	NullSafe bool   // true for $object?->property
 	Pos      Position
 }
 
@@ -46,6 +46,7 @@ type PropertyNode struct {
 	IsStatic      bool
 	IsReadonly    bool
 	Hooks         []PropertyHookNode
+	PHPDoc        *PHPDocNode
 	Pos           Position
 }
 
@@ -87,8 +88,20 @@ func (n *PropertyNode) TokenLiteral() string {
 }
 
 type TraitUseNode struct {
-	Traits []string
-	Pos    Position
+	Traits      []string
+	Adaptations []TraitAdaptation // Rules of a trailing { ... } block
+	Pos         Position
+}
+
+// TraitAdaptation is one rule of a trait use block, either
+// "Trait::method insteadof Other" or "[Trait::]method as [visibility] [alias]".
+type TraitAdaptation struct {
+	Trait      string // Empty when the method is not qualified
+	Method     string
+	InsteadOf  []string // Traits excluded by an insteadof rule
+	Alias      string
+	Visibility string
+	Pos        Position
 }
 
 func (t *TraitUseNode) NodeType() string    { return "TraitUse" }
@@ -123,10 +136,11 @@ func (n *NewNode) TokenLiteral() string {
 
 // MethodCallNode represents a method call on an object
 type MethodCallNode struct {
-	Object Node
-	Method string
-	Args   []Node
-	Pos    Position
+	Object   Node
+	Method   string
+	Args     []Node
+	NullSafe bool // true for $object?->method()
+	Pos      Position
 }
 
 func (m *MethodCallNode) NodeType() string    { return "MethodCall" }
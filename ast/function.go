@@ -12,8 +12,10 @@ type FunctionNode struct {
 	Modifiers  []string // All modifiers, e.g. public, static, final, abstract
 	ReturnType string
 	Params     []Node
+	Uses       []ClosureUse // Variables a closure imports with use (...)
 	Body       []Node
 	PHPDoc     *PHPDocNode // Associated PHPDoc comment
+	Attributes []*AttributeNode
 	Pos        Position
 }
 
@@ -38,6 +40,13 @@ func (f *FunctionNode) TokenLiteral() string {
 	return "function"
 }
 
+// ClosureUse is one variable imported by a closure's use (...) list.
+type ClosureUse struct {
+	Name  string
+	ByRef bool
+	Pos   Position
+}
+
 // FunctionCallNode represents a function call expression
 // (e.g., sprintf($format ?? ”, ...$values))
 type FunctionCallNode struct {
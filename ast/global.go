@@ -0,0 +1,21 @@
+package ast
+
+import "strings"
+
+// GlobalNode represents a 'global $a, $b;' statement inside a function
+type GlobalNode struct {
+	Vars []*VariableNode
+	Pos  Position
+}
+
+func (g *GlobalNode) NodeType() string    { return "Global" }
+func (g *GlobalNode) GetPos() Position    { return g.Pos }
+func (g *GlobalNode) SetPos(pos Position) { g.Pos = pos }
+func (g *GlobalNode) String() string {
+	names := make([]string, len(g.Vars))
+	for i, v := range g.Vars {
+		names[i] = "$" + v.Name
+	}
+	return "global " + strings.Join(names, ", ")
+}
+func (g *GlobalNode) TokenLiteral() string { return "global" }
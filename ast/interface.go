@@ -42,6 +42,7 @@ type InterfaceMethodNode struct {
 	ReturnType Node   // Changed from string to Node to support union types
 	Params     []Node
 	PHPDoc     *PHPDocNode // Associated PHPDoc comment
+	Attributes []*AttributeNode
 	Pos        Position
 }
 
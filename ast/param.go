@@ -16,6 +16,7 @@ type ParamNode struct {
 	IsReadonly   bool   // true if this promoted parameter is readonly
 	IsVariadic   bool   // true if this param is variadic (...$values)
 	IsByRef      bool   // true if this param is passed by reference (&$data)
+	Attributes   []*AttributeNode
 	Pos          Position
 }
 
@@ -14,6 +14,9 @@ type PHPDocNode struct {
 	Templates   []PHPDocTemplate
 	Extends     []PHPDocTypeReference
 	Implements  []PHPDocTypeReference
+	Properties  []PHPDocProperty
+	Methods     []PHPDocMethod
+	Mixins      []string
 	Description string
 	Pos         Position
 }
@@ -42,6 +45,32 @@ func (p *PHPDocNode) TokenLiteral() string {
 	return "/** ... */"
 }
 
+// PHPDocProperty describes a magic property declared with @property,
+// @property-read or @property-write.
+type PHPDocProperty struct {
+	Name      string
+	Type      string
+	ReadOnly  bool
+	WriteOnly bool
+}
+
+// PHPDocMethod describes a magic method declared with @method.
+type PHPDocMethod struct {
+	Name       string
+	ReturnType string
+	Static     bool
+	Params     []PHPDocMethodParam
+}
+
+// PHPDocMethodParam is a parameter of an @method signature.
+type PHPDocMethodParam struct {
+	Name       string
+	Type       string
+	HasDefault bool
+	IsVariadic bool
+	ByRef      bool
+}
+
 // PHPDocParam represents a parameter documented in PHPDoc
 type PHPDocParam struct {
 	Name        string
@@ -108,6 +137,21 @@ func ParsePHPDoc(rawContent string) *PHPDocNode {
 			if ref, ok := parsePHPDocTypeReference(value); ok {
 				phpdoc.Implements = append(phpdoc.Implements, ref)
 			}
+		} else if tag, value, ok := phpDocTag(line); ok && isPropertyTag(tag) {
+			inDescription = false
+			if property, ok := parsePHPDocProperty(tag, value); ok {
+				phpdoc.Properties = append(phpdoc.Properties, property)
+			}
+		} else if tag, value, ok := phpDocTag(line); ok && isMethodTag(tag) {
+			inDescription = false
+			if method, ok := parsePHPDocMethod(value); ok {
+				phpdoc.Methods = append(phpdoc.Methods, method)
+			}
+		} else if tag, value, ok := phpDocTag(line); ok && isMixinTag(tag) {
+			inDescription = false
+			if mixin, _ := splitPHPDocTypeAndRest(value); mixin != "" {
+				phpdoc.Mixins = append(phpdoc.Mixins, mixin)
+			}
 		} else if strings.HasPrefix(line, "@") {
 			// Any other @tag should stop description parsing
 			inDescription = false
@@ -132,7 +176,8 @@ func splitPHPDocTypeAndRest(value string) (string, string) {
 				depth--
 			}
 		case ' ', '\t':
-			if depth == 0 {
+			// A callable type continues past the space in callable(T): R.
+			if depth == 0 && !strings.HasSuffix(strings.TrimSpace(value[:idx]), ":") && !strings.HasPrefix(strings.TrimSpace(value[idx:]), ":") {
 				return strings.TrimSpace(value[:idx]), strings.TrimSpace(value[idx:])
 			}
 		}
@@ -175,6 +220,144 @@ func isImplementsTag(tag string) bool {
 	}
 }
 
+func isPropertyTag(tag string) bool {
+	switch strings.TrimPrefix(strings.TrimPrefix(tag, "phpstan-"), "psalm-") {
+	case "property", "property-read", "property-write":
+		return true
+	default:
+		return false
+	}
+}
+
+func isMethodTag(tag string) bool {
+	switch tag {
+	case "method", "phpstan-method", "psalm-method":
+		return true
+	default:
+		return false
+	}
+}
+
+func isMixinTag(tag string) bool {
+	switch tag {
+	case "mixin", "phpstan-mixin", "psalm-mixin":
+		return true
+	default:
+		return false
+	}
+}
+
+// parsePHPDocProperty parses "Type $name description"; the type may be
+// omitted.
+func parsePHPDocProperty(tag, value string) (PHPDocProperty, bool) {
+	property := PHPDocProperty{
+		ReadOnly:  strings.HasSuffix(tag, "-read"),
+		WriteOnly: strings.HasSuffix(tag, "-write"),
+	}
+	rest := strings.TrimSpace(value)
+	if !strings.HasPrefix(rest, "$") {
+		property.Type, rest = splitPHPDocTypeAndRest(rest)
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "$") {
+		return PHPDocProperty{}, false
+	}
+	property.Name = strings.TrimPrefix(fields[0], "$")
+	return property, property.Name != ""
+}
+
+// parsePHPDocMethod parses "[static] [ReturnType] name(params) description".
+// Like PHPStan, a lone "static" before the name is the return type rather
+// than a modifier.
+func parsePHPDocMethod(value string) (PHPDocMethod, bool) {
+	var method PHPDocMethod
+	rest := strings.TrimSpace(value)
+	if fields := strings.Fields(rest); len(fields) > 1 && fields[0] == "static" {
+		method.Static = true
+		rest = strings.TrimSpace(strings.TrimPrefix(rest, "static"))
+	}
+	if phpDocMethodNameEnd(rest) < 0 {
+		method.ReturnType, rest = splitPHPDocTypeAndRest(rest)
+		if phpDocMethodNameEnd(rest) < 0 {
+			return PHPDocMethod{}, false
+		}
+	} else if method.Static {
+		method.Static = false
+		method.ReturnType = "static"
+	}
+	open := phpDocMethodNameEnd(rest)
+	method.Name = strings.TrimSpace(rest[:open])
+	closing := matchingParen(rest, open)
+	if closing < 0 {
+		return PHPDocMethod{}, false
+	}
+	for _, raw := range splitPHPDocGenericArguments(rest[open+1 : closing]) {
+		if param, ok := parsePHPDocMethodParam(raw); ok {
+			method.Params = append(method.Params, param)
+		}
+	}
+	return method, method.Name != ""
+}
+
+// phpDocMethodNameEnd returns the index of the parenthesis that follows a
+// leading method name, or -1 when value does not start with one.
+func phpDocMethodNameEnd(value string) int {
+	for idx, r := range value {
+		switch {
+		case r == '(':
+			if idx == 0 {
+				return -1
+			}
+			return idx
+		case r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= 0x80 || idx > 0 && r >= '0' && r <= '9':
+		default:
+			return -1
+		}
+	}
+	return -1
+}
+
+func matchingParen(value string, open int) int {
+	depth := 0
+	for idx := open; idx < len(value); idx++ {
+		switch value[idx] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return idx
+			}
+		}
+	}
+	return -1
+}
+
+func parsePHPDocMethodParam(raw string) (PHPDocMethodParam, bool) {
+	raw = strings.TrimSpace(raw)
+	var param PHPDocMethodParam
+	if before, _, ok := strings.Cut(raw, "="); ok {
+		param.HasDefault = true
+		raw = strings.TrimSpace(before)
+	}
+	dollar := strings.LastIndex(raw, "$")
+	if dollar < 0 {
+		return PHPDocMethodParam{}, false
+	}
+	param.Name = strings.TrimSpace(raw[dollar+1:])
+	typeName := strings.TrimSpace(raw[:dollar])
+	if strings.HasSuffix(typeName, "...") {
+		param.IsVariadic = true
+		typeName = strings.TrimSpace(strings.TrimSuffix(typeName, "..."))
+	}
+	if strings.HasSuffix(typeName, "&") {
+		param.ByRef = true
+		typeName = strings.TrimSpace(strings.TrimSuffix(typeName, "&"))
+	}
+	param.Type = typeName
+	return param, param.Name != ""
+}
+
 func parsePHPDocTemplate(value string) (PHPDocTemplate, bool) {
 	parts := strings.Fields(value)
 	if len(parts) == 0 {
@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -157,6 +158,20 @@ func TestParsePHPDocPreservesWhitespaceInsideGenericTypes(t *testing.T) {
 	}
 }
 
+func TestParsePHPDocKeepsCallableReturnTypes(t *testing.T) {
+	doc := ParsePHPDoc(`/**
+ * @param callable(T): R $fn Mapper
+ * @return Closure(int) : string
+ */`)
+
+	if len(doc.Params) != 1 || doc.Params[0].Type != "callable(T): R" || doc.Params[0].Name != "fn" {
+		t.Fatalf("expected complete callable param type, got %#v", doc.Params)
+	}
+	if doc.ReturnType != "Closure(int) : string" {
+		t.Fatalf("expected complete callable return type, got %q", doc.ReturnType)
+	}
+}
+
 func TestGetParamTypeFromPHPDoc(t *testing.T) {
 	phpdoc := &PHPDocNode{
 		Params: []PHPDocParam{
@@ -202,3 +217,37 @@ func TestParsePHPDocGenerics(t *testing.T) {
 		t.Fatalf("unexpected implements references: %#v", doc.Implements)
 	}
 }
+
+func TestParsePHPDocMagicMembers(t *testing.T) {
+	doc := ParsePHPDoc(`/**
+ * @property int $id
+ * @property-read array<string, int> $counts The counters
+ * @property-write $raw
+ * @method string format(int $width, string ...$parts)
+ * @method static static create(array &$attributes = [])
+ * @method static build()
+ * @method touch()
+ * @mixin \App\Builder
+ */`)
+
+	wantProperties := []PHPDocProperty{
+		{Name: "id", Type: "int"},
+		{Name: "counts", Type: "array<string, int>", ReadOnly: true},
+		{Name: "raw", WriteOnly: true},
+	}
+	if !reflect.DeepEqual(doc.Properties, wantProperties) {
+		t.Fatalf("unexpected properties: %#v", doc.Properties)
+	}
+	wantMethods := []PHPDocMethod{
+		{Name: "format", ReturnType: "string", Params: []PHPDocMethodParam{{Name: "width", Type: "int"}, {Name: "parts", Type: "string", IsVariadic: true}}},
+		{Name: "create", ReturnType: "static", Static: true, Params: []PHPDocMethodParam{{Name: "attributes", Type: "array", HasDefault: true, ByRef: true}}},
+		{Name: "build", ReturnType: "static"},
+		{Name: "touch"},
+	}
+	if !reflect.DeepEqual(doc.Methods, wantMethods) {
+		t.Fatalf("unexpected methods: %#v", doc.Methods)
+	}
+	if !reflect.DeepEqual(doc.Mixins, []string{`\App\Builder`}) {
+		t.Fatalf("unexpected mixins: %#v", doc.Mixins)
+	}
+}
@@ -0,0 +1,198 @@
+// Package cache keeps analysis data between runs in a directory such as
+// .go-phpcs-cache. Entries are stored under a namespace derived from the tool
+// version and a fingerprint of the configuration, so upgrading the binary or
+// changing the configuration starts from an empty cache; namespaces left by
+// earlier versions or configurations are removed when a store is opened.
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+)
+
+// formatVersion changes whenever the layout or meaning of cached data changes
+// without a new tool version, for example in development builds.
+const formatVersion = "2"
+
+// Store reads and writes gob-encoded entries. It is safe for concurrent use.
+type Store struct {
+	dir string
+
+	mu      sync.Mutex
+	touched map[string]struct{}
+}
+
+// Open prepares the namespace for the running tool and the given
+// configuration fingerprint below root, removing other namespaces.
+func Open(root, fingerprint string) (*Store, error) {
+	namespace := Key([]byte(formatVersion), []byte(ToolVersion()), []byte(fingerprint))[:16]
+	dir := filepath.Join(root, namespace)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != namespace && isNamespace(entry.Name()) {
+			if err := os.RemoveAll(filepath.Join(root, entry.Name())); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &Store{dir: dir, touched: make(map[string]struct{})}, nil
+}
+
+// isNamespace reports whether a directory name could have been created by
+// Open, so unrelated directories below a misconfigured root are left alone.
+func isNamespace(name string) bool {
+	if len(name) != 16 {
+		return false
+	}
+	_, err := hex.DecodeString(name)
+	return err == nil
+}
+
+// Load decodes the entry of the given kind and key into v. A missing or
+// unreadable entry is a miss.
+func (s *Store) Load(kind, key string, v any) bool {
+	path := s.path(kind, key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(v); err != nil {
+		return false
+	}
+	s.touch(path)
+	return true
+}
+
+// Save encodes v as the entry of the given kind and key. The entry is
+// written to a temporary file and renamed, so concurrent runs never read a
+// partial entry.
+func (s *Store) Save(kind, key string, v any) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return err
+	}
+	path := s.path(kind, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	s.touch(path)
+	return nil
+}
+
+// Prune removes entries of the given kind that were neither loaded nor saved
+// by this store and have not been modified for maxAge. Entries are keyed by
+// content, so edited files leave stale entries behind.
+func (s *Store) Prune(kind string, maxAge time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cutoff := time.Now().Add(-maxAge)
+	return filepath.WalkDir(filepath.Join(s.dir, kind), func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if _, ok := s.touched[path]; ok {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.ModTime().After(cutoff) {
+			return nil
+		}
+		return os.Remove(path)
+	})
+}
+
+func (s *Store) touch(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.touched[path]; ok {
+		return
+	}
+	s.touched[path] = struct{}{}
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+}
+
+func (s *Store) path(kind, key string) string {
+	return filepath.Join(s.dir, kind, key[:2], key+".gob")
+}
+
+// Key returns the hex SHA-256 of the parts. Each part is length-prefixed, so
+// different splits of the same bytes give different keys.
+func Key(parts ...[]byte) string {
+	h := sha256.New()
+	var size [8]byte
+	for _, part := range parts {
+		binary.LittleEndian.PutUint64(size[:], uint64(len(part)))
+		h.Write(size[:])
+		h.Write(part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ToolVersion identifies the running binary: the module version and VCS
+// revision it was built from. Development builds from a modified tree, and
+// builds without VCS information, also include the size and modification
+// time of the executable, so a rebuild invalidates the cache.
+func ToolVersion() string {
+	var parts []string
+	revision, dirty := false, false
+	if info, ok := debug.ReadBuildInfo(); ok {
+		parts = append(parts, info.Main.Version, info.GoVersion)
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				parts = append(parts, setting.Value)
+				revision = true
+			case "vcs.modified":
+				dirty = setting.Value == "true"
+			}
+		}
+	}
+	if !revision || dirty {
+		if exe, err := os.Executable(); err == nil {
+			if info, err := os.Stat(exe); err == nil {
+				parts = append(parts, fmt.Sprintf("%d-%d", info.Size(), info.ModTime().UnixNano()))
+			}
+		}
+	}
+	return strings.Join(parts, " ")
+}
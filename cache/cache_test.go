@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type entry struct {
+	Name  string
+	Lines []int
+}
+
+func TestStoreRoundTripAndNamespaces(t *testing.T) {
+	root := t.TempDir()
+	unrelated := filepath.Join(root, "keep-me")
+	if err := os.Mkdir(unrelated, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := Open(root, "config-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := Key([]byte("<?php class A {}"))
+	var got entry
+	if store.Load("index", key, &got) {
+		t.Fatal("expected a miss in an empty cache")
+	}
+	if err := store.Save("index", key, entry{Name: "A", Lines: []int{1, 2}}); err != nil {
+		t.Fatal(err)
+	}
+	if !store.Load("index", key, &got) || got.Name != "A" || len(got.Lines) != 2 {
+		t.Fatalf("expected the saved entry, got %#v", got)
+	}
+
+	reopened, err := Open(root, "config-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reopened.Load("index", key, &got) {
+		t.Fatal("expected entries to survive reopening with the same fingerprint")
+	}
+
+	changed, err := Open(root, "config-b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed.Load("index", key, &got) {
+		t.Fatal("expected a changed fingerprint to invalidate the cache")
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected the old namespace to be removed and unrelated directories kept, got %v", entries)
+	}
+	if _, err := os.Stat(unrelated); err != nil {
+		t.Fatalf("expected unrelated directory to be kept: %v", err)
+	}
+}
+
+func TestStorePruneKeepsTouchedAndRecentEntries(t *testing.T) {
+	root := t.TempDir()
+	store, err := Open(root, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale, fresh := Key([]byte("stale")), Key([]byte("fresh"))
+	for _, key := range []string{stale, fresh} {
+		if err := store.Save("index", key, entry{Name: key}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	for _, key := range []string{stale, fresh} {
+		if err := os.Chtimes(store.path("index", key), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	next, err := Open(root, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got entry
+	if !next.Load("index", fresh, &got) {
+		t.Fatal("expected fresh entry to load")
+	}
+	if err := next.Prune("index", 24*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if next.Load("index", stale, &got) {
+		t.Fatal("expected untouched stale entry to be pruned")
+	}
+	if !next.Load("index", fresh, &got) {
+		t.Fatal("expected entry loaded during this run to be kept")
+	}
+}
+
+func TestKeyIsLengthPrefixed(t *testing.T) {
+	if Key([]byte("ab"), []byte("c")) == Key([]byte("a"), []byte("bc")) {
+		t.Fatal("expected different splits to give different keys")
+	}
+}
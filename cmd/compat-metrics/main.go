@@ -1,15 +1,18 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"github.com/ayanozturk/go-php-parser/lexer"
 	"github.com/ayanozturk/go-php-parser/parser"
+	"github.com/ayanozturk/go-php-parser/token"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"sort"
 	"strings"
@@ -24,6 +27,34 @@ type fileResult struct {
 	errorCount int
 	firstError string
 	readError  string
+	category   string
+	signatures []errorSignature
+}
+
+// File categories. Only categoryPHP is plain PHP source; the others are
+// files the parser is not expected to handle as a PHP script on its own.
+const (
+	categoryPHP            = "php"
+	categoryBlade          = "blade"
+	categoryTemplate       = "inline-html"
+	categoryNoOpenTag      = "no-open-tag"
+	categoryInvalidFixture = "invalid-fixture"
+)
+
+var categoryOrder = []string{categoryPHP, categoryBlade, categoryTemplate, categoryNoOpenTag, categoryInvalidFixture}
+
+// errorSignature identifies a class of parse failure independently of the
+// file, line and identifiers involved.
+type errorSignature struct {
+	Template string `json:"template"`
+	Token    string `json:"token"`
+}
+
+func (s errorSignature) String() string {
+	if s.Token == "" {
+		return s.Template
+	}
+	return s.Template + " [" + s.Token + "]"
 }
 
 type fileFailure struct {
@@ -42,17 +73,55 @@ type projectReport struct {
 	SampleFailures   []fileFailure `json:"sampleFailures,omitempty"`
 }
 
+type categoryReport struct {
+	Category         string  `json:"category"`
+	TotalFiles       int     `json:"totalFiles"`
+	PassingFiles     int     `json:"passingFiles"`
+	FailingFiles     int     `json:"failingFiles"`
+	CompatibilityPct float64 `json:"compatibilityPct"`
+	TotalParseErrors int     `json:"totalParseErrors"`
+}
+
+type failureCluster struct {
+	Signature   errorSignature `json:"signature"`
+	Files       int            `json:"files"`
+	Errors      int            `json:"errors"`
+	SampleFiles []string       `json:"sampleFiles,omitempty"`
+}
+
+// fileStatus records the outcome for a single file so that a later run can
+// be diffed against this report with -baseline.
+type fileStatus struct {
+	Path       string `json:"path"`
+	Category   string `json:"category"`
+	ErrorCount int    `json:"errorCount"`
+}
+
+type baselineDiff struct {
+	Baseline     string   `json:"baseline"`
+	Regressions  []string `json:"regressions"`
+	NewlyPassing []string `json:"newlyPassing"`
+}
+
 type report struct {
-	GeneratedAt      string          `json:"generatedAt"`
-	Root             string          `json:"root"`
-	Workers          int             `json:"workers"`
-	DurationMs       int64           `json:"durationMs"`
-	TotalFiles       int             `json:"totalFiles"`
-	PassingFiles     int             `json:"passingFiles"`
-	FailingFiles     int             `json:"failingFiles"`
-	CompatibilityPct float64         `json:"compatibilityPct"`
-	TotalParseErrors int             `json:"totalParseErrors"`
-	Projects         []projectReport `json:"projects"`
+	GeneratedAt      string           `json:"generatedAt"`
+	Root             string           `json:"root"`
+	Workers          int              `json:"workers"`
+	DurationMs       int64            `json:"durationMs"`
+	TotalFiles       int              `json:"totalFiles"`
+	PassingFiles     int              `json:"passingFiles"`
+	FailingFiles     int              `json:"failingFiles"`
+	CompatibilityPct float64          `json:"compatibilityPct"`
+	TotalParseErrors int              `json:"totalParseErrors"`
+	HeadlineExcludes []string         `json:"headlineExcludes,omitempty"`
+	HeadlineFiles    int              `json:"headlineFiles"`
+	HeadlinePassing  int              `json:"headlinePassing"`
+	HeadlineErrors   int              `json:"headlineParseErrors"`
+	Categories       []categoryReport `json:"categories"`
+	Projects         []projectReport  `json:"projects"`
+	Clusters         []failureCluster `json:"clusters"`
+	Baseline         *baselineDiff    `json:"baseline,omitempty"`
+	Files            []fileStatus     `json:"files"`
 }
 
 func main() {
@@ -61,6 +130,9 @@ func main() {
 	top := flag.Int("top", 3, "number of sample failing files to report per project")
 	jsonOutput := flag.Bool("json", false, "emit JSON instead of text")
 	outputPath := flag.String("output", "", "optional file to write the report to")
+	clusters := flag.Int("clusters", 20, "number of failure clusters to print in the text report (0 = all)")
+	baselinePath := flag.String("baseline", "", "previous JSON report; exit non-zero if files that passed there now fail")
+	phpOnly := flag.Bool("php-only", false, "exclude Blade, inline-HTML, no-open-tag and invalid-fixture files from the headline percentage")
 	flag.Parse()
 
 	if *workers < 1 {
@@ -70,6 +142,16 @@ func main() {
 		*top = 0
 	}
 
+	var baseline *report
+	if *baselinePath != "" {
+		loaded, err := loadBaseline(*baselinePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "compat-metrics: %v\n", err)
+			os.Exit(1)
+		}
+		baseline = loaded
+	}
+
 	start := time.Now()
 	files, err := collectPHPFiles(*root)
 	if err != nil {
@@ -78,7 +160,14 @@ func main() {
 	}
 
 	results := scanFiles(files, *root, *workers)
-	report := buildReport(*root, *workers, *top, start, results)
+	var excluded []string
+	if *phpOnly {
+		excluded = categoryOrder[1:]
+	}
+	report := buildReport(*root, *workers, *top, excluded, start, results)
+	if baseline != nil {
+		report.Baseline = diffBaseline(*baselinePath, baseline, report)
+	}
 
 	out := io.Writer(os.Stdout)
 	if *outputPath != "" {
@@ -98,10 +187,54 @@ func main() {
 			fmt.Fprintf(os.Stderr, "compat-metrics: %v\n", err)
 			os.Exit(1)
 		}
-		return
+	} else {
+		printTextReport(out, report, *clusters)
+	}
+
+	if report.Baseline != nil && len(report.Baseline.Regressions) > 0 {
+		fmt.Fprintf(os.Stderr, "compat-metrics: %d file(s) passing in %s now fail\n", len(report.Baseline.Regressions), report.Baseline.Baseline)
+		os.Exit(2)
+	}
+}
+
+func loadBaseline(path string) (*report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
 	}
+	var baseline report
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("baseline %s: %w", path, err)
+	}
+	if baseline.TotalFiles > 0 && len(baseline.Files) == 0 {
+		return nil, fmt.Errorf("baseline %s has no per-file results; regenerate it with -json", path)
+	}
+	return &baseline, nil
+}
 
-	printTextReport(out, report)
+// diffBaseline compares per-file outcomes against a previous report. Files
+// that only exist on one side are ignored: they are additions or removals in
+// the corpus, not parser changes.
+func diffBaseline(path string, baseline *report, current report) *baselineDiff {
+	previous := make(map[string]bool, len(baseline.Files))
+	for _, file := range baseline.Files {
+		previous[file.Path] = file.ErrorCount == 0
+	}
+	diff := &baselineDiff{Baseline: path, Regressions: []string{}, NewlyPassing: []string{}}
+	for _, file := range current.Files {
+		passedBefore, ok := previous[file.Path]
+		if !ok {
+			continue
+		}
+		passesNow := file.ErrorCount == 0
+		switch {
+		case passedBefore && !passesNow:
+			diff.Regressions = append(diff.Regressions, file.Path)
+		case !passedBefore && passesNow:
+			diff.NewlyPassing = append(diff.NewlyPassing, file.Path)
+		}
+	}
+	return diff
 }
 
 func collectPHPFiles(root string) ([]string, error) {
@@ -166,6 +299,8 @@ func parseFile(path, root string) fileResult {
 			errorCount: 1,
 			firstError: err.Error(),
 			readError:  err.Error(),
+			category:   classifyFile(relativePath(root, path), nil),
+			signatures: []errorSignature{{Template: "read error"}},
 		}
 	}
 
@@ -175,16 +310,126 @@ func parseFile(path, root string) fileResult {
 	errs := p.Errors()
 
 	result := fileResult{
-		path:    path,
-		project: projectName(root, path),
+		path:     path,
+		project:  projectName(root, path),
+		category: classifyFile(relativePath(root, path), content),
 	}
 	if len(errs) > 0 {
 		result.errorCount = len(errs)
 		result.firstError = errs[0]
+		for _, detail := range p.ErrorDetails() {
+			result.signatures = append(result.signatures, signatureFor(detail))
+		}
 	}
 	return result
 }
 
+var (
+	// invalidFixturePattern matches lower-case marker words in a file name, so
+	// "invalid_syntax.php" is a fixture while a stub such as
+	// "InvalidArgumentException.php" or "ParseError.php" is not.
+	invalidFixturePattern = regexp.MustCompile(`(^|[^A-Za-z])(invalid|broken|malformed|syntax[-_]?error|parse[-_]?error|bad[-_]?syntax)([^A-Za-z]|$)`)
+	// fixtureDirPattern matches directories that hold test inputs. Generic
+	// names such as "data" and "stubs" only count below a tests directory, so
+	// an App/Data namespace is still treated as source.
+	fixtureDirPattern = regexp.MustCompile(`(?i)(^|/)((fixtures?|_files)|tests?/(.+/)?(data|stubs?))(/|$)`)
+	openTagPattern    = regexp.MustCompile(`(?i)<\?(php\b|=)`)
+)
+
+// classifyFile assigns a file to a category based on its path relative to the
+// scanned root and its content. The checks are ordered from most to least
+// specific so that, for example, a Blade view without an open tag is still
+// reported as Blade.
+func classifyFile(path string, content []byte) string {
+	slashed := filepath.ToSlash(path)
+	if strings.HasSuffix(strings.ToLower(slashed), ".blade.php") {
+		return categoryBlade
+	}
+	dir, name := "", slashed
+	if slash := strings.LastIndex(slashed, "/"); slash >= 0 {
+		dir, name = slashed[:slash], slashed[slash+1:]
+	}
+	if fixtureDirPattern.MatchString(dir) && invalidFixturePattern.MatchString(name) {
+		return categoryInvalidFixture
+	}
+	if content == nil {
+		return categoryPHP
+	}
+	loc := openTagPattern.FindIndex(content)
+	if loc == nil {
+		return categoryNoOpenTag
+	}
+	openTag := loc[0]
+	leading := strings.TrimSpace(string(content[:openTag]))
+	if strings.HasPrefix(leading, "#!") && !strings.Contains(leading, "\n") {
+		leading = ""
+	}
+	if leading != "" || hasInlineHTML(content[openTag:]) {
+		return categoryTemplate
+	}
+	return categoryPHP
+}
+
+// hasInlineHTML reports whether anything other than whitespace follows a
+// closing "?>" tag. The source is lexed so that "?>" inside a string, heredoc
+// or block comment is not mistaken for a closing tag; a line comment does end
+// at "?>", as it does in PHP.
+func hasInlineHTML(source []byte) bool {
+	l := lexer.New(string(source))
+	for {
+		tok := l.NextToken()
+		end := -1
+		switch tok.Type {
+		case token.T_EOF:
+			return false
+		case token.T_QUESTION, token.T_COALESCE:
+			// The lexer has no closing tag token: "?>" arrives as "?" or
+			// "??" directly followed by ">".
+			if next := tok.Pos.Offset + len(tok.Literal); next < len(source) && source[next] == '>' {
+				end = next + 1
+			}
+		case token.T_COMMENT:
+			if !strings.HasPrefix(tok.Literal, "/*") {
+				if idx := strings.Index(tok.Literal, "?>"); idx >= 0 {
+					end = tok.Pos.Offset + idx + 2
+				}
+			}
+		}
+		if end >= 0 && len(bytes.TrimSpace(source[end:])) > 0 {
+			return true
+		}
+	}
+}
+
+var (
+	linePrefixPattern  = regexp.MustCompile(`^line (\d+|%d):(\d+|%d):\s*`)
+	formatVerbPattern  = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z]`)
+	quotedPattern      = regexp.MustCompile(`"[^"]*"|'[^']*'`)
+	variablePattern    = regexp.MustCompile(`\$[A-Za-z_][A-Za-z0-9_]*`)
+	numberPattern      = regexp.MustCompile(`\b\d+\b`)
+	whitespacePattern  = regexp.MustCompile(`\s+`)
+	placeholderPattern = regexp.MustCompile(`_(\s*_)+`)
+)
+
+// signatureFor normalises a parser error into its message template plus the
+// offending token type. Positions, identifiers and literals are replaced
+// with "_" so that the same root cause clusters across files.
+func signatureFor(detail parser.ErrorDeferred) errorSignature {
+	template := detail.Format
+	template = linePrefixPattern.ReplaceAllString(template, "")
+	if len(detail.Args) > 0 {
+		// Errors propagated via err.Error() arrive pre-formatted and have no
+		// verbs to strip.
+		template = formatVerbPattern.ReplaceAllString(template, "_")
+	}
+	template = quotedPattern.ReplaceAllString(template, "_")
+	template = variablePattern.ReplaceAllString(template, "$_")
+	template = numberPattern.ReplaceAllString(template, "_")
+	template = whitespacePattern.ReplaceAllString(strings.TrimSpace(template), " ")
+	template = placeholderPattern.ReplaceAllString(template, "_")
+	return errorSignature{Template: template, Token: string(detail.Token)}
+}
+
 func projectName(root, path string) string {
 	rel, err := filepath.Rel(root, path)
 	if err != nil {
@@ -197,14 +442,21 @@ func projectName(root, path string) string {
 	return parts[0]
 }
 
-func buildReport(root string, workers, top int, start time.Time, results []fileResult) report {
+func buildReport(root string, workers, top int, excluded []string, start time.Time, results []fileResult) report {
 	projects := map[string]*projectReport{}
+	categories := map[string]*categoryReport{}
+	excludedSet := map[string]bool{}
+	for _, category := range excluded {
+		excludedSet[category] = true
+	}
 	failures := map[string][]fileFailure{}
+	clusters := map[errorSignature]*failureCluster{}
 	compat := report{
-		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
-		Root:        root,
-		Workers:     workers,
-		DurationMs:  time.Since(start).Milliseconds(),
+		GeneratedAt:      time.Now().UTC().Format(time.RFC3339),
+		Root:             root,
+		Workers:          workers,
+		DurationMs:       time.Since(start).Milliseconds(),
+		HeadlineExcludes: excluded,
 	}
 
 	for _, result := range results {
@@ -215,25 +467,76 @@ func buildReport(root string, workers, top int, start time.Time, results []fileR
 			projects[result.project] = project
 		}
 		project.TotalFiles++
+		category := categories[result.category]
+		if category == nil {
+			category = &categoryReport{Category: result.category}
+			categories[result.category] = category
+		}
+		category.TotalFiles++
+		inHeadline := !excludedSet[result.category]
+		if inHeadline {
+			compat.HeadlineFiles++
+		}
+		path := relativePath(root, result.path)
+		compat.Files = append(compat.Files, fileStatus{
+			Path:       path,
+			Category:   result.category,
+			ErrorCount: result.errorCount,
+		})
 
 		if result.errorCount == 0 {
 			compat.PassingFiles++
 			project.PassingFiles++
+			category.PassingFiles++
+			if inHeadline {
+				compat.HeadlinePassing++
+			}
 			continue
 		}
 
 		compat.FailingFiles++
 		compat.TotalParseErrors += result.errorCount
+		if inHeadline {
+			compat.HeadlineErrors += result.errorCount
+		}
 		project.FailingFiles++
 		project.TotalParseErrors += result.errorCount
+		category.FailingFiles++
+		category.TotalParseErrors += result.errorCount
 		failures[result.project] = append(failures[result.project], fileFailure{
-			Path:       result.path,
+			Path:       path,
 			ErrorCount: result.errorCount,
 			FirstError: result.firstError,
 		})
-	}
 
-	compat.CompatibilityPct = pct(compat.PassingFiles, compat.TotalFiles)
+		seen := map[errorSignature]bool{}
+		for _, signature := range result.signatures {
+			cluster := clusters[signature]
+			if cluster == nil {
+				cluster = &failureCluster{Signature: signature}
+				clusters[signature] = cluster
+			}
+			cluster.Errors++
+			if seen[signature] {
+				continue
+			}
+			seen[signature] = true
+			cluster.Files++
+			if len(cluster.SampleFiles) < top {
+				cluster.SampleFiles = append(cluster.SampleFiles, path)
+			}
+		}
+	}
+	compat.Clusters = rankClusters(clusters)
+
+	compat.CompatibilityPct = pct(compat.HeadlinePassing, compat.HeadlineFiles)
+	compat.Categories = make([]categoryReport, 0, len(categories))
+	for _, name := range categoryOrder {
+		if category := categories[name]; category != nil {
+			category.CompatibilityPct = pct(category.PassingFiles, category.TotalFiles)
+			compat.Categories = append(compat.Categories, *category)
+		}
+	}
 
 	projectNames := make([]string, 0, len(projects))
 	for name := range projects {
@@ -256,6 +559,33 @@ func buildReport(root string, workers, top int, start time.Time, results []fileR
 	return compat
 }
 
+// rankClusters orders clusters by the number of files they affect, so the
+// first entry is the fix that would unblock the most files.
+func rankClusters(clusters map[errorSignature]*failureCluster) []failureCluster {
+	ranked := make([]failureCluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		ranked = append(ranked, *cluster)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Files != ranked[j].Files {
+			return ranked[i].Files > ranked[j].Files
+		}
+		if ranked[i].Errors != ranked[j].Errors {
+			return ranked[i].Errors > ranked[j].Errors
+		}
+		return ranked[i].Signature.String() < ranked[j].Signature.String()
+	})
+	return ranked
+}
+
+func relativePath(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
 func pct(passing, total int) float64 {
 	if total == 0 {
 		return 100
@@ -263,20 +593,39 @@ func pct(passing, total int) float64 {
 	return float64(passing) * 100 / float64(total)
 }
 
-func printTextReport(w io.Writer, report report) {
+func printTextReport(w io.Writer, report report, maxClusters int) {
 	fmt.Fprintf(w, "Compatibility Metrics\n")
 	fmt.Fprintf(w, "Root: %s\n", report.Root)
 	fmt.Fprintf(w, "Generated: %s\n", report.GeneratedAt)
 	fmt.Fprintf(w, "Scanned %d PHP files in %dms using %d workers\n", report.TotalFiles, report.DurationMs, report.Workers)
-	fmt.Fprintf(w, "Overall: %.2f%% compatible (%d/%d passing), %d failing files, %d parse errors\n\n",
+	fmt.Fprintf(w, "Overall: %.2f%% compatible (%d/%d passing), %d failing files, %d parse errors\n",
 		report.CompatibilityPct,
-		report.PassingFiles,
-		report.TotalFiles,
-		report.FailingFiles,
-		report.TotalParseErrors,
+		report.HeadlinePassing,
+		report.HeadlineFiles,
+		report.HeadlineFiles-report.HeadlinePassing,
+		report.HeadlineErrors,
 	)
+	if len(report.HeadlineExcludes) > 0 {
+		fmt.Fprintf(w, "Headline excludes: %s (%d files)\n", strings.Join(report.HeadlineExcludes, ", "), report.TotalFiles-report.HeadlineFiles)
+	}
+	fmt.Fprintln(w)
 
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CATEGORY\tTOTAL\tPASS\tFAIL\tCOMPAT\tPARSE_ERRORS")
+	for _, category := range report.Categories {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.2f%%\t%d\n",
+			category.Category,
+			category.TotalFiles,
+			category.PassingFiles,
+			category.FailingFiles,
+			category.CompatibilityPct,
+			category.TotalParseErrors,
+		)
+	}
+	_ = tw.Flush()
+	fmt.Fprintln(w)
+
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
 	fmt.Fprintln(tw, "PROJECT\tTOTAL\tPASS\tFAIL\tCOMPAT\tPARSE_ERRORS")
 	for _, project := range report.Projects {
 		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.2f%%\t%d\n",
@@ -300,4 +649,45 @@ func printTextReport(w io.Writer, report report) {
 			fmt.Fprintf(w, "    %s\n", failure.FirstError)
 		}
 	}
+
+	printClusters(w, report.Clusters, maxClusters)
+	if report.Baseline != nil {
+		printBaselineDiff(w, report.Baseline)
+	}
+}
+
+func printClusters(w io.Writer, clusters []failureCluster, limit int) {
+	if len(clusters) == 0 {
+		return
+	}
+	shown := clusters
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	fmt.Fprintf(w, "\nFailure clusters (%d of %d, ranked by files):\n", len(shown), len(clusters))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FILES\tERRORS\tTOKEN\tSIGNATURE")
+	for _, cluster := range shown {
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%s\n", cluster.Files, cluster.Errors, cluster.Signature.Token, cluster.Signature.Template)
+	}
+	_ = tw.Flush()
+	for _, cluster := range shown {
+		if len(cluster.SampleFiles) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n  %s\n", cluster.Signature)
+		for _, path := range cluster.SampleFiles {
+			fmt.Fprintf(w, "    %s\n", path)
+		}
+	}
+}
+
+func printBaselineDiff(w io.Writer, diff *baselineDiff) {
+	fmt.Fprintf(w, "\nBaseline %s: %d regression(s), %d newly passing file(s)\n", diff.Baseline, len(diff.Regressions), len(diff.NewlyPassing))
+	for _, path := range diff.Regressions {
+		fmt.Fprintf(w, "  REGRESSED  %s\n", path)
+	}
+	for _, path := range diff.NewlyPassing {
+		fmt.Fprintf(w, "  FIXED      %s\n", path)
+	}
 }
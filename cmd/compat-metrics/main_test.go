@@ -0,0 +1,213 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestClassifyFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    string
+	}{
+		{
+			name:    "plain php",
+			path:    "app/src/User.php",
+			content: "<?php\nclass User {}\n",
+			want:    categoryPHP,
+		},
+		{
+			name:    "blade view",
+			path:    "app/resources/views/home.blade.php",
+			content: "<div>{{ $name }}</div>\n",
+			want:    categoryBlade,
+		},
+		{
+			name:    "invalid fixture",
+			path:    "parser/tests/fixtures/invalid_syntax.php",
+			content: "<?php\nclass {\n",
+			want:    categoryInvalidFixture,
+		},
+		{
+			name:    "parse error fixture",
+			path:    "parser/tests/data/parse-error-01.php",
+			content: "<?php\nfunction (\n",
+			want:    categoryInvalidFixture,
+		},
+		{
+			name:    "data directory below tests",
+			path:    "app/tests/Unit/data/invalid.php",
+			content: "<?php\nclass {\n",
+			want:    categoryInvalidFixture,
+		},
+		{
+			name:    "data namespace outside tests",
+			path:    "app/src/Data/invalid.php",
+			content: "<?php\nclass {\n",
+			want:    categoryPHP,
+		},
+		{
+			name:    "stubs directory outside tests",
+			path:    "app/stubs/broken.php",
+			content: "<?php\nclass {\n",
+			want:    categoryPHP,
+		},
+		{
+			name:    "invalid marker outside a fixture directory",
+			path:    "app/src/invalid.php",
+			content: "<?php\n",
+			want:    categoryPHP,
+		},
+		{
+			name:    "exception stub named after a marker",
+			path:    "phpstorm/stubs/SPL/InvalidArgumentException.php",
+			content: "<?php\nclass InvalidArgumentException extends LogicException {}\n",
+			want:    categoryPHP,
+		},
+		{
+			name:    "parse error class stub",
+			path:    "phpstorm/stubs/Core/ParseError.php",
+			content: "<?php\nclass ParseError extends CompileError {}\n",
+			want:    categoryPHP,
+		},
+		{
+			name:    "marker only in a directory name",
+			path:    "app/tests/fixtures/broken/Valid.php",
+			content: "<?php\n",
+			want:    categoryPHP,
+		},
+		{
+			name:    "no open tag",
+			path:    "app/config/settings.php",
+			content: "return [];\n",
+			want:    categoryNoOpenTag,
+		},
+		{
+			name:    "html before the open tag",
+			path:    "app/views/page.php",
+			content: "<html>\n<?php echo $title; ?>\n</html>\n",
+			want:    categoryTemplate,
+		},
+		{
+			name:    "html after a closing tag",
+			path:    "app/views/list.php",
+			content: "<?php if ($items): ?>\n<ul></ul>\n<?php endif; ?>\n",
+			want:    categoryTemplate,
+		},
+		{
+			name:    "closing tag inside a line comment",
+			path:    "app/views/note.php",
+			content: "<?php\n// done ?> <p>shown</p>\n",
+			want:    categoryTemplate,
+		},
+		{
+			name:    "trailing closing tag",
+			path:    "app/src/legacy.php",
+			content: "<?php\n$a = 1;\n?>\n",
+			want:    categoryPHP,
+		},
+		{
+			name:    "closing tag inside strings",
+			path:    "app/src/Renderer.php",
+			content: "<?php\n$a = '?> one';\n$b = \"?>two\";\n/* ?> three */\n$c = <<<EOT\n?> four\nEOT;\n",
+			want:    categoryPHP,
+		},
+		{
+			name:    "shebang",
+			path:    "app/bin/console.php",
+			content: "#!/usr/bin/env php\n<?php\nrun();\n",
+			want:    categoryPHP,
+		},
+		{
+			name: "unreadable file",
+			path: "app/src/Missing.php",
+			want: categoryPHP,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var content []byte
+			if tt.content != "" {
+				content = []byte(tt.content)
+			}
+			if got := classifyFile(tt.path, content); got != tt.want {
+				t.Fatalf("classifyFile(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFileClassifiesRelativeToRoot(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "data", "invalid")
+	path := filepath.Join(root, "app", "User.php")
+
+	// The file does not exist; only the path takes part in classification.
+	result := parseFile(path, root)
+	if result.category != categoryPHP {
+		t.Fatalf("category = %q, want %q: directories above the root must not be classified", result.category, categoryPHP)
+	}
+}
+
+func TestBuildReportHeadlineExcludesCategories(t *testing.T) {
+	results := []fileResult{
+		{path: "root/app/a.php", project: "app", category: categoryPHP},
+		{path: "root/app/b.php", project: "app", category: categoryPHP, errorCount: 2},
+		{path: "root/app/c.blade.php", project: "app", category: categoryBlade, errorCount: 5},
+	}
+
+	tests := []struct {
+		name         string
+		excluded     []string
+		wantFiles    int
+		wantPassing  int
+		wantErrors   int
+		wantTotalErr int
+	}{
+		{name: "all categories", wantFiles: 3, wantPassing: 1, wantErrors: 7, wantTotalErr: 7},
+		{name: "php only", excluded: categoryOrder[1:], wantFiles: 2, wantPassing: 1, wantErrors: 2, wantTotalErr: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildReport("root", 1, 0, tt.excluded, time.Now(), results)
+			if got.HeadlineFiles != tt.wantFiles || got.HeadlinePassing != tt.wantPassing {
+				t.Fatalf("headline = %d/%d, want %d/%d", got.HeadlinePassing, got.HeadlineFiles, tt.wantPassing, tt.wantFiles)
+			}
+			if got.HeadlineErrors != tt.wantErrors {
+				t.Fatalf("HeadlineErrors = %d, want %d", got.HeadlineErrors, tt.wantErrors)
+			}
+			if got.TotalParseErrors != tt.wantTotalErr {
+				t.Fatalf("TotalParseErrors = %d, want %d", got.TotalParseErrors, tt.wantTotalErr)
+			}
+		})
+	}
+}
+
+func TestBuildReportUsesRootRelativePaths(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "corpus")
+	signature := errorSignature{Template: "unexpected _", Token: "T_STRING"}
+	results := []fileResult{{
+		path:       filepath.Join(root, "app", "src", "Broken.php"),
+		project:    "app",
+		category:   categoryPHP,
+		errorCount: 1,
+		firstError: "line 3:1: unexpected foo",
+		signatures: []errorSignature{signature},
+	}}
+
+	got := buildReport(root, 1, 3, nil, time.Now(), results)
+	want := "app/src/Broken.php"
+	if len(got.Files) != 1 || got.Files[0].Path != want {
+		t.Fatalf("Files = %#v, want path %q", got.Files, want)
+	}
+	if len(got.Clusters) != 1 || len(got.Clusters[0].SampleFiles) != 1 || got.Clusters[0].SampleFiles[0] != want {
+		t.Fatalf("Clusters = %#v, want sample file %q", got.Clusters, want)
+	}
+	if len(got.Projects) != 1 || len(got.Projects[0].SampleFailures) != 1 || got.Projects[0].SampleFailures[0].Path != want {
+		t.Fatalf("Projects = %#v, want sample failure %q", got.Projects, want)
+	}
+}
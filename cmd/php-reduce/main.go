@@ -0,0 +1,129 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func main() {
+	match := flag.String("match", "", "keep inputs whose parse errors match this regular expression")
+	panics := flag.Bool("panic", false, "keep inputs that make the parser panic")
+	outputPath := flag.String("output", "", "file to write the reduced input to (default stdout)")
+	format := flag.String("format", "php", "output format: php (raw source) or go (parser test function)")
+	timeout := flag.Duration("timeout", 2*time.Second, "per-parse timeout; candidates that exceed it are rejected")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: php-reduce [flags] <file.php>\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Shrinks a PHP file to the smallest input that still meets the predicate.\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Without -match or -panic, the first parse error of the input (minus its position) must be kept.\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *format != "php" && *format != "go" {
+		fmt.Fprintf(os.Stderr, "php-reduce: unknown format %q\n", *format)
+		os.Exit(2)
+	}
+
+	var pattern *regexp.Regexp
+	if *match != "" {
+		compiled, err := regexp.Compile(*match)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "php-reduce: invalid -match: %v\n", err)
+			os.Exit(2)
+		}
+		pattern = compiled
+	}
+
+	content, err := os.ReadFile(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "php-reduce: %v\n", err)
+		os.Exit(1)
+	}
+
+	r := &reducer{
+		predicate: predicate{match: pattern, panics: *panics, timeout: *timeout},
+		seen:      map[string]bool{},
+	}
+	start := time.Now()
+	if pattern == nil && !*panics {
+		errs := r.predicate.errors(string(content))
+		if len(errs) == 0 {
+			fmt.Fprintf(os.Stderr, "php-reduce: %s parses without errors; nothing to reduce\n", flag.Arg(0))
+			os.Exit(1)
+		}
+		r.predicate.match = firstErrorPattern(errs[0])
+		fmt.Fprintf(os.Stderr, "php-reduce: keeping inputs matching %s\n", r.predicate.match)
+	}
+	if !r.interesting(string(content)) {
+		fmt.Fprintf(os.Stderr, "php-reduce: %s does not meet the predicate; nothing to reduce\n", flag.Arg(0))
+		os.Exit(1)
+	}
+	reduced := r.reduce(string(content))
+
+	out := io.Writer(os.Stdout)
+	if *outputPath != "" {
+		file, createErr := os.Create(*outputPath)
+		if createErr != nil {
+			fmt.Fprintf(os.Stderr, "php-reduce: %v\n", createErr)
+			os.Exit(1)
+		}
+		defer file.Close()
+		out = file
+	}
+	if *format == "go" {
+		writeGoTest(out, reduced, r.predicate)
+	} else {
+		fmt.Fprint(out, reduced)
+	}
+
+	fmt.Fprintf(os.Stderr, "php-reduce: %d -> %d bytes (%d lines) in %d parses, %s\n",
+		len(content), len(reduced), strings.Count(reduced, "\n")+1, r.parses, time.Since(start).Round(time.Millisecond))
+	if errs := r.predicate.errors(reduced); len(errs) > 0 {
+		fmt.Fprintf(os.Stderr, "php-reduce: first error: %s\n", errs[0])
+	}
+}
+
+var errorPositionPattern = regexp.MustCompile(`^line \d+:\d+: `)
+
+// firstErrorPattern matches the given parse error anywhere in the file, so the
+// reducer can move code around without losing the original failure.
+func firstErrorPattern(err string) *regexp.Regexp {
+	message := errorPositionPattern.ReplaceAllString(err, "")
+	return regexp.MustCompile(`^(line \d+:\d+: )?` + regexp.QuoteMeta(message) + `$`)
+}
+
+// writeGoTest emits a test function that can be pasted into parser/*_test.go.
+func writeGoTest(w io.Writer, source string, pred predicate) {
+	literal := "`" + source + "`"
+	if strings.Contains(source, "`") {
+		literal = strconv.Quote(source)
+	}
+	fmt.Fprintln(w, "func TestReducedParserFailure(t *testing.T) {")
+	fmt.Fprintf(w, "\tl := lexer.New(%s)\n", literal)
+	fmt.Fprintln(w, "\tp := New(l, false)")
+	fmt.Fprintln(w, "\t_ = p.Parse()")
+	fmt.Fprintln(w)
+	switch {
+	case pred.panics:
+		fmt.Fprintln(w, "\tfor _, err := range p.Errors() {")
+		fmt.Fprintf(w, "\t\tif strings.HasPrefix(err, %q) {\n", panicPrefix)
+		io.WriteString(w, "\t\t\tt.Fatalf(\"parser panicked: %s\", err)\n")
+		fmt.Fprintln(w, "\t\t}")
+		fmt.Fprintln(w, "\t}")
+	default:
+		fmt.Fprintln(w, "\tif errs := p.Errors(); len(errs) > 0 {")
+		io.WriteString(w, "\t\tt.Fatalf(\"expected reduced input to parse, got %v\", errs)\n")
+		fmt.Fprintln(w, "\t}")
+	}
+	fmt.Fprintln(w, "}")
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"github.com/ayanozturk/go-php-parser/lexer"
+	"github.com/ayanozturk/go-php-parser/parser"
+	"github.com/ayanozturk/go-php-parser/token"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// panicPrefix is how parser.Parse reports a recovered panic.
+const panicPrefix = "Parser panic:"
+
+type predicate struct {
+	match   *regexp.Regexp
+	panics  bool
+	timeout time.Duration
+}
+
+// errors parses source and returns its parse errors. A parse that does not
+// finish within the timeout reports nil so that it is never kept; the parser
+// checks its context as it reads tokens, so the goroutine stops soon after.
+func (pr predicate) errors(source string) []string {
+	ctx, cancel := context.WithTimeout(context.Background(), pr.timeout)
+	defer cancel()
+
+	done := make(chan []string, 1)
+	go func() {
+		p := parser.New(lexer.New(source), false)
+		p.Ctx = ctx
+		_ = p.Parse()
+		done <- p.Errors()
+	}()
+
+	select {
+	case errs := <-done:
+		if ctx.Err() != nil {
+			return nil
+		}
+		return errs
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+func (pr predicate) holds(source string) bool {
+	errs := pr.errors(source)
+	if len(errs) == 0 {
+		return false
+	}
+	for _, err := range errs {
+		if pr.panics && !strings.HasPrefix(err, panicPrefix) {
+			continue
+		}
+		if pr.match != nil && !pr.match.MatchString(err) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+type reducer struct {
+	predicate predicate
+	// holds replaces predicate.holds when set. Tests use it to drive the
+	// reduction without going through the parser.
+	holds  func(source string) bool
+	seen   map[string]bool
+	parses int
+}
+
+func (r *reducer) interesting(source string) bool {
+	if result, ok := r.seen[source]; ok {
+		return result
+	}
+	r.parses++
+	holds := r.predicate.holds
+	if r.holds != nil {
+		holds = r.holds
+	}
+	result := holds(source)
+	r.seen[source] = result
+	return result
+}
+
+// reduce runs delta debugging first over statement-sized chunks, then over
+// single tokens, and repeats until neither level removes anything.
+func (r *reducer) reduce(source string) string {
+	for {
+		before := len(source)
+		source = strings.Join(r.ddmin(statementUnits(source)), "")
+		source = strings.Join(r.ddmin(tokenUnits(source)), "")
+		if len(source) >= before {
+			break
+		}
+	}
+	// Token units carry their trailing whitespace; drop what is left over at
+	// the end of the file if the failure does not depend on it.
+	if trimmed := strings.TrimRight(source, " \t\r\n") + "\n"; len(trimmed) < len(source) && r.interesting(trimmed) {
+		return trimmed
+	}
+	return source
+}
+
+// ddmin is Zeller's minimising delta debugging algorithm: it removes
+// progressively smaller groups of units while the predicate still holds.
+func (r *reducer) ddmin(units []string) []string {
+	n := 2
+	for len(units) >= 2 {
+		chunk := (len(units) + n - 1) / n
+		reduced := false
+
+		for start := 0; start < len(units); start += chunk {
+			end := start + chunk
+			if end > len(units) {
+				end = len(units)
+			}
+			subset := units[start:end]
+			if len(subset) < len(units) && r.interesting(strings.Join(subset, "")) {
+				units = append([]string(nil), subset...)
+				n = 2
+				reduced = true
+				break
+			}
+			complement := make([]string, 0, len(units)-len(subset))
+			complement = append(complement, units[:start]...)
+			complement = append(complement, units[end:]...)
+			if r.interesting(strings.Join(complement, "")) {
+				units = complement
+				if n > 2 {
+					n--
+				}
+				reduced = true
+				break
+			}
+		}
+
+		if reduced {
+			continue
+		}
+		if n >= len(units) {
+			break
+		}
+		n *= 2
+		if n > len(units) {
+			n = len(units)
+		}
+	}
+	return units
+}
+
+// tokenUnits splits source at lexer token boundaries. Each unit runs from the
+// start of one token to the start of the next, so joining the units always
+// reproduces the input byte for byte.
+func tokenUnits(source string) []string {
+	return splitAt(source, tokenBoundaries(source, func(token.Token) bool { return true }))
+}
+
+// statementUnits splits source after every ';', '{' and '}' token, which
+// approximates statement and block boundaries without needing a valid AST.
+func statementUnits(source string) []string {
+	return splitAt(source, tokenBoundaries(source, func(tok token.Token) bool {
+		switch tok.Type {
+		case token.T_SEMICOLON, token.T_LBRACE, token.T_RBRACE, token.T_OPEN_TAG:
+			return true
+		}
+		return false
+	}))
+}
+
+// tokenBoundaries returns the offsets just after each token accepted by keep.
+func tokenBoundaries(source string, keep func(token.Token) bool) []int {
+	l := lexer.New(source)
+	var starts []int
+	var kept []bool
+	for {
+		tok := l.NextToken()
+		if tok.Type == token.T_EOF {
+			break
+		}
+		if len(starts) > 0 && tok.Pos.Offset <= starts[len(starts)-1] {
+			// Queued tokens (heredoc parts) can share an offset; keep the
+			// first so units stay non-empty.
+			continue
+		}
+		starts = append(starts, tok.Pos.Offset)
+		kept = append(kept, keep(tok))
+	}
+
+	var boundaries []int
+	for i := range starts {
+		if !kept[i] {
+			continue
+		}
+		end := len(source)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		boundaries = append(boundaries, end)
+	}
+	return boundaries
+}
+
+func splitAt(source string, boundaries []int) []string {
+	units := make([]string, 0, len(boundaries)+1)
+	prev := 0
+	for _, boundary := range boundaries {
+		if boundary <= prev || boundary > len(source) {
+			continue
+		}
+		units = append(units, source[prev:boundary])
+		prev = boundary
+	}
+	if prev < len(source) {
+		units = append(units, source[prev:])
+	}
+	return units
+}
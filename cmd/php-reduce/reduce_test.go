@@ -0,0 +1,124 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTokenUnits(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []string
+	}{
+		{
+			name:   "statement",
+			source: "<?php $a = 1;\n",
+			want:   []string{"<?php ", "$a ", "= ", "1", ";\n"},
+		},
+		{
+			name:   "block",
+			source: "<?php\nif ($a) { echo 1; }\n",
+			want:   []string{"<?php\n", "if ", "(", "$a", ") ", "{ ", "echo ", "1", "; ", "}\n"},
+		},
+		{
+			name:   "heredoc parts stay in one unit",
+			source: "<?php\n$x = <<<EOT\nhi $a\nEOT;\n",
+			want:   []string{"<?php\n", "$x ", "= ", "<<<EOT\nhi $a\nEOT", ";\n"},
+		},
+		{
+			name:   "empty",
+			source: "",
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tokenUnits(tt.source)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("tokenUnits(%q) = %q, want %q", tt.source, got, tt.want)
+			}
+			if joined := strings.Join(got, ""); joined != tt.source {
+				t.Fatalf("units join to %q, want %q", joined, tt.source)
+			}
+		})
+	}
+}
+
+func TestStatementUnits(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []string
+	}{
+		{
+			name:   "statements",
+			source: "<?php\n$a = 1;\n$b = 2;\n",
+			want:   []string{"<?php\n", "$a = 1;\n", "$b = 2;\n"},
+		},
+		{
+			name:   "block",
+			source: "<?php\nif ($a) { echo 1; }\n",
+			want:   []string{"<?php\n", "if ($a) { ", "echo 1; ", "}\n"},
+		},
+		{
+			name:   "trailing code without a terminator",
+			source: "<?php\n$a = 1;\n$b",
+			want:   []string{"<?php\n", "$a = 1;\n", "$b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := statementUnits(tt.source)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("statementUnits(%q) = %q, want %q", tt.source, got, tt.want)
+			}
+			if joined := strings.Join(got, ""); joined != tt.source {
+				t.Fatalf("units join to %q, want %q", joined, tt.source)
+			}
+		})
+	}
+}
+
+func TestDdmin(t *testing.T) {
+	units := strings.Split("abcdefgh", "")
+	tests := []struct {
+		name  string
+		holds func(string) bool
+		want  []string
+	}{
+		{
+			name:  "single unit",
+			holds: func(s string) bool { return strings.Contains(s, "e") },
+			want:  []string{"e"},
+		},
+		{
+			name: "units far apart",
+			holds: func(s string) bool {
+				return strings.Contains(s, "b") && strings.Contains(s, "g")
+			},
+			want: []string{"b", "g"},
+		},
+		{
+			name:  "every unit needed",
+			holds: func(s string) bool { return s == "abcdefgh" },
+			want:  units,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &reducer{holds: tt.holds, seen: map[string]bool{}}
+			got := r.ddmin(append([]string(nil), units...))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ddmin = %q, want %q", got, tt.want)
+			}
+			if r.parses != len(r.seen) {
+				t.Fatalf("predicate ran %d times for %d distinct inputs", r.parses, len(r.seen))
+			}
+		})
+	}
+}
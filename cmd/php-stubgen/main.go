@@ -0,0 +1,131 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ayanozturk/go-php-parser/analyse/builtins"
+	"github.com/ayanozturk/go-php-parser/ast"
+	"github.com/ayanozturk/go-php-parser/lexer"
+	"github.com/ayanozturk/go-php-parser/parser"
+)
+
+// skippedDirs are top-level directories of a phpstorm-stubs checkout that do
+// not describe an extension.
+var skippedDirs = map[string]bool{"tests": true, "meta": true, "vendor": true}
+
+func main() {
+	stubsDir := flag.String("stubs", "", "root of a phpstorm-stubs checkout; each top-level directory is one extension")
+	outputPath := flag.String("out", "", "file to write the symbol database to (default stdout)")
+	only := flag.String("extensions", "", "comma-separated extensions to include (default all)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: php-stubgen -stubs <dir> [-out symbols.json]\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Builds the builtin symbol database from phpstorm-stubs-format PHP files.\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *stubsDir == "" || flag.NArg() != 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	wanted := map[string]bool{}
+	for _, name := range strings.Split(*only, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			wanted[strings.ToLower(name)] = true
+		}
+	}
+
+	db, err := build(*stubsDir, wanted, os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "php-stubgen: %v\n", err)
+		os.Exit(1)
+	}
+	data, err := db.Encode()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "php-stubgen: %v\n", err)
+		os.Exit(1)
+	}
+	if *outputPath == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(*outputPath, data, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "php-stubgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// build extracts the extensions below stubsDir, or only the wanted ones when
+// wanted is not empty, sorted by name. Parse errors are written to errs.
+func build(stubsDir string, wanted map[string]bool, errs io.Writer) (*builtins.Database, error) {
+	entries, err := os.ReadDir(stubsDir)
+	if err != nil {
+		return nil, err
+	}
+	db := &builtins.Database{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || strings.HasPrefix(name, ".") || skippedDirs[strings.ToLower(name)] {
+			continue
+		}
+		if len(wanted) > 0 && !wanted[strings.ToLower(name)] {
+			continue
+		}
+		files, err := parseExtension(filepath.Join(stubsDir, name), errs)
+		if err != nil {
+			return nil, err
+		}
+		ext := builtins.Extract(name, files...)
+		if len(ext.Classes)+len(ext.Functions)+len(ext.Constants) == 0 {
+			continue
+		}
+		db.Extensions = append(db.Extensions, ext)
+	}
+	sort.Slice(db.Extensions, func(i, j int) bool {
+		return strings.ToLower(db.Extensions[i].Name) < strings.ToLower(db.Extensions[j].Name)
+	})
+	return db, nil
+}
+
+// parseExtension parses every PHP file below dir in path order. Parse errors
+// are reported but do not stop generation; the symbols parsed so far are kept.
+func parseExtension(dir string, errs io.Writer) ([][]ast.Node, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.EqualFold(filepath.Ext(path), ".php") {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	files := make([][]ast.Node, 0, len(paths))
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		p := parser.New(lexer.New(string(content)), false)
+		p.SkipFunctionBodies = true
+		nodes := p.Parse()
+		for _, parseErr := range p.Errors() {
+			fmt.Fprintf(errs, "php-stubgen: %s: %s\n", path, parseErr)
+		}
+		files = append(files, nodes)
+	}
+	return files, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestBundledDatabaseIsUpToDate fails when a change to the stubs, the parser
+// or the extractor is not followed by go generate ./analyse/builtins.
+func TestBundledDatabaseIsUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "analyse", "builtins")
+	var errs bytes.Buffer
+	db, err := build(filepath.Join(dir, "stubs"), nil, &errs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs.Len() > 0 {
+		t.Fatalf("expected the bundled stubs to parse, got:\n%s", errs.String())
+	}
+	generated, err := db.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	committed, err := os.ReadFile(filepath.Join(dir, "symbols.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(generated, committed) {
+		t.Fatal("analyse/builtins/symbols.json is out of date; run go generate ./analyse/builtins")
+	}
+}
@@ -0,0 +1,62 @@
+package command
+
+import (
+	"fmt"
+	"github.com/ayanozturk/go-php-parser/analyse"
+	"github.com/ayanozturk/go-php-parser/ast"
+	"github.com/ayanozturk/go-php-parser/composer"
+	"github.com/ayanozturk/go-php-parser/lexer"
+	"github.com/ayanozturk/go-php-parser/parser"
+	"os"
+)
+
+// configuredAutoloadFingerprint identifies the PSR-4 mappings files are
+// checked against, which cached results depend on.
+var configuredAutoloadFingerprint string
+
+// ConfigureAutoload lets analysis load classes the scanned files do not
+// declare through the autoload maps of the Composer project containing path,
+// and checks files against its PSR-4 mappings. Nothing is configured when
+// there is no composer.json above path.
+func ConfigureAutoload(path string) error {
+	root, ok := composer.FindRoot(path)
+	if !ok {
+		analyse.ConfigureClassLoader(nil)
+		analyse.ConfigurePSR4(nil)
+		configuredAutoloadFingerprint = ""
+		return nil
+	}
+	autoloader, err := composer.Load(root)
+	if err != nil {
+		return fmt.Errorf("composer project %s: %w", root, err)
+	}
+	analyse.ConfigureClassLoader(composerClassLoader{autoloader})
+	var mappings []analyse.PSR4Mapping
+	for _, mapping := range autoloader.ProjectPSR4() {
+		mappings = append(mappings, analyse.PSR4Mapping{Prefix: mapping.Prefix, Dirs: mapping.Dirs})
+	}
+	analyse.ConfigurePSR4(mappings)
+	configuredAutoloadFingerprint = fmt.Sprint(root, mappings)
+	return nil
+}
+
+// composerClassLoader parses the files an Autoloader locates. Only
+// declarations are needed, so function bodies are skipped and parse errors
+// are ignored: autoloaded files are never reported on.
+type composerClassLoader struct {
+	autoloader *composer.Autoloader
+}
+
+func (l composerClassLoader) LoadClass(name string) (string, []ast.Node, bool) {
+	path, ok := l.autoloader.LocateClass(name)
+	if !ok {
+		return "", nil, false
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, false
+	}
+	p := parser.New(lexer.New(string(content)), false)
+	p.SkipFunctionBodies = true
+	return path, p.Parse(), true
+}
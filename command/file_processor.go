@@ -12,6 +12,7 @@ import (
 	"io"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -38,6 +39,10 @@ type fileResult struct {
 type parseAnalysisResult struct {
 	issues []style.StyleIssue
 	errors int
+	// deps are the project symbols the analysis looked up.
+	deps []string
+	// complete is false when the file was skipped, for example on timeout.
+	complete bool
 }
 
 var configuredAnalysisLevel *int
@@ -109,15 +114,7 @@ func ProcessFileWithErrors(filePath, commandName string, debug bool, rules []str
 	if commandName == "style" {
 		analysisIssues := analyse.FilterIssues(runAnalysis(filePath, nodes, nil), matcher)
 		for _, iss := range analysisIssues {
-			style.PrintPHPCSStyleIssueToWriter(w, style.StyleIssue{
-				Filename: iss.Filename,
-				Line:     iss.Line,
-				Column:   iss.Column,
-				Type:     style.Error,
-				Fixable:  false,
-				Message:  iss.Message,
-				Code:     iss.Code,
-			})
+			style.PrintPHPCSStyleIssueToWriter(w, analysisStyleIssue(iss))
 		}
 		Commands["style"].ExecuteWithRules(nodes, filePath, w, rules, matcher)
 	} else {
@@ -209,15 +206,7 @@ func processFileForStyle(file string, rules []string, matcher *overrides.Compile
 	var fileIssues []style.StyleIssue
 	for _, iss := range analysisIssues {
 		// Convert AnalysisIssue to StyleIssue for unified reporting
-		fileIssues = append(fileIssues, style.StyleIssue{
-			Filename: iss.Filename,
-			Line:     iss.Line,
-			Column:   iss.Column,
-			Type:     style.Error,
-			Fixable:  false,
-			Message:  iss.Message,
-			Code:     iss.Code,
-		})
+		fileIssues = append(fileIssues, analysisStyleIssue(iss))
 	}
 	issueWriter := &style.IssueCollector{Issues: &fileIssues}
 	Commands["style"].ExecuteWithRules(nodes, file, issueWriter, rules, matcher)
@@ -231,36 +220,51 @@ func parseAndAnalyzeStyleFile(path string, content []byte, rules []string, match
 	lex := lexer.New(string(content))
 	p := parser.New(lex, false)
 	nodes := p.Parse()
-	if len(p.Errors()) > 0 {
-		return parseAnalysisResult{errors: len(p.Errors())}
+	return analyzeStyleFile(path, nodes, len(p.Errors()), rules, matcher, project)
+}
+
+// analyzeStyleFile runs analysis and style rules over a parsed file. Files
+// with parse errors are only counted.
+func analyzeStyleFile(path string, nodes []ast.Node, parseErrors int, rules []string, matcher *overrides.Compiled, project *analyse.ProjectIndex) parseAnalysisResult {
+	if parseErrors > 0 {
+		return parseAnalysisResult{errors: parseErrors, complete: true}
 	}
 
+	var recorder *analyse.DependencyRecorder
+	if project != nil {
+		recorder = analyse.NewDependencyRecorder()
+		project = project.WithRecorder(recorder)
+	}
 	analysisIssues := analyse.FilterIssues(runAnalysis(path, nodes, project), matcher)
 	fileIssues := make([]style.StyleIssue, 0, len(analysisIssues))
 	for _, iss := range analysisIssues {
-		fileIssues = append(fileIssues, style.StyleIssue{
-			Filename: iss.Filename,
-			Line:     iss.Line,
-			Column:   iss.Column,
-			Type:     style.Error,
-			Fixable:  false,
-			Message:  iss.Message,
-			Code:     iss.Code,
-		})
+		fileIssues = append(fileIssues, analysisStyleIssue(iss))
 	}
 	issueWriter := &style.IssueCollector{Issues: &fileIssues}
 	Commands["style"].ExecuteWithRules(nodes, path, issueWriter, rules, matcher)
-	return parseAnalysisResult{issues: fileIssues}
+	res := parseAnalysisResult{issues: fileIssues, complete: true}
+	if recorder != nil {
+		res.deps = recorder.Dependencies()
+	}
+	return res
 }
 
 func parseAndAnalyzeStyleFileWithTimeout(path string, content []byte, rules []string, matcher *overrides.Compiled, project *analyse.ProjectIndex) parseAnalysisResult {
-	if len(content) < fileParseTimeoutMinBytes {
+	return withFileTimeout(path, len(content), func() parseAnalysisResult {
 		return parseAndAnalyzeStyleFile(path, content, rules, matcher, project)
+	})
+}
+
+// withFileTimeout runs fn, giving up after fileParseTimeout on files large
+// enough to make a parser or analysis hang expensive.
+func withFileTimeout(path string, size int, fn func() parseAnalysisResult) parseAnalysisResult {
+	if size < fileParseTimeoutMinBytes {
+		return fn()
 	}
 
 	done := make(chan parseAnalysisResult, 1)
 	go func() {
-		done <- parseAndAnalyzeStyleFile(path, content, rules, matcher, project)
+		done <- fn()
 	}()
 
 	select {
@@ -312,16 +316,40 @@ func processStyleBatch(batch []string, rules []string, matcher *overrides.Compil
 	return allIssues, totalParseErrors, totalLines
 }
 
-// ProcessStyleFilesParallelWithCallback scans all files in a streaming pipeline:
+// ProcessStyleFilesParallelWithCallback checks all files in up to three
+// phases. With an analysis level set, the declarations of every file are
+// first collected in parallel and merged into the project index; see
+// choosePipelineMode for whether the ASTs parsed there are kept for analysis.
+// The analysis phase is a streaming pipeline:
 // - ioWorkers goroutines read files from disk (I/O bound, more workers than CPUs)
 // - parallelism goroutines parse + analyse (CPU bound)
-// I/O and CPU overlap fully; no preload→process serialization.
+// I/O and CPU overlap fully; no preload→process serialization. Phase
+// timings are available from LastPipelineStats.
 func ProcessStyleFilesParallelWithCallback(files []string, rules []string, matcher *overrides.Compiled, parallelism int, callback func()) ([]style.StyleIssue, int, int) {
 	nFiles := len(files)
 	if nFiles == 0 {
 		return nil, 0, 0
 	}
-	project := buildProjectIndexForFiles(files)
+	stats := PipelineStats{Mode: PipelineNoIndex, Files: nFiles}
+	var project *analyse.ProjectIndex
+	var indexed []indexedFile
+	if configuredAnalysisLevel != nil {
+		stats.Mode = choosePipelineMode(files)
+		start := time.Now()
+		indexed = indexFiles(files, stats.Mode == PipelineKeepASTs, parallelism)
+		stats.Parse = time.Since(start)
+		start = time.Now()
+		project = mergeIndex(indexed)
+		stats.Merge = time.Since(start)
+		if stats.Mode != PipelineKeepASTs {
+			indexed = nil
+		}
+	}
+	var shapes *dependencyShapes
+	if project != nil {
+		shapes = &dependencyShapes{project: project}
+	}
+	start := time.Now()
 
 	// Use more goroutines for I/O than CPU — high-latency volumes benefit greatly.
 	ioWorkers := parallelism * 4
@@ -332,41 +360,58 @@ func ProcessStyleFilesParallelWithCallback(files []string, rules []string, match
 	type readResult struct {
 		path    string
 		content []byte
+		// nodes are set when the index phase kept the file's AST.
+		nodes       []ast.Node
+		parsed      bool
+		parseErrors int
 	}
 
 	pathCh := make(chan string, ioWorkers*2)
 	contentCh := make(chan readResult, parallelism*4)
 	resultCh := make(chan fileResult, parallelism)
 
-	// Feed paths
-	go func() {
-		for _, f := range files {
-			pathCh <- f
-		}
-		close(pathCh)
-	}()
-
-	// I/O workers: read files concurrently
-	var ioWg sync.WaitGroup
-	for i := 0; i < ioWorkers; i++ {
-		ioWg.Add(1)
+	if indexed != nil {
+		// Contents and ASTs are already in memory.
 		go func() {
-			defer ioWg.Done()
-			for path := range pathCh {
-				content, err := os.ReadFile(path)
-				if err != nil {
-					content = nil
-				}
-				contentCh <- readResult{path: path, content: content}
+			for i := range indexed {
+				file := &indexed[i]
+				contentCh <- readResult{path: file.path, content: file.content, nodes: file.nodes, parsed: file.parsed, parseErrors: file.parseErrors}
+				*file = indexedFile{}
 			}
+			close(contentCh)
+		}()
+	} else {
+		// Feed paths
+		go func() {
+			for _, f := range files {
+				pathCh <- f
+			}
+			close(pathCh)
+		}()
+
+		// I/O workers: read files concurrently
+		var ioWg sync.WaitGroup
+		for i := 0; i < ioWorkers; i++ {
+			ioWg.Add(1)
+			go func() {
+				defer ioWg.Done()
+				for path := range pathCh {
+					content, err := os.ReadFile(path)
+					if err != nil {
+						content = nil
+					}
+					contentCh <- readResult{path: path, content: content}
+				}
+			}()
+		}
+		go func() {
+			ioWg.Wait()
+			close(contentCh)
 		}()
 	}
-	go func() {
-		ioWg.Wait()
-		close(contentCh)
-	}()
 
 	// CPU workers: parse + analyse
+	var cachedResults atomic.Int64
 	var cpuWg sync.WaitGroup
 	for i := 0; i < parallelism; i++ {
 		cpuWg.Add(1)
@@ -384,7 +429,20 @@ func ProcessStyleFilesParallelWithCallback(files []string, rules []string, match
 				sharedcache.StoreCachedFileContent(rr.path, rr.content)
 
 				lines := CountLines(rr.content)
-				res := parseAndAnalyzeStyleFileWithTimeout(rr.path, rr.content, rules, matcher, project)
+				key := resultCacheKey(rr.path, rr.content, rules)
+				res, cached := loadCachedResult(key, shapes)
+				switch {
+				case cached:
+					cachedResults.Add(1)
+				case rr.parsed:
+					res = withFileTimeout(rr.path, len(rr.content), func() parseAnalysisResult {
+						return analyzeStyleFile(rr.path, rr.nodes, rr.parseErrors, rules, matcher, project)
+					})
+					saveCachedResult(key, res, shapes)
+				default:
+					res = parseAndAnalyzeStyleFileWithTimeout(rr.path, rr.content, rules, matcher, project)
+					saveCachedResult(key, res, shapes)
+				}
 				sharedcache.DeleteCachedFileContent(rr.path)
 				sharedcache.DeleteCachedLines(rr.content)
 				resultCh <- fileResult{issues: res.issues, lines: lines, errors: res.errors}
@@ -408,6 +466,12 @@ func ProcessStyleFilesParallelWithCallback(files []string, rules []string, match
 		totalLines += res.lines
 		totalParseErrors += res.errors
 	}
+	if configuredCache != nil {
+		_ = configuredCache.Prune(resultCacheKind, cacheEntryMaxAge)
+	}
+	stats.Analysis = time.Since(start)
+	stats.CachedResults = int(cachedResults.Load())
+	setLastPipelineStats(stats)
 
 	return allIssues, totalParseErrors, totalLines
 }
@@ -423,26 +487,22 @@ func runAnalysis(path string, nodes []ast.Node, project *analyse.ProjectIndex) [
 	return analyse.RunAnalysisRulesWithContext(path, nodes, ctx)
 }
 
-func buildProjectIndexForFiles(files []string) *analyse.ProjectIndex {
-	if configuredAnalysisLevel == nil {
-		return nil
-	}
-	parsed := make(map[string][]ast.Node, len(files))
-	for _, file := range files {
-		content, err := os.ReadFile(file)
-		if err != nil {
-			continue
-		}
-		sharedcache.StoreCachedFileContent(file, content)
-		lex := lexer.New(string(content))
-		p := parser.New(lex, false)
-		nodes := p.Parse()
-		if len(p.Errors()) > 0 {
-			continue
-		}
-		parsed[file] = nodes
+// analysisStyleIssue converts an analysis issue for reporting alongside
+// style issues.
+func analysisStyleIssue(iss analyse.AnalysisIssue) style.StyleIssue {
+	issueType := style.Error
+	if iss.Warning {
+		issueType = style.Warning
+	}
+	return style.StyleIssue{
+		Filename: iss.Filename,
+		Line:     iss.Line,
+		Column:   iss.Column,
+		Type:     issueType,
+		Fixable:  false,
+		Message:  iss.Message,
+		Code:     iss.Code,
 	}
-	return analyse.BuildProjectIndex(parsed)
 }
 
 func ProcessStyleFilesParallel(files []string, rules []string, matcher *overrides.Compiled, parallelism int) ([]style.StyleIssue, int, int) {
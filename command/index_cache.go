@@ -0,0 +1,47 @@
+package command
+
+import (
+	"github.com/ayanozturk/go-php-parser/analyse"
+	"github.com/ayanozturk/go-php-parser/cache"
+	"time"
+)
+
+const (
+	indexCacheKind = "index"
+	// cacheEntryMaxAge keeps cache entries of files that were not part of this
+	// run, for example when scanning one directory of a larger project.
+	cacheEntryMaxAge = 30 * 24 * time.Hour
+)
+
+var configuredCache *cache.Store
+
+// ConfigureCache sets the store project index entries are kept in between
+// runs; nil disables caching.
+func ConfigureCache(store *cache.Store) {
+	configuredCache = store
+}
+
+// indexCacheEntry is what the cache holds per file content. Files that do not
+// parse are cached too, so they are not parsed again on every run.
+type indexCacheEntry struct {
+	Symbols     *analyse.FileSymbols
+	ParseFailed bool
+}
+
+// cachedFileSymbols looks up the declarations of a file with the given
+// content. The returned key stores an entry for the content on a miss.
+func cachedFileSymbols(content []byte) (indexCacheEntry, string, bool) {
+	if configuredCache == nil {
+		return indexCacheEntry{}, "", false
+	}
+	key := cache.Key(content)
+	var entry indexCacheEntry
+	ok := configuredCache.Load(indexCacheKind, key, &entry)
+	return entry, key, ok
+}
+
+func storeFileSymbols(key string, entry indexCacheEntry) {
+	if configuredCache != nil {
+		_ = configuredCache.Save(indexCacheKind, key, entry)
+	}
+}
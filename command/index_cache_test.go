@@ -0,0 +1,95 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayanozturk/go-php-parser/analyse"
+	"github.com/ayanozturk/go-php-parser/cache"
+)
+
+func TestBuildProjectIndexUsesCachedFileSymbols(t *testing.T) {
+	level := 0
+	ConfigureAnalysis(&level)
+	t.Cleanup(func() { ConfigureAnalysis(nil); ConfigureCache(nil) })
+
+	store, err := cache.Open(t.TempDir(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ConfigureCache(store)
+
+	dir := t.TempDir()
+	content := []byte("<?php\nclass Invoice {}\n")
+	path := filepath.Join(dir, "Invoice.php")
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	broken := filepath.Join(dir, "Broken.php")
+	if err := os.WriteFile(broken, []byte("<?php\nclass {\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if project := buildProjectIndexForFiles([]string{path, broken}); !project.ClassExists("Invoice") {
+		t.Fatal("expected the parsed class to be indexed")
+	}
+	var entry indexCacheEntry
+	if !store.Load(indexCacheKind, cache.Key(content), &entry) || entry.Symbols == nil {
+		t.Fatal("expected the file symbols to be cached")
+	}
+
+	// A cached entry is used instead of parsing the file again.
+	entry.Symbols.Classes = append(entry.Symbols.Classes, analyse.FileClass{Class: analyse.ResolvedClass{Name: "FromCache", Kind: "class"}})
+	if err := store.Save(indexCacheKind, cache.Key(content), entry); err != nil {
+		t.Fatal(err)
+	}
+	project := buildProjectIndexForFiles([]string{path, broken})
+	if !project.ClassExists("Invoice") || !project.ClassExists("FromCache") {
+		t.Fatal("expected the index to be built from the cached entry")
+	}
+
+	// Changed content misses the cache.
+	if err := os.WriteFile(path, []byte("<?php\nclass Receipt {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	project = buildProjectIndexForFiles([]string{path, broken})
+	if !project.ClassExists("Receipt") || project.ClassExists("FromCache") {
+		t.Fatal("expected a changed file to be parsed again")
+	}
+}
+
+func TestCachedFileSymbolsKeepTheirDependencyShapes(t *testing.T) {
+	t.Cleanup(func() { ConfigureCache(nil) })
+	store, err := cache.Open(t.TempDir(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ConfigureCache(store)
+
+	path := filepath.Join(t.TempDir(), "Shapes.php")
+	content := []byte(`<?php
+interface Named {}
+class Invoice {
+    public $total;
+    public function __construct() {}
+    public function lines(): array { return []; }
+}
+enum Status {}
+function helper() {}
+`)
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	collected := mergeIndex([]indexedFile{indexFile(path, false)})
+	if _, _, ok := cachedFileSymbols(content); !ok {
+		t.Fatal("expected the file symbols to be cached")
+	}
+	loaded := mergeIndex([]indexedFile{indexFile(path, false)})
+	for _, dep := range []string{"class:Named", "class:Invoice", "class:Status", "function:helper", "duplicates:" + path} {
+		if collected.DependencyShape(dep) != loaded.DependencyShape(dep) {
+			t.Errorf("expected %s to keep its shape after a cache round trip", dep)
+		}
+	}
+}
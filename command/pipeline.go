@@ -0,0 +1,183 @@
+package command
+
+import (
+	"github.com/ayanozturk/go-php-parser/analyse"
+	"github.com/ayanozturk/go-php-parser/ast"
+	"github.com/ayanozturk/go-php-parser/lexer"
+	"github.com/ayanozturk/go-php-parser/parser"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Pipeline modes of ProcessStyleFilesParallelWithCallback.
+const (
+	// PipelineNoIndex checks files one by one; no analysis level is set, so
+	// no project index is needed.
+	PipelineNoIndex = "no-index"
+	// PipelineKeepASTs parses each file once and keeps the AST from the index
+	// phase until the file is analysed.
+	PipelineKeepASTs = "keep-asts"
+	// PipelineSkimIndex keeps only the declarations from the index phase and
+	// parses each file again, one at a time, for analysis.
+	PipelineSkimIndex = "skim-index"
+)
+
+// keepASTsMaxBytes is the largest corpus whose ASTs are kept between the
+// index and analysis phases. ASTs take roughly ten times the memory of the
+// source, so larger corpora are parsed twice instead.
+var keepASTsMaxBytes int64 = 64 << 20
+
+// PipelineStats describes the last run of ProcessStyleFilesParallelWithCallback.
+type PipelineStats struct {
+	Mode  string
+	Files int
+	// Parse is the time spent reading files and collecting their
+	// declarations, in parallel; Merge the time spent building the index.
+	Parse time.Duration
+	Merge time.Duration
+	// Analysis is the time spent analysing and style checking the files.
+	Analysis time.Duration
+	// CachedResults counts files whose result was reused from the cache.
+	CachedResults int
+}
+
+var (
+	lastPipelineStatsMu sync.Mutex
+	lastPipelineStats   *PipelineStats
+)
+
+// LastPipelineStats returns the phase timings of the last style run.
+func LastPipelineStats() (PipelineStats, bool) {
+	lastPipelineStatsMu.Lock()
+	defer lastPipelineStatsMu.Unlock()
+	if lastPipelineStats == nil {
+		return PipelineStats{}, false
+	}
+	return *lastPipelineStats, true
+}
+
+func setLastPipelineStats(stats PipelineStats) {
+	lastPipelineStatsMu.Lock()
+	defer lastPipelineStatsMu.Unlock()
+	lastPipelineStats = &stats
+}
+
+// choosePipelineMode keeps ASTs when the whole corpus comfortably fits in
+// memory.
+func choosePipelineMode(files []string) string {
+	var total int64
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			total += info.Size()
+		}
+		if total > keepASTsMaxBytes {
+			return PipelineSkimIndex
+		}
+	}
+	return PipelineKeepASTs
+}
+
+// indexedFile is the outcome of the index phase for one file.
+type indexedFile struct {
+	path    string
+	symbols *analyse.FileSymbols
+	// content, nodes and parseErrors are kept for analysis in
+	// PipelineKeepASTs mode. parsed is false when the declarations came from
+	// the cache, so the file still has to be parsed.
+	content     []byte
+	nodes       []ast.Node
+	parsed      bool
+	parseErrors int
+}
+
+// indexFiles reads the files and collects their declarations in parallel,
+// loading them from the cache when possible. With keep set, the contents and
+// ASTs are returned as well; otherwise nothing but the declarations is kept.
+// Files are parsed in full either way, so a file whose only errors are inside
+// function bodies is left out of the index in both modes. Results are in path
+// order.
+func indexFiles(files []string, keep bool, parallelism int) []indexedFile {
+	sorted := append([]string(nil), files...)
+	sort.Strings(sorted)
+	out := make([]indexedFile, len(sorted))
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	next := make(chan int, parallelism*2)
+	go func() {
+		for i := range sorted {
+			next <- i
+		}
+		close(next)
+	}()
+	var wg sync.WaitGroup
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				out[i] = indexFile(sorted[i], keep)
+			}
+		}()
+	}
+	wg.Wait()
+	return out
+}
+
+func indexFile(path string, keep bool) indexedFile {
+	file := indexedFile{path: path}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return file
+	}
+	if keep {
+		file.content = content
+	}
+	entry, key, ok := cachedFileSymbols(content)
+	if ok {
+		file.symbols = entry.Symbols
+		return file
+	}
+	p := parser.New(lexer.New(string(content)), false)
+	nodes := p.Parse()
+	entry = indexCacheEntry{ParseFailed: len(p.Errors()) > 0}
+	if !entry.ParseFailed {
+		entry.Symbols = analyse.CollectFileSymbols(path, nodes)
+	}
+	if key != "" {
+		storeFileSymbols(key, entry)
+	}
+	file.symbols = entry.Symbols
+	if keep {
+		file.nodes, file.parsed, file.parseErrors = nodes, true, len(p.Errors())
+	}
+	return file
+}
+
+// mergeIndex builds the project index from indexed files in path order, so
+// the first declaration of a class wins deterministically.
+func mergeIndex(files []indexedFile) *analyse.ProjectIndex {
+	project := analyse.NewProjectIndex()
+	for _, file := range files {
+		if file.symbols != nil {
+			project.AddFileSymbols(file.path, file.symbols)
+		}
+	}
+	if configuredCache != nil {
+		_ = configuredCache.Prune(indexCacheKind, cacheEntryMaxAge)
+	}
+	return project
+}
+
+// buildProjectIndexForFiles indexes files without keeping their ASTs. It
+// returns nil when no analysis level is configured.
+func buildProjectIndexForFiles(files []string) *analyse.ProjectIndex {
+	if configuredAnalysisLevel == nil {
+		return nil
+	}
+	return mergeIndex(indexFiles(files, false, runtime.NumCPU()))
+}
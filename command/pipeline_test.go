@@ -0,0 +1,90 @@
+package command
+
+import (
+	"github.com/ayanozturk/go-php-parser/cache"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPipelineModesReportTheSameIssues(t *testing.T) {
+	level := 0
+	ConfigureAnalysis(&level)
+	ConfigureCache(nil)
+	limit := keepASTsMaxBytes
+	t.Cleanup(func() { ConfigureAnalysis(nil); ConfigureCache(nil); keepASTsMaxBytes = limit })
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	files := []string{
+		write("lib.php", "<?php\n\nfunction greet(string $name): string\n{\n    return $name;\n}\n"),
+		write("caller.php", "<?php\n\nfunction run(): string\n{\n    return greet('a', 'b') . shout('a', 'b');\n}"),
+		write("broken.php", "<?php\nclass {\n"),
+		// Only the body is broken, so a parse that skips bodies would index
+		// shout and report the extra argument in caller.php.
+		write("broken_body.php", "<?php\n\nfunction shout(string $text): string\n{\n    return strtoupper($text) +;\n}\n"),
+	}
+	rules := []string{"PSR12.Files.EndFileNewline"}
+
+	run := func(want string) ([]string, int) {
+		t.Helper()
+		issues, _, parseErrors := ProcessStyleFilesParallel(files, rules, nil, 2)
+		stats, ok := LastPipelineStats()
+		if !ok || stats.Mode != want || stats.Files != len(files) {
+			t.Fatalf("expected %s stats for %d files, got %#v", want, len(files), stats)
+		}
+		var out []string
+		for _, issue := range issues {
+			out = append(out, filepath.Base(issue.Filename)+": "+issue.Code+": "+issue.Message)
+		}
+		return out, parseErrors
+	}
+
+	kept, keptErrors := run(PipelineKeepASTs)
+	if len(kept) < 2 || keptErrors == 0 {
+		t.Fatalf("expected analysis and style issues and a parse error, got %v (%d parse errors)", kept, keptErrors)
+	}
+	keepASTsMaxBytes = 0
+	skimmed, skimmedErrors := run(PipelineSkimIndex)
+	if !reflect.DeepEqual(kept, skimmed) || keptErrors != skimmedErrors {
+		t.Fatalf("expected both modes to agree:\nkeep-asts:  %v (%d)\nskim-index: %v (%d)", kept, keptErrors, skimmed, skimmedErrors)
+	}
+
+	// An index cache filled by one mode must give the other the same result.
+	store, err := cache.Open(t.TempDir(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ConfigureCache(store)
+	run(PipelineSkimIndex)
+	keepASTsMaxBytes = limit
+	cached, cachedErrors := run(PipelineKeepASTs)
+	if !reflect.DeepEqual(kept, cached) || keptErrors != cachedErrors {
+		t.Fatalf("expected a cache filled while skimming to keep results:\nuncached: %v (%d)\ncached:   %v (%d)", kept, keptErrors, cached, cachedErrors)
+	}
+}
+
+func TestChoosePipelineModeSkimsLargeCorpora(t *testing.T) {
+	limit := keepASTsMaxBytes
+	t.Cleanup(func() { keepASTsMaxBytes = limit })
+	path := filepath.Join(t.TempDir(), "a.php")
+	if err := os.WriteFile(path, []byte("<?php\necho 1;\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	keepASTsMaxBytes = 100
+	if mode := choosePipelineMode([]string{path}); mode != PipelineKeepASTs {
+		t.Fatalf("expected a small corpus to keep ASTs, got %s", mode)
+	}
+	keepASTsMaxBytes = 10
+	if mode := choosePipelineMode([]string{path}); mode != PipelineSkimIndex {
+		t.Fatalf("expected a large corpus to skim, got %s", mode)
+	}
+}
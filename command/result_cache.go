@@ -0,0 +1,86 @@
+package command
+
+import (
+	"fmt"
+	"github.com/ayanozturk/go-php-parser/analyse"
+	"github.com/ayanozturk/go-php-parser/cache"
+	"github.com/ayanozturk/go-php-parser/style"
+	"strings"
+	"sync"
+)
+
+const resultCacheKind = "results"
+
+// resultCacheEntry is the outcome of checking one file. It is reused while
+// the file is unchanged and every project symbol its analysis looked up
+// still resolves to the same shape.
+type resultCacheEntry struct {
+	Issues      []style.StyleIssue
+	ParseErrors int
+	// Dependencies maps each recorded dependency to its shape at the time.
+	Dependencies map[string]string
+}
+
+// dependencyShapes memoises analyse.ProjectIndex.DependencyShape for a run;
+// most dependencies are shared by many files.
+type dependencyShapes struct {
+	project *analyse.ProjectIndex
+	memo    sync.Map
+}
+
+func (s *dependencyShapes) shape(dep string) string {
+	if shape, ok := s.memo.Load(dep); ok {
+		return shape.(string)
+	}
+	shape := s.project.DependencyShape(dep)
+	s.memo.Store(dep, shape)
+	return shape
+}
+
+// resultCacheKey covers everything a file's result depends on besides the
+// project symbols: its path, its content and the rule selection.
+func resultCacheKey(path string, content []byte, rules []string) string {
+	level := "none"
+	if configuredAnalysisLevel != nil {
+		level = fmt.Sprint(*configuredAnalysisLevel)
+	}
+	return cache.Key([]byte(path), content, []byte(strings.Join(rules, "\n")), []byte(level), []byte(configuredAutoloadFingerprint))
+}
+
+// loadCachedResult returns the stored result of a file when none of its
+// dependencies changed shape.
+func loadCachedResult(key string, shapes *dependencyShapes) (parseAnalysisResult, bool) {
+	if configuredCache == nil {
+		return parseAnalysisResult{}, false
+	}
+	var entry resultCacheEntry
+	if !configuredCache.Load(resultCacheKind, key, &entry) {
+		return parseAnalysisResult{}, false
+	}
+	if len(entry.Dependencies) > 0 && shapes == nil {
+		return parseAnalysisResult{}, false
+	}
+	for dep, shape := range entry.Dependencies {
+		if shapes.shape(dep) != shape {
+			return parseAnalysisResult{}, false
+		}
+	}
+	return parseAnalysisResult{issues: entry.Issues, errors: entry.ParseErrors}, true
+}
+
+func saveCachedResult(key string, res parseAnalysisResult, shapes *dependencyShapes) {
+	if configuredCache == nil || !res.complete {
+		return
+	}
+	entry := resultCacheEntry{Issues: res.issues, ParseErrors: res.errors}
+	if len(res.deps) > 0 {
+		if shapes == nil {
+			return
+		}
+		entry.Dependencies = make(map[string]string, len(res.deps))
+		for _, dep := range res.deps {
+			entry.Dependencies[dep] = shapes.shape(dep)
+		}
+	}
+	_ = configuredCache.Save(resultCacheKind, key, entry)
+}
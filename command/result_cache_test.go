@@ -0,0 +1,74 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ayanozturk/go-php-parser/cache"
+	"github.com/ayanozturk/go-php-parser/style"
+)
+
+func TestStyleFilesReuseResultsUntilDependenciesChange(t *testing.T) {
+	level := 0
+	ConfigureAnalysis(&level)
+	store, err := cache.Open(t.TempDir(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ConfigureCache(store)
+	t.Cleanup(func() { ConfigureAnalysis(nil); ConfigureCache(nil) })
+
+	dir := t.TempDir()
+	lib := filepath.Join(dir, "lib.php")
+	caller := filepath.Join(dir, "caller.php")
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(lib, "<?php\n\nfunction greet(string $name): string\n{\n    return $name;\n}\n")
+	callerSource := "<?php\n\nfunction run(): string\n{\n    return greet('a', 'b');\n}\n"
+	write(caller, callerSource)
+
+	files := []string{lib, caller}
+	rules := []string{"PSR12.Files.EndFileNewline"}
+	countIssues := func(issues []style.StyleIssue, message string) int {
+		n := 0
+		for _, issue := range issues {
+			if issue.Filename == caller && strings.Contains(issue.Message, message) {
+				n++
+			}
+		}
+		return n
+	}
+
+	issues, _, _ := ProcessStyleFilesParallel(files, rules, nil, 2)
+	if countIssues(issues, "invoked with 2 parameters") != 1 {
+		t.Fatalf("expected an argument count issue, got %#v", issues)
+	}
+
+	// An unchanged file with unchanged dependencies reuses its stored result.
+	key := resultCacheKey(caller, []byte(callerSource), rules)
+	var entry resultCacheEntry
+	if !store.Load(resultCacheKind, key, &entry) || len(entry.Dependencies) == 0 {
+		t.Fatalf("expected the result and its dependencies to be cached, got %#v", entry)
+	}
+	entry.Issues = append(entry.Issues, style.StyleIssue{Filename: caller, Message: "stored issue"})
+	if err := store.Save(resultCacheKind, key, entry); err != nil {
+		t.Fatal(err)
+	}
+	issues, _, _ = ProcessStyleFilesParallel(files, rules, nil, 2)
+	if countIssues(issues, "stored issue") != 1 {
+		t.Fatalf("expected the stored result to be reused, got %#v", issues)
+	}
+
+	// Changing the signature the caller depends on re-analyses the caller.
+	write(lib, "<?php\n\nfunction greet(string $name, string $greeting = 'hi'): string\n{\n    return $greeting . $name;\n}\n")
+	issues, _, _ = ProcessStyleFilesParallel(files, rules, nil, 2)
+	if countIssues(issues, "stored issue") != 0 || countIssues(issues, "invoked with 2 parameters") != 0 {
+		t.Fatalf("expected the caller to be analysed again, got %#v", issues)
+	}
+}
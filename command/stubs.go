@@ -0,0 +1,77 @@
+package command
+
+import (
+	"fmt"
+	"github.com/ayanozturk/go-php-parser/analyse"
+	"github.com/ayanozturk/go-php-parser/ast"
+	"github.com/ayanozturk/go-php-parser/lexer"
+	"github.com/ayanozturk/go-php-parser/parser"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConfigureBuiltins selects the builtin extensions analysis knows about and
+// adds the declarations of the configured stub files. Parse errors in a stub
+// and extensions missing from the builtin database are reported to w; the
+// declarations parsed around the errors are still used.
+func ConfigureBuiltins(stubPaths []string, extensions map[string]bool, w io.Writer) error {
+	stubs, err := LoadStubs(stubPaths, w)
+	if err != nil {
+		return err
+	}
+	for _, name := range analyse.ConfigureBuiltins(analyse.BuiltinOptions{Extensions: extensions, Stubs: stubs}) {
+		fmt.Fprintf(w, "Warning: unknown PHP extension %q in php_extensions is ignored\n", name)
+	}
+	return nil
+}
+
+// LoadStubs parses the given PHP files and every .php file below the given
+// directories, skipping function bodies.
+func LoadStubs(paths []string, w io.Writer) (map[string][]ast.Node, error) {
+	stubs := make(map[string][]ast.Node)
+	for _, root := range paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, fmt.Errorf("stub %s: %w", root, err)
+		}
+		if !info.IsDir() {
+			if err := loadStub(root, stubs, w); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".php") {
+				return nil
+			}
+			return loadStub(path, stubs, w)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("stub %s: %w", root, err)
+		}
+	}
+	return stubs, nil
+}
+
+func loadStub(path string, stubs map[string][]ast.Node, w io.Writer) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("stub %s: %w", path, err)
+	}
+	p := parser.New(lexer.New(string(content)), false)
+	p.SkipFunctionBodies = true
+	stubs[path] = p.Parse()
+	if errs := p.Errors(); len(errs) > 0 {
+		fmt.Fprintf(w, "Parsing errors in stub %s (%d error(s)):\n", path, len(errs))
+		for _, err := range errs {
+			fmt.Fprintf(w, ErrorLineFormat, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadStubs(t *testing.T) {
+	dir := t.TempDir()
+	writeStub := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	writeStub("ext/redis.php", "<?php\nclass Redis { public function get($key) { return $this->{$key}; } }\n")
+	writeStub("ext/notes.txt", "not php")
+	broken := writeStub("ext/nested/broken.php", "<?php\nfunction broken( {}\n")
+	single := writeStub("generated.stub", "<?php\nfunction generated_helper(): string {}\n")
+
+	var out bytes.Buffer
+	stubs, err := LoadStubs([]string{filepath.Join(dir, "ext"), single}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stubs) != 3 {
+		t.Fatalf("expected 3 stub files, got %d: %v", len(stubs), stubs)
+	}
+	if _, ok := stubs[single]; !ok {
+		t.Fatalf("expected an explicitly listed file to be loaded regardless of extension")
+	}
+	if !strings.Contains(out.String(), "Parsing errors in stub "+broken) {
+		t.Fatalf("expected parse errors of %s to be reported, got %q", broken, out.String())
+	}
+
+	if _, err := LoadStubs([]string{filepath.Join(dir, "missing")}, &out); err == nil {
+		t.Fatal("expected a missing stub path to be an error")
+	}
+}
+
+func TestConfigureBuiltinsWarnsAboutUnknownExtensions(t *testing.T) {
+	t.Cleanup(func() { _ = ConfigureBuiltins(nil, nil, &bytes.Buffer{}) })
+
+	var out bytes.Buffer
+	if err := ConfigureBuiltins(nil, map[string]bool{"redis": true, "ctype": false}, &out); err != nil {
+		t.Fatalf("expected an unknown extension not to fail, got %v", err)
+	}
+	if !strings.Contains(out.String(), `Warning: unknown PHP extension "redis" in php_extensions is ignored`) {
+		t.Fatalf("expected a warning about redis, got %q", out.String())
+	}
+	if strings.Contains(out.String(), "ctype") {
+		t.Fatalf("expected no warning about a known extension, got %q", out.String())
+	}
+}
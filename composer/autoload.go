@@ -0,0 +1,339 @@
+// Package composer locates the files that declare classes of a Composer
+// project. It reads composer.json and the autoload maps Composer dumps into
+// vendor/composer, falling back to vendor/composer/installed.json when the
+// maps have not been dumped.
+package composer
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+	"github.com/ayanozturk/go-php-parser/lexer"
+	"github.com/ayanozturk/go-php-parser/parser"
+)
+
+// Autoloader maps class names to files like Composer's ClassLoader, without
+// running PHP.
+type Autoloader struct {
+	// Root is the directory that holds composer.json.
+	Root string
+	// VendorDir is the absolute vendor directory.
+	VendorDir string
+
+	classMap    map[string]string
+	psr4        []psr4Prefix
+	projectPSR4 []PSR4Mapping
+}
+
+// PSR4Mapping is one PSR-4 namespace prefix and the directories it maps to.
+type PSR4Mapping struct {
+	// Prefix is the namespace prefix with its trailing backslash, as written.
+	Prefix string
+	// Dirs are absolute directories.
+	Dirs []string
+}
+
+type psr4Prefix struct {
+	prefix string
+	dirs   []string
+}
+
+type manifest struct {
+	Autoload    autoloadSection `json:"autoload"`
+	AutoloadDev autoloadSection `json:"autoload-dev"`
+	Config      struct {
+		VendorDir string `json:"vendor-dir"`
+	} `json:"config"`
+}
+
+type autoloadSection struct {
+	PSR4 map[string]pathList `json:"psr-4"`
+}
+
+type installedPackage struct {
+	Name        string          `json:"name"`
+	InstallPath string          `json:"install-path"`
+	Autoload    autoloadSection `json:"autoload"`
+}
+
+// pathList accepts the string or list-of-strings forms composer.json allows.
+type pathList []string
+
+func (p *pathList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*p = pathList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*p = list
+	return nil
+}
+
+// FindRoot returns the nearest directory at or above dir that contains a
+// composer.json.
+func FindRoot(dir string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	for {
+		if info, err := os.Stat(filepath.Join(dir, "composer.json")); err == nil && !info.IsDir() {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// Load reads the autoload configuration of the project rooted at root.
+func Load(root string) (*Autoloader, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(root, "composer.json"))
+	if err != nil {
+		return nil, err
+	}
+	var project manifest
+	if err := json.Unmarshal(data, &project); err != nil {
+		return nil, err
+	}
+	vendorDir := project.Config.VendorDir
+	if vendorDir == "" {
+		vendorDir = "vendor"
+	}
+	if !filepath.IsAbs(vendorDir) {
+		vendorDir = filepath.Join(root, vendorDir)
+	}
+
+	a := &Autoloader{Root: root, VendorDir: vendorDir, classMap: make(map[string]string)}
+	prefixes := make(map[string][]string)
+	for _, section := range []autoloadSection{project.Autoload, project.AutoloadDev} {
+		addPSR4(prefixes, section.PSR4, root)
+	}
+	for prefix, dirs := range prefixes {
+		a.projectPSR4 = append(a.projectPSR4, PSR4Mapping{Prefix: prefix, Dirs: append([]string(nil), dirs...)})
+	}
+	sort.Slice(a.projectPSR4, func(i, j int) bool { return a.projectPSR4[i].Prefix < a.projectPSR4[j].Prefix })
+
+	composerDir := filepath.Join(vendorDir, "composer")
+	dumped, err := readAutoloadMap(filepath.Join(composerDir, "autoload_psr4.php"), vendorDir, root)
+	switch {
+	case err == nil:
+		for prefix, dirs := range dumped {
+			prefixes[prefix] = appendMissing(prefixes[prefix], dirs...)
+		}
+	case errors.Is(err, os.ErrNotExist):
+		if err := addInstalledPackages(prefixes, composerDir, vendorDir); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	classMap, err := readAutoloadMap(filepath.Join(composerDir, "autoload_classmap.php"), vendorDir, root)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for class, files := range classMap {
+		if len(files) > 0 {
+			a.classMap[strings.ToLower(strings.TrimPrefix(class, `\`))] = files[0]
+		}
+	}
+
+	for prefix, dirs := range prefixes {
+		a.psr4 = append(a.psr4, psr4Prefix{prefix: strings.ToLower(prefix), dirs: dirs})
+	}
+	sort.Slice(a.psr4, func(i, j int) bool {
+		if len(a.psr4[i].prefix) != len(a.psr4[j].prefix) {
+			return len(a.psr4[i].prefix) > len(a.psr4[j].prefix)
+		}
+		return a.psr4[i].prefix < a.psr4[j].prefix
+	})
+	return a, nil
+}
+
+// LocateClass returns the file that should declare the class, checking the
+// class map first and then the PSR-4 prefixes from the longest down. Only
+// files that exist are returned.
+func (a *Autoloader) LocateClass(name string) (string, bool) {
+	name = strings.TrimPrefix(strings.TrimSpace(name), `\`)
+	if name == "" {
+		return "", false
+	}
+	key := strings.ToLower(name)
+	if path, ok := a.classMap[key]; ok {
+		return path, true
+	}
+	for _, entry := range a.psr4 {
+		if !strings.HasPrefix(key, entry.prefix) {
+			continue
+		}
+		relative := strings.ReplaceAll(name[len(entry.prefix):], `\`, string(filepath.Separator)) + ".php"
+		for _, dir := range entry.dirs {
+			path := filepath.Join(dir, relative)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ProjectPSR4 returns the PSR-4 mappings of the autoload and autoload-dev
+// sections of composer.json, ordered by prefix.
+func (a *Autoloader) ProjectPSR4() []PSR4Mapping {
+	return a.projectPSR4
+}
+
+func addPSR4(prefixes map[string][]string, entries map[string]pathList, base string) {
+	for prefix, dirs := range entries {
+		for _, dir := range dirs {
+			if !filepath.IsAbs(dir) {
+				dir = filepath.Join(base, dir)
+			}
+			prefixes[prefix] = appendMissing(prefixes[prefix], filepath.Clean(dir))
+		}
+	}
+}
+
+func appendMissing(list []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, existing := range list {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// addInstalledPackages reads the PSR-4 prefixes of every installed package.
+// Composer 2 wraps the list in {"packages": [...]}; Composer 1 writes the
+// bare list.
+func addInstalledPackages(prefixes map[string][]string, composerDir, vendorDir string) error {
+	data, err := os.ReadFile(filepath.Join(composerDir, "installed.json"))
+	if err != nil {
+		return err
+	}
+	var packages []installedPackage
+	var wrapped struct {
+		Packages []installedPackage `json:"packages"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err == nil {
+		packages = wrapped.Packages
+	} else if err := json.Unmarshal(data, &packages); err != nil {
+		return err
+	}
+	for _, pkg := range packages {
+		installPath := filepath.Join(vendorDir, filepath.FromSlash(pkg.Name))
+		if pkg.InstallPath != "" {
+			installPath = filepath.Join(composerDir, filepath.FromSlash(pkg.InstallPath))
+		}
+		addPSR4(prefixes, pkg.Autoload.PSR4, installPath)
+	}
+	return nil
+}
+
+// readAutoloadMap evaluates one of the PHP files Composer dumps into
+// vendor/composer. They assign $vendorDir and $baseDir and return an array
+// whose values are path expressions or lists of them.
+func readAutoloadMap(path, vendorDir, baseDir string) (map[string][]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := parser.New(lexer.New(string(content)), false)
+	nodes := p.Parse()
+	if errs := p.Errors(); len(errs) > 0 {
+		return nil, errors.New(path + ": " + errs[0])
+	}
+	vars := map[string]string{"vendorDir": vendorDir, "baseDir": baseDir}
+	out := make(map[string][]string)
+	for _, node := range nodes {
+		ret, ok := node.(*ast.ReturnNode)
+		if !ok {
+			continue
+		}
+		list, ok := ret.Expr.(*ast.ArrayNode)
+		if !ok {
+			continue
+		}
+		for _, element := range list.Elements {
+			item, ok := element.(*ast.ArrayItemNode)
+			if !ok {
+				continue
+			}
+			key, ok := evalPath(item.Key, vars)
+			if !ok {
+				continue
+			}
+			if values, ok := item.Value.(*ast.ArrayNode); ok {
+				for _, value := range values.Elements {
+					if inner, ok := value.(*ast.ArrayItemNode); ok {
+						value = inner.Value
+					}
+					if dir, ok := evalPath(value, vars); ok {
+						out[key] = append(out[key], filepath.Clean(filepath.FromSlash(dir)))
+					}
+				}
+				continue
+			}
+			if file, ok := evalPath(item.Value, vars); ok {
+				out[key] = append(out[key], filepath.Clean(filepath.FromSlash(file)))
+			}
+		}
+	}
+	return out, nil
+}
+
+func evalPath(node ast.Node, vars map[string]string) (string, bool) {
+	switch n := node.(type) {
+	case *ast.StringLiteral:
+		return n.Value, true
+	case *ast.StringNode:
+		return n.Value, true
+	case *ast.VariableNode:
+		value, ok := vars[strings.TrimPrefix(n.Name, "$")]
+		return value, ok
+	case *ast.BinaryExpr:
+		if n.Operator != "." {
+			return "", false
+		}
+		left, ok := evalPath(n.Left, vars)
+		if !ok {
+			return "", false
+		}
+		right, ok := evalPath(n.Right, vars)
+		return left + right, ok
+	case *ast.ConcatNode:
+		var b strings.Builder
+		for _, part := range n.Parts {
+			value, ok := evalPath(part, vars)
+			if !ok {
+				return "", false
+			}
+			b.WriteString(value)
+		}
+		return b.String(), true
+	}
+	return "", false
+}
@@ -0,0 +1,99 @@
+package composer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeProjectFile(t *testing.T, root, name, content string) string {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadReadsDumpedAutoloadMaps(t *testing.T) {
+	root := t.TempDir()
+	writeProjectFile(t, root, "composer.json", `{"autoload": {"psr-4": {"App\\": "src/"}}, "autoload-dev": {"psr-4": {"App\\Tests\\": ["tests/"]}}}`)
+	writeProjectFile(t, root, "vendor/composer/autoload_psr4.php", `<?php
+
+// autoload_psr4.php @generated by Composer
+
+$vendorDir = dirname(__DIR__);
+$baseDir = dirname($vendorDir);
+
+return array(
+    'Symfony\\Component\\Console\\' => array($vendorDir . '/symfony/console'),
+    'App\\' => array($baseDir . '/src'),
+);
+`)
+	writeProjectFile(t, root, "vendor/composer/autoload_classmap.php", `<?php
+
+$vendorDir = dirname(__DIR__);
+$baseDir = dirname($vendorDir);
+
+return array(
+    'Legacy_Helper' => $vendorDir . '/legacy/lib/Helper.php',
+);
+`)
+	command := writeProjectFile(t, root, "vendor/symfony/console/Command/Command.php", "<?php\n")
+	helper := writeProjectFile(t, root, "vendor/legacy/lib/Helper.php", "<?php\n")
+	service := writeProjectFile(t, root, "src/Service/Mailer.php", "<?php\n")
+	test := writeProjectFile(t, root, "tests/MailerTest.php", "<?php\n")
+
+	found, ok := FindRoot(filepath.Join(root, "src", "Service"))
+	if !ok || found != root {
+		t.Fatalf("expected root %s, got %q (%v)", root, found, ok)
+	}
+	a, err := Load(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for class, want := range map[string]string{
+		`Symfony\Component\Console\Command\Command`: command,
+		`\legacy_helper`:       helper,
+		`App\Service\Mailer`:   service,
+		`App\Tests\MailerTest`: test,
+	} {
+		if got, ok := a.LocateClass(class); !ok || got != want {
+			t.Errorf("LocateClass(%s) = %q, %v; want %q", class, got, ok, want)
+		}
+	}
+	project := a.ProjectPSR4()
+	if len(project) != 2 || project[0].Prefix != `App\` || project[1].Prefix != `App\Tests\` || project[1].Dirs[0] != filepath.Join(root, "tests") {
+		t.Errorf("expected the composer.json mappings only, got %#v", project)
+	}
+	if path, ok := a.LocateClass(`App\Service\Missing`); ok {
+		t.Errorf("expected a class without a file to be unresolved, got %q", path)
+	}
+}
+
+func TestLoadFallsBackToInstalledPackages(t *testing.T) {
+	root := t.TempDir()
+	writeProjectFile(t, root, "composer.json", `{"config": {"vendor-dir": "lib"}}`)
+	writeProjectFile(t, root, "lib/composer/installed.json", `{"packages": [
+		{"name": "acme/log", "install-path": "../acme/log", "autoload": {"psr-4": {"Acme\\Log\\": "src"}}},
+		{"name": "acme/http", "autoload": {"psr-4": {"Acme\\Http\\": ["src/", "compat/"]}}}
+	]}`)
+	logger := writeProjectFile(t, root, "lib/acme/log/src/Logger.php", "<?php\n")
+	client := writeProjectFile(t, root, "lib/acme/http/compat/Client.php", "<?php\n")
+
+	a, err := Load(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.VendorDir != filepath.Join(root, "lib") {
+		t.Fatalf("expected configured vendor dir, got %s", a.VendorDir)
+	}
+	for class, want := range map[string]string{`Acme\Log\Logger`: logger, `Acme\Http\Client`: client} {
+		if got, ok := a.LocateClass(class); !ok || got != want {
+			t.Errorf("LocateClass(%s) = %q, %v; want %q", class, got, ok, want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
@@ -13,6 +15,10 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+// DefaultCacheDir is where analysis data is kept between runs when cache_dir
+// is not set.
+const DefaultCacheDir = ".go-phpcs-cache"
+
 var DefaultConfigFilenames = []string{
 	"go-phpcs.yaml",
 	"go-phpcs.yml",
@@ -25,6 +31,9 @@ type Config struct {
 	Ignore        []string                `yaml:"ignore"`
 	Rules         []string                `yaml:"rules"`
 	AnalysisLevel *int                    `yaml:"analysis_level"`
+	Stubs         []string                `yaml:"stubs"`
+	PHPExtensions map[string]bool         `yaml:"php_extensions"`
+	CacheDir      string                  `yaml:"cache_dir"`
 	Overrides     overrides.RuleOverrides `yaml:"overrides"`
 }
 
@@ -77,9 +86,31 @@ func PrintEffectiveConfig(w io.Writer, cfg *Config, source string) {
 	} else {
 		fmt.Fprintf(w, "analysis_level: %d\n", *cfg.AnalysisLevel)
 	}
+	writeStringList(w, "stubs", cfg.Stubs)
+	writeExtensionSwitches(w, cfg.PHPExtensions)
+	fmt.Fprintf(w, "cache_dir: %s\n", quoteYAMLString(cfg.EffectiveCacheDir()))
 	writeOverrides(w, cfg.Overrides)
 }
 
+// EffectiveCacheDir returns the configured cache directory or the default.
+func (c *Config) EffectiveCacheDir() string {
+	if c.CacheDir == "" {
+		return DefaultCacheDir
+	}
+	return c.CacheDir
+}
+
+// Fingerprint identifies the configuration. Cached analysis data is only
+// reused by runs with the same fingerprint.
+func (c *Config) Fingerprint() string {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
 func writeStringList(w io.Writer, name string, values []string) {
 	if len(values) == 0 {
 		fmt.Fprintf(w, "%s: []\n", name)
@@ -91,6 +122,24 @@ func writeStringList(w io.Writer, name string, values []string) {
 	}
 }
 
+func writeExtensionSwitches(w io.Writer, extensions map[string]bool) {
+	if len(extensions) == 0 {
+		fmt.Fprintln(w, "php_extensions: {}")
+		return
+	}
+
+	names := make([]string, 0, len(extensions))
+	for name := range extensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "php_extensions:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s: %t\n", quoteYAMLString(name), extensions[name])
+	}
+}
+
 func writeOverrides(w io.Writer, ruleOverrides overrides.RuleOverrides) {
 	if len(ruleOverrides) == 0 {
 		fmt.Fprintln(w, "overrides: {}")
@@ -125,6 +174,34 @@ func quoteYAMLString(value string) string {
 	return strconv.Quote(value)
 }
 
+// stubSet holds the absolute paths of configured stub files and directories.
+type stubSet map[string]struct{}
+
+// stubPaths collects the configured stubs. They only provide declarations
+// for analysis, so the file walkers skip them even when they are inside the
+// scanned path.
+func stubPaths(config *Config) stubSet {
+	stubs := make(stubSet, len(config.Stubs))
+	for _, stub := range config.Stubs {
+		if abs, err := filepath.Abs(stub); err == nil {
+			stubs[abs] = struct{}{}
+		}
+	}
+	return stubs
+}
+
+func (s stubSet) contains(path string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	_, ok := s[abs]
+	return ok
+}
+
 func GetFilesToScan(config *Config) ([]string, error) {
 	var filesToScan []string
 	ignoreDirs := make(map[string]struct{}, len(config.Ignore))
@@ -135,6 +212,7 @@ func GetFilesToScan(config *Config) ([]string, error) {
 	for _, ext := range config.Extensions {
 		allowedExts["."+ext] = struct{}{}
 	}
+	stubs := stubPaths(config)
 
 	err := filepath.WalkDir(config.Path, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
@@ -143,11 +221,14 @@ func GetFilesToScan(config *Config) ([]string, error) {
 
 		// Skip ignored directories
 		if d.IsDir() {
-			if _, ignored := ignoreDirs[d.Name()]; ignored {
+			if _, ignored := ignoreDirs[d.Name()]; ignored || stubs.contains(path) {
 				return filepath.SkipDir
 			}
 			return nil
 		}
+		if stubs.contains(path) {
+			return nil
+		}
 
 		// Check file extensions
 		if _, allowed := allowedExts[filepath.Ext(path)]; allowed {
@@ -173,6 +254,7 @@ func StreamFilesToScan(config *Config) <-chan string {
 	for _, ext := range config.Extensions {
 		allowedExts["."+ext] = struct{}{}
 	}
+	stubs := stubPaths(config)
 
 	ch := make(chan string, 256)
 	go func() {
@@ -182,11 +264,14 @@ func StreamFilesToScan(config *Config) <-chan string {
 				return nil // skip unreadable entries
 			}
 			if d.IsDir() {
-				if _, ignored := ignoreDirs[d.Name()]; ignored {
+				if _, ignored := ignoreDirs[d.Name()]; ignored || stubs.contains(path) {
 					return filepath.SkipDir
 				}
 				return nil
 			}
+			if stubs.contains(path) {
+				return nil
+			}
 			if _, allowed := allowedExts[filepath.Ext(path)]; allowed {
 				ch <- path
 			}
@@ -24,6 +24,12 @@ extensions:
 ignore:
   - vendor
   - testdata
+stubs:
+  - stubs
+php_extensions:
+  redis: true
+  curl: false
+cache_dir: /tmp/phpcs-cache
 overrides:
   PSR1.Classes.ClassDeclaration.PascalCase:
     classes:
@@ -40,9 +46,12 @@ overrides:
 	}
 
 	expected := &Config{
-		Path:       "./testdata",
-		Extensions: []string{"php", "inc"},
-		Ignore:     []string{"vendor", "testdata"},
+		Path:          "./testdata",
+		Extensions:    []string{"php", "inc"},
+		Ignore:        []string{"vendor", "testdata"},
+		Stubs:         []string{"stubs"},
+		PHPExtensions: map[string]bool{"redis": true, "curl": false},
+		CacheDir:      "/tmp/phpcs-cache",
 		Overrides: map[string]overrides.RuleOverride{
 			"PSR1.Classes.ClassDeclaration.PascalCase": {
 				Classes: []string{"/Legacy_.*/"},
@@ -128,6 +137,9 @@ func TestPrintEffectiveConfig(t *testing.T) {
 		Ignore:        []string{"vendor"},
 		Rules:         []string{"PSR12.Files.EndFileNewline"},
 		AnalysisLevel: &level,
+		Stubs:         []string{"stubs/redis.php", "stubs/generated"},
+		PHPExtensions: map[string]bool{"redis": true, "curl": false},
+		CacheDir:      "build/phpcs-cache",
 		Overrides: overrides.RuleOverrides{
 			"Z.Rule": {Classes: []string{"LegacyZ"}},
 			"A.Rule": {Classes: []string{"/LegacyA.*/"}},
@@ -147,6 +159,13 @@ ignore:
 rules:
   - "PSR12.Files.EndFileNewline"
 analysis_level: 0
+stubs:
+  - "stubs/redis.php"
+  - "stubs/generated"
+php_extensions:
+  "curl": false
+  "redis": true
+cache_dir: "build/phpcs-cache"
 overrides:
   "A.Rule":
     classes:
@@ -172,6 +191,9 @@ extensions: []
 ignore: []
 rules: []
 analysis_level: null
+stubs: []
+php_extensions: {}
+cache_dir: ".go-phpcs-cache"
 overrides: {}
 `
 	if got := buf.String(); got != want {
@@ -179,6 +201,19 @@ overrides: {}
 	}
 }
 
+func TestConfigFingerprint(t *testing.T) {
+	level := 1
+	base := &Config{Path: "src", Rules: []string{"PSR12.Files.EndFileNewline"}, AnalysisLevel: &level}
+	same := &Config{Path: "src", Rules: []string{"PSR12.Files.EndFileNewline"}, AnalysisLevel: &level}
+	if base.Fingerprint() != same.Fingerprint() {
+		t.Fatal("expected equal configs to have the same fingerprint")
+	}
+	changed := &Config{Path: "src", Rules: []string{"PSR12.Files.EndFileNewline", "PSR1.Files.SideEffects"}, AnalysisLevel: &level}
+	if base.Fingerprint() == changed.Fingerprint() {
+		t.Fatal("expected a changed rule set to change the fingerprint")
+	}
+}
+
 func TestGetFilesToScan(t *testing.T) {
 	dir, err := os.MkdirTemp("", "scanroot-")
 	if err != nil {
@@ -216,6 +251,42 @@ func TestGetFilesToScan(t *testing.T) {
 	}
 }
 
+func TestGetFilesToScanSkipsStubs(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "stubs", "generated"), 0755)
+	os.Mkdir(filepath.Join(dir, "src"), 0755)
+	source := filepath.Join(dir, "src", "a.php")
+	for _, f := range []string{
+		source,
+		filepath.Join(dir, "stubs", "generated", "b.php"),
+		filepath.Join(dir, "src", "redis.php"),
+	} {
+		os.WriteFile(f, []byte("<?php\n"), 0644)
+	}
+
+	cfg := &Config{
+		Path:       dir,
+		Extensions: []string{"php"},
+		Stubs:      []string{filepath.Join(dir, "stubs"), filepath.Join(dir, "src", "..", "src", "redis.php")},
+	}
+
+	scanned, err := GetFilesToScan(cfg)
+	if err != nil {
+		t.Fatalf("GetFilesToScan failed: %v", err)
+	}
+	if want := []string{source}; !reflect.DeepEqual(scanned, want) {
+		t.Errorf("unexpected files to scan: got %v, want %v", scanned, want)
+	}
+
+	var streamed []string
+	for path := range StreamFilesToScan(cfg) {
+		streamed = append(streamed, path)
+	}
+	if want := []string{source}; !reflect.DeepEqual(streamed, want) {
+		t.Errorf("unexpected streamed files: got %v, want %v", streamed, want)
+	}
+}
+
 func sorted(s []string) []string {
 	copyS := append([]string{}, s...)
 	if len(copyS) > 1 {
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"flag"
 	"fmt"
+	"github.com/ayanozturk/go-php-parser/cache"
 	"github.com/ayanozturk/go-php-parser/command"
 	"github.com/ayanozturk/go-php-parser/config"
 	"github.com/ayanozturk/go-php-parser/overrides"
@@ -16,6 +17,7 @@ import (
 	"runtime"
 	"runtime/pprof"
 	"sort"
+	"time"
 )
 
 type CliArgs struct {
@@ -29,6 +31,7 @@ type CliArgs struct {
 	filePath        string
 	Fix             bool
 	PprofAddr       string
+	NoCache         bool
 }
 
 func ParseCLIArgs(filesToScan []string) CliArgs {
@@ -40,6 +43,7 @@ func ParseCLIArgs(filesToScan []string) CliArgs {
 	parallelism := flag.Int("p", 0, "Number of files to process in parallel (0=auto: NumCPU)")
 	fix := flag.Bool("fix", false, "Automatically fix fixable style issues")
 	pprofAddr := flag.String("pprof", "", "Start pprof HTTP server on addr (e.g. localhost:6060)")
+	noCache := flag.Bool("no-cache", false, "Do not read or write the analysis cache (cache_dir)")
 	flag.Parse()
 
 	if *pprofAddr != "" {
@@ -75,6 +79,7 @@ func ParseCLIArgs(filesToScan []string) CliArgs {
 		filePath:  filePath,
 		Fix:       *fix,
 		PprofAddr: *pprofAddr,
+		NoCache:   *noCache,
 	}
 }
 
@@ -153,6 +158,25 @@ func RunScanOrCommand(args CliArgs, c *config.Config, filesToScan []string, outW
 		log.Fatalf("Error compiling overrides: %v", err)
 	}
 	command.ConfigureAnalysis(c.AnalysisLevel)
+	if err := command.ConfigureBuiltins(c.Stubs, c.PHPExtensions, outWriter); err != nil {
+		log.Fatalf("Error configuring builtin symbols: %v", err)
+	}
+	autoloadPath := c.Path
+	if args.filePath != "" {
+		autoloadPath = args.filePath
+	}
+	if err := command.ConfigureAutoload(autoloadPath); err != nil {
+		fmt.Fprintf(outWriter, "Warning: vendor classes are not autoloaded: %v\n", err)
+	}
+	command.ConfigureCache(nil)
+	if !args.NoCache {
+		store, err := cache.Open(c.EffectiveCacheDir(), c.Fingerprint())
+		if err != nil {
+			fmt.Fprintf(outWriter, "Warning: analysis cache disabled: %v\n", err)
+		} else {
+			command.ConfigureCache(store)
+		}
+	}
 	if args.filePath != "" {
 		errList, lineCount := command.ProcessFileWithErrors(args.filePath, args.CommandName, args.debug, c.Rules, matcher, outWriter)
 		totalParseErrors = len(errList)
@@ -236,6 +260,10 @@ func PrintSummary(w io.Writer, totalParseErrors, totalLines int, elapsed float64
 		fmt.Fprintf(w, "Lines per second: N/A (too fast to measure)\n")
 	}
 	fmt.Fprintf(w, "Total parsing errors: \033[31;1m%d\033[0m\n", totalParseErrors)
+	if stats, ok := command.LastPipelineStats(); ok {
+		fmt.Fprintf(w, "Pipeline: \033[32;1m%s\033[0m (parse+index %s, merge %s, analysis %s; %d/%d results cached)\n",
+			stats.Mode, stats.Parse.Round(time.Millisecond), stats.Merge.Round(time.Millisecond), stats.Analysis.Round(time.Millisecond), stats.CachedResults, stats.Files)
+	}
 	fmt.Fprintf(w, "HeapAlloc: \033[35m%.2f MB\033[0m\n", float64(mem.End.HeapAlloc)/(1024*1024))
 	fmt.Fprintf(w, "Sys: \033[35m%.2f MB\033[0m\n", float64(mem.End.Sys)/(1024*1024))
 }
@@ -3,7 +3,11 @@ package helper
 import (
 	"bytes"
 	"flag"
+	"github.com/ayanozturk/go-php-parser/command"
+	"github.com/ayanozturk/go-php-parser/config"
+	"io"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
@@ -160,3 +164,50 @@ func TestSetupProfilingEnabled(t *testing.T) {
 	}
 	removeProfileFiles()
 }
+
+func TestStubDirectoryInsideProjectIsNotAnalysed(t *testing.T) {
+	level := 6
+	command.ConfigureAnalysis(&level)
+	command.ConfigureCache(nil)
+	t.Cleanup(func() {
+		command.ConfigureAnalysis(nil)
+		_ = command.ConfigureBuiltins(nil, nil, io.Discard)
+	})
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"src/Cache.php":   "<?php\n\nclass Cache\n{\n    public function get(Redis $redis): string\n    {\n        return $redis->get('key');\n    }\n}\n",
+		"stubs/redis.php": "<?php\n\nclass Redis\n{\n    public function get($key) {}\n}\n",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := &config.Config{
+		Path:          dir,
+		Extensions:    []string{"php"},
+		AnalysisLevel: &level,
+		Stubs:         []string{filepath.Join(dir, "stubs")},
+	}
+
+	files, err := config.GetFilesToScan(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := command.ConfigureBuiltins(c.Stubs, nil, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	issues, _, _ := command.ProcessStyleFilesParallel(files, nil, nil, 1)
+	for _, issue := range issues {
+		if strings.Contains(filepath.ToSlash(issue.Filename), "/stubs/") {
+			t.Errorf("expected stub files not to be analysed, got %#v", issue)
+		}
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected only the project file to be scanned, got %v", files)
+	}
+}
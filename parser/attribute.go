@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+	"github.com/ayanozturk/go-php-parser/lexer"
+	"github.com/ayanozturk/go-php-parser/token"
+)
+
+// parseAttributeGroup splits an attribute group token such as
+// #[Pure, Deprecated(since: '8.1')] into one node per attribute. The arguments
+// are parsed as call arguments; their positions are relative to the group.
+func parseAttributeGroup(tok token.Token) []*ast.AttributeNode {
+	pos := ast.Position(tok.Pos)
+	inner := strings.TrimSpace(tok.Literal)
+	inner = strings.TrimPrefix(inner, "#[")
+	inner = strings.TrimSuffix(inner, "]")
+
+	sub := New(lexer.New("<?php ["+inner+"];"), false)
+	nodes := sub.Parse()
+	fallback := []*ast.AttributeNode{{Name: attributeNameFromLiteral(tok.Literal), Pos: pos}}
+	if len(sub.errors) > 0 || len(nodes) != 1 {
+		return fallback
+	}
+	stmt, ok := nodes[0].(*ast.ExpressionStmt)
+	if !ok {
+		return fallback
+	}
+	list, ok := stmt.Expr.(*ast.ArrayNode)
+	if !ok {
+		return fallback
+	}
+
+	var attributes []*ast.AttributeNode
+	for _, element := range list.Elements {
+		if item, ok := element.(*ast.ArrayItemNode); ok {
+			element = item.Value
+		}
+		switch n := element.(type) {
+		case *ast.IdentifierNode:
+			attributes = append(attributes, &ast.AttributeNode{Name: n.Value, Pos: pos})
+		case *ast.FunctionCallNode:
+			if name, ok := n.Name.(*ast.IdentifierNode); ok {
+				attributes = append(attributes, &ast.AttributeNode{Name: name.Value, Arguments: n.Args, Pos: pos})
+			}
+		}
+	}
+	if len(attributes) == 0 {
+		return fallback
+	}
+	return attributes
+}
+
+// collectAttributes records the attribute group at the current token for the
+// next function, method or interface method declaration.
+func (p *Parser) collectAttributes() {
+	p.pendingAttributes = append(p.pendingAttributes, parseAttributeGroup(p.tok)...)
+	p.nextToken()
+}
+
+// takeAttributes returns and clears the attributes collected for the
+// declaration being parsed.
+func (p *Parser) takeAttributes() []*ast.AttributeNode {
+	attributes := p.pendingAttributes
+	p.pendingAttributes = nil
+	return attributes
+}
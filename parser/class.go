@@ -4,6 +4,7 @@ import (
 	"github.com/ayanozturk/go-php-parser/ast"
 	"github.com/ayanozturk/go-php-parser/token"
 	"strings"
+	"unicode"
 )
 
 func (p *Parser) parseClassDeclarationWithModifier(modifier string) (ast.Node, error) {
@@ -329,6 +330,13 @@ func (p *Parser) parseTraitUseStatement() ast.Node {
 		}
 		p.nextToken() // consume comma
 	}
+	if p.tok.Type == token.T_LBRACE {
+		adaptations, ok := p.parseTraitAdaptations()
+		if !ok {
+			return nil
+		}
+		return &ast.TraitUseNode{Traits: traits, Adaptations: adaptations, Pos: ast.Position(pos)}
+	}
 	if p.tok.Type != token.T_SEMICOLON {
 		p.addError("line %d:%d: expected ; after trait use, got %s", p.tok.Pos.Line, p.tok.Pos.Column, p.tok.Literal)
 		return nil
@@ -337,6 +345,88 @@ func (p *Parser) parseTraitUseStatement() ast.Node {
 	return &ast.TraitUseNode{Traits: traits, Pos: ast.Position(pos)}
 }
 
+// parseTraitAdaptations parses the { ... } block of a trait use statement.
+func (p *Parser) parseTraitAdaptations() ([]ast.TraitAdaptation, bool) {
+	p.nextToken() // consume {
+	var adaptations []ast.TraitAdaptation
+	for p.tok.Type != token.T_RBRACE && p.tok.Type != token.T_EOF {
+		adaptation := ast.TraitAdaptation{Pos: ast.Position(p.tok.Pos)}
+		name := p.parseTraitMemberName()
+		if p.tok.Type == token.T_NS_SEPARATOR {
+			typeNode := p.parseFQCN()
+			if identifier, ok := typeNode.(*ast.IdentifierNode); ok && identifier != nil {
+				name += identifier.Value
+			}
+		}
+		if p.tok.Type == token.T_DOUBLE_COLON {
+			p.nextToken() // consume ::
+			adaptation.Trait = name
+			name = p.parseTraitMemberName()
+		}
+		adaptation.Method = name
+		if adaptation.Method == "" {
+			p.addError("line %d:%d: expected method name in trait adaptation, got %s", p.tok.Pos.Line, p.tok.Pos.Column, p.tok.Literal)
+			return nil, false
+		}
+		switch {
+		case p.tok.Type == token.T_INSTEADOF || p.tok.Type == token.T_STRING && strings.EqualFold(p.tok.Literal, "insteadof"):
+			p.nextToken() // consume insteadof
+			for {
+				identifier, ok := p.parseFQCN().(*ast.IdentifierNode)
+				if !ok || identifier == nil {
+					return nil, false
+				}
+				adaptation.InsteadOf = append(adaptation.InsteadOf, identifier.Value)
+				if p.tok.Type != token.T_COMMA {
+					break
+				}
+				p.nextToken() // consume comma
+			}
+		case p.tok.Type == token.T_AS:
+			p.nextToken() // consume as
+			switch p.tok.Type {
+			case token.T_PUBLIC, token.T_PROTECTED, token.T_PRIVATE:
+				adaptation.Visibility = p.tok.Literal
+				p.nextToken()
+			}
+			if p.tok.Type != token.T_SEMICOLON {
+				adaptation.Alias = p.parseTraitMemberName()
+			}
+		default:
+			p.addError("line %d:%d: expected insteadof or as in trait adaptation, got %s", p.tok.Pos.Line, p.tok.Pos.Column, p.tok.Literal)
+			return nil, false
+		}
+		if p.tok.Type != token.T_SEMICOLON {
+			p.addError("line %d:%d: expected ; after trait adaptation, got %s", p.tok.Pos.Line, p.tok.Pos.Column, p.tok.Literal)
+			return nil, false
+		}
+		p.nextToken() // consume ;
+		adaptations = append(adaptations, adaptation)
+	}
+	if p.tok.Type != token.T_RBRACE {
+		p.addError("line %d:%d: expected } to close trait adaptations, got %s", p.tok.Pos.Line, p.tok.Pos.Column, p.tok.Literal)
+		return nil, false
+	}
+	p.nextToken() // consume }
+	return adaptations, true
+}
+
+// parseTraitMemberName consumes a method or trait name. Method names may be
+// reserved words, so any word-like token is accepted.
+func (p *Parser) parseTraitMemberName() string {
+	name := p.tok.Literal
+	if name == "" || p.tok.Type == token.T_VARIABLE {
+		return ""
+	}
+	for i, r := range name {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return ""
+		}
+	}
+	p.nextToken()
+	return name
+}
+
 // Helper: skip to next class member or end of class on parse error
 func (p *Parser) syncToNextClassMember() {
 	for {
@@ -429,6 +519,7 @@ func (p *Parser) parsePropertyDeclaration(modifiers []string, typeHint string) (
 		IsStatic:      isStatic,
 		IsReadonly:    isReadonly,
 		Hooks:         hooks,
+		PHPDoc:        p.consumeCurrentDoc(pos),
 		Pos:           ast.Position(pos),
 	}, nil
 }
@@ -303,6 +303,40 @@ class VisibilityFixture
 	}
 }
 
+func TestParsePropertyPHPDoc(t *testing.T) {
+	php := `<?php
+class DocFixture
+{
+    /** @var list<string> */
+    private array $names = [];
+
+    public function names(): array { return $this->names; }
+}`
+	p := New(lexer.New(php), true)
+	nodes := p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("Parser errors: %v", p.Errors())
+	}
+	classNode, ok := nodes[0].(*ast.ClassNode)
+	if !ok {
+		t.Fatalf("Expected ClassNode, got %T", nodes[0])
+	}
+	prop, ok := classNode.Properties[0].(*ast.PropertyNode)
+	if !ok {
+		t.Fatalf("Expected PropertyNode, got %T", classNode.Properties[0])
+	}
+	if prop.PHPDoc == nil || prop.PHPDoc.VarType != "list<string>" {
+		t.Fatalf("Expected property PHPDoc with list<string>, got %#v", prop.PHPDoc)
+	}
+	method, ok := classNode.Methods[0].(*ast.FunctionNode)
+	if !ok {
+		t.Fatalf("Expected FunctionNode, got %T", classNode.Methods[0])
+	}
+	if method.PHPDoc != nil {
+		t.Fatalf("Expected the property PHPDoc not to carry over to the method, got %#v", method.PHPDoc)
+	}
+}
+
 func TestParseFunctionWithStaticReturnType(t *testing.T) {
 	php := `<?php
 class Foo {
@@ -13,6 +13,8 @@ func (p *Parser) parseConstant() *ast.ConstantNode {
 
 func (p *Parser) parseConstantWithModifiers(modifiers []string) *ast.ConstantNode {
 	pos := p.tok.Pos
+	// Take the doc comment now so it does not carry over to the next member.
+	phpdoc := p.consumeCurrentDoc(pos)
 	visibility := visibilityFromModifiers(modifiers)
 	if len(modifiers) == 0 && (p.tok.Type == token.T_PUBLIC || p.tok.Type == token.T_PROTECTED || p.tok.Type == token.T_PRIVATE) {
 		visibility = p.tok.Literal
@@ -63,6 +65,7 @@ func (p *Parser) parseConstantWithModifiers(modifiers []string) *ast.ConstantNod
 		Visibility: visibility,
 		Modifiers:  append([]string(nil), modifiers...),
 		Value:      value,
+		PHPDoc:     phpdoc,
 		Pos:        ast.Position(pos),
 	}
 }
@@ -1,11 +1,17 @@
 package parser
 
-import "fmt"
+import (
+	"fmt"
+	"github.com/ayanozturk/go-php-parser/token"
+)
 
 // ErrorDeferred represents a deferred error message for the parser.
 type ErrorDeferred struct {
 	Format string
 	Args   []interface{}
+	// Token is the type of the token the parser was positioned on when the
+	// error was recorded. Tooling uses it to group failures by cause.
+	Token token.TokenType
 }
 
 // Error implements the error interface, formatting only when needed.
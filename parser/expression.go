@@ -82,6 +82,13 @@ func (p *Parser) parseUnaryExpression() ast.Node {
 			p.addError("line %d:%d: expected operand after unary operator !", notTok.Pos.Line, notTok.Pos.Column)
 			return nil
 		}
+		// instanceof binds tighter than !: `!$a instanceof B` negates the test.
+		for right != nil && p.tok.Type == token.T_INSTANCEOF {
+			right = p.parseBinaryOperator(right, PhpOperatorPrecedence[token.T_INSTANCEOF], false)
+		}
+		if right == nil {
+			return nil
+		}
 		return &ast.UnaryExpr{
 			Operator: "!",
 			Operand:  right,
@@ -256,9 +263,10 @@ func (p *Parser) parseBinaryOperator(left ast.Node, prec int, validateAssignment
 	if isAssignmentOperator(op) {
 		if unary, ok := left.(*ast.UnaryExpr); ok && unary.Operator == "!" && isValidAssignmentTarget(unary.Operand) {
 			assignment := &ast.AssignmentNode{
-				Left:  unary.Operand,
-				Right: right,
-				Pos:   ast.Position(pos),
+				Left:     unary.Operand,
+				Operator: operator,
+				Right:    right,
+				Pos:      ast.Position(pos),
 			}
 			return &ast.UnaryExpr{
 				Operator: unary.Operator,
@@ -275,9 +283,10 @@ func (p *Parser) parseBinaryOperator(left ast.Node, prec int, validateAssignment
 	}
 	if isAssignmentOperator(op) {
 		return &ast.AssignmentNode{
-			Left:  left,
-			Right: right,
-			Pos:   ast.Position(pos),
+			Left:     left,
+			Operator: operator,
+			Right:    right,
+			Pos:      ast.Position(pos),
 		}
 	}
 	return &ast.BinaryExpr{
@@ -787,6 +796,7 @@ func (p *Parser) parseSimpleObjectOrMethod(expr ast.Node) ast.Node {
 		return &ast.PropertyFetchNode{
 			Object:   expr,
 			Property: memberExpr.TokenLiteral(),
+			NullSafe: operator == "?->",
 			Pos:      ast.Position(objOpPos),
 		}
 	}
@@ -797,16 +807,17 @@ func (p *Parser) parseSimpleObjectOrMethod(expr ast.Node) ast.Node {
 	member := p.tok.Literal
 	p.nextToken() // consume property/method name
 	if p.tok.Type == token.T_LPAREN {
-		return p.parseSimpleMethodCall(expr, member, objOpPos)
+		return p.parseSimpleMethodCall(expr, member, operator == "?->", objOpPos)
 	}
 	return &ast.PropertyFetchNode{
 		Object:   expr,
 		Property: member,
+		NullSafe: operator == "?->",
 		Pos:      ast.Position(objOpPos),
 	}
 }
 
-func (p *Parser) parseSimpleMethodCall(expr ast.Node, member string, objOpPos token.Position) ast.Node {
+func (p *Parser) parseSimpleMethodCall(expr ast.Node, member string, nullSafe bool, objOpPos token.Position) ast.Node {
 	p.nextToken() // consume '('
 	if p.tok.Type == token.T_ELLIPSIS && p.peekToken().Type == token.T_RPAREN {
 		p.nextToken() // consume '...'
@@ -826,10 +837,11 @@ func (p *Parser) parseSimpleMethodCall(expr ast.Node, member string, objOpPos to
 	}
 	p.nextToken() // consume )
 	return &ast.MethodCallNode{
-		Object: expr,
-		Method: member,
-		Args:   args,
-		Pos:    ast.Position(objOpPos),
+		Object:   expr,
+		Method:   member,
+		Args:     args,
+		NullSafe: nullSafe,
+		Pos:      ast.Position(objOpPos),
 	}
 }
 
@@ -16,10 +16,29 @@ func (p *Parser) parseForStatement() (ast.Node, error) {
 		p.addError("line %d:%d: expected ( after for, got %s", p.tok.Pos.Line, p.tok.Pos.Column, p.tok.Literal)
 		return nil, nil
 	}
-	// Tolerant skip until the matching ')', to avoid strict expression parsing for for-control
-	depth := 1
+	// Tolerant skip until the matching ')', to avoid strict expression parsing for for-control.
+	// Top-level semicolons split the header, so an empty condition can still be recorded.
+	depth, braces := 1, 0
+	sections, conditionTokens := 0, 0
+	var controlVars []*ast.VariableNode
+	seen := map[string]bool{}
 	for depth > 0 {
 		p.nextToken()
+		topLevel := depth == 1 && braces == 0
+		if topLevel && p.tok.Type == token.T_SEMICOLON {
+			sections++
+		} else if sections == 1 && !(topLevel && p.tok.Type == token.T_RPAREN) {
+			conditionTokens++
+		}
+		if p.tok.Type == token.T_LBRACE {
+			braces++
+		} else if p.tok.Type == token.T_RBRACE {
+			braces--
+		}
+		if p.tok.Type == token.T_VARIABLE && len(p.tok.Literal) > 1 && !seen[p.tok.Literal] {
+			seen[p.tok.Literal] = true
+			controlVars = append(controlVars, &ast.VariableNode{Name: p.tok.Literal[1:], Pos: ast.Position(p.tok.Pos)})
+		}
 		if p.tok.Type == token.T_LPAREN {
 			depth++
 		} else if p.tok.Type == token.T_RPAREN {
@@ -52,5 +71,5 @@ func (p *Parser) parseForStatement() (ast.Node, error) {
 	}
 
 	// Represent for-loop as a BlockNode wrapper to keep AST stable without new node type
-	return &ast.BlockNode{Statements: body, Pos: ast.Position(pos)}, nil
+	return &ast.BlockNode{Statements: body, Loop: true, ControlVars: controlVars, NoCondition: sections == 2 && conditionTokens == 0, Pos: ast.Position(pos)}, nil
 }
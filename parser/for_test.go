@@ -24,6 +24,12 @@ for ($i = 0; $i < 3; $i++) {
 	if !ok {
 		t.Fatalf("Expected BlockNode for for-loop body, got %T", nodes[0])
 	}
+	if !blk.Loop {
+		t.Fatal("Expected for-loop body block to be marked as a loop")
+	}
+	if len(blk.ControlVars) != 1 || blk.ControlVars[0].Name != "i" {
+		t.Fatalf("Expected control variable $i, got %#v", blk.ControlVars)
+	}
 	if len(blk.Statements) != 1 {
 		t.Fatalf("Expected 1 statement in for body, got %d", len(blk.Statements))
 	}
@@ -64,3 +70,63 @@ for ($i = 0; $i < 2; $i++) echo 1;`
 		}())
 	}
 }
+
+func TestParseBreakAndContinueLevels(t *testing.T) {
+	php := `<?php
+foreach ($rows as $row) {
+    foreach ($row as $cell) {
+        break 2;
+        continue;
+    }
+}`
+	l := lexer.New(php)
+	p := New(l, false)
+	nodes := p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("Parser errors: %v", p.Errors())
+	}
+	outer := nodes[0].(*ast.ForeachNode)
+	inner := outer.Body[0].(*ast.ForeachNode)
+	breakStmt, ok := inner.Body[0].(*ast.ExpressionStmt)
+	if !ok {
+		t.Fatalf("Expected ExpressionStmt for break, got %T", inner.Body[0])
+	}
+	level, ok := breakStmt.Expr.(*ast.UnaryExpr)
+	if !ok || level.Operator != "break" {
+		t.Fatalf("Expected break with level as UnaryExpr, got %#v", breakStmt.Expr)
+	}
+	if lit, ok := level.Operand.(*ast.IntegerNode); !ok || lit.Value != 2 {
+		t.Fatalf("Expected break level 2, got %#v", level.Operand)
+	}
+	continueStmt := inner.Body[1].(*ast.ExpressionStmt)
+	if ident, ok := continueStmt.Expr.(*ast.IdentifierNode); !ok || ident.Value != "continue" {
+		t.Fatalf("Expected bare continue as IdentifierNode, got %#v", continueStmt.Expr)
+	}
+}
+
+func TestParseForRecordsMissingCondition(t *testing.T) {
+	tests := []struct {
+		php         string
+		noCondition bool
+	}{
+		{"<?php\nfor (;;) {}", true},
+		{"<?php\nfor ($i = 0; ; $i++) {}", true},
+		{"<?php\nfor ($i = 0; $i < 3; $i++) {}", false},
+		{"<?php\nfor (; $run();) {}", false},
+		{"<?php\nfor ($f = function () { a(); b(); }; ;) {}", true},
+	}
+	for _, tt := range tests {
+		p := New(lexer.New(tt.php), false)
+		nodes := p.Parse()
+		if len(p.Errors()) > 0 {
+			t.Fatalf("Parser errors for %q: %v", tt.php, p.Errors())
+		}
+		blk, ok := nodes[0].(*ast.BlockNode)
+		if !ok {
+			t.Fatalf("Expected BlockNode for %q, got %T", tt.php, nodes[0])
+		}
+		if blk.NoCondition != tt.noCondition {
+			t.Errorf("Expected NoCondition %v for %q, got %v", tt.noCondition, tt.php, blk.NoCondition)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 func (p *Parser) parseFunction(modifiers []string) (ast.Node, error) {
 	pos := p.tok.Pos
 	phpdoc := p.consumeCurrentDoc(pos)
+	attributes := p.takeAttributes()
 	p.nextToken() // consume 'function'
 
 	var savedModifiers []string
@@ -68,6 +69,7 @@ func (p *Parser) parseFunction(modifiers []string) (ast.Node, error) {
 	}
 	p.nextToken() // consume )
 
+	var uses []ast.ClosureUse
 	if name == "" && p.tok.Type == token.T_USE {
 		p.nextToken() // consume use
 		if p.tok.Type != token.T_LPAREN {
@@ -76,13 +78,16 @@ func (p *Parser) parseFunction(modifiers []string) (ast.Node, error) {
 		}
 		p.nextToken() // consume (
 		for p.tok.Type != token.T_RPAREN && p.tok.Type != token.T_EOF {
+			byRef := false
 			if p.tok.Type == token.T_AMPERSAND {
+				byRef = true
 				p.nextToken()
 			}
 			if p.tok.Type != token.T_VARIABLE {
 				p.addError("line %d:%d: expected closure use variable, got %s", p.tok.Pos.Line, p.tok.Pos.Column, p.tok.Literal)
 				return nil, nil
 			}
+			uses = append(uses, ast.ClosureUse{Name: p.tok.Literal[1:], ByRef: byRef, Pos: ast.Position(p.tok.Pos)})
 			p.nextToken()
 			if p.tok.Type == token.T_COMMA {
 				p.nextToken()
@@ -124,6 +129,7 @@ func (p *Parser) parseFunction(modifiers []string) (ast.Node, error) {
 				Modifiers:  savedModifiers,
 				Body:       nil,
 				PHPDoc:     phpdoc,
+				Attributes: attributes,
 				Pos:        ast.Position(pos),
 			}, nil
 		}
@@ -144,10 +150,12 @@ func (p *Parser) parseFunction(modifiers []string) (ast.Node, error) {
 		return &ast.FunctionNode{
 			Name:       name,
 			Params:     params,
+			Uses:       uses,
 			ReturnType: returnType,
 			Modifiers:  savedModifiers,
 			Body:       nil,
 			PHPDoc:     phpdoc,
+			Attributes: attributes,
 			Pos:        ast.Position(pos),
 		}, nil
 	}
@@ -194,10 +202,12 @@ func (p *Parser) parseFunction(modifiers []string) (ast.Node, error) {
 	return &ast.FunctionNode{
 		Name:       name,
 		Params:     params,
+		Uses:       uses,
 		ReturnType: returnType,
 		Modifiers:  savedModifiers,
 		Body:       body,
 		PHPDoc:     phpdoc,
+		Attributes: attributes,
 		Pos:        ast.Position(pos),
 	}, nil
 }
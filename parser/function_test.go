@@ -357,7 +357,19 @@ func TestParseNullCoalescingAssignment(t *testing.T) {
 	if len(nodes) < 2 {
 		t.Fatal("Expected at least two statements")
 	}
-	// Optionally, check for AssignmentNode with Operator "??="
+	for i, node := range nodes[:2] {
+		stmt, ok := node.(*ast.ExpressionStmt)
+		if !ok {
+			t.Fatalf("statement %d: expected ExpressionStmt, got %T", i, node)
+		}
+		assignment, ok := stmt.Expr.(*ast.AssignmentNode)
+		if !ok {
+			t.Fatalf("statement %d: expected AssignmentNode, got %T", i, stmt.Expr)
+		}
+		if assignment.Operator != "??=" {
+			t.Fatalf("statement %d: expected operator ??=, got %q", i, assignment.Operator)
+		}
+	}
 }
 
 func TestParsePHPDocInFunction(t *testing.T) {
@@ -457,6 +469,33 @@ function foo() {
 	}
 }
 
+func TestParseGlobalVariablesInFunction(t *testing.T) {
+	input := `<?php
+function f() {
+    global $config, $db;
+    return $db;
+}`
+	p := New(lexer.New(input), false)
+	nodes := p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("Parser errors: %v", p.Errors())
+	}
+	fn, ok := nodes[0].(*ast.FunctionNode)
+	if !ok {
+		t.Fatalf("Expected FunctionNode, got %T", nodes[0])
+	}
+	decl, ok := fn.Body[0].(*ast.GlobalNode)
+	if !ok {
+		t.Fatalf("Expected first statement to be GlobalNode, got %T", fn.Body[0])
+	}
+	if len(decl.Vars) != 2 || decl.Vars[0].Name != "config" || decl.Vars[1].Name != "db" {
+		t.Fatalf("Expected global vars config,db got %v", decl)
+	}
+	if len(fn.Body) != 2 {
+		t.Fatalf("Expected 2 statements, got %d", len(fn.Body))
+	}
+}
+
 func TestParseNeverReturnTypePreservesFollowingMethod(t *testing.T) {
 	input := `<?php
 class DepartmentData {
@@ -49,6 +49,8 @@ func isValidMethodNameToken(t token.TokenType) bool {
 		token.T_REQUIRE,
 		token.T_CLONE,
 		token.T_GLOBAL,
+		token.T_THROW,
+		token.T_PRINT,
 		token.T_STATIC:
 		// Add more keywords as needed if legal as method names in PHP
 		// See https://www.php.net/manual/en/reserved.keywords.php
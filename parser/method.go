@@ -63,11 +63,20 @@ func (p *Parser) parseInterfaceDeclaration() ast.Node {
 
 	var members []ast.Node
 	for p.tok.Type != token.T_RBRACE && p.tok.Type != token.T_EOF {
-		// Skip doc comments and regular comments in interface body
-		if p.tok.Type == token.T_DOC_COMMENT || p.tok.Type == token.T_COMMENT {
+		// Keep doc comments and attributes for the next method
+		if p.tok.Type == token.T_DOC_COMMENT {
+			p.currentDoc = p.tok.Literal
 			p.nextToken()
 			continue
 		}
+		if p.tok.Type == token.T_COMMENT {
+			p.nextToken()
+			continue
+		}
+		if p.tok.Type == token.T_ATTRIBUTE {
+			p.collectAttributes()
+			continue
+		}
 		// Interface members: methods and constants
 		if p.tok.Type == token.T_PUBLIC || p.tok.Type == token.T_PRIVATE || p.tok.Type == token.T_PROTECTED {
 			visibility := p.tok.Literal
@@ -81,6 +90,7 @@ func (p *Parser) parseInterfaceDeclaration() ast.Node {
 				}
 			}
 			if p.tok.Type == token.T_CONST {
+				p.pendingAttributes = nil
 				if constant := p.parseConstantWithModifiers([]string{visibility}); constant != nil {
 					members = append(members, constant)
 				}
@@ -97,6 +107,7 @@ func (p *Parser) parseInterfaceDeclaration() ast.Node {
 				members = append(members, method)
 			}
 		} else if p.tok.Type == token.T_CONST {
+			p.pendingAttributes = nil
 			if constant := p.parseConstant(); constant != nil {
 				members = append(members, constant)
 			}
@@ -128,6 +139,7 @@ func (p *Parser) parseInterfaceMethod() ast.Node {
 
 func (p *Parser) parseInterfaceMethodWithVisibility(initialVisibility string) ast.Node {
 	pos := p.tok.Pos
+	attributes := p.takeAttributes()
 
 	// Skip doc comments and regular comments before method signature
 	for p.tok.Type == token.T_DOC_COMMENT || p.tok.Type == token.T_COMMENT {
@@ -263,6 +275,7 @@ func (p *Parser) parseInterfaceMethodWithVisibility(initialVisibility string) as
 		ReturnType: returnType,
 		Params:     params,
 		PHPDoc:     p.consumeCurrentDoc(pos),
+		Attributes: attributes,
 		Pos:        ast.Position(pos),
 	}
 }
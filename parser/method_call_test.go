@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"github.com/ayanozturk/go-php-parser/ast"
 	"github.com/ayanozturk/go-php-parser/lexer"
 	"testing"
 )
@@ -82,6 +83,38 @@ class Foo {
 	}
 }
 
+func TestParseChainedNullsafeMethodCall(t *testing.T) {
+	php := `<?php
+class Foo {
+    public function test($admin) {
+        return $admin?->profile?->getEmail();
+    }
+}`
+	l := lexer.New(php)
+	p := New(l, true)
+	nodes := p.Parse()
+	err := p.Errors()
+	if len(err) > 0 {
+		t.Fatalf("Unexpected errors: %v", err)
+	}
+	if len(nodes) == 0 {
+		t.Fatal("No AST nodes returned")
+	}
+	method := nodes[0].(*ast.ClassNode).Methods[0].(*ast.FunctionNode)
+	ret, ok := method.Body[0].(*ast.ReturnNode)
+	if !ok {
+		t.Fatalf("expected return statement, got %T", method.Body[0])
+	}
+	call, ok := ret.Expr.(*ast.MethodCallNode)
+	if !ok || !call.NullSafe {
+		t.Fatalf("expected nullsafe method call, got %#v", ret.Expr)
+	}
+	fetch, ok := call.Object.(*ast.PropertyFetchNode)
+	if !ok || !fetch.NullSafe {
+		t.Fatalf("expected nullsafe property fetch, got %#v", call.Object)
+	}
+}
+
 func TestParseMethodChainOnNewExpressionAcrossLines(t *testing.T) {
 	php := `<?php
 class Factory {
@@ -3,6 +3,7 @@ package parser
 import (
 	"github.com/ayanozturk/go-php-parser/ast"
 	"github.com/ayanozturk/go-php-parser/lexer"
+	"reflect"
 	"testing"
 )
 
@@ -89,6 +90,54 @@ class TwigCallable {
 	}
 }
 
+func TestParseReservedKeywordMethodNames(t *testing.T) {
+	php := `<?php
+final class Generator {
+    public function throw(Throwable $exception): mixed {}
+    public function print(): void {}
+}
+`
+
+	l := lexer.New(php)
+	p := New(l, true)
+	nodes := p.Parse()
+
+	if len(p.Errors()) > 0 {
+		t.Fatalf("unexpected parser errors: %v", p.Errors())
+	}
+	classNode, ok := nodes[0].(*ast.ClassNode)
+	if !ok || len(classNode.Methods) != 2 {
+		t.Fatalf("expected class with 2 methods, got %#v", nodes[0])
+	}
+	if name := classNode.Methods[0].(*ast.FunctionNode).Name; name != "throw" {
+		t.Fatalf("expected method throw, got %q", name)
+	}
+}
+
+func TestParseTrueType(t *testing.T) {
+	php := `<?php
+function alwaysTrue(string|true $flag): true {}
+`
+
+	l := lexer.New(php)
+	p := New(l, true)
+	nodes := p.Parse()
+
+	if len(p.Errors()) > 0 {
+		t.Fatalf("unexpected parser errors: %v", p.Errors())
+	}
+	fn, ok := nodes[0].(*ast.FunctionNode)
+	if !ok {
+		t.Fatalf("expected FunctionNode, got %T", nodes[0])
+	}
+	if fn.ReturnType != "true" {
+		t.Fatalf("expected return type true, got %q", fn.ReturnType)
+	}
+	if param := fn.Params[0].(*ast.ParamNode); param.TypeHint != "string|true" {
+		t.Fatalf("expected param type string|true, got %q", param.TypeHint)
+	}
+}
+
 func TestParseTypedClassConstant(t *testing.T) {
 	php := `<?php
 final readonly class HtmlRenderer {
@@ -895,6 +944,56 @@ class MultiSelectPromptRenderer {
 	}
 }
 
+func TestParseTraitUseAdaptations(t *testing.T) {
+	php := `<?php
+class Talker {
+    use A, \Vendor\B {
+        B::smallTalk insteadof A;
+        A::bigTalk insteadof \Vendor\B, C;
+        B::bigTalk as protected talk;
+        sayHello as private;
+        list as public;
+    }
+}
+
+trait Combined {
+    use A, B;
+}
+`
+	p := New(lexer.New(php), false)
+	nodes := p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("unexpected parser errors: %v", p.Errors())
+	}
+	classNode := nodes[0].(*ast.ClassNode)
+	use, ok := classNode.Properties[0].(*ast.TraitUseNode)
+	if !ok {
+		t.Fatalf("expected TraitUseNode, got %T", classNode.Properties[0])
+	}
+	if !reflect.DeepEqual(use.Traits, []string{"A", `\Vendor\B`}) {
+		t.Fatalf("unexpected traits %#v", use.Traits)
+	}
+	var got []ast.TraitAdaptation
+	for _, adaptation := range use.Adaptations {
+		adaptation.Pos = ast.Position{}
+		got = append(got, adaptation)
+	}
+	want := []ast.TraitAdaptation{
+		{Trait: "B", Method: "smallTalk", InsteadOf: []string{"A"}},
+		{Trait: "A", Method: "bigTalk", InsteadOf: []string{`\Vendor\B`, "C"}},
+		{Trait: "B", Method: "bigTalk", Alias: "talk", Visibility: "protected"},
+		{Method: "sayHello", Visibility: "private"},
+		{Method: "list", Visibility: "public"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+	trait := nodes[1].(*ast.TraitNode)
+	if len(trait.Body) != 1 {
+		t.Fatalf("expected the trait to keep its trait use, got %#v", trait.Body)
+	}
+}
+
 func TestParseReadonlyClassKeepsMethodsAfterPromotedConstructor(t *testing.T) {
 	src := `<?php
 final readonly class PreferenceView
@@ -964,6 +1063,34 @@ $result = array_map(function ($label, $key) use ($prompt) {
 	}
 }
 
+func TestParseClosureUseListIsKept(t *testing.T) {
+	php := `<?php
+$fn = function () use ($a, &$b) {
+};
+`
+
+	p := New(lexer.New(php), true)
+	nodes := p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("unexpected parser errors: %v", p.Errors())
+	}
+	stmt, ok := nodes[0].(*ast.ExpressionStmt)
+	if !ok {
+		t.Fatalf("expected ExpressionStmt, got %T", nodes[0])
+	}
+	assign, ok := stmt.Expr.(*ast.AssignmentNode)
+	if !ok {
+		t.Fatalf("expected AssignmentNode, got %T", stmt.Expr)
+	}
+	closure, ok := assign.Right.(*ast.FunctionNode)
+	if !ok {
+		t.Fatalf("expected FunctionNode, got %T", assign.Right)
+	}
+	if len(closure.Uses) != 2 || closure.Uses[0].Name != "a" || closure.Uses[0].ByRef || closure.Uses[1].Name != "b" || !closure.Uses[1].ByRef {
+		t.Fatalf("unexpected closure uses: %#v", closure.Uses)
+	}
+}
+
 func TestParseFunctionCallArgumentTrailingComment(t *testing.T) {
 	php := `<?php
 $result = $this->when(
@@ -55,6 +55,7 @@ func (p *Parser) parseNamespaceDeclaration() (ast.Node, error) {
 			if stmt != nil {
 				body = append(body, stmt)
 			}
+			body = append(body, p.takePendingStatements()...)
 		}
 		if p.tok.Type != token.T_RBRACE {
 			p.addError("line %d:%d: expected } to close namespace %s body, got %s", p.tok.Pos.Line, p.tok.Pos.Column, name, p.tok.Literal)
@@ -8,9 +8,11 @@ import (
 
 // parseParameter parses a function or method parameter
 func (p *Parser) parseParameter() ast.Node {
-	// Skip PHP attributes (#[...]) and comments before parameter, and allow attributes before any parameter element
+	// Collect PHP attributes (#[...]) and skip comments before the parameter
+	var attributes []*ast.AttributeNode
 	for {
 		if p.tok.Type == token.T_ATTRIBUTE {
+			attributes = append(attributes, parseAttributeGroup(p.tok)...)
 			p.nextToken()
 			continue
 		}
@@ -45,7 +47,7 @@ func (p *Parser) parseParameter() ast.Node {
 	// Parse type hint if present (support nullable, union, intersection, FQCNs, parenthesized types)
 	var typeHint string
 	switch p.tok.Type {
-	case token.T_LPAREN, token.T_NS_SEPARATOR, token.T_STRING, token.T_CALLABLE, token.T_ARRAY, token.T_STATIC, token.T_SELF, token.T_PARENT, token.T_NEW, token.T_QUESTION, token.T_MIXED, token.T_NULL, token.T_FALSE:
+	case token.T_LPAREN, token.T_NS_SEPARATOR, token.T_STRING, token.T_CALLABLE, token.T_ARRAY, token.T_STATIC, token.T_SELF, token.T_PARENT, token.T_NEW, token.T_QUESTION, token.T_MIXED, token.T_NULL, token.T_FALSE, token.T_TRUE:
 		typeHint = parseFullTypeHint(p)
 	default:
 		if p.tok.Literal == "\\" {
@@ -125,6 +127,7 @@ func (p *Parser) parseParameter() ast.Node {
 		IsReadonly:   isReadonly,
 		IsVariadic:   isVariadic,
 		IsByRef:      isByRef,
+		Attributes:   attributes,
 		Pos:          ast.Position(pos),
 	}
 }
@@ -19,13 +19,23 @@ type Parser struct {
 	errors             []error
 	debug              bool
 	currentDoc         string // Current PHPDoc comment being tracked
+	pendingAttributes  []*ast.AttributeNode
+	pendingStatements  []ast.Node
 	modifierArr        [4]string
 	modifierBuf        []string
 	nameBuf            strings.Builder
 	stopBuf            [4]token.TokenType
 	stopLen            int
+	steps              int
 }
 
+// cancelled is panicked from deep inside the parser once Ctx is done, so that
+// a cancelled parse unwinds at once instead of finishing the current statement.
+type cancelled struct{ err error }
+
+// cancelCheckInterval is how many tokens are read between checks of Ctx.
+const cancelCheckInterval = 256
+
 func New(l *lexer.Lexer, debug bool) *Parser {
 	p := &Parser{
 		l:     l,
@@ -46,11 +56,29 @@ func (p *Parser) isStopToken(t token.TokenType) bool {
 }
 
 func (p *Parser) nextToken() {
+	p.checkCancelled()
 	p.tok = p.l.NextToken()
 }
 
+// checkCancelled aborts the parse when Ctx is done. It is called for every
+// token and every error, which bounds how long any parser loop can keep
+// running after cancellation, but only consults Ctx periodically.
+func (p *Parser) checkCancelled() {
+	if p.Ctx == nil {
+		return
+	}
+	p.steps++
+	if p.steps%cancelCheckInterval != 0 {
+		return
+	}
+	if err := p.Ctx.Err(); err != nil {
+		panic(cancelled{err})
+	}
+}
+
 func (p *Parser) addError(format string, args ...interface{}) {
-	p.errors = append(p.errors, ErrorDeferred{Format: format, Args: args})
+	p.checkCancelled()
+	p.errors = append(p.errors, ErrorDeferred{Format: format, Args: args, Token: p.tok.Type})
 }
 
 // Errors returns the list of errors encountered during parsing
@@ -62,6 +90,18 @@ func (p *Parser) Errors() []string {
 	return res
 }
 
+// ErrorDetails returns the recorded errors with their unformatted message
+// template and offending token type.
+func (p *Parser) ErrorDetails() []ErrorDeferred {
+	res := make([]ErrorDeferred, 0, len(p.errors))
+	for _, err := range p.errors {
+		if deferred, ok := err.(ErrorDeferred); ok {
+			res = append(res, deferred)
+		}
+	}
+	return res
+}
+
 // consumeCurrentDoc consumes the current PHPDoc comment and returns a PHPDocNode
 func (p *Parser) consumeCurrentDoc(pos token.Position) *ast.PHPDocNode {
 	if p.currentDoc == "" {
@@ -79,6 +119,10 @@ func (p *Parser) Parse() []ast.Node {
 	// Add panic recovery
 	defer func() {
 		if r := recover(); r != nil {
+			if c, ok := r.(cancelled); ok {
+				p.errors = append(p.errors, ErrorDeferred{Format: "parser context cancelled: %v", Args: []interface{}{c.err}, Token: p.tok.Type})
+				return
+			}
 			p.addError("Parser panic: %v", r)
 		}
 	}()
@@ -125,6 +169,7 @@ func (p *Parser) Parse() []ast.Node {
 		if node != nil {
 			nodes = append(nodes, node)
 		}
+		nodes = append(nodes, p.takePendingStatements()...)
 	}
 
 	return nodes
@@ -173,6 +218,7 @@ func (p *Parser) parseFQCN() ast.Node {
 // parseModifiers parses and returns member modifiers, reusing the internal modifierBuf.
 func (p *Parser) parseModifiers() []string {
 	p.modifierBuf = p.modifierBuf[:0]
+	p.pendingAttributes = nil
 	for {
 		if modifier, ok := p.parsePropertyModifier(); ok {
 			p.modifierBuf = append(p.modifierBuf, modifier)
@@ -187,7 +233,10 @@ func (p *Parser) parseModifiers() []string {
 			p.currentDoc = p.tok.Literal
 			p.nextToken()
 			continue
-		case token.T_COMMENT, token.T_ATTRIBUTE:
+		case token.T_ATTRIBUTE:
+			p.collectAttributes()
+			continue
+		case token.T_COMMENT:
 			p.nextToken()
 			continue
 		}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"github.com/ayanozturk/go-php-parser/ast"
 	"github.com/ayanozturk/go-php-parser/lexer"
 	"testing"
 )
@@ -19,3 +20,65 @@ final class HeadersConfigurator implements RequestConfiguratorInterface {
 		t.Errorf("Parser errors: %v", p.Errors())
 	}
 }
+
+func TestAttributesAreKeptOnFunctionsAndParameters(t *testing.T) {
+	php := `<?php
+#[Pure, PhpStormStubsElementAvailable(from: '8.0')]
+function str_contains(#[LanguageLevelTypeAware(['8.0' => 'string'], default: '')] $haystack): bool {}
+$x = 1;
+function plain() {}
+`
+	p := New(lexer.New(php), false)
+	nodes := p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("Parser errors: %v", p.Errors())
+	}
+
+	attr, ok := nodes[0].(*ast.AttributeNode)
+	if !ok || attr.Name != "Pure" {
+		t.Fatalf("expected leading Pure attribute statement, got %#v", nodes[0])
+	}
+	fn := nodes[1].(*ast.FunctionNode)
+	if len(fn.Attributes) != 2 || fn.Attributes[1].Name != "PhpStormStubsElementAvailable" {
+		t.Fatalf("expected both attributes on the function, got %#v", fn.Attributes)
+	}
+	named, ok := fn.Attributes[1].Arguments[0].(*ast.NamedArgumentNode)
+	if !ok || named.Name != "from" {
+		t.Fatalf("expected named from argument, got %#v", fn.Attributes[1].Arguments)
+	}
+	param := fn.Params[0].(*ast.ParamNode)
+	if len(param.Attributes) != 1 || len(param.Attributes[0].Arguments) != 2 {
+		t.Fatalf("expected parameter attribute with two arguments, got %#v", param.Attributes)
+	}
+	if plain := nodes[len(nodes)-1].(*ast.FunctionNode); len(plain.Attributes) != 0 {
+		t.Fatalf("attributes leaked to a later function: %#v", plain.Attributes)
+	}
+}
+
+func TestAttributesInInterfaceBody(t *testing.T) {
+	php := `<?php
+interface Countable {
+    /** @return int */
+    #[TentativeType]
+    public function count(): int;
+    #[Deprecated]
+    const X = 1;
+    function other();
+}`
+	p := New(lexer.New(php), false)
+	nodes := p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("Parser errors: %v", p.Errors())
+	}
+	iface := nodes[0].(*ast.InterfaceNode)
+	count := iface.Members[0].(*ast.InterfaceMethodNode)
+	if len(count.Attributes) != 1 || count.Attributes[0].Name != "TentativeType" {
+		t.Fatalf("expected TentativeType on count, got %#v", count.Attributes)
+	}
+	if count.PHPDoc == nil || count.PHPDoc.ReturnType != "int" {
+		t.Fatalf("expected PHPDoc on interface method, got %#v", count.PHPDoc)
+	}
+	if other := iface.Members[2].(*ast.InterfaceMethodNode); len(other.Attributes) != 0 {
+		t.Fatalf("constant attribute leaked to method: %#v", other.Attributes)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/ayanozturk/go-php-parser/ast"
 	"github.com/ayanozturk/go-php-parser/lexer"
 	"github.com/ayanozturk/go-php-parser/token"
+	"strings"
 	"testing"
 )
 
@@ -102,3 +103,38 @@ class Demo {
 		t.Fatalf("final public constant modifiers not preserved: %#v", constant)
 	}
 }
+
+func TestParseClassConstantConsumesItsDocComment(t *testing.T) {
+	src := `<?php
+class Demo {
+    /**
+     * @since 8.2
+     */
+    const LIMIT = 2;
+
+    public function __construct() {}
+}
+interface Shape {
+    /** @var int */
+    const SIDES = 4;
+
+    public function area(): float;
+}`
+	p := New(lexer.New(src), false)
+	nodes := p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("unexpected parser errors: %v", p.Errors())
+	}
+	classNode := nodes[0].(*ast.ClassNode)
+	constant := classNode.Constants[0].(*ast.ConstantNode)
+	if constant.PHPDoc == nil || !strings.Contains(constant.PHPDoc.RawContent, "@since 8.2") {
+		t.Fatalf("expected the constant to keep its doc comment, got %#v", constant.PHPDoc)
+	}
+	if method := classNode.Methods[0].(*ast.FunctionNode); method.PHPDoc != nil {
+		t.Fatalf("expected the constructor to have no doc comment, got %#v", method.PHPDoc)
+	}
+	interfaceNode := nodes[1].(*ast.InterfaceNode)
+	if method := interfaceNode.Members[1].(*ast.InterfaceMethodNode); method.PHPDoc != nil {
+		t.Fatalf("expected the interface method to have no doc comment, got %#v", method.PHPDoc)
+	}
+}
@@ -1,8 +1,10 @@
 package parser
 
 import (
+	"context"
 	"github.com/ayanozturk/go-php-parser/ast"
 	"github.com/ayanozturk/go-php-parser/lexer"
+	"strings"
 	"testing"
 )
 
@@ -78,6 +80,37 @@ func TestParserRecordsErrorsWhenDebugIsDisabled(t *testing.T) {
 	}
 }
 
+// cancelAfterFirstCheck reports itself cancelled from the second Err call on,
+// so the check between top-level statements passes and only checks made
+// inside a statement can notice the cancellation.
+type cancelAfterFirstCheck struct {
+	context.Context
+	checks int
+}
+
+func (c *cancelAfterFirstCheck) Err() error {
+	c.checks++
+	if c.checks > 1 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestParserStopsInsideAStatementWhenCancelled(t *testing.T) {
+	source := "<?php\nfunction run() {\n" + strings.Repeat("$a = $b + 1;\n", 10000) + "}\n"
+	p := New(lexer.New(source), false)
+	p.Ctx = &cancelAfterFirstCheck{Context: context.Background()}
+	nodes := p.Parse()
+
+	if len(nodes) != 0 {
+		t.Fatalf("cancelled parse returned %d nodes, want 0", len(nodes))
+	}
+	errs := p.Errors()
+	if len(errs) != 1 || errs[0] != "parser context cancelled: context canceled" {
+		t.Fatalf("errors = %v, want a single cancellation error", errs)
+	}
+}
+
 func TestParserAcceptsTopLevelConstAndPrint(t *testing.T) {
 	l := lexer.New(`<?php
 		const MESSAGE = "hello";
@@ -130,6 +163,32 @@ func TestInstanceOf(t *testing.T) {
 	}
 }
 
+func TestNegatedInstanceOfNegatesTheTest(t *testing.T) {
+	l := lexer.New(`<?php
+		if (!$a instanceof \Exception) {
+			echo "not an exception";
+		}
+	`)
+	p := New(l, true)
+	nodes := p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("expected no parser errors, got %v", p.Errors())
+	}
+
+	ifNode, ok := nodes[0].(*ast.IfNode)
+	if !ok {
+		t.Fatalf("expected IfNode, got %T", nodes[0])
+	}
+	not, ok := ifNode.Condition.(*ast.UnaryExpr)
+	if !ok || not.Operator != "!" {
+		t.Fatalf("expected ! at the top of the condition, got %s", ifNode.Condition.String())
+	}
+	test, ok := not.Operand.(*ast.BinaryExpr)
+	if !ok || test.Operator != "instanceof" {
+		t.Fatalf("expected instanceof operand, got %s", not.Operand.String())
+	}
+}
+
 func TestIfConditionWithNotIdenticalAndBooleanAnd(t *testing.T) {
 	l := lexer.New(`<?php
 		if ('lint' !== $mode && false === getenv('SYMFONY_PATCH_TYPE_DECLARATIONS')) {
@@ -342,3 +401,29 @@ class Foo {
 		})
 	}
 }
+
+func TestParserErrorDetailsRecordTemplateAndToken(t *testing.T) {
+	l := lexer.New(`<?php
+		class Broken {
+			public function ok() {}
+			)
+		}
+	`)
+	p := New(l, false)
+	_ = p.Parse()
+
+	details := p.ErrorDetails()
+	if len(details) == 0 {
+		t.Fatal("expected malformed class body to produce error details")
+	}
+	if len(details) != len(p.Errors()) {
+		t.Fatalf("expected one detail per error, got %d details for %d errors", len(details), len(p.Errors()))
+	}
+	first := details[0]
+	if first.Token == "" {
+		t.Fatalf("expected offending token type to be recorded, got %+v", first)
+	}
+	if first.Error() != p.Errors()[0] {
+		t.Fatalf("expected detail to format like Errors(), got %q vs %q", first.Error(), p.Errors()[0])
+	}
+}
@@ -10,11 +10,15 @@ import (
 func (p *Parser) parseStatement() (ast.Node, error) {
 retry:
 	// Keep attributes as lightweight nodes so analysis rules can validate them.
+	// The group is also kept for a function declared right after it.
 	if p.tok.Type == token.T_ATTRIBUTE {
-		pos := p.tok.Pos
-		name := attributeNameFromLiteral(p.tok.Literal)
+		group := parseAttributeGroup(p.tok)
+		p.pendingAttributes = append(p.pendingAttributes, group...)
 		p.nextToken()
-		return &ast.AttributeNode{Name: name, Pos: ast.Position(pos)}, nil
+		return group[0], nil
+	}
+	if p.tok.Type != token.T_FUNCTION && p.tok.Type != token.T_COMMENT && p.tok.Type != token.T_DOC_COMMENT {
+		p.pendingAttributes = nil
 	}
 	if p.tok.Type == token.T_NAMESPACE {
 		return p.parseNamespaceDeclaration()
@@ -95,6 +99,11 @@ retry:
 			return nil, nil
 		}
 		p.nextToken() // consume ;
+		if expr != nil {
+			// break 2; / continue 2; keep the keyword so the level is not
+			// mistaken for an ordinary expression statement.
+			expr = &ast.UnaryExpr{Operator: strings.ToLower(keyword), Operand: expr, Pos: ast.Position(pos)}
+		}
 		return &ast.ExpressionStmt{
 			Expr: exprOrIdentifier(keyword, expr, ast.Position(pos)),
 			Pos:  ast.Position(pos),
@@ -136,6 +145,29 @@ retry:
 		}
 		p.nextToken() // consume ';'
 		return &ast.StaticVarDeclNode{Vars: entries, Pos: ast.Position(pos)}, nil
+	case token.T_GLOBAL:
+		// global $x, $y; inside functions
+		pos := p.tok.Pos
+		p.nextToken() // consume 'global'
+		var vars []*ast.VariableNode
+		for {
+			if p.tok.Type != token.T_VARIABLE {
+				p.addError("line %d:%d: expected variable name after global, got %s", p.tok.Pos.Line, p.tok.Pos.Column, p.tok.Literal)
+				return nil, nil
+			}
+			vars = append(vars, &ast.VariableNode{Name: p.tok.Literal[1:], Pos: ast.Position(p.tok.Pos)})
+			p.nextToken() // consume $var
+			if p.tok.Type != token.T_COMMA {
+				break
+			}
+			p.nextToken() // consume ','
+		}
+		if p.tok.Type != token.T_SEMICOLON {
+			p.addError("line %d:%d: expected ; after global declaration, got %s", p.tok.Pos.Line, p.tok.Pos.Column, p.tok.Literal)
+			return nil, nil
+		}
+		p.nextToken() // consume ';'
+		return &ast.GlobalNode{Vars: vars, Pos: ast.Position(pos)}, nil
 	case token.T_FUNCTION:
 		return p.parseFunction(nil)
 	case token.T_IF:
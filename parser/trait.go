@@ -53,6 +53,12 @@ func (p *Parser) parseTraitDeclaration() (ast.Node, error) {
 			}
 			continue
 		}
+		if p.tok.Type == token.T_USE {
+			if traitUse := p.parseTraitUseStatement(); traitUse != nil {
+				body = append(body, traitUse)
+			}
+			continue
+		}
 		if p.tok.Type == token.T_VARIABLE {
 			if prop, err := p.parsePropertyDeclaration(modifiers, typeHint); prop != nil {
 				body = append(body, prop)
@@ -6,48 +6,112 @@ import (
 	"strings"
 )
 
+// parseUseDeclaration parses an import statement. Statements importing
+// several names, use A, B; or use A\{B, C as D};, produce one UseNode per
+// name: the first is returned and the rest are queued for the enclosing
+// statement list.
 func (p *Parser) parseUseDeclaration() (ast.Node, error) {
 	pos := p.tok.Pos
 	p.nextToken() // consume use
 
-	useType := "class"
-	if p.tok.Type == token.T_FUNCTION {
-		useType = "function"
-		p.nextToken()
-	} else if p.tok.Type == token.T_CONST {
-		useType = "const"
-		p.nextToken()
+	useType := p.parseUseType("class")
+
+	var uses []*ast.UseNode
+	for {
+		path := p.parseQualifiedName()
+		if path == "" {
+			p.addError("line %d:%d: expected imported symbol after use, got %s", p.tok.Pos.Line, p.tok.Pos.Column, p.tok.Literal)
+			return nil, nil
+		}
+		if p.tok.Type == token.T_LBRACE {
+			// Group use: use Prefix\{Name, function name, Other as Alias};
+			p.nextToken() // consume {
+			for p.tok.Type != token.T_RBRACE && p.tok.Type != token.T_EOF {
+				itemType := p.parseUseType(useType)
+				name := p.parseQualifiedName()
+				if name == "" {
+					p.addError("line %d:%d: expected imported symbol in group use, got %s", p.tok.Pos.Line, p.tok.Pos.Column, p.tok.Literal)
+					return nil, nil
+				}
+				use, ok := p.parseUseAlias(strings.TrimSuffix(path, "\\")+"\\"+name, itemType, pos)
+				if !ok {
+					return nil, nil
+				}
+				uses = append(uses, use)
+				if p.tok.Type != token.T_COMMA {
+					break
+				}
+				p.nextToken() // consume ,
+			}
+			if p.tok.Type != token.T_RBRACE {
+				p.addError("line %d:%d: expected } to close group use, got %s", p.tok.Pos.Line, p.tok.Pos.Column, p.tok.Literal)
+				return nil, nil
+			}
+			p.nextToken() // consume }
+		} else {
+			use, ok := p.parseUseAlias(path, useType, pos)
+			if !ok {
+				return nil, nil
+			}
+			uses = append(uses, use)
+		}
+		if p.tok.Type != token.T_COMMA {
+			break
+		}
+		p.nextToken() // consume ,
 	}
 
-	path := p.parseQualifiedName()
-	if path == "" {
-		p.addError("line %d:%d: expected imported symbol after use, got %s", p.tok.Pos.Line, p.tok.Pos.Column, p.tok.Literal)
+	if p.tok.Type != token.T_SEMICOLON {
+		p.addError("line %d:%d: expected ; after use declaration, got %s", p.tok.Pos.Line, p.tok.Pos.Column, p.tok.Literal)
 		return nil, nil
 	}
+	p.nextToken()
+
+	for _, use := range uses[1:] {
+		p.pendingStatements = append(p.pendingStatements, use)
+	}
+	return uses[0], nil
+}
 
+// parseUseType consumes an optional function or const keyword of a use
+// declaration or group item.
+func (p *Parser) parseUseType(defaultType string) string {
+	switch p.tok.Type {
+	case token.T_FUNCTION:
+		p.nextToken()
+		return "function"
+	case token.T_CONST:
+		p.nextToken()
+		return "const"
+	}
+	return defaultType
+}
+
+func (p *Parser) parseUseAlias(path, useType string, pos token.Position) (*ast.UseNode, bool) {
 	alias := defaultUseAlias(path)
 	if p.tok.Type == token.T_AS {
 		p.nextToken()
 		if p.tok.Type != token.T_STRING {
 			p.addError("line %d:%d: expected alias after 'as', got %s", p.tok.Pos.Line, p.tok.Pos.Column, p.tok.Literal)
-			return nil, nil
+			return nil, false
 		}
 		alias = p.tok.Literal
 		p.nextToken()
 	}
-
-	if p.tok.Type != token.T_SEMICOLON {
-		p.addError("line %d:%d: expected ; after use declaration, got %s", p.tok.Pos.Line, p.tok.Pos.Column, p.tok.Literal)
-		return nil, nil
-	}
-	p.nextToken()
-
 	return &ast.UseNode{
 		Path:  path,
 		Alias: alias,
 		Type:  useType,
 		Pos:   ast.Position(pos),
-	}, nil
+	}, true
+}
+
+// takePendingStatements returns the statements queued by the last parsed
+// statement, such as the further imports of a grouped use.
+func (p *Parser) takePendingStatements() []ast.Node {
+	pending := p.pendingStatements
+	p.pendingStatements = nil
+	return pending
 }
 
 func (p *Parser) parseQualifiedName() string {
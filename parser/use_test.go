@@ -46,3 +46,40 @@ func TestParseUseDeclarationWithAlias(t *testing.T) {
 		t.Fatalf("expected alias MessageNotification, got %q", useNode.Alias)
 	}
 }
+
+func TestParseGroupedAndMultipleUseDeclarations(t *testing.T) {
+	code := "<?php\nnamespace App {\n    use Foo\\{Bar, Sub\\Baz as Qux, function helper};\n    use A, B as C;\n    class X {}\n}\n"
+	p := New(lexer.New(code), true)
+	nodes := p.Parse()
+	if errs := p.Errors(); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	ns, ok := nodes[0].(*ast.NamespaceNode)
+	if !ok {
+		t.Fatalf("expected NamespaceNode, got %T", nodes[0])
+	}
+	var got []string
+	for _, node := range ns.Body {
+		if use, ok := node.(*ast.UseNode); ok {
+			got = append(got, use.Type+" "+use.Path+" as "+use.Alias)
+		}
+	}
+	want := []string{
+		"class Foo\\Bar as Bar",
+		"class Foo\\Sub\\Baz as Qux",
+		"function Foo\\helper as helper",
+		"class A as A",
+		"class B as C",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("use %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if _, ok := ns.Body[len(ns.Body)-1].(*ast.ClassNode); !ok {
+		t.Fatalf("expected the class after the imports, got %T", ns.Body[len(ns.Body)-1])
+	}
+}
@@ -0,0 +1,445 @@
+package style
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ayanozturk/go-php-parser/ast"
+	"github.com/ayanozturk/go-php-parser/lexer"
+	"github.com/ayanozturk/go-php-parser/token"
+)
+
+const unusedUsesCode = "SlevomatCodingStandard.Namespaces.UnusedUses"
+
+// UnusedUsesChecker reports imports that nothing in their namespace refers
+// to, and names imported more than once.
+//
+// References are collected from the token stream: any unqualified or
+// relative name in code, type declarations, attributes and PHPDoc comments
+// counts, so imports used only from @param, @var or @see tags are kept.
+// Names after ->, ::, or a declaration keyword are member or declaration
+// names and do not count.
+type UnusedUsesChecker struct{}
+
+// useImport is one name imported by a top-level use statement.
+type useImport struct {
+	kind   string // class, function or const
+	name   string
+	alias  string
+	line   int
+	column int
+	// start and end delimit the item in the source, including an inline
+	// function or const keyword and its alias.
+	start, end int
+	stmt       *useStatement
+	segment    int // index of the group or comma list within the statement
+	region     int // index of the namespace the import belongs to
+	unused     bool
+	duplicate  bool
+}
+
+// useStatement is one use ...; statement.
+type useStatement struct {
+	start, end int
+	imports    []*useImport
+}
+
+// useScan is what scanUses learns about a file.
+type useScan struct {
+	imports []*useImport
+	// refs holds the names referenced in each namespace region, lowercased
+	// for classes and functions and as written for constants.
+	refs []map[string]bool
+}
+
+var docReferencePattern = regexp.MustCompile(`\\?[A-Za-z_\x80-\xff][A-Za-z0-9_\x80-\xff]*`)
+
+// CheckIssues reports the unused and duplicate imports of a file.
+func (c *UnusedUsesChecker) CheckIssues(content, filename string) []StyleIssue {
+	var issues []StyleIssue
+	for _, imp := range scanUses(content).imports {
+		var message string
+		switch {
+		case imp.duplicate:
+			message = fmt.Sprintf("%s %s is already imported.", useKindLabel(imp.kind), imp.name)
+		case imp.unused:
+			message = fmt.Sprintf("%s %s is not used in this file.", useKindLabel(imp.kind), imp.name)
+		default:
+			continue
+		}
+		issues = append(issues, StyleIssue{
+			Filename: filename,
+			Line:     imp.line,
+			Column:   imp.column,
+			Type:     Error,
+			Fixable:  true,
+			Message:  message,
+			Code:     unusedUsesCode,
+		})
+	}
+	return issues
+}
+
+func useKindLabel(kind string) string {
+	switch kind {
+	case "function":
+		return "Function"
+	case "const":
+		return "Constant"
+	}
+	return "Type"
+}
+
+// scanUses finds the top-level imports of a file and the names its code
+// refers to.
+func scanUses(content string) useScan {
+	var toks []token.Token
+	l := lexer.New(content)
+	for {
+		tok := l.NextToken()
+		if tok.Type == token.T_EOF {
+			break
+		}
+		if tok.Type == token.T_WHITESPACE || tok.Type == token.T_COMMENT {
+			continue
+		}
+		toks = append(toks, tok)
+	}
+
+	scan := useScan{refs: []map[string]bool{{}}}
+	region := 0
+	// braces records, for each open brace, whether it opened a namespace.
+	var braces []bool
+	prev := token.T_OPEN_TAG
+	for i := 0; i < len(toks); i++ {
+		tok := toks[i]
+		switch tok.Type {
+		case token.T_DOC_COMMENT, token.T_ATTRIBUTE:
+			scan.addTextReferences(region, tok.Literal)
+			continue
+		case token.T_NAMESPACE:
+			if i+1 < len(toks) && toks[i+1].Type != token.T_NS_SEPARATOR && toks[i+1].Literal != `\` {
+				region++
+				scan.refs = append(scan.refs, map[string]bool{})
+				for i+1 < len(toks) && toks[i+1].Type != token.T_SEMICOLON && toks[i+1].Type != token.T_LBRACE {
+					i++
+				}
+				if i+1 < len(toks) && toks[i+1].Type == token.T_LBRACE {
+					braces = append(braces, true)
+					i++
+				}
+				prev = toks[i].Type
+				continue
+			}
+		case token.T_LBRACE, token.T_CURLY_OPEN, token.T_DOLLAR_OPEN_CURLY_BRACES:
+			braces = append(braces, false)
+		case token.T_RBRACE:
+			if len(braces) > 0 {
+				braces = braces[:len(braces)-1]
+			}
+		case token.T_USE:
+			if atStatementStart(prev) && onlyNamespaceBraces(braces) {
+				if next, ok := scan.parseUseStatement(toks, i, region); ok {
+					i = next
+					prev = token.T_SEMICOLON
+					continue
+				}
+			}
+		case token.T_STRING:
+			if !isMemberOrDeclarationName(prev) {
+				scan.refs[region][strings.ToLower(tok.Literal)] = true
+				scan.refs[region][tok.Literal] = true
+			}
+		}
+		prev = tok.Type
+	}
+
+	seen := map[string]bool{}
+	for _, imp := range scan.imports {
+		alias := imp.alias
+		if imp.kind != "const" {
+			alias = strings.ToLower(alias)
+		}
+		imp.unused = !scan.refs[imp.region][alias]
+		key := fmt.Sprintf("%d|%s|%s|%s", imp.region, imp.kind, strings.ToLower(imp.name), alias)
+		imp.duplicate = seen[key]
+		seen[key] = true
+	}
+	return scan
+}
+
+// addTextReferences records the names mentioned in a PHPDoc comment or an
+// attribute. Fully qualified names and variables are skipped.
+func (scan *useScan) addTextReferences(region int, text string) {
+	for _, loc := range docReferencePattern.FindAllStringIndex(text, -1) {
+		word := text[loc[0]:loc[1]]
+		if strings.HasPrefix(word, `\`) || (loc[0] > 0 && (text[loc[0]-1] == '$' || text[loc[0]-1] == '\\')) {
+			continue
+		}
+		scan.refs[region][strings.ToLower(word)] = true
+		scan.refs[region][word] = true
+	}
+}
+
+func atStatementStart(prev token.TokenType) bool {
+	switch prev {
+	case token.T_OPEN_TAG, token.T_SEMICOLON, token.T_LBRACE, token.T_RBRACE:
+		return true
+	}
+	return false
+}
+
+func onlyNamespaceBraces(braces []bool) bool {
+	for _, namespace := range braces {
+		if !namespace {
+			return false
+		}
+	}
+	return true
+}
+
+// isMemberOrDeclarationName reports whether a name following prev names a
+// member or a declaration rather than referring to an imported symbol. A
+// name following a namespace separator is part of a qualified name whose
+// first segment was already recorded.
+func isMemberOrDeclarationName(prev token.TokenType) bool {
+	switch prev {
+	case token.T_OBJECT_OPERATOR, token.T_NULLSAFE_OBJECT_OPERATOR, token.T_DOUBLE_COLON,
+		token.T_NS_SEPARATOR, token.T_BACKSLASH,
+		token.T_FUNCTION, token.T_CONST, token.T_CLASS, token.T_INTERFACE, token.T_TRAIT, token.T_ENUM:
+		return true
+	}
+	return false
+}
+
+// parseUseStatement reads the use statement starting at toks[i] and
+// returns the index of its closing semicolon.
+func (scan *useScan) parseUseStatement(toks []token.Token, i, region int) (int, bool) {
+	stmt := &useStatement{start: toks[i].Pos.Offset}
+	j := i + 1
+	kind, j := useKindAt(toks, j, "class")
+	var imports []*useImport
+	segment := 0
+	for {
+		start := j
+		name, next := readUseName(toks, j)
+		if name == "" {
+			return i, false
+		}
+		j = next
+		if j < len(toks) && toks[j].Type == token.T_LBRACE {
+			j++
+			for j < len(toks) && toks[j].Type != token.T_RBRACE {
+				itemStart := j
+				itemKind, afterKind := useKindAt(toks, j, kind)
+				itemName, afterName := readUseName(toks, afterKind)
+				if itemName == "" {
+					return i, false
+				}
+				imp, afterAlias := newUseImport(toks, itemStart, afterKind, afterName, itemKind, strings.TrimSuffix(name, `\`)+`\`+itemName)
+				imp.segment = segment
+				imports = append(imports, imp)
+				j = afterAlias
+				if j < len(toks) && toks[j].Type == token.T_COMMA {
+					j++
+				}
+			}
+			if j >= len(toks) {
+				return i, false
+			}
+			j++ // }
+			segment++
+		} else {
+			imp, afterAlias := newUseImport(toks, start, start, j, kind, name)
+			imports = append(imports, imp)
+			j = afterAlias
+		}
+		if j < len(toks) && toks[j].Type == token.T_COMMA {
+			j++
+			continue
+		}
+		break
+	}
+	if j >= len(toks) || toks[j].Type != token.T_SEMICOLON || len(imports) == 0 {
+		return i, false
+	}
+	stmt.end = toks[j].Pos.Offset + len(toks[j].Literal)
+	for _, imp := range imports {
+		imp.stmt = stmt
+		imp.region = region
+	}
+	stmt.imports = imports
+	scan.imports = append(scan.imports, imports...)
+	return j, true
+}
+
+func useKindAt(toks []token.Token, j int, fallback string) (string, int) {
+	if j < len(toks) {
+		switch toks[j].Type {
+		case token.T_FUNCTION:
+			return "function", j + 1
+		case token.T_CONST:
+			return "const", j + 1
+		}
+	}
+	return fallback, j
+}
+
+// readUseName reads a possibly qualified name starting at toks[j].
+func readUseName(toks []token.Token, j int) (string, int) {
+	var b strings.Builder
+	for j < len(toks) {
+		switch {
+		case toks[j].Type == token.T_STRING:
+			b.WriteString(toks[j].Literal)
+		case toks[j].Type == token.T_NS_SEPARATOR || toks[j].Literal == `\`:
+			b.WriteString(`\`)
+		default:
+			return strings.TrimPrefix(b.String(), `\`), j
+		}
+		j++
+	}
+	return strings.TrimPrefix(b.String(), `\`), j
+}
+
+// newUseImport builds the import whose tokens start at toks[start] and
+// whose name starts at toks[nameStart], reading an optional alias after it.
+func newUseImport(toks []token.Token, start, nameStart, j int, kind, name string) (*useImport, int) {
+	last := toks[j-1]
+	alias := name[strings.LastIndex(name, `\`)+1:]
+	if j+1 < len(toks) && toks[j].Type == token.T_AS && toks[j+1].Type == token.T_STRING {
+		alias = toks[j+1].Literal
+		last = toks[j+1]
+		j += 2
+	}
+	return &useImport{
+		kind:   kind,
+		name:   name,
+		alias:  alias,
+		line:   toks[nameStart].Pos.Line,
+		column: toks[nameStart].Pos.Column,
+		start:  toks[start].Pos.Offset,
+		end:    last.Pos.Offset + len(last.Literal),
+	}, j
+}
+
+// useEdit removes content[start:end]. line is set when the removal covers
+// whole lines.
+type useEdit struct {
+	start, end int
+	line       bool
+}
+
+// FixUnusedUses removes unused and duplicate imports. Statements left
+// without imports are removed with their line; single names are cut out of
+// grouped and comma-separated statements.
+func FixUnusedUses(content string) string {
+	scan := scanUses(content)
+	var edits []useEdit
+	done := map[*useStatement]bool{}
+	for _, imp := range scan.imports {
+		if done[imp.stmt] {
+			continue
+		}
+		done[imp.stmt] = true
+		edits = append(edits, useStatementEdits(content, imp.stmt)...)
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	for _, edit := range edits {
+		end := edit.end
+		if edit.line && blankLineBefore(content, edit.start) && strings.HasPrefix(content[end:], "\n") {
+			end++
+		} else if edit.line && blankLineBefore(content, edit.start) && strings.HasPrefix(content[end:], "\r\n") {
+			end += 2
+		}
+		content = content[:edit.start] + content[end:]
+	}
+	return content
+}
+
+func useStatementEdits(content string, stmt *useStatement) []useEdit {
+	kept, segments := 0, map[int]bool{}
+	for _, imp := range stmt.imports {
+		segments[imp.segment] = true
+		if !imp.unused && !imp.duplicate {
+			kept++
+		}
+	}
+	if kept == len(stmt.imports) {
+		return nil
+	}
+	if kept == 0 {
+		return []useEdit{statementEdit(content, stmt)}
+	}
+	if len(segments) > 1 {
+		// Statements mixing several groups or a group and plain names are
+		// left for manual editing.
+		return nil
+	}
+	var edits []useEdit
+	lastKept := -1
+	for i, imp := range stmt.imports {
+		if !imp.unused && !imp.duplicate {
+			lastKept = i
+		}
+	}
+	for i, imp := range stmt.imports {
+		if !imp.unused && !imp.duplicate {
+			continue
+		}
+		if i < lastKept {
+			// Remove the name up to the next one, taking its comma along.
+			edits = append(edits, useEdit{start: imp.start, end: stmt.imports[i+1].start})
+		}
+	}
+	if lastKept < len(stmt.imports)-1 {
+		// Remove the trailing run from the end of the last kept name.
+		edits = append(edits, useEdit{start: stmt.imports[lastKept].end, end: stmt.imports[len(stmt.imports)-1].end})
+	}
+	return edits
+}
+
+// statementEdit removes a whole statement, with its line when nothing else
+// shares it.
+func statementEdit(content string, stmt *useStatement) useEdit {
+	start, end := stmt.start, stmt.end
+	lineStart := start
+	for lineStart > 0 && (content[lineStart-1] == ' ' || content[lineStart-1] == '\t') {
+		lineStart--
+	}
+	for end < len(content) && (content[end] == ' ' || content[end] == '\t') {
+		end++
+	}
+	if lineStart == 0 || content[lineStart-1] == '\n' {
+		if strings.HasPrefix(content[end:], "\r\n") {
+			return useEdit{start: lineStart, end: end + 2, line: true}
+		}
+		if strings.HasPrefix(content[end:], "\n") {
+			return useEdit{start: lineStart, end: end + 1, line: true}
+		}
+	}
+	return useEdit{start: start, end: end}
+}
+
+// blankLineBefore reports whether the line before offset is empty.
+func blankLineBefore(content string, offset int) bool {
+	before := strings.TrimSuffix(content[:offset], "\n")
+	before = strings.TrimSuffix(before, "\r")
+	return len(before) < offset && (strings.HasSuffix(before, "\n") || before == "")
+}
+
+// UnusedUsesFixer implements StyleFixer for autofix support.
+type UnusedUsesFixer struct{}
+
+func (f UnusedUsesFixer) Code() string              { return unusedUsesCode }
+func (f UnusedUsesFixer) Fix(content string) string { return FixUnusedUses(content) }
+
+func init() {
+	RegisterRule(unusedUsesCode, func(filename string, content []byte, _ []ast.Node) []StyleIssue {
+		checker := &UnusedUsesChecker{}
+		return checker.CheckIssues(string(content), filename)
+	})
+	RegisterFixer(UnusedUsesFixer{})
+}
@@ -0,0 +1,123 @@
+package style
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnusedUsesChecker_CheckIssues(t *testing.T) {
+	code := `<?php
+namespace App;
+
+use App\Model\User;
+use App\Model\Order;
+use App\Model\User;
+use App\Contracts\{Repository, Cache as CacheContract, Logger};
+use App\Attributes\Route;
+use App\Docs\OnlyInDocs;
+use function App\helpers\format;
+use function App\helpers\unused;
+use const App\LIMIT;
+use Vendor\Sub;
+
+#[Route('/users')]
+final class UserController implements Repository
+{
+    use Logger;
+
+    /** @var array<int, OnlyInDocs> */
+    private array $items = [];
+
+    public function show(User $user): CacheContract
+    {
+        $this->Order();
+        Sub\Factory::make();
+        return format($user, LIMIT);
+    }
+}
+`
+	issues := (&UnusedUsesChecker{}).CheckIssues(code, "test.php")
+	var got []string
+	for _, issue := range issues {
+		if issue.Code != unusedUsesCode || !issue.Fixable {
+			t.Fatalf("unexpected issue %#v", issue)
+		}
+		got = append(got, issue.Message)
+	}
+	want := []string{
+		`Type App\Model\Order is not used in this file.`,
+		`Type App\Model\User is already imported.`,
+		`Function App\helpers\unused is not used in this file.`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+}
+
+func TestUnusedUsesImportsAreScopedToTheirNamespace(t *testing.T) {
+	code := `<?php
+namespace First {
+    use Shared\Thing;
+}
+
+namespace Second {
+    use Shared\Thing;
+
+    new Thing();
+}
+`
+	issues := (&UnusedUsesChecker{}).CheckIssues(code, "test.php")
+	if len(issues) != 1 || issues[0].Line != 3 {
+		t.Fatalf("expected only the first namespace's import to be reported, got %#v", issues)
+	}
+}
+
+func TestFixUnusedUses(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want string
+	}{
+		{
+			name: "whole statements",
+			code: "<?php\nnamespace App;\n\nuse A\\Used;\nuse A\\Unused;\nuse A\\Used;\n\nnew Used();\n",
+			want: "<?php\nnamespace App;\n\nuse A\\Used;\n\nnew Used();\n",
+		},
+		{
+			name: "every import",
+			code: "<?php\nnamespace App;\n\nuse A\\Unused;\nuse A\\Other;\n\nclass X {}\n",
+			want: "<?php\nnamespace App;\n\nclass X {}\n",
+		},
+		{
+			name: "grouped imports",
+			code: "<?php\nuse A\\{B, C, D as E, F};\n\nnew C(); new F();\n",
+			want: "<?php\nuse A\\{C, F};\n\nnew C(); new F();\n",
+		},
+		{
+			name: "trailing group members",
+			code: "<?php\nuse A\\{\n    B,\n    C,\n    D,\n};\n\nnew B();\n",
+			want: "<?php\nuse A\\{\n    B,\n};\n\nnew B();\n",
+		},
+		{
+			name: "comma separated imports",
+			code: "<?php\nuse A\\B, A\\C;\n\nnew C();\n",
+			want: "<?php\nuse A\\C;\n\nnew C();\n",
+		},
+		{
+			name: "PHPDoc references are kept",
+			code: "<?php\nuse A\\B;\n\n/** @param B $b */\nfunction f($b) {}\n",
+			want: "<?php\nuse A\\B;\n\n/** @param B $b */\nfunction f($b) {}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixer := GetFixer(unusedUsesCode)
+			if fixer == nil {
+				t.Fatal("expected a registered fixer")
+			}
+			if got := fixer.Fix(tt.code); got != tt.want {
+				t.Errorf("Fix() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}